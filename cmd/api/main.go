@@ -23,15 +23,30 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	authEntity "github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
 	authHandler "github.com/akbarwjyy/go-commerce-api/internal/auth/handler"
 	authMiddleware "github.com/akbarwjyy/go-commerce-api/internal/auth/middleware"
 	authRepo "github.com/akbarwjyy/go-commerce-api/internal/auth/repository"
 	authService "github.com/akbarwjyy/go-commerce-api/internal/auth/service"
+	cartEntity "github.com/akbarwjyy/go-commerce-api/internal/cart/entity"
+	cartHandler "github.com/akbarwjyy/go-commerce-api/internal/cart/handler"
+	cartRepo "github.com/akbarwjyy/go-commerce-api/internal/cart/repository"
+	cartService "github.com/akbarwjyy/go-commerce-api/internal/cart/service"
 	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
+	couponEntity "github.com/akbarwjyy/go-commerce-api/internal/coupon/entity"
+	couponHandler "github.com/akbarwjyy/go-commerce-api/internal/coupon/handler"
+	couponRepo "github.com/akbarwjyy/go-commerce-api/internal/coupon/repository"
+	couponService "github.com/akbarwjyy/go-commerce-api/internal/coupon/service"
+	dashboardHandler "github.com/akbarwjyy/go-commerce-api/internal/dashboard/handler"
+	dashboardService "github.com/akbarwjyy/go-commerce-api/internal/dashboard/service"
 	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
 	orderHandler "github.com/akbarwjyy/go-commerce-api/internal/order/handler"
 	orderRepo "github.com/akbarwjyy/go-commerce-api/internal/order/repository"
@@ -44,10 +59,27 @@ import (
 	productHandler "github.com/akbarwjyy/go-commerce-api/internal/product/handler"
 	productRepo "github.com/akbarwjyy/go-commerce-api/internal/product/repository"
 	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	reviewEntity "github.com/akbarwjyy/go-commerce-api/internal/review/entity"
+	reviewHandler "github.com/akbarwjyy/go-commerce-api/internal/review/handler"
+	reviewRepo "github.com/akbarwjyy/go-commerce-api/internal/review/repository"
+	reviewService "github.com/akbarwjyy/go-commerce-api/internal/review/service"
+	webhookEntity "github.com/akbarwjyy/go-commerce-api/internal/webhook/entity"
+	webhookHandler "github.com/akbarwjyy/go-commerce-api/internal/webhook/handler"
+	webhookRepo "github.com/akbarwjyy/go-commerce-api/internal/webhook/repository"
+	webhookService "github.com/akbarwjyy/go-commerce-api/internal/webhook/service"
 	"github.com/akbarwjyy/go-commerce-api/pkg/config"
 	"github.com/akbarwjyy/go-commerce-api/pkg/database"
+	"github.com/akbarwjyy/go-commerce-api/pkg/events"
+	"github.com/akbarwjyy/go-commerce-api/pkg/health"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/akbarwjyy/go-commerce-api/pkg/middleware"
+	"github.com/akbarwjyy/go-commerce-api/pkg/notifier"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+	"github.com/akbarwjyy/go-commerce-api/pkg/storage"
 	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
+	"github.com/akbarwjyy/go-commerce-api/pkg/validator"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 
 	_ "github.com/akbarwjyy/go-commerce-api/docs"
 	swaggerFiles "github.com/swaggo/files"
@@ -57,32 +89,104 @@ import (
 func main() {
 	// Load configuration
 	cfg := config.Load()
+	logger.Init(cfg.App.Env)
+
+	// Fail-fast di production jika secret/kredensial masih default; di development cukup warning
+	if err := cfg.Validate(); err != nil {
+		if cfg.IsProduction() {
+			logger.Fatal().Err(err).Msg("Refusing to start with invalid configuration")
+		}
+		logger.Warn().Err(err).Msg("Insecure configuration, do not use in production")
+	}
+	pagination.Configure(cfg.App.DefaultPageSize, cfg.App.MaxPageSize)
+	database.SetRedisKeyPrefix(cfg.Redis.Prefix)
 
 	// Initialize database connections
-	db, err := database.NewPostgresDB(&cfg.Database)
+	db, err := database.NewPostgresDB(&cfg.Database, cfg.App.Env)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		logger.Fatal().Err(err).Msg("Failed to connect to database")
 	}
 
 	// Auto migrate (hanya untuk development)
 	if cfg.App.Env == "development" {
+		// Produk lama perlu SKU placeholder sebelum kolom sku menjadi NOT NULL dan unik
+		if err := productRepo.BackfillProductSKUs(db); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to backfill product SKUs")
+		}
+
+		// Kolom uang lama decimal(12,2) dikonversi ke sen sebelum AutoMigrate mengubah tipenya ke bigint
+		for _, legacy := range []struct {
+			model   interface{}
+			columns []string
+		}{
+			{&productEntity.Product{}, []string{"price"}},
+			{&orderEntity.Order{}, []string{"total_amount", "discount_amount"}},
+			{&orderEntity.OrderItem{}, []string{"price", "subtotal"}},
+			{&paymentEntity.Payment{}, []string{"amount"}},
+			{&couponEntity.Coupon{}, []string{"min_spend"}},
+		} {
+			if err := database.ConvertMoneyToCents(db, legacy.model, legacy.columns...); err != nil {
+				logger.Fatal().Err(err).Msg("Failed to convert money columns to cents")
+			}
+		}
+
 		if err := database.AutoMigrate(db,
 			&authEntity.User{},
+			&authEntity.SellerProfile{},
 			&productEntity.Category{},
 			&productEntity.Product{},
+			&productEntity.ProductVariant{},
+			&productEntity.InventoryLog{},
+			&productEntity.PriceHistory{},
+			&productEntity.StockReservation{},
+			&productEntity.Tag{},
+			&productEntity.StockSubscription{},
 			&orderEntity.Order{},
 			&orderEntity.OrderItem{},
+			&orderEntity.OrderStatusHistory{},
+			&orderEntity.OrderNote{},
+			&orderEntity.CheckoutIdempotencyKey{},
+			&orderEntity.OrderReturn{},
+			&paymentEntity.Payment{},
+			&cartEntity.Cart{},
+			&cartEntity.CartItem{},
+			&reviewEntity.Review{},
+			&couponEntity.Coupon{},
+			&webhookEntity.Webhook{},
+			&webhookEntity.WebhookDeadLetter{},
+		); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to migrate database")
+		}
+
+		// Baris lama dari sebelum multi-currency memakai mata uang dasar
+		if err := database.BackfillCurrency(db, cfg.App.BaseCurrency,
+			&productEntity.Product{},
+			&orderEntity.Order{},
 			&paymentEntity.Payment{},
 		); err != nil {
-			log.Fatalf("Failed to migrate database: %v", err)
+			logger.Fatal().Err(err).Msg("Failed to backfill currency")
+		}
+
+		// Item order lama dari sebelum snapshot nama dan SKU produk
+		if err := orderRepo.BackfillOrderItemSnapshots(db); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to backfill order item snapshots")
+		}
+
+		// Seller lama dari sebelum alur approval langsung dianggap approved
+		if err := authRepo.BackfillSellerProfiles(db); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to backfill seller profiles")
+		}
+
+		// search_vector sebagai generated column + GIN index untuk full-text search produk (khusus PostgreSQL)
+		if err := productRepo.MigrateSearchVector(db); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to migrate product search vector")
 		}
 	}
 
 	// Initialize Redis
 	redisClient, err := database.NewRedisClient(&cfg.Redis)
 	if err != nil {
-		log.Printf("Warning: Failed to connect to Redis: %v", err)
-		log.Println("Token blacklist feature will be disabled")
+		logger.Warn().Err(err).Msg("Failed to connect to Redis, token blacklist feature will be disabled")
 		redisClient = nil
 	}
 
@@ -91,28 +195,149 @@ func main() {
 	// ========================================
 
 	// JWT Service
-	jwtService := utils.NewJWTService(cfg.JWT.Secret, cfg.JWT.ExpireHour)
+	jwtService, err := utils.NewJWTServiceFromConfig(&cfg.JWT)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize JWT service")
+	}
 
 	// Auth Module
 	userRepository := authRepo.NewUserRepository(db)
-	authSvc := authService.NewAuthService(userRepository, jwtService, redisClient)
+	sellerProfileRepository := authRepo.NewSellerProfileRepository(db)
+
+	// Email notification (asynchronous, no-op jika SMTP_HOST kosong)
+	userNotifier := notifier.NewUserNotifier(notifier.NewEmailSender(&cfg.SMTP), func(userID uint) (string, error) {
+		user, err := userRepository.FindByID(userID)
+		if err != nil {
+			return "", err
+		}
+		return user.Email, nil
+	})
+
+	authSvc := authService.NewAuthService(
+		userRepository,
+		sellerProfileRepository,
+		jwtService,
+		redisClient,
+		userNotifier,
+		cfg.Auth.BcryptCost,
+		cfg.Auth.LoginMaxAttempts,
+		time.Duration(cfg.Auth.LoginLockoutWindowMinutes)*time.Minute,
+	)
 	authHdl := authHandler.NewAuthHandler(authSvc)
 
 	// Product Module
 	categoryRepository := productRepo.NewCategoryRepository(db)
 	productRepository := productRepo.NewProductRepository(db)
-	productSvc := productService.NewProductService(productRepository, categoryRepository, db)
-	productHdl := productHandler.NewProductHandler(productSvc)
+	productVariantRepository := productRepo.NewProductVariantRepository(db)
+	inventoryLogRepository := productRepo.NewInventoryLogRepository(db)
+	priceHistoryRepository := productRepo.NewPriceHistoryRepository(db)
+	stockReservationRepository := productRepo.NewStockReservationRepository(db)
+	tagRepository := productRepo.NewTagRepository(db)
+	stockSubscriptionRepository := productRepo.NewStockSubscriptionRepository(db)
+	imageStorage, err := storage.New(&cfg.Storage)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize file storage")
+	}
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{
+		ProductRepo:       productRepository,
+		CategoryRepo:      categoryRepository,
+		VariantRepo:       productVariantRepository,
+		InventoryLogRepo:  inventoryLogRepository,
+		PriceHistoryRepo:  priceHistoryRepository,
+		ReservationRepo:   stockReservationRepository,
+		TagRepo:           tagRepository,
+		SubscriptionRepo:  stockSubscriptionRepository,
+		DB:                db,
+		RedisClient:       redisClient,
+		StockNotifier:     nil, // belum ada implementasi, stok menipis hanya dicatat di log
+		UserNotifier:      userNotifier,
+		LowStockThreshold: cfg.Inventory.LowStockThreshold,
+		ReservationTTL:    time.Duration(cfg.Inventory.ReservationTTLMinutes) * time.Minute,
+		ImageStorage:      imageStorage,
+		BaseCurrency:      cfg.App.BaseCurrency,
+	})
+	productHdl := productHandler.NewProductHandler(
+		productSvc,
+		int64(cfg.Inventory.ImportMaxSizeKB)*1024,
+		int64(cfg.Storage.MaxImageSizeKB)*1024,
+		cfg.Inventory.CompareMaxItems,
+	)
+
+	// Cart Module
+	cartRepository := cartRepo.NewCartRepository(db)
+	cartSvc := cartService.NewCartService(cartRepository, productSvc, cfg.App.BaseCurrency)
+	cartHdl := cartHandler.NewCartHandler(cartSvc)
+
+	// Coupon Module
+	couponRepository := couponRepo.NewCouponRepository(db)
+	couponSvc := couponService.NewCouponService(couponRepository, cfg.App.BaseCurrency)
+	couponHdl := couponHandler.NewCouponHandler(couponSvc)
+
+	// Webhook Module (event order/payment dikirim asynchronous ke URL yang didaftarkan admin)
+	webhookRepository := webhookRepo.NewWebhookRepository(db)
+	webhookSvc := webhookService.NewWebhookService(webhookRepository)
+	webhookHdl := webhookHandler.NewWebhookHandler(webhookSvc)
+	webhookDispatcher := webhookService.NewDispatcher(
+		webhookRepository,
+		cfg.Webhook.MaxAttempts,
+		cfg.Webhook.RetryBaseDelay,
+		cfg.Webhook.Timeout,
+	)
+
+	// Event bus in-process: service mempublish perubahan state, modul lain men-subscribe.
+	// Subscriber dipanggil berurutan sesuai urutan pendaftaran.
+	eventBus := events.NewBus()
+	eventBus.SubscribeAll(events.LogEvent)
+	webhookService.SubscribeToEvents(eventBus, webhookDispatcher)
 
 	// Order Module
 	orderRepository := orderRepo.NewOrderRepository(db)
-	orderSvc := orderService.NewOrderService(orderRepository, productSvc, db)
+	orderSvc := orderService.NewOrderService(orderService.OrderServiceDeps{
+		OrderRepo:          orderRepository,
+		ProductService:     productSvc,
+		CartService:        cartSvc,
+		CouponService:      couponSvc,
+		DB:                 db,
+		ShippingCalculator: orderService.NewFlatRateShipping(cfg.Order.ShippingFlatFee),
+		TaxPercent:         cfg.Order.TaxPercent,
+		UserNotifier:       userNotifier,
+		WebhookDispatcher:  webhookDispatcher,
+		BaseCurrency:       cfg.App.BaseCurrency,
+		EventBus:           eventBus,
+	})
+	orderService.SubscribeToEvents(eventBus, orderSvc)
 	orderHdl := orderHandler.NewOrderHandler(orderSvc)
 
 	// Payment Module
 	paymentRepository := paymentRepo.NewPaymentRepository(db)
-	paymentSvc := paymentService.NewPaymentService(paymentRepository, orderSvc, db)
-	paymentHdl := paymentHandler.NewPaymentHandler(paymentSvc)
+	paymentSvc := paymentService.NewPaymentService(
+		paymentRepository,
+		orderSvc,
+		redisClient,
+		db,
+		time.Duration(cfg.Payment.OrderExpiryMinutes)*time.Minute,
+		paymentService.SimulatorConfig{
+			SuccessRate: cfg.Payment.SimSuccessRate,
+			MinDelay:    cfg.Payment.SimMinDelay,
+			MaxDelay:    cfg.Payment.SimMaxDelay,
+		},
+		eventBus,
+	)
+	paymentHdl := paymentHandler.NewPaymentHandler(
+		paymentSvc,
+		cfg.Payment.WebhookSecret,
+		time.Duration(cfg.Payment.StatusMaxWaitSeconds)*time.Second,
+		time.Duration(cfg.Payment.StreamMaxSeconds)*time.Second,
+	)
+
+	// Review Module
+	reviewRepository := reviewRepo.NewReviewRepository(db)
+	reviewSvc := reviewService.NewReviewService(reviewRepository, productSvc, orderSvc)
+	reviewHdl := reviewHandler.NewReviewHandler(reviewSvc)
+
+	// Dashboard (statistik lintas modul untuk admin)
+	dashboardSvc := dashboardService.NewDashboardService(authSvc, productSvc, orderSvc, paymentSvc, cfg.App.BaseCurrency)
+	dashboardHdl := dashboardHandler.NewDashboardHandler(dashboardSvc)
 
 	// ========================================
 	// Setup Gin Router
@@ -120,79 +345,179 @@ func main() {
 	if cfg.App.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
-	router := gin.Default()
+	router := gin.New()
+	// Gzip dipasang sebelum Recovery agar response 500 dari panic juga melewati writer gzip
+	router.Use(middleware.RequestID(), logger.GinLogger(), middleware.Gzip(cfg.App.GzipMinSize), middleware.Recovery())
+
+	// Gunakan custom validator untuk binding request (password, phone, dll)
+	binding.Validator = validator.NewGinValidator()
 
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// Health check endpoint
-	router.GET("/health", func(ctx *gin.Context) {
-		response.OK(ctx, "Service is healthy", gin.H{
-			"app":    cfg.App.Name,
-			"env":    cfg.App.Env,
-			"status": "running",
-		})
-	})
+	// Health check endpoints: live = proses berjalan, ready = database & Redis bisa dijangkau
+	healthHdl := health.NewHandler(db, redisClient, cfg.App.Name, cfg.App.Env)
+	router.GET("/health/live", healthHdl.Live)
+	router.GET("/health/ready", healthHdl.Ready)
+
+	// File upload lokal disajikan langsung oleh API; driver S3 menyajikan file dari bucket/CDN
+	if localStorage, ok := imageStorage.(*storage.LocalStorage); ok {
+		router.Static(cfg.Storage.PublicBaseURL, localStorage.Dir())
+	}
 
 	// ========================================
 	// API v1 Routes
 	// ========================================
-	v1 := router.Group("/api/v1")
+	// Rate limiter: login/register lebih ketat dari endpoint lain
+	authLimiter := middleware.RateLimit(redisClient, middleware.RateLimitRule{
+		Name:   "auth",
+		Limit:  cfg.RateLimit.AuthPerMinute,
+		Window: time.Minute,
+	})
+	apiLimiter := middleware.RateLimit(redisClient, middleware.RateLimitRule{
+		Name:   "api",
+		Limit:  cfg.RateLimit.APIPerMinute,
+		Window: time.Minute,
+	})
+
+	// Accept-Version (default v1) memilih bentuk response tanpa menggandakan route
+	v1 := router.Group("/api/v1", middleware.APIVersion())
 	{
 		// Auth routes (public)
 		auth := v1.Group("/auth")
 		{
-			auth.POST("/register", authHdl.Register)
-			auth.POST("/login", authHdl.Login)
+			auth.POST("/register", authLimiter, authHdl.Register)
+			auth.POST("/login", authLimiter, authHdl.Login)
 			auth.POST("/logout", authHdl.Logout)
+			auth.POST("/refresh", authHdl.RefreshToken)
+			auth.POST("/forgot-password", authLimiter, authHdl.ForgotPassword)
+			auth.POST("/reset-password", authLimiter, authHdl.ResetPassword)
 
 			// Protected route - requires authentication
 			auth.GET("/me", authMiddleware.AuthMiddleware(jwtService, authSvc), authHdl.GetProfile)
+			auth.PUT("/me", authMiddleware.AuthMiddleware(jwtService, authSvc), authHdl.UpdateProfile)
+			auth.PUT("/password", authMiddleware.AuthMiddleware(jwtService, authSvc), authHdl.ChangePassword)
+			auth.DELETE("/me", authMiddleware.AuthMiddleware(jwtService, authSvc), authHdl.DeactivateAccount)
+			auth.POST("/refresh-claims", authMiddleware.AuthMiddleware(jwtService, authSvc), authHdl.RefreshClaims)
+			auth.GET("/sessions", authMiddleware.AuthMiddleware(jwtService, authSvc), authHdl.GetSessions)
+			auth.DELETE("/sessions/:jti", authMiddleware.AuthMiddleware(jwtService, authSvc), authHdl.RevokeSession)
 		}
 
 		// Categories routes (public read, protected write)
 		categories := v1.Group("/categories")
+		categories.Use(apiLimiter)
 		{
 			categories.GET("", productHdl.GetAllCategories)
+			categories.GET("/tree", productHdl.GetCategoryTree)
 			categories.GET("/:id", productHdl.GetCategory)
 
 			// Admin only - create/update/delete categories
 			categories.Use(authMiddleware.AuthMiddleware(jwtService, authSvc))
 			categories.Use(authMiddleware.RoleMiddleware(authEntity.RoleAdmin))
 			categories.POST("", productHdl.CreateCategory)
+			categories.POST("/batch", productHdl.CreateCategories)
 			categories.PUT("/:id", productHdl.UpdateCategory)
 			categories.DELETE("/:id", productHdl.DeleteCategory)
 		}
 
 		// Products routes (public read)
 		products := v1.Group("/products")
+		products.Use(apiLimiter)
 		{
 			products.GET("", productHdl.GetAllProducts)
+			products.GET("/sku/:sku", productHdl.GetProductBySKU)
+			products.POST("/check-availability", productHdl.CheckAvailability)
+			products.GET("/compare", productHdl.CompareProducts)
 			products.GET("/:id", productHdl.GetProduct)
+			products.GET("/:id/reviews", reviewHdl.GetReviewsByProduct)
+			products.GET("/:id/variants", productHdl.ListVariants)
+		}
+
+		// Seller storefront (public read)
+		v1.GET("/sellers/:id/products", apiLimiter, productHdl.GetSellerProducts)
+
+		// Guest order routes (public, order diakses lewat lookup token)
+		guestOrders := v1.Group("/orders")
+		{
+			guestOrders.POST("/guest-checkout", authLimiter, orderHdl.GuestCheckout)
+			guestOrders.GET("/guest/:token", apiLimiter, orderHdl.GetGuestOrder)
+		}
+
+		// Webhook routes (public, diverifikasi via signature HMAC)
+		webhooks := v1.Group("/webhooks")
+		{
+			webhooks.POST("/payment", paymentHdl.PaymentWebhook)
+		}
+
+		// Download dan stream routes: token juga boleh lewat query access_token karena browser tidak bisa
+		// mengirim header Authorization saat mengunduh file lewat link atau membuka EventSource (SSE)
+		queryTokenRoutes := v1.Group("")
+		queryTokenRoutes.Use(authMiddleware.QueryTokenAuthMiddleware(jwtService, authSvc))
+		queryTokenRoutes.Use(apiLimiter)
+		{
+			queryTokenRoutes.GET("/admin/orders/export", authMiddleware.RoleMiddleware(authEntity.RoleAdmin), orderHdl.ExportOrders)
+			queryTokenRoutes.GET("/payments/:id/stream", paymentHdl.StreamPaymentStatus)
 		}
 
 		// Protected routes group (requires authentication)
 		protected := v1.Group("")
 		protected.Use(authMiddleware.AuthMiddleware(jwtService, authSvc))
+		protected.Use(apiLimiter) // setelah auth agar limit dihitung per user
 		{
 			// Product management (seller/admin only)
 			protectedProducts := protected.Group("/products")
 			protectedProducts.Use(authMiddleware.RoleMiddleware(authEntity.RoleSeller, authEntity.RoleAdmin))
 			{
-				protectedProducts.POST("", productHdl.CreateProduct)
+				protectedProducts.POST("", authMiddleware.ApprovedSellerMiddleware(authSvc), productHdl.CreateProduct)
 				protectedProducts.PUT("/:id", productHdl.UpdateProduct)
+				protectedProducts.PATCH("/:id", productHdl.UpdateProduct)
 				protectedProducts.DELETE("/:id", productHdl.DeleteProduct)
 				protectedProducts.PATCH("/:id/stock", productHdl.UpdateStock)
+				protectedProducts.GET("/:id/price-history", productHdl.GetPriceHistory)
+				protectedProducts.POST("/:id/image", productHdl.UploadProductImage)
+				protectedProducts.POST("/:id/tags", productHdl.AttachProductTags)
+				protectedProducts.DELETE("/:id/tags", productHdl.DetachProductTags)
+				protectedProducts.POST("/:id/variants", productHdl.AddVariant)
+				protectedProducts.PUT("/:id/variants/:variantId", productHdl.UpdateVariant)
+				protectedProducts.DELETE("/:id/variants/:variantId", productHdl.DeleteVariant)
 			}
 
+			// Review routes (any authenticated buyer)
+			protected.POST("/products/:id/reviews", reviewHdl.CreateReview)
+			protected.DELETE("/reviews/:id", reviewHdl.DeleteReview)
+
+			// Back-in-stock notification (any authenticated buyer)
+			protected.POST("/products/:id/stock-subscription", productHdl.SubscribeBackInStock)
+			protected.DELETE("/products/:id/stock-subscription", productHdl.UnsubscribeBackInStock)
+
+			// Cart routes
+			cart := protected.Group("/cart")
+			{
+				cart.GET("", cartHdl.GetCart)
+				cart.DELETE("", cartHdl.ClearCart)
+				cart.POST("/items", cartHdl.AddItem)
+				cart.PATCH("/items/:id", cartHdl.UpdateItem)
+				cart.DELETE("/items/:id", cartHdl.RemoveItem)
+			}
+
+			// Coupon routes
+			protected.POST("/coupons/validate", couponHdl.ValidateCoupon)
+
 			// Order routes
 			orders := protected.Group("/orders")
 			{
 				orders.POST("/checkout", orderHdl.Checkout)
+				orders.POST("/checkout/cart", orderHdl.CheckoutFromCart)
+				orders.POST("/quote", orderHdl.QuoteCheckout)
 				orders.GET("", orderHdl.GetMyOrders)
+				orders.GET("/stats", orderHdl.GetMyOrderStats)
 				orders.GET("/:id", orderHdl.GetOrder)
+				orders.GET("/:id/history", orderHdl.GetOrderHistory)
+				orders.POST("/:id/notes", orderHdl.AddOrderNote)
+				orders.GET("/:id/notes", orderHdl.GetOrderNotes)
 				orders.PATCH("/:id/status", orderHdl.UpdateOrderStatus)
 				orders.POST("/:id/cancel", orderHdl.CancelOrder)
+				orders.POST("/:id/items/:itemID/return", orderHdl.ReturnOrderItem)
 				orders.GET("/:id/payment", paymentHdl.GetPaymentByOrder)
 			}
 
@@ -202,28 +527,54 @@ func main() {
 				payments.POST("", paymentHdl.CreatePayment)
 				payments.GET("", paymentHdl.GetMyPayments)
 				payments.GET("/:id", paymentHdl.GetPayment)
-				payments.POST("/callback", paymentHdl.PaymentCallback) // For testing
+				payments.GET("/:id/status", paymentHdl.GetPaymentStatus)
+				// Simulasi callback gateway untuk testing manual: hanya admin dan tidak tersedia di production.
+				// Gateway asli memakai /webhooks/payment yang diverifikasi signature-nya.
+				if !cfg.IsProduction() {
+					payments.POST("/callback", authMiddleware.RoleMiddleware(authEntity.RoleAdmin), paymentHdl.PaymentCallback)
+				}
 			}
 
 			// Seller routes
 			seller := protected.Group("/seller")
 			seller.Use(authMiddleware.RoleMiddleware(authEntity.RoleSeller, authEntity.RoleAdmin))
 			{
-				seller.GET("/dashboard", func(ctx *gin.Context) {
-					response.OK(ctx, "Seller dashboard", nil)
-				})
+				seller.GET("/dashboard", orderHdl.GetSellerDashboard)
+				seller.GET("/reports/sales", orderHdl.GetSellerSalesReport)
 				seller.GET("/products", productHdl.GetMyProducts)
+				seller.POST("/products/import", authMiddleware.ApprovedSellerMiddleware(authSvc), productHdl.ImportProducts)
+				seller.GET("/products/low-stock", productHdl.GetLowStockProducts)
+				seller.DELETE("/products/bulk", productHdl.BulkDeleteProducts)
+				seller.GET("/products/:id/inventory-log", productHdl.GetInventoryLog)
+				seller.GET("/products/:id/stats", orderHdl.GetProductSalesStats)
 			}
 
 			// Admin only routes
 			admin := protected.Group("/admin")
 			admin.Use(authMiddleware.RoleMiddleware(authEntity.RoleAdmin))
 			{
-				admin.GET("/dashboard", func(ctx *gin.Context) {
-					response.OK(ctx, "Admin dashboard", nil)
-				})
+				admin.GET("/dashboard", dashboardHdl.GetAdminDashboard)
 				admin.GET("/orders", orderHdl.GetAllOrders)
+				admin.POST("/orders/:id/cancel", paymentHdl.ForceCancelOrder)
 				admin.GET("/payments", paymentHdl.GetAllPayments)
+				admin.GET("/payments/search", paymentHdl.SearchPayments)
+				admin.POST("/payments/:id/refund", paymentHdl.RefundPayment)
+				admin.POST("/coupons", couponHdl.CreateCoupon)
+				admin.GET("/coupons", couponHdl.GetAllCoupons)
+				admin.POST("/webhooks", webhookHdl.CreateWebhook)
+				admin.GET("/webhooks", webhookHdl.GetAllWebhooks)
+				admin.GET("/webhooks/dead-letters", webhookHdl.GetDeadLetters)
+				admin.GET("/webhooks/:id", webhookHdl.GetWebhook)
+				admin.PUT("/webhooks/:id", webhookHdl.UpdateWebhook)
+				admin.DELETE("/webhooks/:id", webhookHdl.DeleteWebhook)
+				admin.GET("/users", authHdl.GetAllUsers)
+				admin.PATCH("/users/:id/role", authHdl.UpdateUserRole)
+				admin.PATCH("/users/:id/status", authHdl.UpdateUserStatus)
+				admin.DELETE("/products/:id", productHdl.AdminDeleteProduct)
+				admin.PATCH("/products/:id/stock", productHdl.AdminSetStock)
+				admin.GET("/sellers", authHdl.GetAllSellers)
+				admin.PATCH("/sellers/:id/approve", authHdl.ApproveSeller)
+				admin.PATCH("/sellers/:id/reject", authHdl.RejectSeller)
 			}
 		}
 	}
@@ -235,10 +586,48 @@ func main() {
 
 	// Start server
 	serverAddr := ":" + cfg.App.Port
-	log.Printf("Starting %s server on %s (env: %s)", cfg.App.Name, serverAddr, cfg.App.Env)
-	log.Printf("Swagger docs available at http://localhost%s/swagger/index.html", serverAddr)
+	logger.Info().Str("app", cfg.App.Name).Str("addr", serverAddr).Str("env", cfg.App.Env).Msg("Starting server")
+	logger.Info().Msgf("Swagger docs available at http://localhost%s/swagger/index.html", serverAddr)
 
-	if err := http.ListenAndServe(serverAddr, router); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	srv := &http.Server{
+		Addr:    serverAddr,
+		Handler: router,
 	}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal().Err(err).Msg("Failed to start server")
+		}
+	}()
+
+	// Background worker: batalkan order PENDING yang tidak dibayar
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	go paymentSvc.RunExpiryWorker(workerCtx, time.Duration(cfg.Payment.ExpiryCheckIntervalSeconds)*time.Second)
+	go productSvc.RunReservationWorker(workerCtx, time.Duration(cfg.Inventory.ReservationCheckIntervalSeconds)*time.Second)
+
+	// Graceful shutdown: tunggu SIGINT/SIGTERM
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	logger.Info().Msg("Shutting down server...")
+	stopWorkers()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Stop menerima request baru dulu, lalu drain payment async yang sedang berjalan
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error().Err(err).Msg("Server forced to shutdown")
+	}
+	if err := paymentSvc.Shutdown(ctx); err != nil {
+		logger.Warn().Err(err).Msg("Timed out waiting for in-flight payments")
+	}
+	if err := userNotifier.Shutdown(ctx); err != nil {
+		logger.Warn().Err(err).Msg("Timed out waiting for pending email notifications")
+	}
+	if err := webhookDispatcher.Shutdown(ctx); err != nil {
+		logger.Warn().Err(err).Msg("Timed out waiting for pending webhook deliveries")
+	}
+
+	logger.Info().Msg("Server exited")
 }
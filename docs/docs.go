@@ -23,14 +23,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/orders": {
+        "/admin/coupons": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all orders with filters and pagination (Admin only)",
+                "description": "Get all coupons with pagination (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -38,9 +33,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Coupons"
                 ],
-                "summary": "Get all orders (Admin)",
+                "summary": "Get all coupons",
                 "parameters": [
                     {
                         "type": "integer",
@@ -55,19 +50,6 @@ const docTemplate = `{
                         "description": "Items per page",
                         "name": "limit",
                         "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "PENDING",
-                            "PAID",
-                            "SHIPPED",
-                            "COMPLETED",
-                            "CANCELLED"
-                        ],
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -82,15 +64,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderListResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.CouponListResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
@@ -101,92 +83,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            }
-        },
-        "/admin/payments": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get all payments with filters and pagination (Admin only)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Admin"
-                ],
-                "summary": "Get all payments (Admin)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "PENDING",
-                            "PROCESSING",
-                            "SUCCESS",
-                            "FAILED"
-                        ],
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentListResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/login": {
+                ]
+            },
             "post": {
-                "description": "Authenticate user and return JWT token",
+                "description": "Create a new discount coupon (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -194,23 +99,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Coupons"
                 ],
-                "summary": "Login user",
+                "summary": "Create coupon",
                 "parameters": [
                     {
-                        "description": "Login request",
+                        "description": "Create coupon request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.LoginRequest"
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.CreateCouponRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -220,7 +125,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.AuthResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.CouponResponse"
                                         }
                                     }
                                 }
@@ -238,52 +143,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/auth/logout": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Logout user and blacklist the token",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth"
-                ],
-                "summary": "Logout user",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            }
-        },
-        "/auth/me": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get the profile of the currently authenticated user",
+                ]
+            }
+        },
+        "/admin/dashboard": {
+            "get": {
+                "description": "Platform-wide stats: users by role, total products, orders by status, successful payment volume, and daily order counts for the last 30 days (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -291,9 +180,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Get current user profile",
+                "summary": "Get admin dashboard",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -306,7 +195,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_dashboard_dto.AdminDashboardResponse"
                                         }
                                     }
                                 }
@@ -319,18 +208,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user account",
+        "/admin/orders": {
+            "get": {
+                "description": "Get all orders with filters and pagination (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -338,23 +232,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Register new user",
+                "summary": "Get all orders (Admin)",
                 "parameters": [
                     {
-                        "description": "Register request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.RegisterRequest"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "PAID",
+                            "SHIPPED",
+                            "COMPLETED",
+                            "CANCELLED",
+                            "REFUNDED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at_asc",
+                            "created_at_desc",
+                            "amount_asc",
+                            "amount_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default created_at_desc)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created from (YYYY-MM-DD, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created until (YYYY-MM-DD, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response version: v1 (default) or v2 (order amounts grouped under pricing)",
+                        "name": "Accept-Version",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -364,7 +307,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.AuthResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderListResponse"
                                         }
                                     }
                                 }
@@ -377,59 +320,106 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/categories": {
+        "/admin/orders/export": {
             "get": {
-                "description": "Get all product categories",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Download all orders matching the filters as CSV with columns order_id, user_id, status, total, created_at, item_count. Pagination is ignored",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
+                ],
+                "summary": "Export orders as CSV (Admin)",
+                "parameters": [
+                    {
+                        "enum": [
+                            "PENDING",
+                            "PAID",
+                            "SHIPPED",
+                            "COMPLETED",
+                            "CANCELLED",
+                            "REFUNDED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at_asc",
+                            "created_at_desc",
+                            "amount_asc",
+                            "amount_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default created_at_desc)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created from (YYYY-MM-DD, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created until (YYYY-MM-DD, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Access token, used only when the Authorization header is absent (e.g. browser download links)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all categories",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a new product category (Admin only)",
+                ]
+            }
+        },
+        "/admin/orders/{id}/cancel": {
+            "post": {
+                "description": "Cancel any PENDING or PAID order regardless of owner. Stock is restored and a SUCCESS payment is refunded in the same transaction. The admin and reason are recorded in the order status history",
                 "consumes": [
                     "application/json"
                 ],
@@ -437,23 +427,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Create a new category",
+                "summary": "Force-cancel order (Admin)",
                 "parameters": [
                     {
-                        "description": "Create category request",
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation reason",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateCategoryRequest"
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.ForceCancelOrderRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -463,7 +460,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.ForceCancelOrderResponse"
                                         }
                                     }
                                 }
@@ -482,18 +479,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/categories/{id}": {
+        "/admin/payments": {
             "get": {
-                "description": "Get a single category by its ID",
+                "description": "Get all payments with filters and pagination (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -501,16 +509,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Get category by ID",
+                "summary": "Get all payments (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "PROCESSING",
+                            "SUCCESS",
+                            "FAILED",
+                            "REFUNDED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by order ID",
+                        "name": "order_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at_asc",
+                            "created_at_desc",
+                            "amount_asc",
+                            "amount_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default created_at_desc)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -525,7 +577,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentListResponse"
                                         }
                                     }
                                 }
@@ -538,21 +590,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update a product category (Admin only)",
+                ]
+            }
+        },
+        "/admin/payments/search": {
+            "get": {
+                "description": "Find a payment by its gateway transaction ID, returned with its order. Without transaction_id, user_id and/or order_id filter a paginated payment list (data is then a PaymentListResponse)",
                 "consumes": [
                     "application/json"
                 ],
@@ -560,25 +614,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Update category",
+                "summary": "Search payments (Admin)",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gateway transaction ID",
+                        "name": "transaction_id",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Update category request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateCategoryRequest"
-                        }
+                        "type": "integer",
+                        "description": "Filter by order ID",
+                        "name": "order_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "PROCESSING",
+                            "SUCCESS",
+                            "FAILED",
+                            "REFUNDED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at_asc",
+                            "created_at_desc",
+                            "amount_asc",
+                            "amount_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default created_at_desc)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -593,7 +688,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentDetailResponse"
                                         }
                                     }
                                 }
@@ -618,15 +713,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Delete a product category (Admin only)",
+                ]
+            }
+        },
+        "/admin/payments/{id}/refund": {
+            "post": {
+                "description": "Refund a SUCCESS payment and move its order to REFUNDED. Stock is restored if the order has not shipped yet",
                 "consumes": [
                     "application/json"
                 ],
@@ -634,23 +731,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Delete category",
+                "summary": "Refund payment (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Payment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Refund reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.RefundPaymentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -670,18 +788,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
                     }
-                }
-            }
-        },
-        "/orders": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get orders belonging to the current user",
+                ]
+            }
+        },
+        "/admin/products/{id}": {
+            "delete": {
+                "description": "Delete a product regardless of its owner. Soft delete by default. With hard=true the product is removed permanently together with its variants, inventory log, price history and stock reservations (also works on an already soft-deleted product); this is refused with 409 while orders that are not cancelled still contain the product.",
                 "consumes": [
                     "application/json"
                 ],
@@ -689,35 +813,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Get my orders",
+                "summary": "Delete any product (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "PENDING",
-                            "PAID",
-                            "SHIPPED",
-                            "COMPLETED",
-                            "CANCELLED"
-                        ],
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
+                        "type": "boolean",
+                        "description": "Delete permanently",
+                        "name": "hard",
                         "in": "query"
                     }
                 ],
@@ -725,19 +835,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderListResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
                     "400": {
@@ -746,47 +844,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/orders/checkout": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new order from cart items",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Orders"
-                ],
-                "summary": "Checkout order",
-                "parameters": [
-                    {
-                        "description": "Checkout request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.CheckoutRequest"
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "allOf": [
                                 {
@@ -795,37 +866,25 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                                        "error": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductInUseResponse"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
-                        }
                     }
-                }
-            }
-        },
-        "/orders/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get a single order by its ID",
+                ]
+            }
+        },
+        "/admin/products/{id}/stock": {
+            "patch": {
+                "description": "Set the stock of any product to an absolute value, e.g. to correct inventory during a dispute. The reason is required and stored in the inventory log. The stock cannot be lower than the quantity reserved by pending orders. Products with variants are managed per variant.",
                 "consumes": [
                     "application/json"
                 ],
@@ -833,16 +892,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Get order by ID",
+                "summary": "Set product stock (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Product ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Set stock request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.AdminSetStockRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -857,7 +925,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
                                         }
                                     }
                                 }
@@ -881,18 +949,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
                     }
-                }
-            }
-        },
-        "/orders/{id}/cancel": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Cancel an order and restore stock",
+                ]
+            }
+        },
+        "/admin/sellers": {
+            "get": {
+                "description": "Get seller profiles with optional status filter and pagination, oldest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -900,23 +980,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Cancel order",
+                "summary": "List seller applications (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "APPROVED",
+                            "REJECTED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.SellerListResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -930,24 +1040,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
-                        }
                     }
-                }
-            }
-        },
-        "/orders/{id}/payment": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get the payment associated with an order",
+                ]
+            }
+        },
+        "/admin/sellers/{id}/approve": {
+            "patch": {
+                "description": "Approve a seller application so the seller can create products",
                 "consumes": [
                     "application/json"
                 ],
@@ -955,13 +1059,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Get payment by order ID",
+                "summary": "Approve seller (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Seller user ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -979,7 +1083,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.SellerProfileResponse"
                                         }
                                     }
                                 }
@@ -992,23 +1096,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            }
-        },
-        "/orders/{id}/status": {
-            "patch": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update the status of an order",
+                ]
+            }
+        },
+        "/admin/sellers/{id}/reject": {
+            "patch": {
+                "description": "Reject a seller application; the seller cannot create products",
                 "consumes": [
                     "application/json"
                 ],
@@ -1016,25 +1126,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Update order status",
+                "summary": "Reject seller (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Seller user ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update status request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.UpdateOrderStatusRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -1049,7 +1150,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.SellerProfileResponse"
                                         }
                                     }
                                 }
@@ -1074,27 +1175,27 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            }
-        },
-        "/payments": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get payments belonging to the current user",
-                "consumes": [
-                    "application/json"
+                ]
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "description": "Get all users with role/email filters and pagination (Admin only)",
+                "consumes": [
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Payments"
+                    "Admin"
                 ],
-                "summary": "Get my payments",
+                "summary": "Get all users (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1112,14 +1213,19 @@ const docTemplate = `{
                     },
                     {
                         "enum": [
-                            "PENDING",
-                            "PROCESSING",
-                            "SUCCESS",
-                            "FAILED"
+                            "admin",
+                            "seller",
+                            "user"
                         ],
                         "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
+                        "description": "Filter by role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by email substring",
+                        "name": "email",
                         "in": "query"
                     }
                 ],
@@ -1135,7 +1241,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentListResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserListResponse"
                                         }
                                     }
                                 }
@@ -1148,21 +1254,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a new payment for an order (triggers async processing)",
+                ]
+            }
+        },
+        "/admin/users/{id}/role": {
+            "patch": {
+                "description": "Change a user's role. The last remaining admin cannot be demoted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1170,23 +1278,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payments"
+                    "Admin"
                 ],
-                "summary": "Create payment",
+                "summary": "Update user role (Admin)",
                 "parameters": [
                     {
-                        "description": "Create payment request",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update role request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.CreatePaymentRequest"
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UpdateRoleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1196,7 +1311,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserResponse"
                                         }
                                     }
                                 }
@@ -1209,8 +1324,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
@@ -1220,69 +1335,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/payments/callback": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Manual payment callback for testing purposes",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Payments"
-                ],
-                "summary": "Payment callback (Testing)",
-                "parameters": [
-                    {
-                        "description": "Payment callback request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentCallbackRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            }
-        },
-        "/payments/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get a single payment by its ID",
+                ]
+            }
+        },
+        "/admin/users/{id}/status": {
+            "patch": {
+                "description": "Activate or deactivate a user account. Deactivated users cannot log in, their tokens are rejected and their products are hidden from public listings. The last active admin cannot be deactivated.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1290,16 +1366,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payments"
+                    "Admin"
                 ],
-                "summary": "Get payment by ID",
+                "summary": "Activate or deactivate user (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Payment ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update status request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UpdateUserStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1314,7 +1399,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserResponse"
                                         }
                                     }
                                 }
@@ -1338,13 +1423,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/products": {
+        "/admin/webhooks": {
             "get": {
-                "description": "Get all products with filters and pagination",
+                "description": "Get all registered webhooks with pagination (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1352,9 +1454,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Webhooks"
                 ],
-                "summary": "Get all products",
+                "summary": "Get all webhooks",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1369,30 +1471,6 @@ const docTemplate = `{
                         "description": "Items per page",
                         "name": "limit",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by name",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by category ID",
-                        "name": "category_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Minimum price",
-                        "name": "min_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Maximum price",
-                        "name": "max_price",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1407,28 +1485,34 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductListResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.WebhookListResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a new product (Seller/Admin only)",
+                ]
+            },
+            "post": {
+                "description": "Register a URL that receives signed JSON notifications for the subscribed events (order.status_changed, payment.succeeded, payment.failed). Each delivery carries an X-Webhook-Signature header with the hex-encoded HMAC-SHA256 of the raw body using the webhook secret. If no secret is given one is generated; the secret is only returned in this response (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1436,17 +1520,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Webhooks"
                 ],
-                "summary": "Create a new product",
+                "summary": "Create webhook",
                 "parameters": [
                     {
-                        "description": "Create product request",
+                        "description": "Create webhook request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateProductRequest"
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.CreateWebhookRequest"
                         }
                     }
                 ],
@@ -1462,7 +1546,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.WebhookResponse"
                                         }
                                     }
                                 }
@@ -1475,24 +1559,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/products/{id}": {
+        "/admin/webhooks/dead-letters": {
             "get": {
-                "description": "Get a single product by its ID",
+                "description": "Get deliveries that still failed after every retry, newest first (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1500,16 +1595,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Webhooks"
                 ],
-                "summary": "Get product by ID",
+                "summary": "Get webhook dead letters",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by webhook ID",
+                        "name": "webhook_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1524,7 +1632,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.DeadLetterListResponse"
                                         }
                                     }
                                 }
@@ -1537,21 +1645,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update a product (Owner only)",
+                ]
+            }
+        },
+        "/admin/webhooks/{id}": {
+            "get": {
+                "description": "Get a webhook by ID (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1559,25 +1675,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Webhooks"
                 ],
-                "summary": "Update product",
+                "summary": "Get webhook",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update product request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateProductRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -1592,7 +1699,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.WebhookResponse"
                                         }
                                     }
                                 }
@@ -1605,7 +1712,13 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "403": {
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
                         "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
@@ -1617,15 +1730,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Delete a product (Owner only)",
+                ]
+            },
+            "put": {
+                "description": "Update the URL, subscribed events, secret or active flag of a webhook (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1633,23 +1746,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Webhooks"
                 ],
-                "summary": "Delete product",
+                "summary": "Update webhook",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update webhook request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.UpdateWebhookRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.WebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1658,6 +1792,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -1669,18 +1809,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
                     }
-                }
-            }
-        },
-        "/products/{id}/stock": {
-            "patch": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Add or reduce product stock (Owner only)",
+                ]
+            },
+            "delete": {
+                "description": "Delete a webhook; no further events are delivered to it (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1688,24 +1832,130 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Webhooks"
                 ],
-                "summary": "Update product stock",
+                "summary": "Delete webhook",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "description": "Update stock request",
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Generate a single-use password reset token for the given email. The response is the same whether or not the email is registered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Request password reset",
+                "parameters": [
+                    {
+                        "description": "Forgot password request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateStockRequest"
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user and return a Bearer access token (with its expires_at) and a refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.LoginRequest"
                         }
                     }
                 ],
@@ -1721,7 +1971,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.AuthResponse"
                                         }
                                     }
                                 }
@@ -1734,14 +1984,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
@@ -1749,14 +2011,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/seller/products": {
-            "get": {
+        "/auth/logout": {
+            "post": {
+                "description": "Logout user, blacklist the access token, end its session and revoke the refresh token if provided",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout user",
+                "parameters": [
+                    {
+                        "description": "Logout request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get products owned by the current seller",
+                ]
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "description": "Get the profile of the currently authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1764,9 +2077,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Seller"
+                    "Auth"
                 ],
-                "summary": "Get my products",
+                "summary": "Get current user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1779,10 +2092,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
-                                            }
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserResponse"
                                         }
                                     }
                                 }
@@ -1794,170 +2104,7849 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.AuthResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserResponse"
-                }
-            }
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Update the name and phone number of the currently authenticated user. An empty phone removes the stored number. Email cannot be changed here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Update current user profile",
+                "parameters": [
+                    {
+                        "description": "Update profile request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Deactivate the currently authenticated account and revoke the current token. The account can only be reactivated by an admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Deactivate my account",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/password": {
+            "put": {
+                "description": "Change the password of the currently authenticated user. All sessions of the user, including their refresh tokens, are revoked, so the user must log in again on every device.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "description": "Change password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a valid refresh token for a new Bearer access token (with its expires_at)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.RefreshTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh-claims": {
+            "post": {
+                "description": "Issue a new access token with the user's current role and email, e.g. after an admin changed the role, without logging in again. The new token keeps the current session and the token used for this request is revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh token claims",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.RefreshTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user account and return a Bearer access token (with its expires_at) and a refresh token. The phone number is optional but must be unique. Sellers start with a PENDING seller profile and cannot create products until an admin approves them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register new user",
+                "parameters": [
+                    {
+                        "description": "Register request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "Set a new password using a password reset token. The token can only be used once, and all existing sessions of the user are revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "description": "List the active login sessions (one per login, identified by jti) of the current user, newest first, with the IP address and user agent that logged in. The session of the current token is marked current.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "List my active sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.SessionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/sessions/{jti}": {
+            "delete": {
+                "description": "Log out one of the current user's sessions, e.g. on another device. Its refresh token stops working and its access tokens are rejected immediately. Revoking the current session logs out the current token as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Revoke a session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID (jti)",
+                        "name": "jti",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/cart": {
+            "get": {
+                "description": "Get the current user's cart with product details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Get my cart",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_cart_dto.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Remove all items from the current user's cart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Clear cart",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/cart/items": {
+            "post": {
+                "description": "Add a product to the current user's cart (quantity is merged if the same product and variant is already present). Products with variants require variant_id; stock is checked against the variant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Add item to cart",
+                "parameters": [
+                    {
+                        "description": "Add cart item request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_cart_dto.AddCartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_cart_dto.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/cart/items/{id}": {
+            "delete": {
+                "description": "Remove an item from the current user's cart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Remove cart item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cart item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_cart_dto.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Update the quantity of an item in the current user's cart, and optionally switch it to another variant of the same product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Update cart item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cart item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update cart item request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_cart_dto.UpdateCartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_cart_dto.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Get all product categories with ` + "`" + `product_count` + "`" + `, the number of active products visible in public listings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get all categories",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new product category (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Create a new category",
+                "parameters": [
+                    {
+                        "description": "Create category request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/categories/batch": {
+            "post": {
+                "description": "Create up to 100 categories at once (Admin only). All categories are created in one transaction: if any name already exists or appears twice in the batch, nothing is created and 409 lists the colliding names. Parents must be existing categories.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Create categories in batch",
+                "parameters": [
+                    {
+                        "description": "Categories to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.BatchCreateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryBatchConflictResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/categories/tree": {
+            "get": {
+                "description": "Get all product categories as a nested tree",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get category tree",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "description": "Get a single category by its ID with ` + "`" + `product_count` + "`" + `, the number of active products visible in public listings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get category by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a product category (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Update category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update category request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Delete a product category (Admin only). If products still use it, pass reassign_to to move them to another category or unassign=true to clear their category; otherwise 409 is returned with the number of affected products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Delete category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Move the category's products to this category",
+                        "name": "reassign_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Clear the category of the category's products",
+                        "name": "unassign",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryInUseResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/coupons/validate": {
+            "post": {
+                "description": "Check a coupon code against a subtotal without redeeming it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Validate coupon",
+                "parameters": [
+                    {
+                        "description": "Validate coupon request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.ValidateCouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.ValidateCouponResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Check that the process is up (does not touch dependencies)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pkg_health.LivenessResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Ping PostgreSQL and Redis; returns 503 with per-dependency status if any check fails",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pkg_health.ReadinessResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/pkg_health.ReadinessResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "description": "Get orders belonging to the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get my orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "PAID",
+                            "SHIPPED",
+                            "COMPLETED",
+                            "CANCELLED",
+                            "REFUNDED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at_asc",
+                            "created_at_desc",
+                            "amount_asc",
+                            "amount_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default created_at_desc)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created from (YYYY-MM-DD, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created until (YYYY-MM-DD, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response version: v1 (default) or v2 (order amounts grouped under pricing)",
+                        "name": "Accept-Version",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/checkout": {
+            "post": {
+                "description": "Create a new order from cart items. Retrying with the same Idempotency-Key header (per user, valid for 24 hours) returns the order created by the first request instead of creating a new one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Checkout order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client-generated key to make the checkout safe to retry (max 255 characters)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Checkout request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.CheckoutRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response version: v1 (default) or v2 (order amounts grouped under pricing)",
+                        "name": "Accept-Version",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/checkout/cart": {
+            "post": {
+                "description": "Create a new order from the items stored in the current user's cart, then clear the cart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Checkout from cart",
+                "parameters": [
+                    {
+                        "description": "Cart checkout request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.CartCheckoutRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response version: v1 (default) or v2 (order amounts grouped under pricing)",
+                        "name": "Accept-Version",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/guest-checkout": {
+            "post": {
+                "description": "Create an order without an account. The response contains a lookup token that is needed to view the order later; it is only returned once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Guest checkout",
+                "parameters": [
+                    {
+                        "description": "Guest checkout request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.GuestCheckoutRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response version: v1 (default) or v2 (order amounts grouped under pricing)",
+                        "name": "Accept-Version",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.GuestCheckoutResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/guest/{token}": {
+            "get": {
+                "description": "Get an order created through guest checkout using its lookup token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get guest order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lookup token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response version: v1 (default) or v2 (order amounts grouped under pricing)",
+                        "name": "Accept-Version",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/quote": {
+            "post": {
+                "description": "Calculate the subtotal, coupon discount, shipping fee, tax and total of a checkout without creating an order. Runs the same checks as checkout (products, variants, stock, coupon) but does not reserve stock or use up the coupon",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Quote checkout",
+                "parameters": [
+                    {
+                        "description": "Checkout request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.CheckoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.QuoteResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/stats": {
+            "get": {
+                "description": "Summary of the current user's orders: total orders, total spent (COMPLETED orders only), order count per status, and the date of the most recent order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get my order statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.MyOrderStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "Get a single order by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response version: v1 (default) or v2 (order amounts grouped under pricing)",
+                        "name": "Accept-Version",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/cancel": {
+            "post": {
+                "description": "Cancel an order and restore stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/history": {
+            "get": {
+                "description": "Get the status timeline of an order (owner or admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order status history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderStatusHistoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/items/{itemID}/return": {
+            "post": {
+                "description": "Return part of an item from a PAID or SHIPPED order. Restores stock and reduces the order's outstanding total",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Return order item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Order item ID",
+                        "name": "itemID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Return request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.ReturnItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderReturnResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/notes": {
+            "get": {
+                "description": "Get the notes of an order, oldest first. Buyers only see non-internal notes; sellers of an item in the order and admins see all notes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order notes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderNoteResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Append a timestamped note to an order (buyer, seller of an item in the order, or admin). Only sellers and admins can add internal notes, which buyers cannot see",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Add order note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.CreateOrderNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderNoteResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/payment": {
+            "get": {
+                "description": "Get the payment associated with an order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get payment by order ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/status": {
+            "patch": {
+                "description": "Update the status of an order. Allowed transitions: PENDING→PAID/CANCELLED, PAID→SHIPPED/REFUNDED, SHIPPED→COMPLETED/REFUNDED, COMPLETED→REFUNDED; admins may also move PAID→COMPLETED. Paid orders are cancelled with POST /admin/orders/{id}/cancel, which also refunds the payment. Moving to SHIPPED requires a tracking_number (carrier and estimated_delivery optional) and is also allowed for sellers of an item in the order. Non-admin order owners may only set CANCELLED; PAID is set by the payment flow or an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Update order status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update status request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.UpdateOrderStatusRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response version: v1 (default) or v2 (order amounts grouped under pricing)",
+                        "name": "Accept-Version",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments": {
+            "get": {
+                "description": "Get payments belonging to the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get my payments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "PROCESSING",
+                            "SUCCESS",
+                            "FAILED",
+                            "REFUNDED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at_asc",
+                            "created_at_desc",
+                            "amount_asc",
+                            "amount_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default created_at_desc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a new payment for an order (triggers async processing)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Create payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Create payment request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.CreatePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments/callback": {
+            "post": {
+                "description": "Manual payment callback for testing purposes. Admin only and not available in production; payment gateways use /webhooks/payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Payment callback (Testing)",
+                "parameters": [
+                    {
+                        "description": "Payment callback request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentCallbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments/{id}": {
+            "get": {
+                "description": "Get a single payment by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get payment by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments/{id}/status": {
+            "get": {
+                "description": "Get only the status, transaction ID and paid time of a payment. With wait=true, the request is held until the payment is final (SUCCESS/FAILED/REFUNDED) or the server's maximum wait passes, then the latest status is returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get payment status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Wait for a final status (long-polling)",
+                        "name": "wait",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentStatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/payments/{id}/stream": {
+            "get": {
+                "description": "Server-sent events stream of a payment's status. A \"status\" event with the current status is sent immediately, then one per change (PROCESSING, SUCCESS, FAILED, REFUNDED). The stream closes once the status is final or after the server's maximum stream lifetime. EventSource clients can authenticate with the access_token query parameter.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Stream payment status (SSE)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Access token, used only when the Authorization header is absent",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sent as the data of each status event",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Get active products with filters and pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search on name and description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by seller ID",
+                        "name": "seller_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "price_asc",
+                            "price_desc",
+                            "newest",
+                            "relevance"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, e.g. sale,eco",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Match any (default) or all of the tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new product (Seller/Admin only). Sellers must be approved by an admin first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Create product request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/check-availability": {
+            "post": {
+                "description": "Check whether each item could be bought with the requested quantity, without checking out. A product listed more than once is checked against its total quantity. Unknown products are returned with found=false.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Check product availability",
+                "parameters": [
+                    {
+                        "description": "Items to check",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CheckAvailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CheckAvailabilityResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/compare": {
+            "get": {
+                "description": "Get several products side by side, with their category, stock status (in_stock, low_stock, out_of_stock based on available stock) and average rating (null without reviews). Repeated IDs are counted once. IDs that do not exist are skipped and listed in not_found_ids. At most PRODUCT_COMPARE_MAX_ITEMS distinct IDs are allowed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Compare products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "1,2,3",
+                        "description": "Comma-separated product IDs",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductComparisonResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/sku/{sku}": {
+            "get": {
+                "description": "Get a single product by its SKU",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product by SKU",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product SKU",
+                        "name": "sku",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Get a single product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Partially update a product (Owner only). Omitted fields are left unchanged; fields sent with an empty or zero value are applied, e.g. an empty description clears it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update product request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Delete a product (Owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Partially update a product (Owner only). Omitted fields are left unchanged; fields sent with an empty or zero value are applied, e.g. an empty description clears it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update product request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/image": {
+            "post": {
+                "description": "Upload a JPEG, PNG, WebP or GIF image for a product (Owner only). The previous uploaded image is deleted",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Upload product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/price-history": {
+            "get": {
+                "description": "Get the paginated price change history of a product, newest first (Owner/Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product price history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.PriceHistoryListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/reviews": {
+            "get": {
+                "description": "Get reviews of a product with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get product reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_review_dto.ReviewListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Review a product (only buyers with a COMPLETED order containing the product)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Create product review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create review request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_review_dto.CreateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_review_dto.ReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/stock": {
+            "patch": {
+                "description": "Add or reduce product stock (Owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update product stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update stock request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/stock-subscription": {
+            "post": {
+                "description": "Get an email once when an out-of-stock product becomes available again. The subscription is removed after the email is sent. Subscribing again is a no-op",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Subscribe to back-in-stock notification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Cancel the back-in-stock notification for a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Unsubscribe from back-in-stock notification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/tags": {
+            "post": {
+                "description": "Attach one or more tags to a product (Owner only). Tag names are trimmed and lowercased; unknown tags are created and tags already attached are ignored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Attach tags to product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags to attach",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Detach one or more tags from a product (Owner only). Tags that are not attached are ignored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Detach tags from product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags to detach",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/variants": {
+            "get": {
+                "description": "Get all variants (e.g. size/color) of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product variants",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.VariantResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a variant with its own price and stock to a product (Owner only). Product stock becomes the sum of variant stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Add product variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create variant request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.VariantResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/variants/{variantId}": {
+            "put": {
+                "description": "Update a product variant (Owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update product variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update variant request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.VariantResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Delete a product variant (Owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete product variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/reviews/{id}": {
+            "delete": {
+                "description": "Delete a review (author or admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Delete review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/seller/dashboard": {
+            "get": {
+                "description": "Revenue and order count from COMPLETED orders containing the current seller's products, top 5 best sellers, and current inventory value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "Get seller sales dashboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.SellerDashboardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/seller/products": {
+            "get": {
+                "description": "Get products owned by the current seller",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "Get my products",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/seller/products/bulk": {
+            "delete": {
+                "description": "Soft-delete many of the current seller's products in one transaction. The whole batch is rejected if any ID does not exist or is owned by another seller. Products still contained in orders that are not cancelled are skipped and reported with their order count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "Bulk delete my products",
+                "parameters": [
+                    {
+                        "description": "Product IDs to delete (max 100)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.BulkDeleteProductsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.BulkDeleteProductsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/seller/products/import": {
+            "post": {
+                "description": "Bulk-create products for the current seller from a CSV file with header: name, sku, description, price, stock, category_id, image_url. Valid rows are created in one transaction; invalid rows are skipped and reported with their line number",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "Import products from CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/seller/products/low-stock": {
+            "get": {
+                "description": "Get products owned by the current seller whose stock is at or below their low-stock threshold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "Get my low-stock products",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/seller/products/{id}/inventory-log": {
+            "get": {
+                "description": "Get the paginated stock change history of a product, newest first (Owner/Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "Get product inventory log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.InventoryLogListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/seller/products/{id}/stats": {
+            "get": {
+                "description": "Units sold, revenue and number of distinct orders for one product, from paid orders (PAID, SHIPPED, COMPLETED). Cancelled, refunded and unpaid orders are not counted, and returned items are subtracted at their checkout price. A product without sales returns zeros (Owner/Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "Get product sales stats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.ProductSalesStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/seller/reports/sales": {
+            "get": {
+                "description": "Revenue and order count per day, week (starting Monday) or month (UTC) from paid orders (PAID, SHIPPED, COMPLETED) containing the current seller's products, net of returned items. Periods without sales are returned with zero values. Defaults to the last 30 days; the range may not exceed 366 days",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "Get seller sales report",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Grouping",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.SellerSalesReportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/sellers/{id}/products": {
+            "get": {
+                "description": "Get a seller's active products with the same filters and pagination as the product list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get seller storefront products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Seller ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search on name and description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "price_asc",
+                            "price_desc",
+                            "newest",
+                            "relevance"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, e.g. sale,eco",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Match any (default) or all of the tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/payment": {
+            "post": {
+                "description": "Receive payment notifications from the gateway. The raw body must be signed with HMAC-SHA256 using the shared webhook secret and sent hex-encoded in the X-Signature header. Duplicate deliveries for an already processed transaction are acknowledged with 200.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Payment gateway webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hex-encoded HMAC-SHA256 signature of the raw body",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment webhook payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentWebhookPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "waktu expiry access token (RFC3339, UTC)",
+                    "type": "string",
+                    "example": "2024-01-02T15:04:05Z"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserResponse"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.RefreshTokenResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "waktu expiry access token (RFC3339, UTC)",
+                    "type": "string",
+                    "example": "2024-01-02T15:04:05Z"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "store_name": {
+                    "description": "StoreName nama toko untuk role seller; default ke nama user",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.SellerListResponse": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_page": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_page": {
+                    "type": "integer"
+                },
+                "sellers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.SellerProfileResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.SellerProfileResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "PENDING"
+                },
+                "store_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "sesi milik token yang dipakai request ini",
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "description": "waktu expiry refresh token sesi ini (RFC3339, UTC)",
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "jti": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UpdateProfileRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "phone": {
+                    "description": "Phone kosong menghapus nomor telepon yang tersimpan",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UpdateRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "seller",
+                        "user"
+                    ]
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UpdateUserStatusRequest": {
+            "type": "object",
+            "required": [
+                "is_active"
+            ],
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserListResponse": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_page": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserResponse"
+                    }
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "seller_status": {
+                    "description": "SellerStatus status approval seller (PENDING/APPROVED/REJECTED), hanya terisi untuk seller",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_cart_dto.AddCartItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "description": "wajib jika produk punya varian",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_cart_dto.CartItemResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "199.99"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "399.98"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_cart_dto.CartResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "kosong jika item memakai currency berbeda",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_cart_dto.CartItemResponse"
+                    }
+                },
+                "total_amount": {
+                    "type": "string",
+                    "example": "399.98"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_cart_dto.UpdateCartItemRequest": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "description": "ganti varian item; kosong = tetap",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {},
+                "message": {
+                    "type": "string",
+                    "example": "Operation successful"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.CouponListResponse": {
+            "type": "object",
+            "properties": {
+                "coupons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.CouponResponse"
+                    }
+                },
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_page": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.CouponResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "BASE_CURRENCY, berlaku untuk min_spend dan value FIXED",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "type": "string",
+                    "example": "100000.00"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "used_count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.CreateCouponRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "type",
+                "value"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "min_spend": {
+                    "type": "string",
+                    "minLength": 0,
+                    "example": "100000.00"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "PERCENT",
+                        "FIXED"
+                    ]
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.ValidateCouponRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "minLength": 0,
+                    "example": "250000.00"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_coupon_dto.ValidateCouponResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "discount_amount": {
+                    "type": "string",
+                    "example": "25000.00"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "250000.00"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "225000.00"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_dashboard_dto.AdminDashboardResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "BASE_CURRENCY",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "daily_orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_dashboard_dto.DailyOrderCount"
+                    }
+                },
+                "orders_by_status": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "successful_payment_volume": {
+                    "type": "string",
+                    "example": "125000.00"
+                },
+                "total_orders": {
+                    "type": "integer"
+                },
+                "total_products": {
+                    "type": "integer"
+                },
+                "total_users": {
+                    "type": "integer"
+                },
+                "users_by_role": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_dashboard_dto.DailyOrderCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.CartCheckoutRequest": {
+            "type": "object",
+            "required": [
+                "shipping_address"
+            ],
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "shipping_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.CheckoutRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "shipping_address"
+            ],
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "shipping_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.CreateOrderNoteRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Shipped via JNE, resi JNE123456"
+                },
+                "internal": {
+                    "description": "hanya seller/admin; tidak terlihat oleh pembeli",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.GuestCheckoutRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "items",
+                "shipping_address"
+            ],
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "shipping_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.GuestCheckoutResponse": {
+            "type": "object",
+            "properties": {
+                "lookup_token": {
+                    "type": "string"
+                },
+                "order": {
+                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.MyOrderStatsResponse": {
+            "type": "object",
+            "properties": {
+                "count_by_status": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "currency": {
+                    "description": "BASE_CURRENCY",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "last_order_at": {
+                    "type": "string",
+                    "example": "2024-01-31T10:00:00Z"
+                },
+                "total_orders": {
+                    "type": "integer"
+                },
+                "total_spent": {
+                    "description": "total order COMPLETED",
+                    "type": "string",
+                    "example": "1249.50"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "description": "wajib untuk produk yang memiliki varian",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "199.99"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_image_url": {
+                    "description": "gambar produk saat ini",
+                    "type": "string"
+                },
+                "product_name": {
+                    "description": "nama saat checkout",
+                    "type": "string"
+                },
+                "product_sku": {
+                    "description": "SKU produk/varian saat checkout",
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "returned_quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "399.98"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderListResponse": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_page": {
+                    "type": "integer"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderNoteResponse": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "internal": {
+                    "type": "boolean"
+                },
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "type": "string"
+                },
+                "coupon_code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "berlaku untuk seluruh nominal order dan item",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "discount_amount": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "estimated_delivery": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "guest_email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderItemResponse"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "outstanding_total": {
+                    "description": "total - returned_amount",
+                    "type": "string",
+                    "example": "409.98"
+                },
+                "returned_amount": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "shipping_address": {
+                    "type": "string"
+                },
+                "shipping_fee": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "399.98"
+                },
+                "tax": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "409.98"
+                },
+                "total_amount": {
+                    "description": "alias lama dari total",
+                    "type": "string",
+                    "example": "409.98"
+                },
+                "tracking_number": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderReturnResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "199.99"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "order_item_id": {
+                    "type": "integer"
+                },
+                "outstanding_total": {
+                    "type": "string",
+                    "example": "209.99"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderStatusHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "changed_by": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.ProductSalesStatsResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "currency produk",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "order_count": {
+                    "description": "jumlah order berbeda yang berisi produk ini",
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "string",
+                    "example": "1999.90"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "units_sold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.QuoteItemResponse": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "string",
+                    "example": "199.99"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "399.98"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.QuoteResponse": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "discount_amount": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.QuoteItemResponse"
+                    }
+                },
+                "shipping_fee": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "399.98"
+                },
+                "tax": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "409.98"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.ReturnItemRequest": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.SalesReportBucket": {
+            "type": "object",
+            "properties": {
+                "order_count": {
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "tanggal awal periode",
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "revenue": {
+                    "type": "string",
+                    "example": "1999.90"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.SellerDashboardResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "BASE_CURRENCY, berlaku untuk seluruh nominal dashboard",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "inventory_value": {
+                    "type": "string",
+                    "example": "48250.00"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "top_products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.TopProductResponse"
+                    }
+                },
+                "total_orders": {
+                    "type": "integer"
+                },
+                "total_revenue": {
+                    "type": "string",
+                    "example": "15999.20"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.SellerSalesReportResponse": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.SalesReportBucket"
+                    }
+                },
+                "currency": {
+                    "description": "BASE_CURRENCY, berlaku untuk seluruh bucket",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "group_by": {
+                    "type": "string",
+                    "example": "day"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "total_orders": {
+                    "type": "integer"
+                },
+                "total_revenue": {
+                    "type": "string",
+                    "example": "15999.20"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.TopProductResponse": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity_sold": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "string",
+                    "example": "1999.90"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.UpdateOrderStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "carrier": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "JNE"
+                },
+                "estimated_delivery": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PAID",
+                        "SHIPPED",
+                        "COMPLETED",
+                        "CANCELLED"
+                    ]
+                },
+                "tracking_number": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "JNE1234567890"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.CreatePaymentRequest": {
+            "type": "object",
+            "required": [
+                "method",
+                "order_id"
+            ],
+            "properties": {
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "BANK_TRANSFER",
+                        "CREDIT_CARD",
+                        "E_WALLET"
+                    ]
+                },
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.ForceCancelOrderRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Item out of stock at warehouse"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.ForceCancelOrderResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled_by": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "refunded_payment": {
+                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentCallbackRequest": {
+            "type": "object",
+            "required": [
+                "status",
+                "transaction_id"
+            ],
+            "properties": {
+                "failed_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "SUCCESS",
+                        "FAILED"
+                    ]
+                },
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentDetailResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "399.98"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "failed_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "order": {
+                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "refund_reason": {
+                    "type": "string"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentListResponse": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_page": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse"
+                    }
+                },
+                "prev_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "399.98"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "failed_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "refund_reason": {
+                    "type": "string"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentStatusResponse": {
+            "type": "object",
+            "properties": {
+                "paid_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentWebhookPayload": {
+            "type": "object",
+            "required": [
+                "status",
+                "transaction_id"
+            ],
+            "properties": {
+                "failed_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "SUCCESS",
+                        "FAILED"
+                    ]
+                },
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.RefundPaymentRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.AdminSetStockRequest": {
+            "type": "object",
+            "required": [
+                "reason",
+                "stock"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.AvailabilityItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.AvailabilityItemResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "available_stock": {
+                    "description": "stok dikurangi reservasi order PENDING",
+                    "type": "integer"
+                },
+                "found": {
+                    "type": "boolean"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.BatchCreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "categories"
+            ],
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateCategoryRequest"
+                    }
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.BulkDeleteProductsRequest": {
+            "type": "object",
+            "required": [
+                "product_ids"
+            ],
+            "properties": {
+                "product_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.BulkDeleteProductsResult": {
+            "type": "object",
+            "properties": {
+                "deleted_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.BulkDeleteSkippedProduct"
+                    }
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.BulkDeleteSkippedProduct": {
+            "type": "object",
+            "properties": {
+                "order_count": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryBatchConflictResponse": {
+            "type": "object",
+            "properties": {
+                "names": {
+                    "description": "nama yang sudah ada atau muncul lebih dari sekali dalam batch",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryInUseResponse": {
+            "type": "object",
+            "properties": {
+                "product_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "product_count": {
+                    "description": "Jumlah produk yang tampil di listing publik; hanya diisi di list dan detail kategori",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CheckAvailabilityRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.AvailabilityItemRequest"
+                    }
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CheckAvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.AvailabilityItemResponse"
+                    }
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "price",
+                "sku"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "description": "kosong = BASE_CURRENCY",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "low_stock_threshold": {
+                    "description": "Kosong = memakai default LOW_STOCK_THRESHOLD",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 2
+                },
+                "price": {
+                    "type": "string",
+                    "example": "199.99"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "LAPTOP-15-BLK"
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateVariantRequest": {
+            "type": "object",
+            "required": [
+                "attributes",
+                "price",
+                "sku"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "color": "red",
+                        "size": "M"
+                    }
+                },
+                "price": {
+                    "type": "string",
+                    "example": "149.99"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.InventoryLogListResponse": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.InventoryLogResponse"
+                    }
+                },
+                "next_page": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.InventoryLogResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delta": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "ref_id": {
+                    "type": "integer"
+                },
+                "ref_type": {
+                    "type": "string"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.PriceHistoryListResponse": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.PriceHistoryResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_page": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.PriceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "changed_by": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_price": {
+                    "type": "string",
+                    "example": "179.99"
+                },
+                "old_price": {
+                    "type": "string",
+                    "example": "199.99"
+                },
+                "product_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductComparisonItem": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "description": "null jika belum ada review",
+                    "type": "number"
+                },
+                "product": {
+                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                },
+                "stock_status": {
+                    "description": "in_stock, low_stock, out_of_stock",
+                    "type": "string",
+                    "example": "in_stock"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "not_found_ids": {
+                    "description": "ID yang tidak ditemukan (dilewati)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "products": {
+                    "description": "urutan sesuai ids",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductComparisonItem"
+                    }
+                }
+            }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.LoginRequest": {
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductImportResult": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "created": {
+                    "type": "integer"
                 },
-                "password": {
-                    "type": "string"
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductImportRowError"
+                    }
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "total_rows": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.RegisterRequest": {
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductImportRowError": {
             "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "name": {
+                "error": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                    "example": "price must be a positive amount"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "line": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductInUseResponse": {
+            "type": "object",
+            "properties": {
+                "order_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductListResponse": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean"
                 },
-                "role": {
-                    "type": "string"
+                "has_prev": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_page": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_page": {
+                    "type": "integer"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_auth_dto.UserResponse": {
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "available_stock": {
+                    "description": "stok dikurangi reservasi order PENDING",
+                    "type": "integer"
+                },
+                "average_rating": {
+                    "type": "number"
+                },
+                "category": {
+                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "description": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "low_stock_threshold": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
-                "role": {
+                "price": {
+                    "type": "string",
+                    "example": "199.99"
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "sku": {
                     "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.VariantResponse"
+                    }
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_common_response.APIResponse": {
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductTagsRequest": {
             "type": "object",
+            "required": [
+                "tags"
+            ],
             "properties": {
-                "data": {},
-                "error": {},
-                "message": {
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "sale",
+                        "eco"
+                    ]
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string",
-                    "example": "Operation successful"
+                    "maxLength": 100,
+                    "minLength": 2
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "parent_id": {
+                    "description": "ParentID nil = tidak diubah, 0 = jadikan kategori root",
+                    "type": "integer"
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.CheckoutRequest": {
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateProductRequest": {
             "type": "object",
-            "required": [
-                "items",
-                "shipping_address"
-            ],
             "properties": {
-                "items": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderItemRequest"
-                    }
+                "category_id": {
+                    "type": "integer"
                 },
-                "notes": {
+                "description": {
                     "type": "string"
                 },
-                "shipping_address": {
+                "image_url": {
                     "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "low_stock_threshold": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 2
+                },
+                "price": {
+                    "type": "string",
+                    "example": "199.99"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "LAPTOP-15-BLK"
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderItemRequest": {
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateStockRequest": {
             "type": "object",
             "required": [
-                "product_id",
+                "action",
                 "quantity"
             ],
             "properties": {
-                "product_id": {
-                    "type": "integer"
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "add",
+                        "reduce"
+                    ]
                 },
                 "quantity": {
                     "type": "integer"
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderItemResponse": {
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateVariantRequest": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "type": "string",
+                    "example": "149.99"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.VariantResponse": {
             "type": "object",
             "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "available_stock": {
+                    "description": "stok dikurangi reservasi order PENDING",
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "price": {
-                    "type": "number"
+                    "type": "string",
+                    "example": "149.99"
                 },
                 "product_id": {
                     "type": "integer"
                 },
-                "product_name": {
+                "sku": {
                     "type": "string"
                 },
-                "quantity": {
+                "stock": {
                     "type": "integer"
+                }
+            }
+        },
+        "github_com_akbarwjyy_go-commerce-api_internal_review_dto.CreateReviewRequest": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 1000
                 },
-                "subtotal": {
-                    "type": "number"
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderListResponse": {
+        "github_com_akbarwjyy_go-commerce-api_internal_review_dto.ReviewListResponse": {
             "type": "object",
             "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
                 "limit": {
                     "type": "integer"
                 },
-                "orders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse"
-                    }
+                "next_page": {
+                    "type": "integer"
                 },
                 "page": {
                     "type": "integer"
                 },
+                "prev_page": {
+                    "type": "integer"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_review_dto.ReviewResponse"
+                    }
+                },
                 "total": {
                     "type": "integer"
                 },
@@ -1966,114 +9955,88 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderResponse": {
+        "github_com_akbarwjyy_go-commerce-api_internal_review_dto.ReviewResponse": {
             "type": "object",
             "properties": {
+                "comment": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_order_dto.OrderItemResponse"
-                    }
-                },
-                "notes": {
-                    "type": "string"
-                },
-                "shipping_address": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "total_amount": {
-                    "type": "number"
+                "product_id": {
+                    "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
+                "rating": {
+                    "type": "integer"
                 },
                 "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_order_dto.UpdateOrderStatusRequest": {
-            "type": "object",
-            "required": [
-                "status"
-            ],
-            "properties": {
-                "status": {
-                    "type": "string",
-                    "enum": [
-                        "PAID",
-                        "SHIPPED",
-                        "COMPLETED",
-                        "CANCELLED"
-                    ]
-                }
-            }
-        },
-        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.CreatePaymentRequest": {
+        "github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.CreateWebhookRequest": {
             "type": "object",
             "required": [
-                "method",
-                "order_id"
+                "events",
+                "url"
             ],
             "properties": {
-                "method": {
-                    "type": "string",
-                    "enum": [
-                        "BANK_TRANSFER",
-                        "CREDIT_CARD",
-                        "E_WALLET"
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "order.status_changed",
+                        "payment.succeeded"
                     ]
                 },
-                "order_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentCallbackRequest": {
-            "type": "object",
-            "required": [
-                "status",
-                "transaction_id"
-            ],
-            "properties": {
-                "failed_reason": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "status": {
+                "secret": {
                     "type": "string",
-                    "enum": [
-                        "SUCCESS",
-                        "FAILED"
-                    ]
+                    "maxLength": 255,
+                    "minLength": 16
                 },
-                "transaction_id": {
-                    "type": "string"
+                "url": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "https://example.com/hooks/commerce"
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentListResponse": {
+        "github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.DeadLetterListResponse": {
             "type": "object",
             "properties": {
+                "dead_letters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.DeadLetterResponse"
+                    }
+                },
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
                 "limit": {
                     "type": "integer"
                 },
+                "next_page": {
+                    "type": "integer"
+                },
                 "page": {
                     "type": "integer"
                 },
-                "payments": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse"
-                    }
+                "prev_page": {
+                    "type": "integer"
                 },
                 "total": {
                     "type": "integer"
@@ -2083,217 +10046,175 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_payment_dto.PaymentResponse": {
+        "github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.DeadLetterResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
+                "attempts": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "failed_reason": {
+                "delivery_id": {
+                    "type": "string"
+                },
+                "event": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "method": {
+                "last_error": {
                     "type": "string"
                 },
-                "order_id": {
+                "last_status_code": {
                     "type": "integer"
                 },
-                "paid_at": {
-                    "type": "string"
-                },
-                "status": {
+                "payload": {
                     "type": "string"
                 },
-                "transaction_id": {
+                "url": {
                     "type": "string"
                 },
-                "user_id": {
+                "webhook_id": {
                     "type": "integer"
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse": {
+        "github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.UpdateWebhookRequest": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
-                    "type": "integer"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateCategoryRequest": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "description": {
-                    "type": "string"
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16
                 },
-                "name": {
+                "url": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                    "maxLength": 500
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.CreateProductRequest": {
+        "github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.WebhookListResponse": {
             "type": "object",
-            "required": [
-                "name",
-                "price"
-            ],
             "properties": {
-                "category_id": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "image_url": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 200,
-                    "minLength": 2
+                "has_next": {
+                    "type": "boolean"
                 },
-                "price": {
-                    "type": "number"
+                "has_prev": {
+                    "type": "boolean"
                 },
-                "stock": {
-                    "type": "integer",
-                    "minimum": 0
-                }
-            }
-        },
-        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductListResponse": {
-            "type": "object",
-            "properties": {
                 "limit": {
                     "type": "integer"
                 },
+                "next_page": {
+                    "type": "integer"
+                },
                 "page": {
                     "type": "integer"
                 },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse"
-                    }
+                "prev_page": {
+                    "type": "integer"
                 },
                 "total": {
                     "type": "integer"
                 },
                 "total_pages": {
                     "type": "integer"
+                },
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.WebhookResponse"
+                    }
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.ProductResponse": {
+        "github_com_akbarwjyy_go-commerce-api_internal_webhook_dto.WebhookResponse": {
             "type": "object",
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/github_com_akbarwjyy_go-commerce-api_internal_product_dto.CategoryResponse"
-                },
-                "category_id": {
-                    "type": "integer"
-                },
-                "description": {
+                "created_at": {
                     "type": "string"
                 },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
-                "image_url": {
-                    "type": "string"
-                },
                 "is_active": {
                     "type": "boolean"
                 },
-                "name": {
+                "secret": {
+                    "description": "hanya diisi saat webhook dibuat",
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "seller_id": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
                 },
-                "stock": {
-                    "type": "integer"
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateCategoryRequest": {
+        "pkg_health.DependencyStatus": {
             "type": "object",
             "properties": {
-                "description": {
+                "error": {
                     "type": "string"
                 },
-                "name": {
+                "latency_ms": {
+                    "type": "number",
+                    "example": 1.25
+                },
+                "status": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                    "example": "up"
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateProductRequest": {
+        "pkg_health.LivenessResponse": {
             "type": "object",
             "properties": {
-                "category_id": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "image_url": {
-                    "type": "string"
-                },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "name": {
+                "app": {
                     "type": "string",
-                    "maxLength": 200,
-                    "minLength": 2
+                    "example": "go-commerce-api"
                 },
-                "price": {
-                    "type": "number"
+                "env": {
+                    "type": "string",
+                    "example": "development"
                 },
-                "stock": {
-                    "type": "integer",
-                    "minimum": 0
+                "status": {
+                    "type": "string",
+                    "example": "up"
                 }
             }
         },
-        "github_com_akbarwjyy_go-commerce-api_internal_product_dto.UpdateStockRequest": {
+        "pkg_health.ReadinessResponse": {
             "type": "object",
-            "required": [
-                "action",
-                "quantity"
-            ],
             "properties": {
-                "action": {
-                    "type": "string",
-                    "enum": [
-                        "add",
-                        "reduce"
-                    ]
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/pkg_health.DependencyStatus"
+                    }
                 },
-                "quantity": {
-                    "type": "integer"
+                "status": {
+                    "type": "string",
+                    "example": "up"
                 }
             }
         }
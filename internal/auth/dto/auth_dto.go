@@ -1,11 +1,16 @@
 package dto
 
+import "github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+
 // RegisterRequest untuk request registrasi user
 type RegisterRequest struct {
 	Name     string `json:"name" binding:"required,min=2,max=100"`
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
+	Password string `json:"password" binding:"required,password"`
+	Phone    string `json:"phone,omitempty" binding:"omitempty,phone"`
 	Role     string `json:"role,omitempty"`
+	// StoreName nama toko untuk role seller; default ke nama user
+	StoreName string `json:"store_name,omitempty" binding:"omitempty,max=100"`
 }
 
 // LoginRequest untuk request login
@@ -14,16 +19,131 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// RefreshTokenRequest untuk request refresh access token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest untuk request logout (opsional, untuk mencabut refresh token)
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// ChangePasswordRequest untuk request ganti password user yang sedang login
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,password"`
+}
+
+// UpdateProfileRequest untuk request user mengubah profilnya sendiri.
+// Email tidak bisa diubah di sini karena butuh verifikasi ulang.
+type UpdateProfileRequest struct {
+	Name string `json:"name" binding:"required,min=2,max=100,alpha_space"`
+	// Phone kosong menghapus nomor telepon yang tersimpan
+	Phone string `json:"phone,omitempty" binding:"omitempty,phone"`
+}
+
+// ForgotPasswordRequest untuk request link reset password
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest untuk request reset password dengan token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,password"`
+}
+
+// UpdateRoleRequest untuk request admin mengubah role user
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin seller user"`
+}
+
+// UpdateUserStatusRequest untuk request admin mengaktifkan/menonaktifkan akun user
+type UpdateUserStatusRequest struct {
+	IsActive *bool `json:"is_active" binding:"required"`
+}
+
+// UserQueryParams untuk filter dan pagination list user (admin)
+type UserQueryParams struct {
+	Page  int    `form:"page,default=1"`
+	Limit int    `form:"limit"`
+	Role  string `form:"role" binding:"omitempty,oneof=admin seller user"`
+	Email string `form:"email"`
+}
+
+// SellerQueryParams untuk filter dan pagination list seller (admin)
+type SellerQueryParams struct {
+	Page   int    `form:"page,default=1"`
+	Limit  int    `form:"limit"`
+	Status string `form:"status" binding:"omitempty,oneof=PENDING APPROVED REJECTED"`
+}
+
+// SellerProfileResponse untuk response data profil seller
+type SellerProfileResponse struct {
+	ID        uint   `json:"id"`
+	UserID    uint   `json:"user_id"`
+	StoreName string `json:"store_name"`
+	Status    string `json:"status" example:"PENDING"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// SellerListResponse untuk response list seller dengan pagination
+type SellerListResponse struct {
+	Sellers []SellerProfileResponse `json:"sellers"`
+	pagination.Meta
+}
+
+// UserListResponse untuk response list user dengan pagination
+type UserListResponse struct {
+	Users []UserResponse `json:"users"`
+	pagination.Meta
+}
+
 // AuthResponse untuk response setelah login/register
 type AuthResponse struct {
-	User  UserResponse `json:"user"`
-	Token string       `json:"token"`
+	User         UserResponse `json:"user"`
+	Token        string       `json:"token"`
+	TokenType    string       `json:"token_type" example:"Bearer"`
+	ExpiresAt    string       `json:"expires_at" example:"2024-01-02T15:04:05Z"` // waktu expiry access token (RFC3339, UTC)
+	RefreshToken string       `json:"refresh_token"`
+}
+
+// RefreshTokenResponse untuk response setelah refresh access token
+type RefreshTokenResponse struct {
+	Token     string `json:"token"`
+	TokenType string `json:"token_type" example:"Bearer"`
+	ExpiresAt string `json:"expires_at" example:"2024-01-02T15:04:05Z"` // waktu expiry access token (RFC3339, UTC)
+}
+
+// TokenTypeBearer jenis token yang dikembalikan login/register/refresh
+const TokenTypeBearer = "Bearer"
+
+// ClientInfo metadata client yang login, disimpan bersama sesinya
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// SessionResponse untuk response satu sesi login yang masih aktif
+type SessionResponse struct {
+	JTI       string `json:"jti"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+	IssuedAt  string `json:"issued_at"`
+	ExpiresAt string `json:"expires_at"` // waktu expiry refresh token sesi ini (RFC3339, UTC)
+	Current   bool   `json:"current"`    // sesi milik token yang dipakai request ini
 }
 
 // UserResponse untuk response data user (tanpa password)
 type UserResponse struct {
-	ID    uint   `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Phone    string `json:"phone,omitempty"`
+	Role     string `json:"role"`
+	IsActive bool   `json:"is_active"`
+	// SellerStatus status approval seller (PENDING/APPROVED/REJECTED), hanya terisi untuk seller
+	SellerStatus string `json:"seller_status,omitempty"`
 }
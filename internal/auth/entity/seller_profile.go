@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// Status pengajuan seller
+const (
+	SellerStatusPending  = "PENDING"
+	SellerStatusApproved = "APPROVED"
+	SellerStatusRejected = "REJECTED"
+)
+
+// SellerProfile entity untuk tabel seller_profiles.
+// Seller baru berstatus PENDING dan baru bisa membuat produk setelah di-approve admin.
+type SellerProfile struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"uniqueIndex;not null" json:"user_id"`
+	StoreName string    `gorm:"size:100;not null" json:"store_name"`
+	Status    string    `gorm:"size:20;not null;default:PENDING;index" json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (SellerProfile) TableName() string {
+	return "seller_profiles"
+}
+
+// IsApproved mengecek apakah seller sudah di-approve admin
+func (p *SellerProfile) IsApproved() bool {
+	return p.Status == SellerStatusApproved
+}
+
+// IsValidSellerStatus memvalidasi status seller yang valid
+func IsValidSellerStatus(status string) bool {
+	return status == SellerStatusPending || status == SellerStatusApproved || status == SellerStatusRejected
+}
@@ -15,14 +15,17 @@ const (
 
 // User entity untuk tabel users
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Name      string         `gorm:"size:100;not null" json:"name"`
-	Email     string         `gorm:"size:100;uniqueIndex;not null" json:"email"`
-	Password  string         `gorm:"size:255;not null" json:"-"`
-	Role      string         `gorm:"size:20;default:user" json:"role"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	Name          string         `gorm:"size:100;not null" json:"name"`
+	Email         string         `gorm:"size:100;uniqueIndex;not null" json:"email"`
+	Phone         *string        `gorm:"size:20;uniqueIndex" json:"phone,omitempty"` // opsional; NULL tidak bentrok di unique index
+	Password      string         `gorm:"size:255;not null" json:"-"`
+	Role          string         `gorm:"size:20;default:user" json:"role"`
+	IsActive      bool           `gorm:"not null;default:true" json:"is_active"`            // false = akun dinonaktifkan, token ditolak
+	SellerProfile *SellerProfile `gorm:"foreignKey:UserID" json:"seller_profile,omitempty"` // hanya untuk role seller
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // TableName menentukan nama tabel di database
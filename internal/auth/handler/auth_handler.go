@@ -2,9 +2,11 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
 	"github.com/akbarwjyy/go-commerce-api/internal/auth/service"
 	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
 	"github.com/gin-gonic/gin"
@@ -22,7 +24,7 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
 
 // Register godoc
 // @Summary      Register new user
-// @Description  Register a new user account
+// @Description  Register a new user account and return a Bearer access token (with its expires_at) and a refresh token. The phone number is optional but must be unique. Sellers start with a PENDING seller profile and cannot create products until an admin approves them.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
@@ -30,21 +32,25 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
 // @Success      201 {object} response.APIResponse{data=dto.AuthResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
 // @Router       /auth/register [post]
 func (h *AuthHandler) Register(ctx *gin.Context) {
 	var req dto.RegisterRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+		response.BindError(ctx, err)
 		return
 	}
 
-	result, err := h.authService.Register(&req)
+	result, err := h.authService.Register(&req, clientInfo(ctx))
 	if err != nil {
-		if err == service.ErrEmailAlreadyExists {
+		switch err {
+		case service.ErrEmailAlreadyExists:
 			response.Error(ctx, http.StatusConflict, "Email already registered", nil)
-			return
+		case service.ErrPhoneAlreadyExists:
+			response.Error(ctx, http.StatusConflict, "Phone number already registered", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to register user", err.Error())
 		}
-		response.InternalServerError(ctx, "Failed to register user", err.Error())
 		return
 	}
 
@@ -53,7 +59,7 @@ func (h *AuthHandler) Register(ctx *gin.Context) {
 
 // Login godoc
 // @Summary      Login user
-// @Description  Authenticate user and return JWT token
+// @Description  Authenticate user and return a Bearer access token (with its expires_at) and a refresh token
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
@@ -61,21 +67,29 @@ func (h *AuthHandler) Register(ctx *gin.Context) {
 // @Success      200 {object} response.APIResponse{data=dto.AuthResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Failure      429 {object} response.APIResponse
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(ctx *gin.Context) {
 	var req dto.LoginRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+		response.BindError(ctx, err)
 		return
 	}
 
-	result, err := h.authService.Login(&req)
+	result, err := h.authService.Login(&req, clientInfo(ctx))
 	if err != nil {
-		if err == service.ErrInvalidCredentials {
+		switch err {
+		case service.ErrInvalidCredentials:
 			response.Unauthorized(ctx, "Invalid email or password")
-			return
+		case service.ErrAccountDeactivated:
+			response.Forbidden(ctx, "Account is deactivated")
+		case service.ErrAccountLocked:
+			response.TooManyRequests(ctx, "Too many failed login attempts, please try again later")
+		default:
+			response.InternalServerError(ctx, "Failed to login", err.Error())
 		}
-		response.InternalServerError(ctx, "Failed to login", err.Error())
 		return
 	}
 
@@ -84,13 +98,16 @@ func (h *AuthHandler) Login(ctx *gin.Context) {
 
 // Logout godoc
 // @Summary      Logout user
-// @Description  Logout user and blacklist the token
+// @Description  Logout user, blacklist the access token, end its session and revoke the refresh token if provided
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        request body dto.LogoutRequest false "Logout request"
 // @Success      200 {object} response.APIResponse
+// @Failure      400 {object} response.APIResponse
 // @Failure      401 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
 // @Router       /auth/logout [post]
 func (h *AuthHandler) Logout(ctx *gin.Context) {
 	// Ambil token dari header Authorization
@@ -108,7 +125,16 @@ func (h *AuthHandler) Logout(ctx *gin.Context) {
 	}
 	token := parts[1]
 
-	if err := h.authService.Logout(token); err != nil {
+	// Body bersifat opsional, hanya dipakai untuk mencabut refresh token
+	var req dto.LogoutRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.BindError(ctx, err)
+			return
+		}
+	}
+
+	if err := h.authService.Logout(token, req.RefreshToken); err != nil {
 		response.InternalServerError(ctx, "Failed to logout", err.Error())
 		return
 	}
@@ -116,6 +142,478 @@ func (h *AuthHandler) Logout(ctx *gin.Context) {
 	response.OK(ctx, "Logout successful", nil)
 }
 
+// RefreshToken godoc
+// @Summary      Refresh access token
+// @Description  Exchange a valid refresh token for a new Bearer access token (with its expires_at)
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.RefreshTokenRequest true "Refresh token request"
+// @Success      200 {object} response.APIResponse{data=dto.RefreshTokenResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /auth/refresh [post]
+func (h *AuthHandler) RefreshToken(ctx *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.authService.RefreshToken(req.RefreshToken)
+	if err != nil {
+		switch err {
+		case service.ErrInvalidRefreshToken:
+			response.Unauthorized(ctx, "Invalid or expired refresh token")
+		case service.ErrAccountDeactivated:
+			response.Forbidden(ctx, "Account is deactivated")
+		default:
+			response.InternalServerError(ctx, "Failed to refresh token", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Token refreshed successfully", result)
+}
+
+// RefreshClaims godoc
+// @Summary      Refresh token claims
+// @Description  Issue a new access token with the user's current role and email, e.g. after an admin changed the role, without logging in again. The new token keeps the current session and the token used for this request is revoked.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.APIResponse{data=dto.RefreshTokenResponse}
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Router       /auth/refresh-claims [post]
+func (h *AuthHandler) RefreshClaims(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	result, err := h.authService.RefreshClaims(userID.(uint), ctx.GetString("sessionID"), ctx.GetString("token"))
+	if err != nil {
+		switch err {
+		case service.ErrUserNotFound:
+			response.Unauthorized(ctx, "User no longer exists")
+		case service.ErrAccountDeactivated:
+			response.Forbidden(ctx, "Account is deactivated")
+		default:
+			response.InternalServerError(ctx, "Failed to refresh claims", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Claims refreshed successfully", result)
+}
+
+// ChangePassword godoc
+// @Summary      Change password
+// @Description  Change the password of the currently authenticated user. All sessions of the user, including their refresh tokens, are revoked, so the user must log in again on every device.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.ChangePasswordRequest true "Change password request"
+// @Success      200 {object} response.APIResponse
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /auth/password [put]
+func (h *AuthHandler) ChangePassword(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	var req dto.ChangePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	if err := h.authService.ChangePassword(userID.(uint), ctx.GetString("token"), &req); err != nil {
+		switch err {
+		case service.ErrUserNotFound:
+			response.NotFound(ctx, "User not found")
+		case service.ErrInvalidOldPassword:
+			response.BadRequest(ctx, "Old password is incorrect", nil)
+		case service.ErrSamePassword:
+			response.BadRequest(ctx, "New password must be different from the old password", nil)
+		case service.ErrWeakPassword:
+			response.BadRequest(ctx, "Password must be at least 8 characters with uppercase, lowercase, and number", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to change password", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Password changed successfully. Please log in again", nil)
+}
+
+// DeactivateAccount godoc
+// @Summary      Deactivate my account
+// @Description  Deactivate the currently authenticated account and revoke the current token. The account can only be reactivated by an admin.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Router       /auth/me [delete]
+func (h *AuthHandler) DeactivateAccount(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	if err := h.authService.DeactivateAccount(userID.(uint), ctx.GetString("token")); err != nil {
+		switch err {
+		case service.ErrUserNotFound:
+			response.NotFound(ctx, "User not found")
+		case service.ErrLastActiveAdmin:
+			response.Error(ctx, http.StatusConflict, "Cannot deactivate the last active admin", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to deactivate account", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Account deactivated successfully", nil)
+}
+
+// GetSessions godoc
+// @Summary      List my active sessions
+// @Description  List the active login sessions (one per login, identified by jti) of the current user, newest first, with the IP address and user agent that logged in. The session of the current token is marked current.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.APIResponse{data=[]dto.SessionResponse}
+// @Failure      401 {object} response.APIResponse
+// @Failure      503 {object} response.APIResponse
+// @Router       /auth/sessions [get]
+func (h *AuthHandler) GetSessions(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	sessions, err := h.authService.ListSessions(userID.(uint), ctx.GetString("sessionID"))
+	if err != nil {
+		if err == service.ErrSessionsUnavailable {
+			response.Error(ctx, http.StatusServiceUnavailable, "Session management is temporarily unavailable", nil)
+			return
+		}
+		response.InternalServerError(ctx, "Failed to get sessions", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Sessions retrieved successfully", sessions)
+}
+
+// RevokeSession godoc
+// @Summary      Revoke a session
+// @Description  Log out one of the current user's sessions, e.g. on another device. Its refresh token stops working and its access tokens are rejected immediately. Revoking the current session logs out the current token as well.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        jti path string true "Session ID (jti)"
+// @Success      200 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      503 {object} response.APIResponse
+// @Router       /auth/sessions/{jti} [delete]
+func (h *AuthHandler) RevokeSession(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	if err := h.authService.RevokeSession(userID.(uint), ctx.Param("jti")); err != nil {
+		switch err {
+		case service.ErrSessionNotFound:
+			response.NotFound(ctx, "Session not found")
+		case service.ErrSessionsUnavailable:
+			response.Error(ctx, http.StatusServiceUnavailable, "Session management is temporarily unavailable", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to revoke session", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Session revoked successfully", nil)
+}
+
+// ForgotPassword godoc
+// @Summary      Request password reset
+// @Description  Generate a single-use password reset token for the given email. The response is the same whether or not the email is registered.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ForgotPasswordRequest true "Forgot password request"
+// @Success      200 {object} response.APIResponse
+// @Failure      400 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Failure      503 {object} response.APIResponse
+// @Router       /auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(ctx *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(req.Email); err != nil {
+		if err == service.ErrResetUnavailable {
+			response.Error(ctx, http.StatusServiceUnavailable, "Password reset is temporarily unavailable", nil)
+			return
+		}
+		response.InternalServerError(ctx, "Failed to request password reset", err.Error())
+		return
+	}
+
+	response.OK(ctx, "If the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Set a new password using a password reset token. The token can only be used once, and all existing sessions of the user are revoked.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ResetPasswordRequest true "Reset password request"
+// @Success      200 {object} response.APIResponse
+// @Failure      400 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Failure      503 {object} response.APIResponse
+// @Router       /auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(ctx *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	if err := h.authService.ResetPassword(req.Token, req.NewPassword); err != nil {
+		switch err {
+		case service.ErrInvalidResetToken:
+			response.BadRequest(ctx, "Invalid or expired reset token", nil)
+		case service.ErrWeakPassword:
+			response.BadRequest(ctx, "Password must be at least 8 characters with uppercase, lowercase, and number", nil)
+		case service.ErrResetUnavailable:
+			response.Error(ctx, http.StatusServiceUnavailable, "Password reset is temporarily unavailable", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to reset password", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Password has been reset successfully", nil)
+}
+
+// GetAllUsers godoc
+// @Summary      Get all users (Admin)
+// @Description  Get all users with role/email filters and pagination (Admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Param        role query string false "Filter by role" Enums(admin, seller, user)
+// @Param        email query string false "Filter by email substring"
+// @Success      200 {object} response.APIResponse{data=dto.UserListResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Router       /admin/users [get]
+func (h *AuthHandler) GetAllUsers(ctx *gin.Context) {
+	var params dto.UserQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.authService.GetAllUsers(&params)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get users", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Users retrieved successfully", result)
+}
+
+// UpdateUserRole godoc
+// @Summary      Update user role (Admin)
+// @Description  Change a user's role. The last remaining admin cannot be demoted.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "User ID"
+// @Param        request body dto.UpdateRoleRequest true "Update role request"
+// @Success      200 {object} response.APIResponse{data=dto.UserResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /admin/users/{id}/role [patch]
+func (h *AuthHandler) UpdateUserRole(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid user ID", nil)
+		return
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.authService.UpdateRole(uint(id), req.Role)
+	if err != nil {
+		switch err {
+		case service.ErrUserNotFound:
+			response.NotFound(ctx, "User not found")
+		case service.ErrInvalidRole:
+			response.BadRequest(ctx, "Invalid role", nil)
+		case service.ErrLastAdmin:
+			response.Error(ctx, http.StatusConflict, "Cannot demote the last remaining admin", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to update user role", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "User role updated successfully", result)
+}
+
+// UpdateUserStatus godoc
+// @Summary      Activate or deactivate user (Admin)
+// @Description  Activate or deactivate a user account. Deactivated users cannot log in, their tokens are rejected and their products are hidden from public listings. The last active admin cannot be deactivated.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "User ID"
+// @Param        request body dto.UpdateUserStatusRequest true "Update status request"
+// @Success      200 {object} response.APIResponse{data=dto.UserResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /admin/users/{id}/status [patch]
+func (h *AuthHandler) UpdateUserStatus(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid user ID", nil)
+		return
+	}
+
+	var req dto.UpdateUserStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.authService.UpdateStatus(uint(id), *req.IsActive)
+	if err != nil {
+		switch err {
+		case service.ErrUserNotFound:
+			response.NotFound(ctx, "User not found")
+		case service.ErrLastActiveAdmin:
+			response.Error(ctx, http.StatusConflict, "Cannot deactivate the last active admin", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to update user status", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "User status updated successfully", result)
+}
+
+// GetAllSellers godoc
+// @Summary      List seller applications (Admin)
+// @Description  Get seller profiles with optional status filter and pagination, oldest first
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Param        status query string false "Filter by status" Enums(PENDING, APPROVED, REJECTED)
+// @Success      200 {object} response.APIResponse{data=dto.SellerListResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Router       /admin/sellers [get]
+func (h *AuthHandler) GetAllSellers(ctx *gin.Context) {
+	var params dto.SellerQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.authService.GetAllSellers(&params)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get sellers", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Sellers retrieved successfully", result)
+}
+
+// ApproveSeller godoc
+// @Summary      Approve seller (Admin)
+// @Description  Approve a seller application so the seller can create products
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Seller user ID"
+// @Success      200 {object} response.APIResponse{data=dto.SellerProfileResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /admin/sellers/{id}/approve [patch]
+func (h *AuthHandler) ApproveSeller(ctx *gin.Context) {
+	h.updateSellerStatus(ctx, entity.SellerStatusApproved, "Seller approved successfully")
+}
+
+// RejectSeller godoc
+// @Summary      Reject seller (Admin)
+// @Description  Reject a seller application; the seller cannot create products
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Seller user ID"
+// @Success      200 {object} response.APIResponse{data=dto.SellerProfileResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /admin/sellers/{id}/reject [patch]
+func (h *AuthHandler) RejectSeller(ctx *gin.Context) {
+	h.updateSellerStatus(ctx, entity.SellerStatusRejected, "Seller rejected successfully")
+}
+
+// updateSellerStatus helper bersama untuk approve/reject seller
+func (h *AuthHandler) updateSellerStatus(ctx *gin.Context, status string, message string) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid user ID", nil)
+		return
+	}
+
+	result, err := h.authService.UpdateSellerStatus(uint(id), status)
+	if err != nil {
+		switch err {
+		case service.ErrSellerNotFound:
+			response.NotFound(ctx, "Seller not found")
+		default:
+			response.InternalServerError(ctx, "Failed to update seller status", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, message, result)
+}
+
 // GetProfile godoc
 // @Summary      Get current user profile
 // @Description  Get the profile of the currently authenticated user
@@ -141,10 +639,63 @@ func (h *AuthHandler) GetProfile(ctx *gin.Context) {
 		return
 	}
 
-	response.OK(ctx, "Profile retrieved successfully", dto.UserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-		Role:  user.Role,
-	})
+	resp := dto.UserResponse{
+		ID:       user.ID,
+		Name:     user.Name,
+		Email:    user.Email,
+		Role:     user.Role,
+		IsActive: user.IsActive,
+	}
+	if user.Phone != nil {
+		resp.Phone = *user.Phone
+	}
+	response.OK(ctx, "Profile retrieved successfully", resp)
+}
+
+// UpdateProfile godoc
+// @Summary      Update current user profile
+// @Description  Update the name and phone number of the currently authenticated user. An empty phone removes the stored number. Email cannot be changed here.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.UpdateProfileRequest true "Update profile request"
+// @Success      200 {object} response.APIResponse{data=dto.UserResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /auth/me [put]
+func (h *AuthHandler) UpdateProfile(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	var req dto.UpdateProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.authService.UpdateProfile(userID.(uint), &req)
+	if err != nil {
+		switch err {
+		case service.ErrUserNotFound:
+			response.NotFound(ctx, "User not found")
+		case service.ErrPhoneAlreadyExists:
+			response.Error(ctx, http.StatusConflict, "Phone number already registered", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to update profile", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Profile updated successfully", result)
+}
+
+// clientInfo mengambil IP dan user agent request untuk dicatat di sesi login
+func clientInfo(ctx *gin.Context) dto.ClientInfo {
+	return dto.ClientInfo{
+		IP:        ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}
 }
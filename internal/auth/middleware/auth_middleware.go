@@ -3,49 +3,69 @@ package middleware
 import (
 	"strings"
 
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
 	"github.com/akbarwjyy/go-commerce-api/internal/auth/service"
 	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
 	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
+// AccessTokenQueryParam adalah query parameter token untuk route yang memakai QueryTokenAuthMiddleware
+const AccessTokenQueryParam = "access_token"
+
 // AuthMiddleware untuk proteksi route yang membutuhkan authentication
 func AuthMiddleware(jwtService *utils.JWTService, authService service.AuthService) gin.HandlerFunc {
+	return authenticate(jwtService, authService, false)
+}
+
+// QueryTokenAuthMiddleware sama dengan AuthMiddleware, tetapi jika header Authorization kosong
+// token boleh dikirim lewat query parameter access_token. Hanya dipasang pada route yang client-nya
+// tidak bisa mengirim header (download file, server-sent events) karena token di URL lebih mudah bocor.
+func QueryTokenAuthMiddleware(jwtService *utils.JWTService, authService service.AuthService) gin.HandlerFunc {
+	return authenticate(jwtService, authService, true)
+}
+
+// authenticate memvalidasi token dari header Authorization (atau query access_token jika allowQueryToken).
+// Blacklist, validasi, tipe token, dan status akun diperiksa sama persis untuk kedua sumber token.
+func authenticate(jwtService *utils.JWTService, authService service.AuthService, allowQueryToken bool) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		// Ambil token dari header Authorization
-		authHeader := ctx.GetHeader("Authorization")
-		if authHeader == "" {
-			response.Unauthorized(ctx, "Authorization header required")
+		token, ok := extractToken(ctx, allowQueryToken)
+		if !ok {
 			ctx.Abort()
 			return
 		}
 
-		// Parse token - support both "Bearer <token>" and plain "<token>" format
-		var token string
-		parts := strings.Split(authHeader, " ")
-		if len(parts) == 2 && parts[0] == "Bearer" {
-			// Format: "Bearer <token>"
-			token = parts[1]
-		} else if len(parts) == 1 {
-			// Format: plain token (for Swagger UI compatibility)
-			token = parts[0]
-		} else {
-			response.Unauthorized(ctx, "Invalid authorization format. Use: Bearer <token>")
+		// Validasi token
+		claims, err := jwtService.ValidateToken(token)
+		if err != nil {
+			response.Unauthorized(ctx, "Invalid or expired token")
 			ctx.Abort()
 			return
 		}
 
-		// Cek apakah token ada di blacklist
-		if authService.IsTokenBlacklisted(token) {
+		// Cek apakah token (jti) atau sesinya sudah dicabut
+		if authService.IsTokenBlacklisted(claims) {
 			response.Unauthorized(ctx, "Token has been revoked")
 			ctx.Abort()
 			return
 		}
 
-		// Validasi token
-		claims, err := jwtService.ValidateToken(token)
+		// Refresh token tidak boleh dipakai untuk mengakses resource
+		if !claims.IsAccessToken() {
+			response.Unauthorized(ctx, "Invalid token type")
+			ctx.Abort()
+			return
+		}
+
+		// Token milik akun yang sudah dinonaktifkan (atau dihapus) tidak berlaku lagi
+		active, err := authService.IsUserActive(claims.UserID)
 		if err != nil {
-			response.Unauthorized(ctx, "Invalid or expired token")
+			response.InternalServerError(ctx, "Failed to verify account", nil)
+			ctx.Abort()
+			return
+		}
+		if !active {
+			response.Unauthorized(ctx, "Account is deactivated")
 			ctx.Abort()
 			return
 		}
@@ -54,11 +74,43 @@ func AuthMiddleware(jwtService *utils.JWTService, authService service.AuthServic
 		ctx.Set("userID", claims.UserID)
 		ctx.Set("userEmail", claims.Email)
 		ctx.Set("userRole", claims.Role)
+		ctx.Set("token", token)
+		ctx.Set("sessionID", claims.SessionID)
 
 		ctx.Next()
 	}
 }
 
+// extractToken mengambil token dari header Authorization, atau dari query access_token jika
+// header kosong dan allowQueryToken aktif. Response 401 sudah dikirim jika mengembalikan false.
+func extractToken(ctx *gin.Context, allowQueryToken bool) (string, bool) {
+	// Ambil token dari header Authorization
+	authHeader := ctx.GetHeader("Authorization")
+	if authHeader == "" {
+		if allowQueryToken {
+			if token := ctx.Query(AccessTokenQueryParam); token != "" {
+				return token, true
+			}
+			response.Unauthorized(ctx, "Authorization header or access_token query parameter required")
+			return "", false
+		}
+		response.Unauthorized(ctx, "Authorization header required")
+		return "", false
+	}
+
+	// Parse token - support both "Bearer <token>" and plain "<token>" format
+	parts := strings.Split(authHeader, " ")
+	if len(parts) == 2 && parts[0] == "Bearer" {
+		// Format: "Bearer <token>"
+		return parts[1], true
+	} else if len(parts) == 1 {
+		// Format: plain token (for Swagger UI compatibility)
+		return parts[0], true
+	}
+	response.Unauthorized(ctx, "Invalid authorization format. Use: Bearer <token>")
+	return "", false
+}
+
 // RoleMiddleware untuk membatasi akses berdasarkan role
 func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -81,3 +133,28 @@ func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 		ctx.Abort()
 	}
 }
+
+// ApprovedSellerMiddleware memastikan seller sudah di-approve admin sebelum membuat produk.
+// Role selain seller (misalnya admin) tidak diperiksa. Dipasang setelah AuthMiddleware.
+func ApprovedSellerMiddleware(authService service.AuthService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if role, _ := ctx.Get("userRole"); role != entity.RoleSeller {
+			ctx.Next()
+			return
+		}
+
+		approved, err := authService.IsSellerApproved(ctx.GetUint("userID"))
+		if err != nil {
+			response.InternalServerError(ctx, "Failed to verify seller status", nil)
+			ctx.Abort()
+			return
+		}
+		if !approved {
+			response.Forbidden(ctx, "Seller account has not been approved")
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
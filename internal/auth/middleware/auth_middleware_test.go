@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAuthService hanya mengimplementasikan method yang dipakai AuthMiddleware.
+// blacklisted berisi jti token atau ID sesi yang sudah dicabut.
+type fakeAuthService struct {
+	service.AuthService
+	blacklisted map[string]bool
+}
+
+func (f *fakeAuthService) IsTokenBlacklisted(claims *utils.JWTClaims) bool {
+	return f.blacklisted[claims.ID] || (claims.SessionID != "" && f.blacklisted[claims.SessionID])
+}
+
+func (f *fakeAuthService) IsUserActive(id uint) (bool, error) {
+	return true, nil
+}
+
+func newAuthRouter(middleware gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/download", middleware, func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "%d", ctx.GetUint("userID"))
+	})
+	return router
+}
+
+func serve(router *gin.Engine, target, authHeader string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestQueryTokenAuthMiddleware_AcceptsQueryTokenOnlyWhenEnabled(t *testing.T) {
+	jwtService := utils.NewJWTService("secret", 1, 24)
+	token, err := jwtService.GenerateToken(7, "buyer@example.com", "user")
+	require.NoError(t, err)
+	authSvc := &fakeAuthService{}
+
+	// Route biasa tetap mewajibkan header Authorization
+	w := serve(newAuthRouter(AuthMiddleware(jwtService, authSvc)), "/download?access_token="+token, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	queryRouter := newAuthRouter(QueryTokenAuthMiddleware(jwtService, authSvc))
+	w = serve(queryRouter, "/download?access_token="+token, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "7", w.Body.String())
+
+	// Header tetap didahulukan dan tetap divalidasi
+	w = serve(queryRouter, "/download", "Bearer "+token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serve(queryRouter, "/download?access_token="+token, "Bearer invalid")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serve(queryRouter, "/download", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestQueryTokenAuthMiddleware_AppliesSameChecksToQueryToken(t *testing.T) {
+	jwtService := utils.NewJWTService("secret", 1, 24)
+	pair, err := jwtService.GenerateTokenPair(7, "buyer@example.com", "user")
+	require.NoError(t, err)
+	accessClaims, err := jwtService.ValidateToken(pair.AccessToken)
+	require.NoError(t, err)
+	router := newAuthRouter(QueryTokenAuthMiddleware(jwtService, &fakeAuthService{
+		blacklisted: map[string]bool{accessClaims.ID: true},
+	}))
+
+	// Token yang sudah di-revoke ditolak meski dikirim lewat query
+	w := serve(router, "/download?access_token="+pair.AccessToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Refresh token tidak boleh dipakai untuk mengakses resource
+	w = serve(router, "/download?access_token="+pair.RefreshToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serve(router, "/download?access_token=not-a-jwt", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthMiddleware_RejectsTokensOfRevokedSession(t *testing.T) {
+	jwtService := utils.NewJWTService("secret", 1, 24)
+	pair, err := jwtService.GenerateTokenPair(7, "buyer@example.com", "user")
+	require.NoError(t, err)
+	// Access token hasil refresh membawa sesi yang sama dengan jti berbeda
+	refreshed, _, err := jwtService.GenerateSessionToken(7, "buyer@example.com", "user", pair.RefreshJTI)
+	require.NoError(t, err)
+	other, err := jwtService.GenerateTokenPair(7, "buyer@example.com", "user")
+	require.NoError(t, err)
+
+	router := newAuthRouter(AuthMiddleware(jwtService, &fakeAuthService{
+		blacklisted: map[string]bool{pair.RefreshJTI: true},
+	}))
+
+	w := serve(router, "/download", "Bearer "+pair.AccessToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serve(router, "/download", "Bearer "+refreshed)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Sesi lain milik user yang sama tidak terpengaruh
+	w = serve(router, "/download", "Bearer "+other.AccessToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"gorm.io/gorm"
+)
+
+// SellerProfileRepository interface untuk akses data profil seller
+type SellerProfileRepository interface {
+	Create(profile *entity.SellerProfile) error
+	FindByUserID(userID uint) (*entity.SellerProfile, error)
+	FindAll(params *dto.SellerQueryParams) ([]entity.SellerProfile, int64, error)
+	Update(profile *entity.SellerProfile) error
+}
+
+// sellerProfileRepository implementasi SellerProfileRepository
+type sellerProfileRepository struct {
+	db *gorm.DB
+}
+
+// NewSellerProfileRepository membuat instance baru SellerProfileRepository
+func NewSellerProfileRepository(db *gorm.DB) SellerProfileRepository {
+	return &sellerProfileRepository{db: db}
+}
+
+// Create menyimpan profil seller baru ke database
+func (r *sellerProfileRepository) Create(profile *entity.SellerProfile) error {
+	return r.db.Create(profile).Error
+}
+
+// FindByUserID mencari profil seller berdasarkan user ID
+func (r *sellerProfileRepository) FindByUserID(userID uint) (*entity.SellerProfile, error) {
+	var profile entity.SellerProfile
+	if err := r.db.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// FindAll mengambil profil seller dengan filter status dan pagination (untuk admin)
+func (r *sellerProfileRepository) FindAll(params *dto.SellerQueryParams) ([]entity.SellerProfile, int64, error) {
+	var profiles []entity.SellerProfile
+	var total int64
+
+	query := r.db.Model(&entity.SellerProfile{})
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	if err := query.Order("created_at ASC").Offset(offset).Limit(params.Limit).Find(&profiles).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return profiles, total, nil
+}
+
+// Update mengupdate profil seller
+func (r *sellerProfileRepository) Update(profile *entity.SellerProfile) error {
+	return r.db.Save(profile).Error
+}
+
+// BackfillSellerProfiles membuat profil APPROVED untuk seller yang terdaftar sebelum
+// ada alur approval, agar seller lama tidak tiba-tiba kehilangan akses membuat produk.
+// Dijalankan setelah AutoMigrate.
+func BackfillSellerProfiles(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO seller_profiles (user_id, store_name, status, created_at, updated_at)
+		SELECT u.id, u.name, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP FROM users u
+		WHERE u.role = ? AND NOT EXISTS (SELECT 1 FROM seller_profiles sp WHERE sp.user_id = u.id)`,
+		entity.SellerStatusApproved, entity.RoleSeller).Error
+}
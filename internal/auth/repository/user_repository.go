@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"strings"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
 	"gorm.io/gorm"
 )
@@ -10,6 +13,11 @@ type UserRepository interface {
 	Create(user *entity.User) error
 	FindByID(id uint) (*entity.User, error)
 	FindByEmail(email string) (*entity.User, error)
+	FindByPhone(phone string) (*entity.User, error)
+	FindAll(params *dto.UserQueryParams) ([]entity.User, int64, error)
+	CountByRole(role string) (int64, error)
+	CountActiveByRole(role string) (int64, error)
+	CountGroupedByRole() (map[string]int64, error)
 	Update(user *entity.User) error
 	Delete(id uint) error
 }
@@ -47,6 +55,82 @@ func (r *userRepository) FindByEmail(email string) (*entity.User, error) {
 	return &user, nil
 }
 
+// FindByPhone mencari user berdasarkan nomor telepon
+func (r *userRepository) FindByPhone(phone string) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.Where("phone = ?", phone).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindAll mengambil semua user dengan filter role/email dan pagination (untuk admin)
+func (r *userRepository) FindAll(params *dto.UserQueryParams) ([]entity.User, int64, error) {
+	var users []entity.User
+	var total int64
+
+	query := r.db.Model(&entity.User{})
+
+	// Apply filters
+	if params.Role != "" {
+		query = query.Where("role = ?", params.Role)
+	}
+	if params.Email != "" {
+		query = query.Where("LOWER(email) LIKE ?", "%"+strings.ToLower(params.Email)+"%")
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (params.Page - 1) * params.Limit
+	if err := query.Order("created_at DESC").Offset(offset).Limit(params.Limit).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// CountByRole menghitung jumlah user dengan role tertentu
+func (r *userRepository) CountByRole(role string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.User{}).Where("role = ?", role).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountActiveByRole menghitung jumlah user aktif dengan role tertentu
+func (r *userRepository) CountActiveByRole(role string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.User{}).Where("role = ? AND is_active = ?", role, true).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountGroupedByRole menghitung jumlah user untuk setiap role
+func (r *userRepository) CountGroupedByRole() (map[string]int64, error) {
+	var rows []struct {
+		Role  string
+		Count int64
+	}
+	if err := r.db.Model(&entity.User{}).
+		Select("role, COUNT(*) AS count").
+		Group("role").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Role] = row.Count
+	}
+	return counts, nil
+}
+
 // Update mengupdate data user
 func (r *userRepository) Update(user *entity.User) error {
 	return r.db.Save(user).Error
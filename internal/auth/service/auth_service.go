@@ -2,13 +2,24 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
 	"github.com/akbarwjyy/go-commerce-api/internal/auth/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/akbarwjyy/go-commerce-api/pkg/notifier"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
 	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
+	"github.com/akbarwjyy/go-commerce-api/pkg/validator"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -16,42 +27,109 @@ import (
 
 // Common errors
 var (
-	ErrEmailAlreadyExists = errors.New("email already registered")
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrUserNotFound       = errors.New("user not found")
+	ErrEmailAlreadyExists  = errors.New("email already registered")
+	ErrPhoneAlreadyExists  = errors.New("phone number already registered")
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrInvalidOldPassword  = errors.New("old password is incorrect")
+	ErrSamePassword        = errors.New("new password must be different from the old password")
+	ErrWeakPassword        = errors.New("password must be at least 8 characters with uppercase, lowercase, and number")
+	ErrInvalidResetToken   = errors.New("invalid or expired reset token")
+	ErrResetUnavailable    = errors.New("password reset is temporarily unavailable")
+	ErrInvalidRole         = errors.New("invalid role")
+	ErrLastAdmin           = errors.New("cannot demote the last remaining admin")
+	ErrAccountDeactivated  = errors.New("account is deactivated")
+	ErrLastActiveAdmin     = errors.New("cannot deactivate the last active admin")
+	ErrAccountLocked       = errors.New("too many failed login attempts, account is temporarily locked")
+	ErrSellerNotFound      = errors.New("seller not found")
+	ErrInvalidSellerStatus = errors.New("invalid seller status")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionsUnavailable = errors.New("session management is unavailable")
 )
 
+// passwordResetTTL adalah masa berlaku token reset password
+const passwordResetTTL = 30 * time.Minute
+
+// passwordValidator dipakai untuk memvalidasi password di luar binding request
+var passwordValidator = validator.New().GetValidator()
+
 // AuthService interface untuk business logic authentication
 type AuthService interface {
-	Register(req *dto.RegisterRequest) (*dto.AuthResponse, error)
-	Login(req *dto.LoginRequest) (*dto.AuthResponse, error)
-	Logout(token string) error
-	IsTokenBlacklisted(token string) bool
+	Register(req *dto.RegisterRequest, client dto.ClientInfo) (*dto.AuthResponse, error)
+	Login(req *dto.LoginRequest, client dto.ClientInfo) (*dto.AuthResponse, error)
+	Logout(token string, refreshToken string) error
+	RefreshToken(refreshToken string) (*dto.RefreshTokenResponse, error)
+	RefreshClaims(userID uint, sessionID string, token string) (*dto.RefreshTokenResponse, error)
+	IsTokenBlacklisted(claims *utils.JWTClaims) bool
+	ListSessions(userID uint, currentSessionID string) ([]dto.SessionResponse, error)
+	RevokeSession(userID uint, jti string) error
+	ChangePassword(userID uint, token string, req *dto.ChangePasswordRequest) error
+	RequestPasswordReset(email string) error
+	ResetPassword(token string, newPassword string) error
 	GetUserByID(id uint) (*entity.User, error)
+	UpdateProfile(userID uint, req *dto.UpdateProfileRequest) (*dto.UserResponse, error)
+	IsUserActive(id uint) (bool, error)
+	DeactivateAccount(userID uint, token string) error
+
+	// Admin operations
+	GetAllUsers(params *dto.UserQueryParams) (*dto.UserListResponse, error)
+	UpdateRole(userID uint, role string) (*dto.UserResponse, error)
+	UpdateStatus(userID uint, isActive bool) (*dto.UserResponse, error)
+	CountUsersByRole() (map[string]int64, error)
+	GetAllSellers(params *dto.SellerQueryParams) (*dto.SellerListResponse, error)
+	UpdateSellerStatus(userID uint, status string) (*dto.SellerProfileResponse, error)
+
+	// IsSellerApproved mengecek apakah seller sudah di-approve (dipakai ApprovedSellerMiddleware)
+	IsSellerApproved(userID uint) (bool, error)
 }
 
 // authService implementasi AuthService
 type authService struct {
-	userRepo    repository.UserRepository
-	jwtService  *utils.JWTService
-	redisClient *redis.Client
+	userRepo          repository.UserRepository
+	sellerProfileRepo repository.SellerProfileRepository
+	jwtService        *utils.JWTService
+	redisClient       *redis.Client
+	notifier          *notifier.UserNotifier
+	bcryptCost        int
+
+	// Lockout login: setelah maxLoginAttempts gagal dalam loginLockoutWindow,
+	// email dikunci selama loginLockoutWindow (0 = nonaktif)
+	maxLoginAttempts   int
+	loginLockoutWindow time.Duration
 }
 
 // NewAuthService membuat instance baru AuthService
 func NewAuthService(
 	userRepo repository.UserRepository,
+	sellerProfileRepo repository.SellerProfileRepository,
 	jwtService *utils.JWTService,
 	redisClient *redis.Client,
+	userNotifier *notifier.UserNotifier,
+	bcryptCost int,
+	maxLoginAttempts int,
+	loginLockoutWindow time.Duration,
 ) AuthService {
+	// Cost di luar rentang bcrypt (termasuk 0) memakai bcrypt.DefaultCost
+	if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+		bcryptCost = bcrypt.DefaultCost
+	}
+
 	return &authService{
-		userRepo:    userRepo,
-		jwtService:  jwtService,
-		redisClient: redisClient,
+		userRepo:          userRepo,
+		sellerProfileRepo: sellerProfileRepo,
+		jwtService:        jwtService,
+		redisClient:       redisClient,
+		notifier:          userNotifier,
+		bcryptCost:        bcryptCost,
+
+		maxLoginAttempts:   maxLoginAttempts,
+		loginLockoutWindow: loginLockoutWindow,
 	}
 }
 
 // Register mendaftarkan user baru
-func (s *authService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, error) {
+func (s *authService) Register(req *dto.RegisterRequest, client dto.ClientInfo) (*dto.AuthResponse, error) {
 	// Cek apakah email sudah terdaftar
 	existingUser, err := s.userRepo.FindByEmail(req.Email)
 	if err == nil && existingUser != nil {
@@ -61,8 +139,20 @@ func (s *authService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 		return nil, err
 	}
 
+	// Nomor telepon opsional, tapi jika diisi harus unik
+	phone := strings.TrimSpace(req.Phone)
+	if phone != "" {
+		taken, err := s.isPhoneTaken(phone)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, ErrPhoneAlreadyExists
+		}
+	}
+
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := s.hashPassword(req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -77,37 +167,57 @@ func (s *authService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 	user := &entity.User{
 		Name:     req.Name,
 		Email:    req.Email,
-		Password: string(hashedPassword),
+		Password: hashedPassword,
 		Role:     role,
+		IsActive: true,
+	}
+	if phone != "" {
+		user.Phone = &phone
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
-		return nil, err
+	// Seller baru harus menunggu approval admin sebelum bisa membuat produk.
+	// Profil ikut tersimpan lewat association dalam transaksi yang sama dengan user.
+	if role == entity.RoleSeller {
+		storeName := strings.TrimSpace(req.StoreName)
+		if storeName == "" {
+			storeName = req.Name
+		}
+		user.SellerProfile = &entity.SellerProfile{
+			StoreName: storeName,
+			Status:    entity.SellerStatusPending,
+		}
 	}
 
-	// Generate JWT token
-	token, err := s.jwtService.GenerateToken(user.ID, user.Email, user.Role)
-	if err != nil {
+	if err := s.userRepo.Create(user); err != nil {
+		// Registrasi paralel dengan email/phone yang sama bisa lolos pengecekan di atas;
+		// unique index yang menolaknya, dilaporkan sesuai field yang bentrok
+		if database.IsUniqueViolation(err) {
+			if phone != "" {
+				if taken, _ := s.isPhoneTaken(phone); taken {
+					return nil, ErrPhoneAlreadyExists
+				}
+			}
+			return nil, ErrEmailAlreadyExists
+		}
 		return nil, err
 	}
 
-	return &dto.AuthResponse{
-		User: dto.UserResponse{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
-			Role:  user.Role,
-		},
-		Token: token,
-	}, nil
+	return s.buildAuthResponse(user, client)
 }
 
 // Login melakukan autentikasi user
-func (s *authService) Login(req *dto.LoginRequest) (*dto.AuthResponse, error) {
+func (s *authService) Login(req *dto.LoginRequest, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	ctx := context.Background()
+	if s.isLoginLocked(ctx, req.Email) {
+		return nil, ErrAccountLocked
+	}
+
 	// Cari user berdasarkan email
 	user, err := s.userRepo.FindByEmail(req.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Email tidak terdaftar tetap dihitung agar lockout tidak membocorkan email yang ada
+			s.recordFailedLogin(ctx, req.Email)
 			return nil, ErrInvalidCredentials
 		}
 		return nil, err
@@ -115,49 +225,259 @@ func (s *authService) Login(req *dto.LoginRequest) (*dto.AuthResponse, error) {
 
 	// Verifikasi password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		s.recordFailedLogin(ctx, req.Email)
 		return nil, ErrInvalidCredentials
 	}
+	s.resetFailedLogins(ctx, req.Email)
 
-	// Generate JWT token
-	token, err := s.jwtService.GenerateToken(user.ID, user.Email, user.Role)
-	if err != nil {
-		return nil, err
+	// Dicek setelah password agar status akun tidak bocor ke pihak yang tidak tahu password
+	if !user.IsActive {
+		return nil, ErrAccountDeactivated
 	}
 
-	return &dto.AuthResponse{
-		User: dto.UserResponse{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
-			Role:  user.Role,
-		},
-		Token: token,
-	}, nil
+	return s.buildAuthResponse(user, client)
 }
 
-// Logout menambahkan token ke blacklist di Redis
-func (s *authService) Logout(token string) error {
+// Logout mem-blacklist access token, mengakhiri sesinya, dan mencabut refresh token jika disertakan
+func (s *authService) Logout(token string, refreshToken string) error {
 	if s.redisClient == nil {
 		return nil // Skip jika Redis tidak tersedia
 	}
 
 	ctx := context.Background()
-	// Simpan token di Redis dengan TTL sesuai expiry token
-	return s.redisClient.Set(ctx, "blacklist:"+token, "1", s.jwtService.GetTokenExpiry()).Err()
+	if err := s.blacklistToken(ctx, token); err != nil {
+		return err
+	}
+	if claims, err := s.jwtService.ValidateToken(token); err == nil && claims.SessionID != "" {
+		if err := s.revokeSession(ctx, claims.UserID, claims.SessionID); err != nil {
+			return err
+		}
+	}
+
+	// Cabut refresh token jika disertakan
+	if refreshToken != "" {
+		claims, err := s.jwtService.ValidateRefreshToken(refreshToken)
+		if err != nil {
+			return nil // Refresh token sudah tidak valid, tidak perlu dicabut
+		}
+		return s.revokeSession(ctx, claims.UserID, claims.ID)
+	}
+
+	return nil
 }
 
-// IsTokenBlacklisted mengecek apakah token ada di blacklist
-func (s *authService) IsTokenBlacklisted(token string) bool {
+// ChangePassword mengganti password user lalu mem-blacklist token yang sedang dipakai
+// dan mencabut semua sesi user, termasuk refresh token-nya
+func (s *authService) ChangePassword(userID uint, token string, req *dto.ChangePasswordRequest) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if !checkPasswordHash(req.OldPassword, user.Password) {
+		return ErrInvalidOldPassword
+	}
+	if req.OldPassword == req.NewPassword {
+		return ErrSamePassword
+	}
+	if err := passwordValidator.Var(req.NewPassword, "password"); err != nil {
+		return ErrWeakPassword
+	}
+
+	hashedPassword, err := s.hashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
 	if s.redisClient == nil {
-		return false // Skip jika Redis tidak tersedia
+		return nil // Skip jika Redis tidak tersedia
 	}
 
 	ctx := context.Background()
-	result, err := s.redisClient.Get(ctx, "blacklist:"+token).Result()
-	if err == redis.Nil {
-		return false
+	if token != "" {
+		if err := s.blacklistToken(ctx, token); err != nil {
+			return err
+		}
 	}
-	return result == "1"
+	return s.revokeAllSessions(ctx, user.ID)
+}
+
+// RequestPasswordReset membuat token reset sekali pakai untuk user dengan email tersebut.
+// Selalu mengembalikan nil untuk email yang tidak terdaftar agar tidak bisa dipakai enumerasi user.
+func (s *authService) RequestPasswordReset(email string) error {
+	if s.redisClient == nil {
+		return ErrResetUnavailable
+	}
+
+	user, err := s.userRepo.FindByEmail(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := generateResetToken()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	userKey := passwordResetUserKey(user.ID)
+
+	// Hanya token terbaru yang berlaku: hapus token sebelumnya milik user ini
+	if previous, err := s.redisClient.Get(ctx, userKey).Result(); err == nil {
+		s.redisClient.Del(ctx, passwordResetKey(previous))
+	}
+
+	tokenHash := hashResetToken(token)
+	if err := s.redisClient.Set(ctx, passwordResetKey(tokenHash), user.ID, passwordResetTTL).Err(); err != nil {
+		return err
+	}
+	if err := s.redisClient.Set(ctx, userKey, tokenHash, passwordResetTTL).Err(); err != nil {
+		return err
+	}
+
+	logger.Info().Uint("user_id", user.ID).Msg("Password reset requested")
+	s.notifier.NotifyEmail(user.Email,
+		"Reset your password",
+		fmt.Sprintf("We received a request to reset your password.\n\nReset token: %s\n\nThe token expires in %s. If you did not request this, you can ignore this email.",
+			token, passwordResetTTL),
+	)
+	return nil
+}
+
+// ResetPassword memakai token reset (sekali pakai) untuk mengganti password user
+// lalu mencabut semua sesi login user tersebut
+func (s *authService) ResetPassword(token string, newPassword string) error {
+	if err := passwordValidator.Var(newPassword, "password"); err != nil {
+		return ErrWeakPassword
+	}
+	if s.redisClient == nil {
+		return ErrResetUnavailable
+	}
+
+	ctx := context.Background()
+
+	// GETDEL membuat token langsung hangus walau ada request paralel
+	userIDStr, err := s.redisClient.GetDel(ctx, passwordResetKey(hashResetToken(token))).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.FindByID(uint(userID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	hashedPassword, err := s.hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	s.redisClient.Del(ctx, passwordResetUserKey(user.ID))
+	// Password lama mungkin bocor: sesi yang dibuat dengan password itu ikut dicabut
+	return s.revokeAllSessions(ctx, user.ID)
+}
+
+// RefreshToken membuat access token baru dari refresh token yang valid
+func (s *authService) RefreshToken(refreshToken string) (*dto.RefreshTokenResponse, error) {
+	claims, err := s.jwtService.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Pastikan refresh token belum dicabut (jti masih tersimpan di Redis)
+	if s.redisClient != nil {
+		ctx := context.Background()
+		exists, err := s.redisClient.Exists(ctx, refreshTokenKey(claims.ID)).Result()
+		if err != nil {
+			return nil, err
+		}
+		if exists == 0 {
+			return nil, ErrInvalidRefreshToken
+		}
+	}
+
+	// Ambil data user terbaru agar role/email di token selalu up to date
+	user, err := s.userRepo.FindByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrAccountDeactivated
+	}
+
+	token, expiresAt, err := s.jwtService.GenerateSessionToken(user.ID, user.Email, user.Role, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RefreshTokenResponse{
+		Token:     token,
+		TokenType: dto.TokenTypeBearer,
+		ExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// RefreshClaims menerbitkan access token baru berisi role/email terbaru user tanpa login ulang,
+// misalnya setelah admin mengubah role. Sesi (sid) tetap sama dan token lama di-blacklist
+// agar claim lama tidak bisa dipakai lagi.
+func (s *authService) RefreshClaims(userID uint, sessionID string, token string) (*dto.RefreshTokenResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrAccountDeactivated
+	}
+
+	newToken, expiresAt, err := s.jwtService.GenerateSessionToken(user.ID, user.Email, user.Role, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.redisClient != nil && token != "" {
+		if err := s.blacklistToken(context.Background(), token); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.RefreshTokenResponse{
+		Token:     newToken,
+		TokenType: dto.TokenTypeBearer,
+		ExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+	}, nil
 }
 
 // GetUserByID mengambil user berdasarkan ID
@@ -165,9 +485,228 @@ func (s *authService) GetUserByID(id uint) (*entity.User, error) {
 	return s.userRepo.FindByID(id)
 }
 
-// hashPassword helper untuk hash password (tidak diexport)
-func hashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// isPhoneTaken mengecek apakah nomor telepon sudah dipakai user lain
+func (s *authService) isPhoneTaken(phone string) (bool, error) {
+	_, err := s.userRepo.FindByPhone(phone)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return false, err
+}
+
+// UpdateProfile mengubah nama dan nomor telepon user yang sedang login
+func (s *authService) UpdateProfile(userID uint, req *dto.UpdateProfileRequest) (*dto.UserResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	user.Name = strings.TrimSpace(req.Name)
+	phone := strings.TrimSpace(req.Phone)
+	if phone != "" && (user.Phone == nil || *user.Phone != phone) {
+		taken, err := s.isPhoneTaken(phone)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, ErrPhoneAlreadyExists
+		}
+	}
+	user.Phone = nil
+	if phone != "" {
+		user.Phone = &phone
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		// Update paralel dengan phone yang sama bisa lolos pengecekan di atas
+		if database.IsUniqueViolation(err) {
+			return nil, ErrPhoneAlreadyExists
+		}
+		return nil, err
+	}
+
+	resp := toUserResponse(user)
+	return &resp, nil
+}
+
+// IsUserActive mengecek apakah user masih ada dan akunnya aktif (dipakai AuthMiddleware)
+func (s *authService) IsUserActive(id uint) (bool, error) {
+	user, err := s.userRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return user.IsActive, nil
+}
+
+// DeactivateAccount menonaktifkan akun milik user sendiri lalu mem-blacklist token yang sedang dipakai
+func (s *authService) DeactivateAccount(userID uint, token string) error {
+	if _, err := s.UpdateStatus(userID, false); err != nil {
+		return err
+	}
+
+	if s.redisClient == nil || token == "" {
+		return nil // Skip jika Redis tidak tersedia
+	}
+	return s.blacklistToken(context.Background(), token)
+}
+
+// CountUsersByRole menghitung jumlah user per role (untuk dashboard admin).
+// Role yang belum punya user tetap muncul dengan nilai 0.
+func (s *authService) CountUsersByRole() (map[string]int64, error) {
+	counts, err := s.userRepo.CountGroupedByRole()
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range []string{entity.RoleUser, entity.RoleSeller, entity.RoleAdmin} {
+		if _, ok := counts[role]; !ok {
+			counts[role] = 0
+		}
+	}
+	return counts, nil
+}
+
+// GetAllUsers mengambil semua user dengan filter dan pagination (untuk admin)
+func (s *authService) GetAllUsers(params *dto.UserQueryParams) (*dto.UserListResponse, error) {
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+
+	users, total, err := s.userRepo.FindAll(params)
+	if err != nil {
+		return nil, err
+	}
+
+	userResponses := []dto.UserResponse{}
+	for _, u := range users {
+		userResponses = append(userResponses, toUserResponse(&u))
+	}
+
+	return &dto.UserListResponse{
+		Users: userResponses,
+		Meta:  pagination.BuildMeta(total, params.Page, params.Limit),
+	}, nil
+}
+
+// UpdateRole mengubah role user (untuk admin). Admin terakhir tidak bisa diturunkan.
+func (s *authService) UpdateRole(userID uint, role string) (*dto.UserResponse, error) {
+	if !entity.IsValidRole(role) {
+		return nil, ErrInvalidRole
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if user.IsAdmin() && role != entity.RoleAdmin {
+		adminCount, err := s.userRepo.CountByRole(entity.RoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+		if adminCount <= 1 {
+			return nil, ErrLastAdmin
+		}
+	}
+
+	user.Role = role
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	// User yang dijadikan seller oleh admin dianggap sudah di-approve
+	if role == entity.RoleSeller {
+		if err := s.ensureApprovedSellerProfile(user); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := toUserResponse(user)
+	return &resp, nil
+}
+
+// UpdateStatus mengaktifkan atau menonaktifkan akun user (untuk admin).
+// Admin aktif terakhir tidak bisa dinonaktifkan agar platform tidak kehilangan akses admin.
+func (s *authService) UpdateStatus(userID uint, isActive bool) (*dto.UserResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if !isActive && user.IsActive && user.IsAdmin() {
+		activeAdmins, err := s.userRepo.CountActiveByRole(entity.RoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+		if activeAdmins <= 1 {
+			return nil, ErrLastActiveAdmin
+		}
+	}
+
+	user.IsActive = isActive
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	resp := toUserResponse(user)
+	return &resp, nil
+}
+
+// toUserResponse mengubah entity user menjadi response tanpa password
+func toUserResponse(user *entity.User) dto.UserResponse {
+	resp := dto.UserResponse{
+		ID:       user.ID,
+		Name:     user.Name,
+		Email:    user.Email,
+		Role:     user.Role,
+		IsActive: user.IsActive,
+	}
+	if user.Phone != nil {
+		resp.Phone = *user.Phone
+	}
+	if user.SellerProfile != nil {
+		resp.SellerStatus = user.SellerProfile.Status
+	}
+	return resp
+}
+
+// buildAuthResponse membuat token pair dan mencatat sesinya (jti refresh token) di Redis
+func (s *authService) buildAuthResponse(user *entity.User, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	pair, err := s.jwtService.GenerateTokenPair(user.ID, user.Email, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.redisClient != nil {
+		if err := s.createSession(context.Background(), user.ID, pair.RefreshJTI, client); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.AuthResponse{
+		User:         toUserResponse(user),
+		Token:        pair.AccessToken,
+		TokenType:    dto.TokenTypeBearer,
+		ExpiresAt:    pair.AccessTokenExpiresAt.UTC().Format(time.RFC3339),
+		RefreshToken: pair.RefreshToken,
+	}, nil
+}
+
+// hashPassword helper untuk hash password dengan cost yang dikonfigurasi (tidak diexport)
+func (s *authService) hashPassword(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), s.bcryptCost)
 	return string(bytes), err
 }
 
@@ -177,6 +716,31 @@ func checkPasswordHash(password, hash string) bool {
 	return err == nil
 }
 
+// generateResetToken membuat token acak 32 byte (hex) untuk link reset password
+func generateResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashResetToken menghasilkan key Redis dari token, sehingga token asli tidak disimpan
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// passwordResetKey menyimpan user ID pemilik token reset dengan hash tersebut
+func passwordResetKey(tokenHash string) string {
+	return database.RedisKey("password_reset:" + tokenHash)
+}
+
+// passwordResetUserKey menyimpan hash token reset terbaru milik user
+func passwordResetUserKey(userID uint) string {
+	return database.RedisKey(fmt.Sprintf("password_reset_user:%d", userID))
+}
+
 // GetTokenRemainingTime menghitung sisa waktu token (untuk TTL blacklist)
 func GetTokenRemainingTime(expireAt time.Time) time.Duration {
 	remaining := time.Until(expireAt)
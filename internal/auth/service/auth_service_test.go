@@ -1,13 +1,90 @@
 package service
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
+// testBcryptCost memakai cost minimum agar hashing di test tetap cepat
+const testBcryptCost = bcrypt.MinCost
+
+// testHashPassword hash password dengan testBcryptCost untuk seed user di test
+func testHashPassword(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), testBcryptCost)
+	return string(bytes), err
+}
+
+// fakeUserRepository menyimpan user di memory untuk test service
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[uint]*entity.User
+}
+
+func (r *fakeUserRepository) FindByID(id uint) (*entity.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *fakeUserRepository) CountByRole(role string) (int64, error) {
+	var count int64
+	for _, u := range r.users {
+		if u.Role == role {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeUserRepository) CountActiveByRole(role string) (int64, error) {
+	var count int64
+	for _, u := range r.users {
+		if u.Role == role && u.IsActive {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeUserRepository) FindByEmail(email string) (*entity.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeUserRepository) FindByPhone(phone string) (*entity.User, error) {
+	for _, u := range r.users {
+		if u.Phone != nil && *u.Phone == phone {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeUserRepository) Update(user *entity.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
 // Test Register Request Validation
 func TestRegisterRequest(t *testing.T) {
 	req := &dto.RegisterRequest{
@@ -116,3 +193,229 @@ func TestRoleConstants(t *testing.T) {
 	assert.Equal(t, "seller", entity.RoleSeller)
 	assert.Equal(t, "user", entity.RoleUser)
 }
+
+// Test Change Password
+func TestChangePassword(t *testing.T) {
+	hashed, err := testHashPassword("Password123")
+	require.NoError(t, err)
+
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Email: "test@example.com", Password: hashed},
+	}}
+	svc := NewAuthService(repo, nil, nil, nil, nil, testBcryptCost, 0, 0)
+
+	err = svc.ChangePassword(1, "token", &dto.ChangePasswordRequest{OldPassword: "Wrong123", NewPassword: "NewPassword123"})
+	assert.ErrorIs(t, err, ErrInvalidOldPassword)
+
+	err = svc.ChangePassword(1, "token", &dto.ChangePasswordRequest{OldPassword: "Password123", NewPassword: "Password123"})
+	assert.ErrorIs(t, err, ErrSamePassword)
+
+	err = svc.ChangePassword(1, "token", &dto.ChangePasswordRequest{OldPassword: "Password123", NewPassword: "weak"})
+	assert.ErrorIs(t, err, ErrWeakPassword)
+
+	err = svc.ChangePassword(2, "token", &dto.ChangePasswordRequest{OldPassword: "Password123", NewPassword: "NewPassword123"})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	require.NoError(t, svc.ChangePassword(1, "token", &dto.ChangePasswordRequest{OldPassword: "Password123", NewPassword: "NewPassword123"}))
+	assert.True(t, checkPasswordHash("NewPassword123", repo.users[1].Password))
+}
+
+// racingUserRepository mensimulasikan registrasi paralel: FindByEmail belum melihat user lain,
+// tetapi INSERT ditolak database dengan createErr
+type racingUserRepository struct {
+	fakeUserRepository
+	createErr error
+}
+
+func (r *racingUserRepository) Create(user *entity.User) error {
+	return r.createErr
+}
+
+// Test Register Duplicate Email Race
+func TestRegister_TranslatesUniqueViolationToEmailExists(t *testing.T) {
+	req := &dto.RegisterRequest{Name: "Test User", Email: "test@example.com", Password: "Password123"}
+
+	repo := &racingUserRepository{
+		fakeUserRepository: fakeUserRepository{users: map[uint]*entity.User{}},
+		createErr:          &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"},
+	}
+	_, err := NewAuthService(repo, nil, nil, nil, nil, testBcryptCost, 0, 0).Register(req, dto.ClientInfo{})
+	assert.ErrorIs(t, err, ErrEmailAlreadyExists)
+
+	repo.createErr = fmt.Errorf("insert user: %w", gorm.ErrDuplicatedKey)
+	_, err = NewAuthService(repo, nil, nil, nil, nil, testBcryptCost, 0, 0).Register(req, dto.ClientInfo{})
+	assert.ErrorIs(t, err, ErrEmailAlreadyExists)
+
+	// Error database lain tetap diteruskan apa adanya
+	repo.createErr = &pgconn.PgError{Code: "57014"}
+	_, err = NewAuthService(repo, nil, nil, nil, nil, testBcryptCost, 0, 0).Register(req, dto.ClientInfo{})
+	assert.NotErrorIs(t, err, ErrEmailAlreadyExists)
+	assert.ErrorIs(t, err, repo.createErr)
+}
+
+// Test Bcrypt Cost
+func TestNewAuthService_UsesConfiguredBcryptCost(t *testing.T) {
+	repo := &fakeUserRepository{users: map[uint]*entity.User{}}
+
+	svc := NewAuthService(repo, nil, nil, nil, nil, testBcryptCost, 0, 0).(*authService)
+	hashed, err := svc.hashPassword("Password123")
+	require.NoError(t, err)
+	cost, err := bcrypt.Cost([]byte(hashed))
+	require.NoError(t, err)
+	assert.Equal(t, testBcryptCost, cost)
+
+	// Cost di luar rentang bcrypt kembali ke DefaultCost
+	assert.Equal(t, bcrypt.DefaultCost, NewAuthService(repo, nil, nil, nil, nil, 0, 0, 0).(*authService).bcryptCost)
+	assert.Equal(t, bcrypt.DefaultCost, NewAuthService(repo, nil, nil, nil, nil, bcrypt.MaxCost+1, 0, 0).(*authService).bcryptCost)
+}
+
+// Test Password Reset
+func TestPasswordReset_WithoutRedis(t *testing.T) {
+	svc := NewAuthService(&fakeUserRepository{users: map[uint]*entity.User{}}, nil, nil, nil, nil, testBcryptCost, 0, 0)
+
+	assert.ErrorIs(t, svc.ResetPassword("token", "weak"), ErrWeakPassword)
+	assert.ErrorIs(t, svc.ResetPassword("token", "NewPassword123"), ErrResetUnavailable)
+	assert.ErrorIs(t, svc.RequestPasswordReset("unknown@example.com"), ErrResetUnavailable)
+}
+
+func TestResetTokenHelpers(t *testing.T) {
+	token, err := generateResetToken()
+	require.NoError(t, err)
+	assert.Len(t, token, 64)
+
+	other, err := generateResetToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+
+	assert.Equal(t, hashResetToken(token), hashResetToken(token))
+	assert.NotEqual(t, token, hashResetToken(token))
+}
+
+// Test Update Role
+func TestUpdateRole(t *testing.T) {
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Email: "admin@example.com", Role: entity.RoleAdmin},
+		2: {ID: 2, Email: "user@example.com", Role: entity.RoleUser},
+	}}
+	svc := NewAuthService(repo, nil, nil, nil, nil, testBcryptCost, 0, 0)
+
+	_, err := svc.UpdateRole(2, "superuser")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+
+	_, err = svc.UpdateRole(3, entity.RoleSeller)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	// Admin terakhir tidak boleh diturunkan
+	_, err = svc.UpdateRole(1, entity.RoleUser)
+	assert.ErrorIs(t, err, ErrLastAdmin)
+
+	resp, err := svc.UpdateRole(2, entity.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleAdmin, resp.Role)
+
+	resp, err = svc.UpdateRole(1, entity.RoleUser)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleUser, resp.Role)
+	assert.Equal(t, entity.RoleUser, repo.users[1].Role)
+}
+
+// Test Account Deactivation
+func TestUpdateStatus(t *testing.T) {
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Email: "admin@example.com", Role: entity.RoleAdmin, IsActive: true},
+		2: {ID: 2, Email: "user@example.com", Role: entity.RoleUser, IsActive: true},
+	}}
+	svc := NewAuthService(repo, nil, nil, nil, nil, testBcryptCost, 0, 0)
+
+	_, err := svc.UpdateStatus(3, false)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	// Admin aktif terakhir tidak boleh dinonaktifkan
+	_, err = svc.UpdateStatus(1, false)
+	assert.ErrorIs(t, err, ErrLastActiveAdmin)
+
+	require.NoError(t, svc.DeactivateAccount(2, ""))
+	assert.False(t, repo.users[2].IsActive)
+	active, err := svc.IsUserActive(2)
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	resp, err := svc.UpdateStatus(2, true)
+	require.NoError(t, err)
+	assert.True(t, resp.IsActive)
+	active, err = svc.IsUserActive(2)
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	// User yang tidak ada dianggap tidak aktif
+	active, err = svc.IsUserActive(99)
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestLogin_RejectsDeactivatedAccount(t *testing.T) {
+	hashed, err := testHashPassword("Password123")
+	require.NoError(t, err)
+
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Email: "user@example.com", Password: hashed, Role: entity.RoleUser, IsActive: false},
+	}}
+	svc := NewAuthService(repo, nil, nil, nil, nil, testBcryptCost, 0, 0)
+
+	// Password salah tetap invalid credentials, tanpa membocorkan status akun
+	_, err = svc.Login(&dto.LoginRequest{Email: "user@example.com", Password: "Wrong123"}, dto.ClientInfo{})
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = svc.Login(&dto.LoginRequest{Email: "user@example.com", Password: "Password123"}, dto.ClientInfo{})
+	assert.ErrorIs(t, err, ErrAccountDeactivated)
+}
+
+func TestLogin_ReturnsTokenTypeAndExpiry(t *testing.T) {
+	hashed, err := testHashPassword("Password123")
+	require.NoError(t, err)
+
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Email: "user@example.com", Password: hashed, Role: entity.RoleUser, IsActive: true},
+	}}
+	jwtService := utils.NewJWTService("test-secret", 1, 24)
+	svc := NewAuthService(repo, nil, jwtService, nil, nil, testBcryptCost, 0, 0)
+
+	resp, err := svc.Login(&dto.LoginRequest{Email: "user@example.com", Password: "Password123"}, dto.ClientInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", resp.TokenType)
+
+	expiresAt, err := time.Parse(time.RFC3339, resp.ExpiresAt)
+	require.NoError(t, err)
+	claims, err := jwtService.ValidateToken(resp.Token)
+	require.NoError(t, err)
+	assert.True(t, claims.ExpiresAt.Time.Equal(expiresAt))
+}
+
+func TestRefreshClaims_IssuesTokenWithCurrentRoleAndSameSession(t *testing.T) {
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Email: "seller@example.com", Role: entity.RoleSeller, IsActive: true},
+		2: {ID: 2, Email: "inactive@example.com", Role: entity.RoleUser, IsActive: false},
+	}}
+	jwtService := utils.NewJWTService("test-secret", 1, 24)
+	svc := NewAuthService(repo, nil, jwtService, nil, nil, testBcryptCost, 0, 0)
+
+	// Token lama masih membawa role user sebelum dipromosikan menjadi seller
+	oldToken, _, err := jwtService.GenerateSessionToken(1, "user@example.com", entity.RoleUser, "session-1")
+	require.NoError(t, err)
+
+	resp, err := svc.RefreshClaims(1, "session-1", oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", resp.TokenType)
+
+	claims, err := jwtService.ValidateToken(resp.Token)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleSeller, claims.Role)
+	assert.Equal(t, "seller@example.com", claims.Email)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.True(t, claims.IsAccessToken())
+
+	_, err = svc.RefreshClaims(2, "session-2", "")
+	assert.ErrorIs(t, err, ErrAccountDeactivated)
+	_, err = svc.RefreshClaims(3, "session-3", "")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/database"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// loginLockoutKey dan loginAttemptsKey dikunci per email (lowercase) agar variasi huruf tidak mem-bypass lockout
+func loginLockoutKey(email string) string {
+	return database.RedisKey("login_lock:" + normalizeLoginEmail(email))
+}
+
+func loginAttemptsKey(email string) string {
+	return database.RedisKey("login_attempts:" + normalizeLoginEmail(email))
+}
+
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// maskEmail menyamarkan bagian lokal email untuk log, mis. "john@example.com" menjadi "j***@example.com"
+func maskEmail(email string) string {
+	local, domain, found := strings.Cut(normalizeLoginEmail(email), "@")
+	if local == "" {
+		return "***"
+	}
+	masked := local[:1] + "***"
+	if found {
+		masked += "@" + domain
+	}
+	return masked
+}
+
+// lockoutEnabled mengecek apakah lockout aktif; tanpa Redis atau dengan maxLoginAttempts <= 0 lockout tidak berlaku
+func (s *authService) lockoutEnabled() bool {
+	return s.redisClient != nil && s.maxLoginAttempts > 0 && s.loginLockoutWindow > 0
+}
+
+// isLoginLocked mengecek apakah email sedang dalam masa cooldown.
+// Error Redis dianggap tidak terkunci agar login tetap bisa dilakukan saat Redis bermasalah.
+func (s *authService) isLoginLocked(ctx context.Context, email string) bool {
+	if !s.lockoutEnabled() {
+		return false
+	}
+
+	exists, err := s.redisClient.Exists(ctx, loginLockoutKey(email)).Result()
+	if err != nil {
+		return false
+	}
+	return exists > 0
+}
+
+// recordFailedLogin menambah counter gagal login dalam window; saat mencapai batas,
+// email dikunci selama loginLockoutWindow dan counter direset
+func (s *authService) recordFailedLogin(ctx context.Context, email string) {
+	if !s.lockoutEnabled() {
+		return
+	}
+
+	// SET NX EX dan INCR dijalankan dalam satu MULTI: counter selalu punya TTL, dan window
+	// dimulai dari kegagalan pertama karena SET NX tidak mengubah TTL counter yang sudah ada
+	key := loginAttemptsKey(email)
+	var incr *redis.IntCmd
+	_, err := s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SetNX(ctx, key, 0, s.loginLockoutWindow)
+		incr = pipe.Incr(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return
+	}
+	attempts := incr.Val()
+
+	if attempts >= int64(s.maxLoginAttempts) {
+		if err := s.redisClient.Set(ctx, loginLockoutKey(email), "1", s.loginLockoutWindow).Err(); err != nil {
+			return
+		}
+		s.redisClient.Del(ctx, key)
+		logger.Warn().Str("email", maskEmail(email)).Int64("attempts", attempts).Msg("Login locked after failed attempts")
+	}
+}
+
+// resetFailedLogins menghapus counter gagal login setelah login berhasil
+func (s *authService) resetFailedLogins(ctx context.Context, email string) {
+	if !s.lockoutEnabled() {
+		return
+	}
+	s.redisClient.Del(ctx, loginAttemptsKey(email))
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogin_LockoutIsNoOpWithoutRedis(t *testing.T) {
+	hashed, err := testHashPassword("Password123")
+	require.NoError(t, err)
+
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Email: "user@example.com", Password: hashed, Role: entity.RoleUser, IsActive: true},
+	}}
+	svc := NewAuthService(repo, nil, utils.NewJWTService("test-secret", 1, 24), nil, nil, testBcryptCost, 3, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		_, err := svc.Login(&dto.LoginRequest{Email: "user@example.com", Password: "Wrong123"}, dto.ClientInfo{})
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+
+	_, err = svc.Login(&dto.LoginRequest{Email: "user@example.com", Password: "Password123"}, dto.ClientInfo{})
+	assert.NoError(t, err)
+}
+
+func TestLogin_LockoutFailsOpenWhenRedisUnreachable(t *testing.T) {
+	hashed, err := testHashPassword("Password123")
+	require.NoError(t, err)
+
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Email: "user@example.com", Password: hashed, Role: entity.RoleUser, IsActive: true},
+	}}
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	defer redisClient.Close()
+	svc := NewAuthService(repo, nil, nil, redisClient, nil, testBcryptCost, 2, time.Minute)
+
+	for i := 0; i < 4; i++ {
+		_, err := svc.Login(&dto.LoginRequest{Email: "user@example.com", Password: "Wrong123"}, dto.ClientInfo{})
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+}
+
+func TestLoginLockoutKeys_NormalizeEmail(t *testing.T) {
+	assert.Equal(t, "login_lock:user@example.com", loginLockoutKey("  User@Example.COM "))
+	assert.Equal(t, "login_attempts:user@example.com", loginAttemptsKey("user@example.com"))
+
+	svc := NewAuthService(&fakeUserRepository{}, nil, nil, nil, nil, testBcryptCost, 5, time.Minute).(*authService)
+	assert.False(t, svc.lockoutEnabled())
+}
+
+func TestLogin_LocksAfterMaxFailedAttempts(t *testing.T) {
+	hashed, err := testHashPassword("Password123")
+	require.NoError(t, err)
+
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer redisClient.Close()
+
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Email: "user@example.com", Password: hashed, Role: entity.RoleUser, IsActive: true},
+	}}
+	svc := NewAuthService(repo, nil, utils.NewJWTService("test-secret", 1, 24), redisClient, nil, testBcryptCost, 3, time.Minute)
+
+	_, err = svc.Login(&dto.LoginRequest{Email: "User@Example.com", Password: "Wrong123"}, dto.ClientInfo{})
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	// Counter langsung punya TTL sejak kegagalan pertama, dan kegagalan berikutnya tidak memperpanjangnya
+	assert.Equal(t, time.Minute, mr.TTL(loginAttemptsKey("user@example.com")))
+	mr.FastForward(20 * time.Second)
+
+	for i := 0; i < 2; i++ {
+		_, err = svc.Login(&dto.LoginRequest{Email: "user@example.com", Password: "Wrong123"}, dto.ClientInfo{})
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+	assert.False(t, mr.Exists(loginAttemptsKey("user@example.com")))
+	assert.True(t, mr.Exists(loginLockoutKey("user@example.com")))
+
+	// Password benar tetap ditolak selama masa cooldown
+	_, err = svc.Login(&dto.LoginRequest{Email: "user@example.com", Password: "Password123"}, dto.ClientInfo{})
+	assert.ErrorIs(t, err, ErrAccountLocked)
+
+	mr.FastForward(time.Minute)
+	_, err = svc.Login(&dto.LoginRequest{Email: "user@example.com", Password: "Password123"}, dto.ClientInfo{})
+	assert.NoError(t, err)
+}
+
+func TestRecordFailedLogin_WindowStartsAtFirstFailure(t *testing.T) {
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer redisClient.Close()
+	svc := NewAuthService(&fakeUserRepository{}, nil, nil, redisClient, nil, testBcryptCost, 5, time.Minute).(*authService)
+
+	ctx := context.Background()
+	svc.recordFailedLogin(ctx, "user@example.com")
+	mr.FastForward(40 * time.Second)
+	svc.recordFailedLogin(ctx, "user@example.com")
+
+	attempts, err := mr.Get(loginAttemptsKey("user@example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "2", attempts)
+	assert.Equal(t, 20*time.Second, mr.TTL(loginAttemptsKey("user@example.com")))
+
+	// Setelah window habis counter mulai dari awal
+	mr.FastForward(20 * time.Second)
+	svc.recordFailedLogin(ctx, "user@example.com")
+	attempts, err = mr.Get(loginAttemptsKey("user@example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "1", attempts)
+}
+
+func TestMaskEmail(t *testing.T) {
+	assert.Equal(t, "j***@example.com", maskEmail(" John.Doe@Example.com "))
+	assert.Equal(t, "a***@example.com", maskEmail("a@example.com"))
+	assert.Equal(t, "n***", maskEmail("not-an-email"))
+	assert.Equal(t, "***", maskEmail(""))
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// conflictingUserRepository menolak Update seolah unique index bentrok
+type conflictingUserRepository struct {
+	fakeUserRepository
+	updateErr error
+}
+
+func (r *conflictingUserRepository) Update(user *entity.User) error {
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	return r.fakeUserRepository.Update(user)
+}
+
+func TestUpdateProfile_UpdatesNameAndPhone(t *testing.T) {
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Name: "Old Name", Email: "user@example.com", Role: entity.RoleUser, IsActive: true},
+	}}
+	svc := NewAuthService(repo, nil, nil, nil, nil, testBcryptCost, 0, 0)
+
+	resp, err := svc.UpdateProfile(1, &dto.UpdateProfileRequest{Name: " New Name ", Phone: "081234567890"})
+	require.NoError(t, err)
+	assert.Equal(t, "New Name", resp.Name)
+	assert.Equal(t, "081234567890", resp.Phone)
+	// Email tidak ikut berubah
+	assert.Equal(t, "user@example.com", resp.Email)
+	require.NotNil(t, repo.users[1].Phone)
+	assert.Equal(t, "081234567890", *repo.users[1].Phone)
+
+	// Phone kosong menghapus nomor yang tersimpan
+	resp, err = svc.UpdateProfile(1, &dto.UpdateProfileRequest{Name: "New Name"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Phone)
+	assert.Nil(t, repo.users[1].Phone)
+}
+
+func TestUpdateProfile_Errors(t *testing.T) {
+	repo := &conflictingUserRepository{
+		fakeUserRepository: fakeUserRepository{users: map[uint]*entity.User{
+			1: {ID: 1, Name: "User", Email: "user@example.com", Role: entity.RoleUser, IsActive: true},
+		}},
+	}
+	svc := NewAuthService(repo, nil, nil, nil, nil, testBcryptCost, 0, 0)
+
+	_, err := svc.UpdateProfile(2, &dto.UpdateProfileRequest{Name: "Someone"})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	repo.updateErr = fmt.Errorf("update user: %w", gorm.ErrDuplicatedKey)
+	_, err = svc.UpdateProfile(1, &dto.UpdateProfileRequest{Name: "User", Phone: "081234567890"})
+	assert.ErrorIs(t, err, ErrPhoneAlreadyExists)
+}
+
+func TestRegister_PersistsOptionalUniquePhone(t *testing.T) {
+	svc, db := setupSellerApprovalService(t)
+
+	resp, err := svc.Register(&dto.RegisterRequest{Name: "Budi", Email: "budi@example.com", Password: "Password123", Phone: "081234567890"}, dto.ClientInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "081234567890", resp.User.Phone)
+
+	var stored entity.User
+	require.NoError(t, db.First(&stored, resp.User.ID).Error)
+	require.NotNil(t, stored.Phone)
+	assert.Equal(t, "081234567890", *stored.Phone)
+
+	// User tanpa phone (termasuk baris lama) tersimpan NULL dan tidak saling bentrok
+	for _, email := range []string{"ani@example.com", "cici@example.com"} {
+		other, err := svc.Register(&dto.RegisterRequest{Name: "Tanpa Phone", Email: email, Password: "Password123"}, dto.ClientInfo{})
+		require.NoError(t, err)
+		assert.Empty(t, other.User.Phone)
+	}
+
+	_, err = svc.Register(&dto.RegisterRequest{Name: "Dodi", Email: "dodi@example.com", Password: "Password123", Phone: "081234567890"}, dto.ClientInfo{})
+	assert.ErrorIs(t, err, ErrPhoneAlreadyExists)
+
+	// Phone yang sudah dipakai juga ditolak saat update profil
+	var ani entity.User
+	require.NoError(t, db.Where("email = ?", "ani@example.com").First(&ani).Error)
+	_, err = svc.UpdateProfile(ani.ID, &dto.UpdateProfileRequest{Name: "Ani", Phone: "081234567890"})
+	assert.ErrorIs(t, err, ErrPhoneAlreadyExists)
+
+	// Menyimpan ulang phone milik sendiri tidak dianggap bentrok
+	_, err = svc.UpdateProfile(resp.User.ID, &dto.UpdateProfileRequest{Name: "Budi", Phone: "081234567890"})
+	assert.NoError(t, err)
+}
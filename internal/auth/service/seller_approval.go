@@ -0,0 +1,104 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// GetAllSellers mengambil profil seller dengan filter status dan pagination (untuk admin)
+func (s *authService) GetAllSellers(params *dto.SellerQueryParams) (*dto.SellerListResponse, error) {
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+
+	profiles, total, err := s.sellerProfileRepo.FindAll(params)
+	if err != nil {
+		return nil, err
+	}
+
+	sellers := []dto.SellerProfileResponse{}
+	for i := range profiles {
+		sellers = append(sellers, toSellerProfileResponse(&profiles[i]))
+	}
+
+	return &dto.SellerListResponse{
+		Sellers: sellers,
+		Meta:    pagination.BuildMeta(total, params.Page, params.Limit),
+	}, nil
+}
+
+// UpdateSellerStatus meng-approve atau menolak pengajuan seller (untuk admin).
+// userID adalah ID user pemilik profil seller.
+func (s *authService) UpdateSellerStatus(userID uint, status string) (*dto.SellerProfileResponse, error) {
+	if !entity.IsValidSellerStatus(status) {
+		return nil, ErrInvalidSellerStatus
+	}
+
+	profile, err := s.sellerProfileRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSellerNotFound
+		}
+		return nil, err
+	}
+
+	profile.Status = status
+	if err := s.sellerProfileRepo.Update(profile); err != nil {
+		return nil, err
+	}
+
+	resp := toSellerProfileResponse(profile)
+	return &resp, nil
+}
+
+// IsSellerApproved mengecek apakah user punya profil seller berstatus APPROVED.
+// Seller tanpa profil dianggap belum di-approve.
+func (s *authService) IsSellerApproved(userID uint) (bool, error) {
+	profile, err := s.sellerProfileRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return profile.IsApproved(), nil
+}
+
+// ensureApprovedSellerProfile membuat profil APPROVED untuk user yang belum punya profil seller.
+// Profil yang sudah ada (misalnya masih PENDING atau REJECTED) tidak diubah.
+func (s *authService) ensureApprovedSellerProfile(user *entity.User) error {
+	profile, err := s.sellerProfileRepo.FindByUserID(user.ID)
+	if err == nil {
+		user.SellerProfile = profile
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	profile = &entity.SellerProfile{
+		UserID:    user.ID,
+		StoreName: user.Name,
+		Status:    entity.SellerStatusApproved,
+	}
+	if err := s.sellerProfileRepo.Create(profile); err != nil {
+		return err
+	}
+	user.SellerProfile = profile
+	return nil
+}
+
+// toSellerProfileResponse mengubah entity profil seller menjadi response
+func toSellerProfileResponse(p *entity.SellerProfile) dto.SellerProfileResponse {
+	return dto.SellerProfileResponse{
+		ID:        p.ID,
+		UserID:    p.UserID,
+		StoreName: p.StoreName,
+		Status:    p.Status,
+		CreatedAt: p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: p.UpdatedAt.Format(time.RFC3339),
+	}
+}
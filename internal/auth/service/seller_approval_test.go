@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database/dbtest"
+	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupSellerApprovalService membuat AuthService dengan repository sqlite in-memory
+func setupSellerApprovalService(t *testing.T) (AuthService, *gorm.DB) {
+	db := dbtest.NewSQLite(t, &entity.User{}, &entity.SellerProfile{})
+
+	svc := NewAuthService(
+		repository.NewUserRepository(db),
+		repository.NewSellerProfileRepository(db),
+		utils.NewJWTService("test-secret", 1, 24),
+		nil, nil, testBcryptCost, 0, 0,
+	)
+	return svc, db
+}
+
+func TestRegister_SellerStartsPending(t *testing.T) {
+	svc, db := setupSellerApprovalService(t)
+
+	resp, err := svc.Register(&dto.RegisterRequest{Name: "Budi", Email: "budi@example.com", Password: "Password123", Role: entity.RoleSeller, StoreName: "Toko Budi"}, dto.ClientInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, entity.SellerStatusPending, resp.User.SellerStatus)
+
+	var profile entity.SellerProfile
+	require.NoError(t, db.Where("user_id = ?", resp.User.ID).First(&profile).Error)
+	assert.Equal(t, "Toko Budi", profile.StoreName)
+	assert.Equal(t, entity.SellerStatusPending, profile.Status)
+
+	approved, err := svc.IsSellerApproved(resp.User.ID)
+	require.NoError(t, err)
+	assert.False(t, approved)
+
+	// Buyer biasa tidak mendapat profil seller
+	buyer, err := svc.Register(&dto.RegisterRequest{Name: "Ani", Email: "ani@example.com", Password: "Password123"}, dto.ClientInfo{})
+	require.NoError(t, err)
+	assert.Empty(t, buyer.User.SellerStatus)
+	var count int64
+	require.NoError(t, db.Model(&entity.SellerProfile{}).Where("user_id = ?", buyer.User.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestUpdateSellerStatus_ApproveAndReject(t *testing.T) {
+	svc, db := setupSellerApprovalService(t)
+	require.NoError(t, db.Create(&entity.User{ID: 5, Name: "Budi", Email: "budi@example.com", Password: "x", Role: entity.RoleSeller, IsActive: true,
+		SellerProfile: &entity.SellerProfile{StoreName: "Toko Budi", Status: entity.SellerStatusPending}}).Error)
+
+	_, err := svc.UpdateSellerStatus(99, entity.SellerStatusApproved)
+	assert.ErrorIs(t, err, ErrSellerNotFound)
+
+	_, err = svc.UpdateSellerStatus(5, "SUSPENDED")
+	assert.ErrorIs(t, err, ErrInvalidSellerStatus)
+
+	resp, err := svc.UpdateSellerStatus(5, entity.SellerStatusApproved)
+	require.NoError(t, err)
+	assert.Equal(t, entity.SellerStatusApproved, resp.Status)
+	approved, err := svc.IsSellerApproved(5)
+	require.NoError(t, err)
+	assert.True(t, approved)
+
+	_, err = svc.UpdateSellerStatus(5, entity.SellerStatusRejected)
+	require.NoError(t, err)
+	approved, err = svc.IsSellerApproved(5)
+	require.NoError(t, err)
+	assert.False(t, approved)
+
+	list, err := svc.GetAllSellers(&dto.SellerQueryParams{Status: entity.SellerStatusRejected})
+	require.NoError(t, err)
+	require.Len(t, list.Sellers, 1)
+	assert.Equal(t, uint(5), list.Sellers[0].UserID)
+}
+
+func TestUpdateRole_PromotedSellerIsApproved(t *testing.T) {
+	svc, db := setupSellerApprovalService(t)
+	require.NoError(t, db.Create(&entity.User{ID: 3, Name: "Citra", Email: "citra@example.com", Password: "x", Role: entity.RoleUser, IsActive: true}).Error)
+
+	approved, err := svc.IsSellerApproved(3)
+	require.NoError(t, err)
+	assert.False(t, approved)
+
+	resp, err := svc.UpdateRole(3, entity.RoleSeller)
+	require.NoError(t, err)
+	assert.Equal(t, entity.SellerStatusApproved, resp.SellerStatus)
+
+	approved, err = svc.IsSellerApproved(3)
+	require.NoError(t, err)
+	assert.True(t, approved)
+}
+
+func TestBackfillSellerProfiles_ApprovesExistingSellers(t *testing.T) {
+	_, db := setupSellerApprovalService(t)
+	require.NoError(t, db.Create(&entity.User{ID: 1, Name: "Old Seller", Email: "old@example.com", Password: "x", Role: entity.RoleSeller, IsActive: true}).Error)
+	require.NoError(t, db.Create(&entity.User{ID: 2, Name: "Pending", Email: "pending@example.com", Password: "x", Role: entity.RoleSeller, IsActive: true,
+		SellerProfile: &entity.SellerProfile{StoreName: "Pending", Status: entity.SellerStatusPending}}).Error)
+	require.NoError(t, db.Create(&entity.User{ID: 3, Name: "Buyer", Email: "buyer@example.com", Password: "x", Role: entity.RoleUser, IsActive: true}).Error)
+
+	require.NoError(t, repository.BackfillSellerProfiles(db))
+
+	var profiles []entity.SellerProfile
+	require.NoError(t, db.Order("user_id").Find(&profiles).Error)
+	require.Len(t, profiles, 2)
+	assert.Equal(t, uint(1), profiles[0].UserID)
+	assert.Equal(t, entity.SellerStatusApproved, profiles[0].Status)
+	assert.Equal(t, "Old Seller", profiles[0].StoreName)
+	assert.Equal(t, entity.SellerStatusPending, profiles[1].Status)
+}
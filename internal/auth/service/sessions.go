@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database"
+	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+// maxSessionUserAgentLength membatasi panjang user agent yang disimpan per sesi
+const maxSessionUserAgentLength = 255
+
+// sessionRecord disimpan sebagai value refresh:<jti>. Sesi aktif selama key itu ada,
+// sehingga mencabut sesi juga membuat refresh token-nya tidak bisa dipakai lagi.
+type sessionRecord struct {
+	UserID    uint      `json:"user_id"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// refreshTokenKey menyimpan sesi milik refresh token dengan jti tersebut
+func refreshTokenKey(jti string) string {
+	return database.RedisKey("refresh:" + jti)
+}
+
+// userSessionsKey adalah set jti sesi milik user (bisa berisi sesi yang sudah expired)
+func userSessionsKey(userID uint) string {
+	return database.RedisKey(fmt.Sprintf("sessions:%d", userID))
+}
+
+// blacklistKey menandai jti access token, atau ID sesi, yang sudah dicabut
+func blacklistKey(jti string) string {
+	return database.RedisKey("blacklist:" + jti)
+}
+
+// createSession mencatat sesi baru untuk refresh token dengan jti tersebut
+func (s *authService) createSession(ctx context.Context, userID uint, jti string, client dto.ClientInfo) error {
+	ttl := s.jwtService.GetRefreshTokenExpiry()
+	userAgent := client.UserAgent
+	if len(userAgent) > maxSessionUserAgentLength {
+		userAgent = userAgent[:maxSessionUserAgentLength]
+	}
+
+	now := time.Now().UTC()
+	payload, err := json.Marshal(sessionRecord{
+		UserID:    userID,
+		IP:        client.IP,
+		UserAgent: userAgent,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	})
+	if err != nil {
+		return err
+	}
+
+	pipe := s.redisClient.TxPipeline()
+	pipe.Set(ctx, refreshTokenKey(jti), payload, ttl)
+	pipe.SAdd(ctx, userSessionsKey(userID), jti)
+	pipe.Expire(ctx, userSessionsKey(userID), ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// ListSessions mengambil sesi login user yang masih aktif, terbaru lebih dulu.
+// currentSessionID adalah sesi token yang dipakai request (ditandai current).
+func (s *authService) ListSessions(userID uint, currentSessionID string) ([]dto.SessionResponse, error) {
+	if s.redisClient == nil {
+		return nil, ErrSessionsUnavailable
+	}
+
+	ctx := context.Background()
+	jtis, err := s.redisClient.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(jtis) == 0 {
+		return []dto.SessionResponse{}, nil
+	}
+
+	keys := make([]string, len(jtis))
+	for i, jti := range jtis {
+		keys[i] = refreshTokenKey(jti)
+	}
+	values, err := s.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]sessionRecord, len(jtis))
+	var stale []interface{}
+	for i, value := range values {
+		record, ok := parseSessionRecord(value, userID)
+		if !ok {
+			stale = append(stale, jtis[i])
+			continue
+		}
+		records[jtis[i]] = record
+	}
+	// Sesi yang sudah expired atau dicabut dibersihkan dari set milik user
+	if len(stale) > 0 {
+		s.redisClient.SRem(ctx, userSessionsKey(userID), stale...)
+	}
+
+	return toSessionResponses(records, currentSessionID), nil
+}
+
+// RevokeSession mencabut sesi milik user: refresh token-nya dihapus dan jti sesi di-blacklist
+// sehingga access token yang diterbitkan untuk sesi itu langsung ditolak
+func (s *authService) RevokeSession(userID uint, jti string) error {
+	if s.redisClient == nil {
+		return ErrSessionsUnavailable
+	}
+
+	ctx := context.Background()
+	value, err := s.redisClient.Get(ctx, refreshTokenKey(jti)).Result()
+	if err == redis.Nil {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if _, ok := parseSessionRecord(value, userID); !ok {
+		return ErrSessionNotFound
+	}
+
+	return s.revokeSession(ctx, userID, jti)
+}
+
+// revokeSession menghapus sesi dan mem-blacklist ID-nya selama umur access token
+func (s *authService) revokeSession(ctx context.Context, userID uint, jti string) error {
+	pipe := s.redisClient.TxPipeline()
+	pipe.Del(ctx, refreshTokenKey(jti))
+	pipe.SRem(ctx, userSessionsKey(userID), jti)
+	pipe.Set(ctx, blacklistKey(jti), "1", s.jwtService.GetTokenExpiry())
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// revokeAllSessions mencabut semua sesi user, misalnya setelah password diganti,
+// sehingga refresh token dan access token yang beredar untuk sesi-sesi itu tidak berlaku lagi
+func (s *authService) revokeAllSessions(ctx context.Context, userID uint) error {
+	jtis, err := s.redisClient.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	for _, jti := range jtis {
+		if err := s.revokeSession(ctx, userID, jti); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blacklistToken mem-blacklist jti access token sampai token itu expired.
+// Token yang sudah tidak valid tidak perlu di-blacklist.
+func (s *authService) blacklistToken(ctx context.Context, token string) error {
+	claims, err := s.jwtService.ValidateToken(token)
+	if err != nil || claims.ID == "" {
+		return nil
+	}
+
+	ttl := s.jwtService.GetTokenExpiry()
+	if claims.ExpiresAt != nil {
+		ttl = GetTokenRemainingTime(claims.ExpiresAt.Time)
+	}
+	if ttl <= 0 {
+		return nil
+	}
+	return s.redisClient.Set(ctx, blacklistKey(claims.ID), "1", ttl).Err()
+}
+
+// IsTokenBlacklisted mengecek apakah access token (jti) atau sesinya sudah dicabut
+func (s *authService) IsTokenBlacklisted(claims *utils.JWTClaims) bool {
+	if s.redisClient == nil {
+		return false // Skip jika Redis tidak tersedia
+	}
+
+	keys := []string{blacklistKey(claims.ID)}
+	if claims.SessionID != "" {
+		keys = append(keys, blacklistKey(claims.SessionID))
+	}
+	count, err := s.redisClient.Exists(context.Background(), keys...).Result()
+	if err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// parseSessionRecord membaca value refresh:<jti>. Value kosong, milik user lain, atau format lama
+// (hanya user ID, dari sebelum sesi dicatat) dianggap bukan sesi user tersebut.
+func parseSessionRecord(value interface{}, userID uint) (sessionRecord, bool) {
+	raw, ok := value.(string)
+	if !ok {
+		return sessionRecord{}, false
+	}
+
+	var record sessionRecord
+	if err := json.Unmarshal([]byte(raw), &record); err != nil || record.UserID != userID {
+		return sessionRecord{}, false
+	}
+	return record, true
+}
+
+// toSessionResponses mengurutkan sesi dari yang terbaru dan menandai sesi yang sedang dipakai
+func toSessionResponses(records map[string]sessionRecord, currentSessionID string) []dto.SessionResponse {
+	sessions := make([]dto.SessionResponse, 0, len(records))
+	for jti, record := range records {
+		sessions = append(sessions, dto.SessionResponse{
+			JTI:       jti,
+			IP:        record.IP,
+			UserAgent: record.UserAgent,
+			IssuedAt:  record.IssuedAt.UTC().Format(time.RFC3339),
+			ExpiresAt: record.ExpiresAt.UTC().Format(time.RFC3339),
+			Current:   jti == currentSessionID,
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		a, b := records[sessions[i].JTI], records[sessions[j].JTI]
+		if !a.IssuedAt.Equal(b.IssuedAt) {
+			return a.IssuedAt.After(b.IssuedAt)
+		}
+		return sessions[i].JTI < sessions[j].JTI
+	})
+	return sessions
+}
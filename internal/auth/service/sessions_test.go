@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSessionService membuat AuthService dengan Redis miniredis dan satu user aktif
+func setupSessionService(t *testing.T) (AuthService, *utils.JWTService) {
+	hashed, err := testHashPassword("Password123")
+	require.NoError(t, err)
+
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+
+	repo := &fakeUserRepository{users: map[uint]*entity.User{
+		1: {ID: 1, Email: "user@example.com", Password: hashed, Role: entity.RoleUser, IsActive: true},
+	}}
+	jwtService := utils.NewJWTService("test-secret", 1, 24)
+	return NewAuthService(repo, nil, jwtService, redisClient, nil, testBcryptCost, 0, 0), jwtService
+}
+
+func TestSessions_UnavailableWithoutRedis(t *testing.T) {
+	svc := NewAuthService(&fakeUserRepository{}, nil, nil, nil, nil, testBcryptCost, 0, 0)
+
+	_, err := svc.ListSessions(1, "")
+	assert.ErrorIs(t, err, ErrSessionsUnavailable)
+	assert.ErrorIs(t, svc.RevokeSession(1, "abc"), ErrSessionsUnavailable)
+}
+
+func TestParseSessionRecord_OnlyAcceptsOwnSessions(t *testing.T) {
+	issuedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	payload, err := json.Marshal(sessionRecord{UserID: 7, IP: "10.0.0.1", UserAgent: "curl/8.0", IssuedAt: issuedAt})
+	require.NoError(t, err)
+
+	record, ok := parseSessionRecord(string(payload), 7)
+	require.True(t, ok)
+	assert.Equal(t, "10.0.0.1", record.IP)
+	assert.True(t, record.IssuedAt.Equal(issuedAt))
+
+	_, ok = parseSessionRecord(string(payload), 8)
+	assert.False(t, ok)
+
+	// Key sudah expired (MGET mengembalikan nil) atau format lama yang hanya berisi user ID
+	_, ok = parseSessionRecord(nil, 7)
+	assert.False(t, ok)
+	_, ok = parseSessionRecord("7", 7)
+	assert.False(t, ok)
+}
+
+func TestToSessionResponses_NewestFirstAndMarksCurrent(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	sessions := toSessionResponses(map[string]sessionRecord{
+		"old":    {UserID: 7, IP: "10.0.0.1", IssuedAt: base, ExpiresAt: base.Add(24 * time.Hour)},
+		"newest": {UserID: 7, IP: "10.0.0.3", IssuedAt: base.Add(2 * time.Hour)},
+		"middle": {UserID: 7, IP: "10.0.0.2", IssuedAt: base.Add(time.Hour)},
+	}, "middle")
+
+	require.Len(t, sessions, 3)
+	assert.Equal(t, []string{"newest", "middle", "old"}, []string{sessions[0].JTI, sessions[1].JTI, sessions[2].JTI})
+	assert.True(t, sessions[1].Current)
+	assert.False(t, sessions[0].Current)
+	assert.Equal(t, "2024-05-01T10:00:00Z", sessions[2].IssuedAt)
+	assert.Equal(t, "2024-05-02T10:00:00Z", sessions[2].ExpiresAt)
+}
+
+func TestChangePassword_RevokesAllSessions(t *testing.T) {
+	svc, jwtService := setupSessionService(t)
+
+	login := &dto.LoginRequest{Email: "user@example.com", Password: "Password123"}
+	current, err := svc.Login(login, dto.ClientInfo{})
+	require.NoError(t, err)
+	other, err := svc.Login(login, dto.ClientInfo{})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.ChangePassword(1, current.Token, &dto.ChangePasswordRequest{OldPassword: "Password123", NewPassword: "NewPassword123"}))
+
+	sessions, err := svc.ListSessions(1, "")
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	// Refresh token dan access token dari perangkat lain ikut tidak berlaku
+	for _, resp := range []*dto.AuthResponse{current, other} {
+		_, err := svc.RefreshToken(resp.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+		claims, err := jwtService.ValidateToken(resp.Token)
+		require.NoError(t, err)
+		assert.True(t, svc.IsTokenBlacklisted(claims))
+	}
+}
+
+func TestResetPassword_RevokesAllSessions(t *testing.T) {
+	svc, jwtService := setupSessionService(t)
+
+	resp, err := svc.Login(&dto.LoginRequest{Email: "user@example.com", Password: "Password123"}, dto.ClientInfo{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	redisClient := svc.(*authService).redisClient
+	require.NoError(t, redisClient.Set(ctx, passwordResetKey(hashResetToken("reset-token")), 1, passwordResetTTL).Err())
+	require.NoError(t, svc.ResetPassword("reset-token", "NewPassword123"))
+
+	sessions, err := svc.ListSessions(1, "")
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	_, err = svc.RefreshToken(resp.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	claims, err := jwtService.ValidateToken(resp.Token)
+	require.NoError(t, err)
+	assert.True(t, svc.IsTokenBlacklisted(claims))
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "github.com/akbarwjyy/go-commerce-api/pkg/money"
+
+// AddCartItemRequest untuk request menambah item ke cart
+type AddCartItemRequest struct {
+	ProductID uint  `json:"product_id" binding:"required"`
+	VariantID *uint `json:"variant_id,omitempty"` // wajib jika produk punya varian
+	Quantity  int   `json:"quantity" binding:"required,gt=0"`
+}
+
+// UpdateCartItemRequest untuk request mengubah jumlah item di cart
+type UpdateCartItemRequest struct {
+	Quantity  int   `json:"quantity" binding:"required,gt=0"`
+	VariantID *uint `json:"variant_id,omitempty"` // ganti varian item; kosong = tetap
+}
+
+// CartItemResponse untuk response item dalam cart
+type CartItemResponse struct {
+	ID          uint        `json:"id"`
+	ProductID   uint        `json:"product_id"`
+	VariantID   *uint       `json:"variant_id,omitempty"`
+	ProductName string      `json:"product_name"`
+	Price       money.Money `json:"price" swaggertype:"string" example:"199.99"`
+	Currency    string      `json:"currency" example:"IDR"`
+	Quantity    int         `json:"quantity"`
+	Subtotal    money.Money `json:"subtotal" swaggertype:"string" example:"399.98"`
+}
+
+// CartResponse untuk response data cart
+type CartResponse struct {
+	ID          uint               `json:"id"`
+	UserID      uint               `json:"user_id"`
+	Items       []CartItemResponse `json:"items"`
+	TotalAmount money.Money        `json:"total_amount" swaggertype:"string" example:"399.98"`
+	Currency    string             `json:"currency" example:"IDR"` // kosong jika item memakai currency berbeda
+}
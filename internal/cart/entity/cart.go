@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Cart entity untuk tabel carts (satu cart per user)
+type Cart struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	UserID    uint           `gorm:"uniqueIndex;not null" json:"user_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	Items     []CartItem     `gorm:"foreignKey:CartID" json:"items,omitempty"`
+}
+
+// TableName menentukan nama tabel di database
+func (Cart) TableName() string {
+	return "carts"
+}
+
+// IsOwner mengecek apakah user adalah pemilik cart
+func (c *Cart) IsOwner(userID uint) bool {
+	return c.UserID == userID
+}
+
+// IsEmpty mengecek apakah cart tidak memiliki item
+func (c *Cart) IsEmpty() bool {
+	return len(c.Items) == 0
+}
+
+// FindItemByProduct mencari item tanpa varian dalam cart berdasarkan product ID
+func (c *Cart) FindItemByProduct(productID uint) *CartItem {
+	return c.FindItem(productID, nil)
+}
+
+// FindItem mencari item dalam cart berdasarkan product ID dan varian (nil = tanpa varian)
+func (c *Cart) FindItem(productID uint, variantID *uint) *CartItem {
+	for i := range c.Items {
+		if c.Items[i].ProductID == productID && sameVariant(c.Items[i].VariantID, variantID) {
+			return &c.Items[i]
+		}
+	}
+	return nil
+}
+
+// sameVariant membandingkan dua variant ID opsional
+func sameVariant(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
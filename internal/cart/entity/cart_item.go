@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CartItem entity untuk tabel cart_items
+type CartItem struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CartID    uint           `gorm:"index;not null" json:"cart_id"`
+	ProductID uint           `gorm:"index;not null" json:"product_id"`
+	VariantID *uint          `gorm:"index" json:"variant_id,omitempty"` // wajib jika produk punya varian
+	Quantity  int            `gorm:"not null" json:"quantity"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName menentukan nama tabel di database
+func (CartItem) TableName() string {
+	return "cart_items"
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/cart/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/cart/service"
+	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
+	"github.com/gin-gonic/gin"
+)
+
+// CartHandler menangani HTTP request untuk cart
+type CartHandler struct {
+	cartService service.CartService
+}
+
+// NewCartHandler membuat instance baru CartHandler
+func NewCartHandler(cartService service.CartService) *CartHandler {
+	return &CartHandler{cartService: cartService}
+}
+
+// AddItem godoc
+// @Summary      Add item to cart
+// @Description  Add a product to the current user's cart (quantity is merged if the same product and variant is already present). Products with variants require variant_id; stock is checked against the variant
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.AddCartItemRequest true "Add cart item request"
+// @Success      200 {object} response.APIResponse{data=dto.CartResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /cart/items [post]
+func (h *CartHandler) AddItem(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	var req dto.AddCartItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.cartService.AddItem(userID.(uint), &req)
+	if err != nil {
+		h.handleError(ctx, err, "Failed to add item to cart")
+		return
+	}
+
+	response.OK(ctx, "Item added to cart successfully", result)
+}
+
+// GetCart godoc
+// @Summary      Get my cart
+// @Description  Get the current user's cart with product details
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.APIResponse{data=dto.CartResponse}
+// @Failure      401 {object} response.APIResponse
+// @Router       /cart [get]
+func (h *CartHandler) GetCart(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	result, err := h.cartService.GetCart(userID.(uint))
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get cart", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Cart retrieved successfully", result)
+}
+
+// UpdateItem godoc
+// @Summary      Update cart item
+// @Description  Update the quantity of an item in the current user's cart, and optionally switch it to another variant of the same product
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Cart item ID"
+// @Param        request body dto.UpdateCartItemRequest true "Update cart item request"
+// @Success      200 {object} response.APIResponse{data=dto.CartResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /cart/items/{id} [patch]
+func (h *CartHandler) UpdateItem(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid cart item ID", nil)
+		return
+	}
+
+	var req dto.UpdateCartItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.cartService.UpdateItem(userID.(uint), uint(id), &req)
+	if err != nil {
+		h.handleError(ctx, err, "Failed to update cart item")
+		return
+	}
+
+	response.OK(ctx, "Cart item updated successfully", result)
+}
+
+// RemoveItem godoc
+// @Summary      Remove cart item
+// @Description  Remove an item from the current user's cart
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Cart item ID"
+// @Success      200 {object} response.APIResponse{data=dto.CartResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /cart/items/{id} [delete]
+func (h *CartHandler) RemoveItem(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid cart item ID", nil)
+		return
+	}
+
+	result, err := h.cartService.RemoveItem(userID.(uint), uint(id))
+	if err != nil {
+		h.handleError(ctx, err, "Failed to remove cart item")
+		return
+	}
+
+	response.OK(ctx, "Cart item removed successfully", result)
+}
+
+// ClearCart godoc
+// @Summary      Clear cart
+// @Description  Remove all items from the current user's cart
+// @Tags         Cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Router       /cart [delete]
+func (h *CartHandler) ClearCart(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	if err := h.cartService.ClearCart(userID.(uint)); err != nil {
+		response.InternalServerError(ctx, "Failed to clear cart", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Cart cleared successfully", nil)
+}
+
+// handleError memetakan error service ke response HTTP
+func (h *CartHandler) handleError(ctx *gin.Context, err error, message string) {
+	switch err {
+	case service.ErrCartItemNotFound:
+		response.NotFound(ctx, "Cart item not found")
+	case service.ErrProductNotFound:
+		response.NotFound(ctx, "Product not found")
+	case service.ErrProductUnavailable:
+		response.BadRequest(ctx, "Product is not available", nil)
+	case service.ErrInsufficientStock:
+		response.BadRequest(ctx, "Insufficient stock", nil)
+	case service.ErrVariantNotFound:
+		response.NotFound(ctx, "Product variant not found")
+	case service.ErrVariantRequired:
+		response.BadRequest(ctx, "Product has variants, a variant must be selected", nil)
+	case service.ErrVariantInCart:
+		response.Error(ctx, http.StatusConflict, "This variant is already in the cart, update that item instead", nil)
+	default:
+		response.InternalServerError(ctx, message, err.Error())
+	}
+}
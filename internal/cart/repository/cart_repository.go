@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/cart/entity"
+	"gorm.io/gorm"
+)
+
+// CartRepository interface untuk akses data cart
+type CartRepository interface {
+	FindByUserID(userID uint) (*entity.Cart, error)
+	FindOrCreateByUserID(userID uint) (*entity.Cart, error)
+	FindItemByID(id uint) (*entity.CartItem, error)
+	CreateItem(item *entity.CartItem) error
+	UpdateItem(item *entity.CartItem) error
+	DeleteItem(id uint) error
+	ClearItems(cartID uint) error
+	WithTx(tx *gorm.DB) CartRepository
+}
+
+// cartRepository implementasi CartRepository
+type cartRepository struct {
+	db *gorm.DB
+}
+
+// NewCartRepository membuat instance baru CartRepository
+func NewCartRepository(db *gorm.DB) CartRepository {
+	return &cartRepository{db: db}
+}
+
+// WithTx mengembalikan repository dengan transaction
+func (r *cartRepository) WithTx(tx *gorm.DB) CartRepository {
+	return &cartRepository{db: tx}
+}
+
+// FindByUserID mencari cart milik user beserta items
+func (r *cartRepository) FindByUserID(userID uint) (*entity.Cart, error) {
+	var cart entity.Cart
+	if err := r.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).Where("user_id = ?", userID).First(&cart).Error; err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+// FindOrCreateByUserID mencari cart milik user, membuat cart baru jika belum ada
+func (r *cartRepository) FindOrCreateByUserID(userID uint) (*entity.Cart, error) {
+	cart, err := r.FindByUserID(userID)
+	if err == nil {
+		return cart, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	cart = &entity.Cart{UserID: userID}
+	if err := r.db.Create(cart).Error; err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// FindItemByID mencari item cart berdasarkan ID
+func (r *cartRepository) FindItemByID(id uint) (*entity.CartItem, error) {
+	var item entity.CartItem
+	if err := r.db.First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateItem menyimpan item baru ke cart
+func (r *cartRepository) CreateItem(item *entity.CartItem) error {
+	return r.db.Create(item).Error
+}
+
+// UpdateItem mengupdate item cart
+func (r *cartRepository) UpdateItem(item *entity.CartItem) error {
+	return r.db.Save(item).Error
+}
+
+// DeleteItem menghapus item dari cart
+func (r *cartRepository) DeleteItem(id uint) error {
+	return r.db.Delete(&entity.CartItem{}, id).Error
+}
+
+// ClearItems menghapus semua item dalam cart
+func (r *cartRepository) ClearItems(cartID uint) error {
+	return r.db.Where("cart_id = ?", cartID).Delete(&entity.CartItem{}).Error
+}
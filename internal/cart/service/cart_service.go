@@ -0,0 +1,292 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/cart/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/cart/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/cart/repository"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"gorm.io/gorm"
+)
+
+// Common errors
+var (
+	ErrCartItemNotFound   = errors.New("cart item not found")
+	ErrProductNotFound    = errors.New("product not found")
+	ErrProductUnavailable = errors.New("product is not available")
+	ErrInsufficientStock  = errors.New("insufficient stock")
+	ErrVariantNotFound    = errors.New("product variant not found")
+	ErrVariantRequired    = errors.New("product has variants, a variant must be selected")
+	ErrVariantInCart      = errors.New("this variant is already in the cart")
+)
+
+// CartService interface untuk business logic cart
+type CartService interface {
+	AddItem(userID uint, req *dto.AddCartItemRequest) (*dto.CartResponse, error)
+	UpdateItem(userID uint, itemID uint, req *dto.UpdateCartItemRequest) (*dto.CartResponse, error)
+	RemoveItem(userID uint, itemID uint) (*dto.CartResponse, error)
+	GetCart(userID uint) (*dto.CartResponse, error)
+	ClearCart(userID uint) error
+
+	// Untuk Order Module (checkout dari cart)
+	GetCartItems(userID uint) ([]entity.CartItem, error)
+}
+
+// cartService implementasi CartService
+type cartService struct {
+	cartRepo       repository.CartRepository
+	productService productService.ProductService
+	baseCurrency   string // currency cart kosong
+}
+
+// NewCartService membuat instance baru CartService
+func NewCartService(
+	cartRepo repository.CartRepository,
+	productSvc productService.ProductService,
+	baseCurrency string,
+) CartService {
+	return &cartService{
+		cartRepo:       cartRepo,
+		productService: productSvc,
+		baseCurrency:   baseCurrency,
+	}
+}
+
+// AddItem menambah produk ke cart, atau menambah jumlahnya jika sudah ada
+func (s *cartService) AddItem(userID uint, req *dto.AddCartItemRequest) (*dto.CartResponse, error) {
+	cart, err := s.cartRepo.FindOrCreateByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := req.Quantity
+	existing := cart.FindItem(req.ProductID, req.VariantID)
+	if existing != nil {
+		quantity += existing.Quantity
+	}
+
+	// Validasi stok saat item ditambahkan
+	if err := s.checkAvailability(req.ProductID, req.VariantID, quantity); err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		existing.Quantity = quantity
+		if err := s.cartRepo.UpdateItem(existing); err != nil {
+			return nil, err
+		}
+	} else {
+		item := &entity.CartItem{
+			CartID:    cart.ID,
+			ProductID: req.ProductID,
+			VariantID: req.VariantID,
+			Quantity:  quantity,
+		}
+		if err := s.cartRepo.CreateItem(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetCart(userID)
+}
+
+// UpdateItem mengubah jumlah item di cart, dan variannya jika variant_id dikirim
+func (s *cartService) UpdateItem(userID uint, itemID uint, req *dto.UpdateCartItemRequest) (*dto.CartResponse, error) {
+	cart, item, err := s.findOwnedItem(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	variantID := item.VariantID
+	if req.VariantID != nil {
+		variantID = req.VariantID
+		// Varian yang sama tidak boleh muncul di dua item cart
+		if other := cart.FindItem(item.ProductID, variantID); other != nil && other.ID != item.ID {
+			return nil, ErrVariantInCart
+		}
+	}
+
+	if err := s.checkAvailability(item.ProductID, variantID, req.Quantity); err != nil {
+		return nil, err
+	}
+
+	item.VariantID = variantID
+	item.Quantity = req.Quantity
+	if err := s.cartRepo.UpdateItem(item); err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(userID)
+}
+
+// RemoveItem menghapus item dari cart
+func (s *cartService) RemoveItem(userID uint, itemID uint) (*dto.CartResponse, error) {
+	_, item, err := s.findOwnedItem(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cartRepo.DeleteItem(item.ID); err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(userID)
+}
+
+// GetCart mengambil cart milik user beserta detail produk
+func (s *cartService) GetCart(userID uint) (*dto.CartResponse, error) {
+	cart, err := s.cartRepo.FindOrCreateByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.CartResponse{
+		ID:     cart.ID,
+		UserID: cart.UserID,
+		Items:  []dto.CartItemResponse{},
+	}
+
+	for _, item := range cart.Items {
+		itemResp := dto.CartItemResponse{
+			ID:        item.ID,
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+		}
+
+		// Produk atau varian bisa saja sudah dihapus setelah masuk cart
+		if product, err := s.productService.GetProductByID(item.ProductID); err == nil {
+			price := product.Price
+			if item.VariantID != nil {
+				if variant, err := s.productService.GetVariant(item.ProductID, *item.VariantID); err == nil {
+					price = variant.Price
+				}
+			}
+			itemResp.ProductName = product.Name
+			itemResp.Price = price
+			itemResp.Currency = product.Currency
+			itemResp.Subtotal = price.Mul(item.Quantity)
+		}
+
+		resp.Items = append(resp.Items, itemResp)
+		resp.TotalAmount = resp.TotalAmount.Add(itemResp.Subtotal)
+	}
+	resp.Currency = s.cartCurrency(resp.Items)
+
+	return resp, nil
+}
+
+// cartCurrency mengembalikan currency yang dipakai semua item cart (BASE_CURRENCY untuk cart kosong).
+// Kosong jika item memakai currency berbeda; cart seperti itu tidak bisa di-checkout.
+func (s *cartService) cartCurrency(items []dto.CartItemResponse) string {
+	currency := ""
+	for _, item := range items {
+		switch {
+		case item.Currency == "":
+			// Produk sudah dihapus, tidak ikut menentukan currency
+		case currency == "":
+			currency = item.Currency
+		case item.Currency != currency:
+			return ""
+		}
+	}
+	if currency == "" {
+		return s.baseCurrency
+	}
+	return currency
+}
+
+// ClearCart mengosongkan cart milik user
+func (s *cartService) ClearCart(userID uint) error {
+	cart, err := s.cartRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return s.cartRepo.ClearItems(cart.ID)
+}
+
+// GetCartItems mengambil item cart milik user (untuk checkout)
+func (s *cartService) GetCartItems(userID uint) ([]entity.CartItem, error) {
+	cart, err := s.cartRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cart.Items, nil
+}
+
+// Helper Functions
+
+// findOwnedItem mencari item cart dan memastikan item milik cart user
+func (s *cartService) findOwnedItem(userID uint, itemID uint) (*entity.Cart, *entity.CartItem, error) {
+	cart, err := s.cartRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrCartItemNotFound
+		}
+		return nil, nil, err
+	}
+
+	item, err := s.cartRepo.FindItemByID(itemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrCartItemNotFound
+		}
+		return nil, nil, err
+	}
+
+	if item.CartID != cart.ID {
+		return nil, nil, ErrCartItemNotFound
+	}
+
+	return cart, item, nil
+}
+
+// checkAvailability memastikan produk aktif dan stok mencukupi.
+// Produk bervarian wajib memilih varian, dan stok yang dicek adalah stok varian tersebut.
+func (s *cartService) checkAvailability(productID uint, variantID *uint, quantity int) error {
+	product, err := s.productService.GetProductByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProductNotFound
+		}
+		return err
+	}
+
+	if !product.IsActive {
+		return ErrProductUnavailable
+	}
+
+	if variantID != nil {
+		variant, err := s.productService.GetVariant(productID, *variantID)
+		if err != nil {
+			if err == productService.ErrVariantNotFound {
+				return ErrVariantNotFound
+			}
+			return err
+		}
+		if !variant.HasStock(quantity) {
+			return ErrInsufficientStock
+		}
+		return nil
+	}
+
+	hasVariants, err := s.productService.HasVariants(productID)
+	if err != nil {
+		return err
+	}
+	if hasVariants {
+		return ErrVariantRequired
+	}
+	if !product.HasStock(quantity) {
+		return ErrInsufficientStock
+	}
+
+	return nil
+}
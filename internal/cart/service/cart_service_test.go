@@ -0,0 +1,138 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/cart/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/cart/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/cart/repository"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database/dbtest"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Test Cart Entity Methods
+func TestCartEntity_IsOwner(t *testing.T) {
+	cart := &entity.Cart{ID: 1, UserID: 10}
+
+	assert.True(t, cart.IsOwner(10))
+	assert.False(t, cart.IsOwner(20))
+}
+
+func TestCartEntity_IsEmpty(t *testing.T) {
+	cart := &entity.Cart{}
+	assert.True(t, cart.IsEmpty())
+
+	cart.Items = []entity.CartItem{{ProductID: 1, Quantity: 1}}
+	assert.False(t, cart.IsEmpty())
+}
+
+func TestCartEntity_FindItemByProduct(t *testing.T) {
+	cart := &entity.Cart{
+		Items: []entity.CartItem{
+			{ID: 1, ProductID: 5, Quantity: 2},
+			{ID: 2, ProductID: 7, Quantity: 1},
+		},
+	}
+
+	item := cart.FindItemByProduct(7)
+	assert.NotNil(t, item)
+	assert.Equal(t, uint(2), item.ID)
+
+	// Pointer harus merujuk ke elemen slice agar update tersimpan
+	item.Quantity = 4
+	assert.Equal(t, 4, cart.Items[1].Quantity)
+
+	assert.Nil(t, cart.FindItemByProduct(99))
+}
+
+func setupCartDB(t *testing.T) *gorm.DB {
+	return dbtest.NewSQLite(t,
+		&productEntity.Category{},
+		&productEntity.Product{},
+		&productEntity.ProductVariant{},
+		&entity.Cart{},
+		&entity.CartItem{},
+	)
+}
+
+func newCartService(db *gorm.DB) CartService {
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	return NewCartService(repository.NewCartRepository(db), productSvc, money.DefaultCurrency)
+}
+
+func TestAddItem_RejectsInsufficientStock(t *testing.T) {
+	db := setupCartDB(t)
+	svc := newCartService(db)
+
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 3, SellerID: 1, IsActive: true}
+	inactive := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(10), Stock: 5, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+	require.NoError(t, db.Create(inactive).Error)
+	require.NoError(t, db.Model(inactive).Update("is_active", false).Error)
+
+	_, err := svc.AddItem(1, &dto.AddCartItemRequest{ProductID: product.ID, Quantity: 4})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+	_, err = svc.AddItem(1, &dto.AddCartItemRequest{ProductID: inactive.ID, Quantity: 1})
+	assert.ErrorIs(t, err, ErrProductUnavailable)
+	_, err = svc.AddItem(1, &dto.AddCartItemRequest{ProductID: product.ID + 100, Quantity: 1})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+
+	cart, err := svc.AddItem(1, &dto.AddCartItemRequest{ProductID: product.ID, Quantity: 2})
+	require.NoError(t, err)
+	require.Len(t, cart.Items, 1)
+
+	// Jumlah yang sudah ada di cart ikut dihitung saat produk yang sama ditambahkan lagi
+	_, err = svc.AddItem(1, &dto.AddCartItemRequest{ProductID: product.ID, Quantity: 2})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	cart, err = svc.AddItem(1, &dto.AddCartItemRequest{ProductID: product.ID, Quantity: 1})
+	require.NoError(t, err)
+	require.Len(t, cart.Items, 1)
+	assert.Equal(t, 3, cart.Items[0].Quantity)
+}
+
+func TestUpdateAndRemoveItem_OnlyOwnCartItems(t *testing.T) {
+	db := setupCartDB(t)
+	svc := newCartService(db)
+
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 5, SellerID: 1, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	const ownerID, otherID = uint(1), uint(2)
+	cart, err := svc.AddItem(ownerID, &dto.AddCartItemRequest{ProductID: product.ID, Quantity: 1})
+	require.NoError(t, err)
+	itemID := cart.Items[0].ID
+
+	// User lain (dengan atau tanpa cart) tidak bisa mengubah atau menghapus item milik orang lain
+	_, err = svc.UpdateItem(otherID, itemID, &dto.UpdateCartItemRequest{Quantity: 2})
+	assert.ErrorIs(t, err, ErrCartItemNotFound)
+	_, err = svc.AddItem(otherID, &dto.AddCartItemRequest{ProductID: product.ID, Quantity: 1})
+	require.NoError(t, err)
+	_, err = svc.UpdateItem(otherID, itemID, &dto.UpdateCartItemRequest{Quantity: 2})
+	assert.ErrorIs(t, err, ErrCartItemNotFound)
+	_, err = svc.RemoveItem(otherID, itemID)
+	assert.ErrorIs(t, err, ErrCartItemNotFound)
+	_, err = svc.UpdateItem(ownerID, itemID+100, &dto.UpdateCartItemRequest{Quantity: 2})
+	assert.ErrorIs(t, err, ErrCartItemNotFound)
+
+	// Update tetap dicek terhadap stok
+	_, err = svc.UpdateItem(ownerID, itemID, &dto.UpdateCartItemRequest{Quantity: 6})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	cart, err = svc.UpdateItem(ownerID, itemID, &dto.UpdateCartItemRequest{Quantity: 4})
+	require.NoError(t, err)
+	assert.Equal(t, 4, cart.Items[0].Quantity)
+
+	cart, err = svc.RemoveItem(ownerID, itemID)
+	require.NoError(t, err)
+	assert.Empty(t, cart.Items)
+
+	items, err := svc.GetCartItems(otherID)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+}
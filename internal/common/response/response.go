@@ -2,9 +2,12 @@
 package response
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/akbarwjyy/go-commerce-api/pkg/validator"
 	"github.com/gin-gonic/gin"
+	validatorpkg "github.com/go-playground/validator/v10"
 )
 
 // APIResponse is the standard API response structure
@@ -67,3 +70,25 @@ func NotFound(ctx *gin.Context, message string) {
 func InternalServerError(ctx *gin.Context, message string, err interface{}) {
 	Error(ctx, http.StatusInternalServerError, message, err)
 }
+
+// UnprocessableEntity mengirim response error 422
+func UnprocessableEntity(ctx *gin.Context, message string, err interface{}) {
+	Error(ctx, http.StatusUnprocessableEntity, message, err)
+}
+
+// TooManyRequests mengirim response error 429
+func TooManyRequests(ctx *gin.Context, message string) {
+	Error(ctx, http.StatusTooManyRequests, message, nil)
+}
+
+// BindError mengirim response untuk error binding request.
+// Error validasi menghasilkan 422 dengan map {"field": "message"}; body yang tidak bisa
+// di-parse (JSON rusak, tipe field salah) menghasilkan 400 dengan pesan error apa adanya.
+func BindError(ctx *gin.Context, err error) {
+	var validationErrs validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		UnprocessableEntity(ctx, "Validation failed", validator.FormatValidationErrors(validationErrs))
+		return
+	}
+	BadRequest(ctx, "Invalid request body", err.Error())
+}
@@ -0,0 +1,53 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type bindRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func bindRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/bind", func(ctx *gin.Context) {
+		var req bindRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			BindError(ctx, err)
+			return
+		}
+		OK(ctx, "ok", nil)
+	})
+	return router
+}
+
+func TestBindError_MalformedJSONIsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	bindRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/bind", strings.NewReader(`{"name":`)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Invalid request body")
+}
+
+func TestBindError_WrongFieldTypeIsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	bindRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/bind", strings.NewReader(`{"name": 5}`)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBindError_ValidationFailureIsUnprocessableEntity(t *testing.T) {
+	w := httptest.NewRecorder()
+	bindRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/bind", strings.NewReader(`{}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "Validation failed")
+	assert.Contains(t, w.Body.String(), `"name":"This field is required"`)
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+)
+
+// CreateCouponRequest untuk request pembuatan coupon
+type CreateCouponRequest struct {
+	Code       string      `json:"code" binding:"required,min=3,max=50,alphanum"`
+	Type       string      `json:"type" binding:"required,oneof=PERCENT FIXED"`
+	Value      float64     `json:"value" binding:"required,gt=0"`
+	MinSpend   money.Money `json:"min_spend" binding:"gte=0" swaggertype:"string" example:"100000.00"`
+	ExpiresAt  *time.Time  `json:"expires_at,omitempty"`
+	UsageLimit int         `json:"usage_limit" binding:"gte=0"`
+}
+
+// ValidateCouponRequest untuk request cek coupon sebelum checkout
+type ValidateCouponRequest struct {
+	Code     string      `json:"code" binding:"required"`
+	Subtotal money.Money `json:"subtotal" binding:"gte=0" swaggertype:"string" example:"250000.00"`
+}
+
+// CouponResponse untuk response data coupon
+type CouponResponse struct {
+	ID         uint        `json:"id"`
+	Code       string      `json:"code"`
+	Type       string      `json:"type"`
+	Value      float64     `json:"value"`
+	MinSpend   money.Money `json:"min_spend" swaggertype:"string" example:"100000.00"`
+	Currency   string      `json:"currency" example:"IDR"` // BASE_CURRENCY, berlaku untuk min_spend dan value FIXED
+	ExpiresAt  string      `json:"expires_at,omitempty"`
+	UsageLimit int         `json:"usage_limit"`
+	UsedCount  int         `json:"used_count"`
+	CreatedAt  string      `json:"created_at"`
+}
+
+// ValidateCouponResponse untuk response hasil cek coupon
+type ValidateCouponResponse struct {
+	Code           string      `json:"code"`
+	Currency       string      `json:"currency" example:"IDR"`
+	Subtotal       money.Money `json:"subtotal" swaggertype:"string" example:"250000.00"`
+	DiscountAmount money.Money `json:"discount_amount" swaggertype:"string" example:"25000.00"`
+	Total          money.Money `json:"total" swaggertype:"string" example:"225000.00"`
+}
+
+// CouponListResponse untuk response list coupon dengan pagination
+type CouponListResponse struct {
+	Coupons []CouponResponse `json:"coupons"`
+	pagination.Meta
+}
+
+// CouponQueryParams untuk pagination list coupon
+type CouponQueryParams struct {
+	Page  int `form:"page,default=1"`
+	Limit int `form:"limit"`
+}
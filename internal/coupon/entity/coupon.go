@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"gorm.io/gorm"
+)
+
+// Coupon type constants
+const (
+	CouponTypePercent = "PERCENT"
+	CouponTypeFixed   = "FIXED"
+)
+
+// Coupon entity untuk tabel coupons
+type Coupon struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	Code       string         `gorm:"size:50;uniqueIndex;not null" json:"code"`
+	Type       string         `gorm:"size:20;not null" json:"type"`
+	Value      float64        `gorm:"type:decimal(12,2);not null" json:"value"` // persen (PERCENT) atau nominal (FIXED)
+	MinSpend   money.Money    `gorm:"type:bigint;default:0" json:"min_spend"`
+	ExpiresAt  *time.Time     `json:"expires_at,omitempty"`
+	UsageLimit int            `gorm:"default:0" json:"usage_limit"` // 0 = tanpa batas
+	UsedCount  int            `gorm:"default:0" json:"used_count"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName menentukan nama tabel di database
+func (Coupon) TableName() string {
+	return "coupons"
+}
+
+// IsValidType mengecek apakah tipe coupon valid
+func IsValidType(couponType string) bool {
+	return couponType == CouponTypePercent || couponType == CouponTypeFixed
+}
+
+// IsExpired mengecek apakah coupon sudah kedaluwarsa pada waktu now
+func (c *Coupon) IsExpired(now time.Time) bool {
+	return c.ExpiresAt != nil && now.After(*c.ExpiresAt)
+}
+
+// IsUsageLimitReached mengecek apakah coupon sudah mencapai batas pemakaian
+func (c *Coupon) IsUsageLimitReached() bool {
+	return c.UsageLimit > 0 && c.UsedCount >= c.UsageLimit
+}
+
+// MeetsMinSpend mengecek apakah subtotal memenuhi minimum belanja
+func (c *Coupon) MeetsMinSpend(subtotal money.Money) bool {
+	return subtotal >= c.MinSpend
+}
+
+// CalculateDiscount menghitung potongan untuk subtotal, tidak melebihi subtotal
+func (c *Coupon) CalculateDiscount(subtotal money.Money) money.Money {
+	discount := money.Zero
+	switch c.Type {
+	case CouponTypePercent:
+		discount = subtotal.Percent(c.Value)
+	case CouponTypeFixed:
+		discount = money.FromFloat(c.Value)
+	}
+
+	if discount > subtotal {
+		discount = subtotal
+	}
+	return discount
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
+	"github.com/akbarwjyy/go-commerce-api/internal/coupon/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/coupon/service"
+	"github.com/gin-gonic/gin"
+)
+
+// CouponHandler menangani HTTP request untuk coupon
+type CouponHandler struct {
+	couponService service.CouponService
+}
+
+// NewCouponHandler membuat instance baru CouponHandler
+func NewCouponHandler(couponService service.CouponService) *CouponHandler {
+	return &CouponHandler{couponService: couponService}
+}
+
+// CreateCoupon godoc
+// @Summary      Create coupon
+// @Description  Create a new discount coupon (Admin only)
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.CreateCouponRequest true "Create coupon request"
+// @Success      201 {object} response.APIResponse{data=dto.CouponResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /admin/coupons [post]
+func (h *CouponHandler) CreateCoupon(ctx *gin.Context) {
+	var req dto.CreateCouponRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.couponService.CreateCoupon(&req)
+	if err != nil {
+		switch err {
+		case service.ErrCouponCodeExists:
+			response.Error(ctx, http.StatusConflict, "Coupon code already exists", nil)
+		case service.ErrInvalidCouponValue:
+			response.BadRequest(ctx, "Percent coupon value must not exceed 100", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to create coupon", err.Error())
+		}
+		return
+	}
+
+	response.Created(ctx, "Coupon created successfully", result)
+}
+
+// GetAllCoupons godoc
+// @Summary      Get all coupons
+// @Description  Get all coupons with pagination (Admin only)
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Success      200 {object} response.APIResponse{data=dto.CouponListResponse}
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Router       /admin/coupons [get]
+func (h *CouponHandler) GetAllCoupons(ctx *gin.Context) {
+	var params dto.CouponQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.couponService.GetAllCoupons(&params)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get coupons", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Coupons retrieved successfully", result)
+}
+
+// ValidateCoupon godoc
+// @Summary      Validate coupon
+// @Description  Check a coupon code against a subtotal without redeeming it
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.ValidateCouponRequest true "Validate coupon request"
+// @Success      200 {object} response.APIResponse{data=dto.ValidateCouponResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /coupons/validate [post]
+func (h *CouponHandler) ValidateCoupon(ctx *gin.Context) {
+	var req dto.ValidateCouponRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.couponService.Validate(req.Code, req.Subtotal)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "Coupon is valid", result)
+}
+
+// handleError memetakan error coupon ke HTTP response
+func handleError(ctx *gin.Context, err error) {
+	switch err {
+	case service.ErrCouponNotFound:
+		response.NotFound(ctx, "Coupon not found")
+	case service.ErrCouponExpired:
+		response.BadRequest(ctx, "Coupon has expired", nil)
+	case service.ErrCouponMinSpendNotMet:
+		response.BadRequest(ctx, "Order subtotal does not meet the coupon minimum spend", nil)
+	case service.ErrCouponUsageLimitReached:
+		response.BadRequest(ctx, "Coupon usage limit has been reached", nil)
+	default:
+		response.InternalServerError(ctx, "Failed to validate coupon", err.Error())
+	}
+}
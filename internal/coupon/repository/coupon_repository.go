@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/coupon/entity"
+	"gorm.io/gorm"
+)
+
+// CouponRepository interface untuk akses data coupon
+type CouponRepository interface {
+	Create(coupon *entity.Coupon) error
+	FindByCode(code string) (*entity.Coupon, error)
+	FindAll(page, limit int) ([]entity.Coupon, int64, error)
+	ExistsByCode(code string) (bool, error)
+	IncrementUsage(id uint) (bool, error)
+	WithTx(tx *gorm.DB) CouponRepository
+}
+
+// couponRepository implementasi CouponRepository
+type couponRepository struct {
+	db *gorm.DB
+}
+
+// NewCouponRepository membuat instance baru CouponRepository
+func NewCouponRepository(db *gorm.DB) CouponRepository {
+	return &couponRepository{db: db}
+}
+
+// WithTx mengembalikan repository dengan transaction
+func (r *couponRepository) WithTx(tx *gorm.DB) CouponRepository {
+	return &couponRepository{db: tx}
+}
+
+// Create menyimpan coupon baru
+func (r *couponRepository) Create(coupon *entity.Coupon) error {
+	return r.db.Create(coupon).Error
+}
+
+// FindByCode mencari coupon berdasarkan kode
+func (r *couponRepository) FindByCode(code string) (*entity.Coupon, error) {
+	var coupon entity.Coupon
+	if err := r.db.Where("code = ?", code).First(&coupon).Error; err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// FindAll mengambil semua coupon dengan pagination
+func (r *couponRepository) FindAll(page, limit int) ([]entity.Coupon, int64, error) {
+	var coupons []entity.Coupon
+	var total int64
+
+	if err := r.db.Model(&entity.Coupon{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := r.db.Order("created_at DESC").Offset(offset).Limit(limit).Find(&coupons).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return coupons, total, nil
+}
+
+// ExistsByCode mengecek apakah kode coupon sudah dipakai
+func (r *couponRepository) ExistsByCode(code string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.Coupon{}).Where("code = ?", code).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// IncrementUsage menambah used_count secara atomik selama belum mencapai usage_limit.
+// Mengembalikan false jika batas pemakaian sudah tercapai.
+func (r *couponRepository) IncrementUsage(id uint) (bool, error) {
+	result := r.db.Model(&entity.Coupon{}).
+		Where("id = ? AND (usage_limit = 0 OR used_count < usage_limit)", id).
+		UpdateColumn("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
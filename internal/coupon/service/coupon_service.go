@@ -0,0 +1,189 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/coupon/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/coupon/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/coupon/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// Common errors
+var (
+	ErrCouponNotFound          = errors.New("coupon not found")
+	ErrCouponExpired           = errors.New("coupon has expired")
+	ErrCouponMinSpendNotMet    = errors.New("order subtotal does not meet the coupon minimum spend")
+	ErrCouponUsageLimitReached = errors.New("coupon usage limit has been reached")
+	ErrCouponCodeExists        = errors.New("coupon code already exists")
+	ErrInvalidCouponValue      = errors.New("percent coupon value must not exceed 100")
+)
+
+// CouponService interface untuk business logic coupon
+type CouponService interface {
+	CreateCoupon(req *dto.CreateCouponRequest) (*dto.CouponResponse, error)
+	GetAllCoupons(params *dto.CouponQueryParams) (*dto.CouponListResponse, error)
+
+	// Validate mengecek coupon tanpa memakainya
+	Validate(code string, subtotal money.Money) (*dto.ValidateCouponResponse, error)
+
+	// Redeem memakai coupon di dalam transaction checkout
+	Redeem(tx *gorm.DB, code string, subtotal money.Money) (*dto.ValidateCouponResponse, error)
+}
+
+// couponService implementasi CouponService
+type couponService struct {
+	couponRepo   repository.CouponRepository
+	baseCurrency string // currency nominal coupon (min_spend dan potongan FIXED)
+}
+
+// NewCouponService membuat instance baru CouponService
+func NewCouponService(couponRepo repository.CouponRepository, baseCurrency string) CouponService {
+	return &couponService{couponRepo: couponRepo, baseCurrency: baseCurrency}
+}
+
+// CreateCoupon membuat coupon baru
+func (s *couponService) CreateCoupon(req *dto.CreateCouponRequest) (*dto.CouponResponse, error) {
+	code := normalizeCode(req.Code)
+
+	if req.Type == entity.CouponTypePercent && req.Value > 100 {
+		return nil, ErrInvalidCouponValue
+	}
+
+	exists, err := s.couponRepo.ExistsByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrCouponCodeExists
+	}
+
+	coupon := &entity.Coupon{
+		Code:       code,
+		Type:       req.Type,
+		Value:      req.Value,
+		MinSpend:   req.MinSpend,
+		ExpiresAt:  req.ExpiresAt,
+		UsageLimit: req.UsageLimit,
+	}
+
+	if err := s.couponRepo.Create(coupon); err != nil {
+		return nil, err
+	}
+
+	return s.toCouponResponse(coupon), nil
+}
+
+// GetAllCoupons mengambil semua coupon dengan pagination
+func (s *couponService) GetAllCoupons(params *dto.CouponQueryParams) (*dto.CouponListResponse, error) {
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+
+	coupons, total, err := s.couponRepo.FindAll(params.Page, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var couponResponses []dto.CouponResponse
+	for _, c := range coupons {
+		couponResponses = append(couponResponses, *s.toCouponResponse(&c))
+	}
+
+	return &dto.CouponListResponse{
+		Coupons: couponResponses,
+		Meta:    pagination.BuildMeta(total, params.Page, params.Limit),
+	}, nil
+}
+
+// Validate mengecek apakah coupon bisa dipakai untuk subtotal tertentu
+func (s *couponService) Validate(code string, subtotal money.Money) (*dto.ValidateCouponResponse, error) {
+	coupon, err := s.findUsableCoupon(s.couponRepo, code, subtotal)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toValidateResponse(coupon, subtotal), nil
+}
+
+// Redeem memvalidasi coupon lalu menambah pemakaiannya dalam transaction yang sama dengan order
+func (s *couponService) Redeem(tx *gorm.DB, code string, subtotal money.Money) (*dto.ValidateCouponResponse, error) {
+	couponRepo := s.couponRepo.WithTx(tx)
+
+	coupon, err := s.findUsableCoupon(couponRepo, code, subtotal)
+	if err != nil {
+		return nil, err
+	}
+
+	// Increment bersyarat mencegah coupon dipakai melebihi batas saat checkout paralel
+	ok, err := couponRepo.IncrementUsage(coupon.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrCouponUsageLimitReached
+	}
+
+	return s.toValidateResponse(coupon, subtotal), nil
+}
+
+// findUsableCoupon mencari coupon dan memastikan masih berlaku untuk subtotal
+func (s *couponService) findUsableCoupon(couponRepo repository.CouponRepository, code string, subtotal money.Money) (*entity.Coupon, error) {
+	coupon, err := couponRepo.FindByCode(normalizeCode(code))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCouponNotFound
+		}
+		return nil, err
+	}
+
+	if coupon.IsExpired(time.Now()) {
+		return nil, ErrCouponExpired
+	}
+	if !coupon.MeetsMinSpend(subtotal) {
+		return nil, ErrCouponMinSpendNotMet
+	}
+	if coupon.IsUsageLimitReached() {
+		return nil, ErrCouponUsageLimitReached
+	}
+
+	return coupon, nil
+}
+
+// normalizeCode menyeragamkan kode coupon menjadi huruf besar
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// toValidateResponse membentuk response hasil perhitungan diskon
+func (s *couponService) toValidateResponse(coupon *entity.Coupon, subtotal money.Money) *dto.ValidateCouponResponse {
+	discount := coupon.CalculateDiscount(subtotal)
+	return &dto.ValidateCouponResponse{
+		Code:           coupon.Code,
+		Currency:       s.baseCurrency,
+		Subtotal:       subtotal,
+		DiscountAmount: discount,
+		Total:          subtotal.Sub(discount),
+	}
+}
+
+// Helper function untuk convert entity ke response
+func (s *couponService) toCouponResponse(c *entity.Coupon) *dto.CouponResponse {
+	resp := &dto.CouponResponse{
+		ID:         c.ID,
+		Code:       c.Code,
+		Type:       c.Type,
+		Value:      c.Value,
+		MinSpend:   c.MinSpend,
+		Currency:   s.baseCurrency,
+		UsageLimit: c.UsageLimit,
+		UsedCount:  c.UsedCount,
+		CreatedAt:  c.CreatedAt.Format(time.RFC3339),
+	}
+	if c.ExpiresAt != nil {
+		resp.ExpiresAt = c.ExpiresAt.Format(time.RFC3339)
+	}
+	return resp
+}
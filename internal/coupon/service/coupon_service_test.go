@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/coupon/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Coupon Entity Methods
+func TestCouponEntity_IsExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.False(t, (&entity.Coupon{}).IsExpired(now))
+	assert.True(t, (&entity.Coupon{ExpiresAt: &past}).IsExpired(now))
+	assert.False(t, (&entity.Coupon{ExpiresAt: &future}).IsExpired(now))
+}
+
+func TestCouponEntity_IsUsageLimitReached(t *testing.T) {
+	tests := []struct {
+		name       string
+		usageLimit int
+		usedCount  int
+		expected   bool
+	}{
+		{"unlimited", 0, 1000, false},
+		{"below limit", 5, 4, false},
+		{"at limit", 5, 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon := &entity.Coupon{UsageLimit: tt.usageLimit, UsedCount: tt.usedCount}
+			assert.Equal(t, tt.expected, coupon.IsUsageLimitReached())
+		})
+	}
+}
+
+func TestCouponEntity_CalculateDiscount(t *testing.T) {
+	tests := []struct {
+		name     string
+		coupon   entity.Coupon
+		subtotal string
+		expected string
+	}{
+		{"percent", entity.Coupon{Type: entity.CouponTypePercent, Value: 10}, "250000", "25000.00"},
+		{"percent rounded", entity.Coupon{Type: entity.CouponTypePercent, Value: 15}, "99.99", "15.00"},
+		{"fixed", entity.Coupon{Type: entity.CouponTypeFixed, Value: 20000}, "100000", "20000.00"},
+		{"fixed capped at subtotal", entity.Coupon{Type: entity.CouponTypeFixed, Value: 50000}, "30000", "30000.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subtotal, err := money.Parse(tt.subtotal)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, tt.coupon.CalculateDiscount(subtotal).String())
+		})
+	}
+}
+
+func TestCouponEntity_MeetsMinSpend(t *testing.T) {
+	coupon := &entity.Coupon{MinSpend: money.FromFloat(100000)}
+
+	assert.True(t, coupon.MeetsMinSpend(money.FromFloat(100000)))
+	assert.False(t, coupon.MeetsMinSpend(money.FromFloat(99999.99)))
+}
+
+func TestNormalizeCode(t *testing.T) {
+	assert.Equal(t, "HEMAT10", normalizeCode("  hemat10 "))
+}
+
+// Test Error Constants
+func TestCouponErrors(t *testing.T) {
+	assert.NotNil(t, ErrCouponNotFound)
+	assert.NotNil(t, ErrCouponExpired)
+	assert.NotNil(t, ErrCouponMinSpendNotMet)
+	assert.NotNil(t, ErrCouponUsageLimitReached)
+	assert.NotEqual(t, ErrCouponExpired, ErrCouponMinSpendNotMet)
+}
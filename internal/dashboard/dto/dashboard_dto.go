@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/akbarwjyy/go-commerce-api/pkg/money"
+
+// DailyOrderCount untuk satu titik pada grafik jumlah order harian
+type DailyOrderCount struct {
+	Date  string `json:"date" example:"2024-01-31"`
+	Count int64  `json:"count"`
+}
+
+// AdminDashboardResponse untuk response statistik platform di dashboard admin
+type AdminDashboardResponse struct {
+	TotalUsers              int64             `json:"total_users"`
+	UsersByRole             map[string]int64  `json:"users_by_role"`
+	TotalProducts           int64             `json:"total_products"`
+	TotalOrders             int64             `json:"total_orders"`
+	OrdersByStatus          map[string]int64  `json:"orders_by_status"`
+	SuccessfulPaymentVolume money.Money       `json:"successful_payment_volume" swaggertype:"string" example:"125000.00"`
+	Currency                string            `json:"currency" example:"IDR"` // BASE_CURRENCY
+	DailyOrders             []DailyOrderCount `json:"daily_orders"`
+}
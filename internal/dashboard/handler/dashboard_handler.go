@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
+	"github.com/akbarwjyy/go-commerce-api/internal/dashboard/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/dashboard/service"
+	"github.com/gin-gonic/gin"
+)
+
+// DashboardHandler menangani HTTP request untuk dashboard admin
+type DashboardHandler struct {
+	dashboardService service.DashboardService
+}
+
+// NewDashboardHandler membuat instance baru DashboardHandler
+func NewDashboardHandler(dashboardService service.DashboardService) *DashboardHandler {
+	return &DashboardHandler{dashboardService: dashboardService}
+}
+
+// GetAdminDashboard godoc
+// @Summary      Get admin dashboard
+// @Description  Platform-wide stats: users by role, total products, orders by status, successful payment volume, and daily order counts for the last 30 days (Admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.APIResponse{data=dto.AdminDashboardResponse}
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Router       /admin/dashboard [get]
+func (h *DashboardHandler) GetAdminDashboard(ctx *gin.Context) {
+	var result *dto.AdminDashboardResponse
+	result, err := h.dashboardService.GetAdminDashboard()
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get admin dashboard", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Admin dashboard retrieved successfully", result)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"time"
+
+	authService "github.com/akbarwjyy/go-commerce-api/internal/auth/service"
+	"github.com/akbarwjyy/go-commerce-api/internal/dashboard/dto"
+	orderService "github.com/akbarwjyy/go-commerce-api/internal/order/service"
+	paymentService "github.com/akbarwjyy/go-commerce-api/internal/payment/service"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+)
+
+// dailyOrderDays adalah panjang deret order harian di dashboard admin (termasuk hari ini)
+const dailyOrderDays = 30
+
+// DashboardService interface untuk statistik lintas modul
+type DashboardService interface {
+	GetAdminDashboard() (*dto.AdminDashboardResponse, error)
+}
+
+// dashboardService implementasi DashboardService.
+// Tidak punya repository sendiri; semua data diambil lewat service modul lain.
+type dashboardService struct {
+	authService    authService.AuthService
+	productService productService.ProductService
+	orderService   orderService.OrderService
+	paymentService paymentService.PaymentService
+	baseCurrency   string // label nominal agregat
+	now            func() time.Time
+}
+
+// NewDashboardService membuat instance baru DashboardService
+func NewDashboardService(
+	authSvc authService.AuthService,
+	productSvc productService.ProductService,
+	orderSvc orderService.OrderService,
+	paymentSvc paymentService.PaymentService,
+	baseCurrency string,
+) DashboardService {
+	return &dashboardService{
+		authService:    authSvc,
+		productService: productSvc,
+		orderService:   orderSvc,
+		paymentService: paymentSvc,
+		baseCurrency:   baseCurrency,
+		now:            time.Now,
+	}
+}
+
+// GetAdminDashboard mengambil statistik platform: user per role, jumlah produk,
+// order per status, volume payment sukses, dan jumlah order harian 30 hari terakhir
+func (s *dashboardService) GetAdminDashboard() (*dto.AdminDashboardResponse, error) {
+	usersByRole, err := s.authService.CountUsersByRole()
+	if err != nil {
+		return nil, err
+	}
+
+	totalProducts, err := s.productService.CountProducts()
+	if err != nil {
+		return nil, err
+	}
+
+	ordersByStatus, err := s.orderService.CountOrdersByStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	paymentVolume, err := s.paymentService.GetSuccessfulPaymentVolume()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	startDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).
+		AddDate(0, 0, -(dailyOrderDays - 1))
+	dailyCounts, err := s.orderService.GetDailyOrderCounts(startDay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AdminDashboardResponse{
+		TotalUsers:              sumCounts(usersByRole),
+		UsersByRole:             usersByRole,
+		TotalProducts:           totalProducts,
+		TotalOrders:             sumCounts(ordersByStatus),
+		OrdersByStatus:          ordersByStatus,
+		SuccessfulPaymentVolume: paymentVolume,
+		Currency:                s.baseCurrency,
+		DailyOrders:             fillDailySeries(startDay, dailyOrderDays, dailyCounts),
+	}, nil
+}
+
+// fillDailySeries membentuk deret harian berurutan mulai startDay; hari tanpa data bernilai 0
+// agar grafik di frontend tidak terputus
+func fillDailySeries(startDay time.Time, days int, counts map[string]int64) []dto.DailyOrderCount {
+	series := make([]dto.DailyOrderCount, 0, days)
+	for i := 0; i < days; i++ {
+		date := startDay.AddDate(0, 0, i).Format(time.DateOnly)
+		series = append(series, dto.DailyOrderCount{Date: date, Count: counts[date]})
+	}
+	return series
+}
+
+// sumCounts menjumlahkan seluruh nilai pada map hitungan
+func sumCounts(counts map[string]int64) int64 {
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+	return total
+}
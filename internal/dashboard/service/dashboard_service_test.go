@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillDailySeries_FillsGapsWithZero(t *testing.T) {
+	start := time.Date(2024, 2, 27, 0, 0, 0, 0, time.UTC)
+	counts := map[string]int64{
+		"2024-02-27": 3,
+		"2024-03-01": 5,
+		"2024-01-01": 9, // di luar rentang, diabaikan
+	}
+
+	series := fillDailySeries(start, 4, counts)
+
+	assert.Len(t, series, 4)
+	assert.Equal(t, "2024-02-27", series[0].Date)
+	assert.Equal(t, int64(3), series[0].Count)
+	assert.Equal(t, "2024-02-28", series[1].Date)
+	assert.Equal(t, int64(0), series[1].Count)
+	assert.Equal(t, "2024-02-29", series[2].Date)
+	assert.Equal(t, int64(0), series[2].Count)
+	assert.Equal(t, "2024-03-01", series[3].Date)
+	assert.Equal(t, int64(5), series[3].Count)
+}
+
+func TestSumCounts(t *testing.T) {
+	assert.Equal(t, int64(0), sumCounts(nil))
+	assert.Equal(t, int64(6), sumCounts(map[string]int64{"user": 4, "seller": 1, "admin": 1}))
+}
@@ -1,8 +1,16 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+)
+
 // OrderItemRequest untuk request item dalam checkout
 type OrderItemRequest struct {
 	ProductID uint `json:"product_id" binding:"required"`
+	VariantID uint `json:"variant_id,omitempty"` // wajib untuk produk yang memiliki varian
 	Quantity  int  `json:"quantity" binding:"required,gt=0"`
 }
 
@@ -11,48 +19,324 @@ type CheckoutRequest struct {
 	Items           []OrderItemRequest `json:"items" binding:"required,min=1,dive"`
 	ShippingAddress string             `json:"shipping_address" binding:"required"`
 	Notes           string             `json:"notes,omitempty"`
+	CouponCode      string             `json:"coupon_code,omitempty"`
+
+	// Diisi handler dari header Idempotency-Key, bukan dari body
+	IdempotencyKey string `json:"-"`
+}
+
+// GuestCheckoutRequest untuk request checkout tanpa akun
+type GuestCheckoutRequest struct {
+	Email           string             `json:"email" binding:"required,email,max=255"`
+	Items           []OrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	ShippingAddress string             `json:"shipping_address" binding:"required"`
+	Notes           string             `json:"notes,omitempty"`
+	CouponCode      string             `json:"coupon_code,omitempty"`
 }
 
-// UpdateOrderStatusRequest untuk request update status
+// GuestCheckoutResponse untuk response guest checkout.
+// LookupToken hanya dikembalikan sekali dan dibutuhkan untuk melihat order kembali.
+type GuestCheckoutResponse struct {
+	Order       OrderResponse `json:"order"`
+	LookupToken string        `json:"lookup_token"`
+}
+
+// GuestCheckoutResponseV2 untuk response checkout guest dengan Accept-Version: v2
+type GuestCheckoutResponseV2 struct {
+	Order       OrderResponseV2 `json:"order"`
+	LookupToken string          `json:"lookup_token"`
+}
+
+// CartCheckoutRequest untuk request checkout dari cart yang tersimpan
+type CartCheckoutRequest struct {
+	ShippingAddress string `json:"shipping_address" binding:"required"`
+	Notes           string `json:"notes,omitempty"`
+	CouponCode      string `json:"coupon_code,omitempty"`
+}
+
+// UpdateOrderStatusRequest untuk request update status.
+// TrackingNumber wajib saat status SHIPPED; field pengiriman diabaikan untuk status lain.
 type UpdateOrderStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=PAID SHIPPED COMPLETED CANCELLED"`
+	Status            string `json:"status" binding:"required,oneof=PAID SHIPPED COMPLETED CANCELLED"`
+	TrackingNumber    string `json:"tracking_number" binding:"max=100" example:"JNE1234567890"`
+	Carrier           string `json:"carrier" binding:"max=50" example:"JNE"`
+	EstimatedDelivery string `json:"estimated_delivery" binding:"omitempty,datetime=2006-01-02" example:"2026-01-31"`
+}
+
+// ReturnItemRequest untuk request pengembalian sebagian item order
+type ReturnItemRequest struct {
+	Quantity int    `json:"quantity" binding:"required,gt=0"`
+	Reason   string `json:"reason,omitempty" binding:"max=500"`
 }
 
 // OrderItemResponse untuk response item dalam order
 type OrderItemResponse struct {
-	ID          uint    `json:"id"`
-	ProductID   uint    `json:"product_id"`
-	ProductName string  `json:"product_name,omitempty"`
-	Quantity    int     `json:"quantity"`
-	Price       float64 `json:"price"`
-	Subtotal    float64 `json:"subtotal"`
+	ID               uint        `json:"id"`
+	ProductID        uint        `json:"product_id"`
+	VariantID        *uint       `json:"variant_id,omitempty"`
+	ProductName      string      `json:"product_name,omitempty"`      // nama saat checkout
+	ProductSKU       string      `json:"product_sku,omitempty"`       // SKU produk/varian saat checkout
+	ProductImageURL  string      `json:"product_image_url,omitempty"` // gambar produk saat ini
+	Quantity         int         `json:"quantity"`
+	Price            money.Money `json:"price" swaggertype:"string" example:"199.99"`
+	Subtotal         money.Money `json:"subtotal" swaggertype:"string" example:"399.98"`
+	ReturnedQuantity int         `json:"returned_quantity"`
 }
 
 // OrderResponse untuk response data order
 type OrderResponse struct {
-	ID              uint                `json:"id"`
-	UserID          uint                `json:"user_id"`
-	TotalAmount     float64             `json:"total_amount"`
-	Status          string              `json:"status"`
-	ShippingAddress string              `json:"shipping_address"`
-	Notes           string              `json:"notes,omitempty"`
-	Items           []OrderItemResponse `json:"items,omitempty"`
-	CreatedAt       string              `json:"created_at"`
-	UpdatedAt       string              `json:"updated_at"`
+	ID                uint                `json:"id"`
+	UserID            uint                `json:"user_id"`
+	GuestEmail        string              `json:"guest_email,omitempty"`
+	Currency          string              `json:"currency" example:"IDR"` // berlaku untuk seluruh nominal order dan item
+	Subtotal          money.Money         `json:"subtotal" swaggertype:"string" example:"399.98"`
+	CouponCode        string              `json:"coupon_code,omitempty"`
+	DiscountAmount    money.Money         `json:"discount_amount" swaggertype:"string" example:"0.00"`
+	ShippingFee       money.Money         `json:"shipping_fee" swaggertype:"string" example:"10.00"`
+	Tax               money.Money         `json:"tax" swaggertype:"string" example:"0.00"`
+	Total             money.Money         `json:"total" swaggertype:"string" example:"409.98"`
+	TotalAmount       money.Money         `json:"total_amount" swaggertype:"string" example:"409.98"` // alias lama dari total
+	ReturnedAmount    money.Money         `json:"returned_amount" swaggertype:"string" example:"0.00"`
+	OutstandingTotal  money.Money         `json:"outstanding_total" swaggertype:"string" example:"409.98"` // total - returned_amount
+	Status            string              `json:"status"`
+	ShippingAddress   string              `json:"shipping_address"`
+	Notes             string              `json:"notes,omitempty"`
+	TrackingNumber    string              `json:"tracking_number,omitempty"`
+	Carrier           string              `json:"carrier,omitempty"`
+	EstimatedDelivery string              `json:"estimated_delivery,omitempty" example:"2026-01-31"` // YYYY-MM-DD
+	Items             []OrderItemResponse `json:"items,omitempty"`
+	CreatedAt         string              `json:"created_at"`
+	UpdatedAt         string              `json:"updated_at"`
+}
+
+// OrderPricing untuk rincian biaya order di response API v2
+type OrderPricing struct {
+	Subtotal         money.Money `json:"subtotal" swaggertype:"string" example:"399.98"`
+	DiscountAmount   money.Money `json:"discount_amount" swaggertype:"string" example:"0.00"`
+	ShippingFee      money.Money `json:"shipping_fee" swaggertype:"string" example:"10.00"`
+	Tax              money.Money `json:"tax" swaggertype:"string" example:"0.00"`
+	Total            money.Money `json:"total" swaggertype:"string" example:"409.98"`
+	ReturnedAmount   money.Money `json:"returned_amount" swaggertype:"string" example:"0.00"`
+	OutstandingTotal money.Money `json:"outstanding_total" swaggertype:"string" example:"409.98"` // total - returned_amount
+}
+
+// OrderResponseV2 untuk response data order dengan Accept-Version: v2.
+// Nominal order dikelompokkan di pricing dan alias total_amount tidak ada lagi.
+type OrderResponseV2 struct {
+	ID                uint                `json:"id"`
+	UserID            uint                `json:"user_id"`
+	GuestEmail        string              `json:"guest_email,omitempty"`
+	Currency          string              `json:"currency" example:"IDR"`
+	CouponCode        string              `json:"coupon_code,omitempty"`
+	Pricing           OrderPricing        `json:"pricing"`
+	Status            string              `json:"status"`
+	ShippingAddress   string              `json:"shipping_address"`
+	Notes             string              `json:"notes,omitempty"`
+	TrackingNumber    string              `json:"tracking_number,omitempty"`
+	Carrier           string              `json:"carrier,omitempty"`
+	EstimatedDelivery string              `json:"estimated_delivery,omitempty" example:"2026-01-31"`
+	Items             []OrderItemResponse `json:"items,omitempty"`
+	CreatedAt         string              `json:"created_at"`
+	UpdatedAt         string              `json:"updated_at"`
+}
+
+// QuoteItemResponse untuk rincian satu item di quote checkout
+type QuoteItemResponse struct {
+	ProductID   uint        `json:"product_id"`
+	VariantID   *uint       `json:"variant_id,omitempty"`
+	ProductName string      `json:"product_name"`
+	Quantity    int         `json:"quantity"`
+	Price       money.Money `json:"price" swaggertype:"string" example:"199.99"`
+	Subtotal    money.Money `json:"subtotal" swaggertype:"string" example:"399.98"`
+}
+
+// QuoteResponse untuk response rincian biaya checkout tanpa membuat order
+type QuoteResponse struct {
+	Currency       string              `json:"currency" example:"IDR"`
+	Items          []QuoteItemResponse `json:"items"`
+	Subtotal       money.Money         `json:"subtotal" swaggertype:"string" example:"399.98"`
+	CouponCode     string              `json:"coupon_code,omitempty"`
+	DiscountAmount money.Money         `json:"discount_amount" swaggertype:"string" example:"0.00"`
+	ShippingFee    money.Money         `json:"shipping_fee" swaggertype:"string" example:"10.00"`
+	Tax            money.Money         `json:"tax" swaggertype:"string" example:"0.00"`
+	Total          money.Money         `json:"total" swaggertype:"string" example:"409.98"`
+}
+
+// OrderReturnResponse untuk response pengembalian item
+type OrderReturnResponse struct {
+	ID               uint        `json:"id"`
+	OrderID          uint        `json:"order_id"`
+	OrderItemID      uint        `json:"order_item_id"`
+	Quantity         int         `json:"quantity"`
+	Amount           money.Money `json:"amount" swaggertype:"string" example:"199.99"`
+	Currency         string      `json:"currency" example:"IDR"`
+	Reason           string      `json:"reason,omitempty"`
+	OutstandingTotal money.Money `json:"outstanding_total" swaggertype:"string" example:"209.99"`
+	CreatedAt        string      `json:"created_at"`
+}
+
+// OrderStatusHistoryResponse untuk response satu entri timeline status order
+type OrderStatusHistoryResponse struct {
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	ChangedBy  *uint  `json:"changed_by,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// CreateOrderNoteRequest untuk request menambah catatan order
+type CreateOrderNoteRequest struct {
+	Body     string `json:"body" binding:"required,max=2000" example:"Shipped via JNE, resi JNE123456"`
+	Internal bool   `json:"internal"` // hanya seller/admin; tidak terlihat oleh pembeli
+}
+
+// OrderNoteResponse untuk response satu catatan order
+type OrderNoteResponse struct {
+	ID        uint   `json:"id"`
+	OrderID   uint   `json:"order_id"`
+	AuthorID  uint   `json:"author_id"`
+	Body      string `json:"body"`
+	Internal  bool   `json:"internal"`
+	CreatedAt string `json:"created_at"`
 }
 
 // OrderListResponse untuk response list order dengan pagination
 type OrderListResponse struct {
-	Orders     []OrderResponse `json:"orders"`
-	Total      int64           `json:"total"`
-	Page       int             `json:"page"`
-	Limit      int             `json:"limit"`
-	TotalPages int             `json:"total_pages"`
+	Orders []OrderResponse `json:"orders"`
+	pagination.Meta
+}
+
+// OrderListResponseV2 untuk response list order dengan Accept-Version: v2
+type OrderListResponseV2 struct {
+	Orders []OrderResponseV2 `json:"orders"`
+	pagination.Meta
 }
 
 // OrderQueryParams untuk filter dan pagination
 type OrderQueryParams struct {
 	Page   int    `form:"page,default=1"`
-	Limit  int    `form:"limit,default=10"`
+	Limit  int    `form:"limit"`
 	Status string `form:"status"`
+	Sort   string `form:"sort"` // nilai di luar OrderSort* diabaikan (default terbaru dulu)
+	From   string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To     string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+
+	// Batas created_at hasil parsing From/To, diisi oleh service sebelum query ke repository
+	CreatedFrom   *time.Time `form:"-"`
+	CreatedBefore *time.Time `form:"-"`
+}
+
+// Sort options untuk list order
+const (
+	OrderSortCreatedAtAsc  = "created_at_asc"
+	OrderSortCreatedAtDesc = "created_at_desc"
+	OrderSortAmountAsc     = "amount_asc"
+	OrderSortAmountDesc    = "amount_desc"
+)
+
+// OrderExportRow satu baris export CSV order
+type OrderExportRow struct {
+	ID          uint
+	UserID      uint
+	Status      string
+	TotalAmount money.Money
+	CreatedAt   time.Time
+	ItemCount   int64
+}
+
+// OrderStatusSummary untuk agregat order user per status (jumlah order dan total nilainya)
+type OrderStatusSummary struct {
+	Status string
+	Count  int64
+	Total  money.Money
+}
+
+// MyOrderStatsResponse untuk response ringkasan order milik user yang sedang login
+type MyOrderStatsResponse struct {
+	TotalOrders   int64            `json:"total_orders"`
+	TotalSpent    money.Money      `json:"total_spent" swaggertype:"string" example:"1249.50"` // total order COMPLETED
+	Currency      string           `json:"currency" example:"IDR"`                             // BASE_CURRENCY
+	CountByStatus map[string]int64 `json:"count_by_status"`
+	LastOrderAt   string           `json:"last_order_at,omitempty" example:"2024-01-31T10:00:00Z"`
+}
+
+// SellerDashboardQueryParams untuk filter rentang tanggal dashboard seller (format YYYY-MM-DD, inklusif)
+type SellerDashboardQueryParams struct {
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// SellerSalesReportQueryParams untuk laporan penjualan seller per periode (tanggal YYYY-MM-DD, inklusif)
+type SellerSalesReportQueryParams struct {
+	GroupBy string `form:"group_by"` // day (default), week, atau month
+	From    string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To      string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// SalesPeriodRow untuk agregat penjualan seller dalam satu periode (awal periode dalam UTC)
+type SalesPeriodRow struct {
+	Period     time.Time
+	Revenue    money.Money
+	OrderCount int64
+}
+
+// SalesReportBucket untuk satu periode pada laporan penjualan seller
+type SalesReportBucket struct {
+	Period     string      `json:"period" example:"2024-01-01"` // tanggal awal periode
+	Revenue    money.Money `json:"revenue" swaggertype:"string" example:"1999.90"`
+	OrderCount int64       `json:"order_count"`
+}
+
+// SellerSalesReportResponse untuk response laporan penjualan seller per periode
+type SellerSalesReportResponse struct {
+	GroupBy      string              `json:"group_by" example:"day"`
+	From         string              `json:"from" example:"2024-01-01"`
+	To           string              `json:"to" example:"2024-01-31"`
+	TotalRevenue money.Money         `json:"total_revenue" swaggertype:"string" example:"15999.20"`
+	Currency     string              `json:"currency" example:"IDR"` // BASE_CURRENCY, berlaku untuk seluruh bucket
+	TotalOrders  int64               `json:"total_orders"`
+	Buckets      []SalesReportBucket `json:"buckets"`
+}
+
+// ProductSalesStatsQueryParams untuk filter rentang tanggal statistik penjualan produk (format YYYY-MM-DD, inklusif)
+type ProductSalesStatsQueryParams struct {
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// ProductSalesRow untuk agregat penjualan satu produk
+type ProductSalesRow struct {
+	UnitsSold  int64
+	Revenue    money.Money
+	OrderCount int64
+}
+
+// ProductSalesStatsResponse untuk response statistik penjualan satu produk
+type ProductSalesStatsResponse struct {
+	ProductID  uint        `json:"product_id"`
+	From       string      `json:"from,omitempty" example:"2024-01-01"`
+	To         string      `json:"to,omitempty" example:"2024-01-31"`
+	UnitsSold  int64       `json:"units_sold"`
+	Revenue    money.Money `json:"revenue" swaggertype:"string" example:"1999.90"`
+	Currency   string      `json:"currency" example:"IDR"` // currency produk
+	OrderCount int64       `json:"order_count"`            // jumlah order berbeda yang berisi produk ini
+}
+
+// TopProductResponse untuk produk terlaris di dashboard seller
+type TopProductResponse struct {
+	ProductID    uint        `json:"product_id"`
+	ProductName  string      `json:"product_name"`
+	QuantitySold int64       `json:"quantity_sold"`
+	Revenue      money.Money `json:"revenue" swaggertype:"string" example:"1999.90"`
+}
+
+// SellerDashboardResponse untuk response dashboard penjualan seller
+type SellerDashboardResponse struct {
+	From           string               `json:"from,omitempty" example:"2024-01-01"`
+	To             string               `json:"to,omitempty" example:"2024-01-31"`
+	TotalRevenue   money.Money          `json:"total_revenue" swaggertype:"string" example:"15999.20"`
+	Currency       string               `json:"currency" example:"IDR"` // BASE_CURRENCY, berlaku untuk seluruh nominal dashboard
+	TotalOrders    int64                `json:"total_orders"`
+	TopProducts    []TopProductResponse `json:"top_products"`
+	InventoryValue money.Money          `json:"inventory_value" swaggertype:"string" example:"48250.00"`
 }
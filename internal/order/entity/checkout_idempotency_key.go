@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// CheckoutIdempotencyKey entity untuk tabel checkout_idempotency_keys.
+// Memetakan header Idempotency-Key milik satu user ke order yang dibuat oleh checkout pertama,
+// sehingga checkout yang terkirim ulang mengembalikan order yang sama.
+type CheckoutIdempotencyKey struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_checkout_idempotency_user_key" json:"user_id"`
+	Key       string    `gorm:"column:idempotency_key;size:255;not null;uniqueIndex:idx_checkout_idempotency_user_key" json:"key"`
+	OrderID   uint      `gorm:"not null" json:"order_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (CheckoutIdempotencyKey) TableName() string {
+	return "checkout_idempotency_keys"
+}
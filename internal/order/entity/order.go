@@ -3,6 +3,7 @@ package entity
 import (
 	"time"
 
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
 	"gorm.io/gorm"
 )
 
@@ -13,20 +14,34 @@ const (
 	OrderStatusShipped   = "SHIPPED"
 	OrderStatusCompleted = "COMPLETED"
 	OrderStatusCancelled = "CANCELLED"
+	OrderStatusRefunded  = "REFUNDED" // dibatalkan setelah pembayaran berhasil dan dana dikembalikan
 )
 
 // Order entity untuk tabel orders
 type Order struct {
-	ID            uint           `gorm:"primaryKey" json:"id"`
-	UserID        uint           `gorm:"index;not null" json:"user_id"`
-	TotalAmount   float64        `gorm:"type:decimal(12,2);not null" json:"total_amount"`
-	Status        string         `gorm:"size:20;default:PENDING" json:"status"`
-	ShippingAddr  string         `gorm:"type:text" json:"shipping_address"`
-	Notes         string         `gorm:"type:text" json:"notes,omitempty"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
-	Items         []OrderItem    `gorm:"foreignKey:OrderID" json:"items,omitempty"`
+	ID             uint        `gorm:"primaryKey" json:"id"`
+	UserID         uint        `gorm:"index;not null" json:"user_id"` // 0 untuk order guest
+	GuestEmail     string      `gorm:"size:255" json:"guest_email,omitempty"`
+	GuestTokenHash *string     `gorm:"size:64;uniqueIndex" json:"-"` // SHA-256 dari lookup token guest, nil untuk order user
+	Currency       string      `gorm:"size:3" json:"currency"`       // ISO 4217, sama untuk seluruh item order
+	Subtotal       money.Money `gorm:"type:bigint;default:0" json:"subtotal"`
+	ShippingFee    money.Money `gorm:"type:bigint;default:0" json:"shipping_fee"`
+	TaxAmount      money.Money `gorm:"type:bigint;default:0" json:"tax_amount"`
+	TotalAmount    money.Money `gorm:"type:bigint;not null" json:"total_amount"`
+	CouponCode     string      `gorm:"size:50" json:"coupon_code,omitempty"`
+	DiscountAmount money.Money `gorm:"type:bigint;default:0" json:"discount_amount"`
+	ReturnedAmount money.Money `gorm:"type:bigint;default:0" json:"returned_amount"`
+	Status         string      `gorm:"size:20;default:PENDING" json:"status"`
+	ShippingAddr   string      `gorm:"type:text" json:"shipping_address"`
+	Notes          string      `gorm:"type:text" json:"notes,omitempty"`
+	// Info pengiriman, diisi saat order diubah menjadi SHIPPED
+	TrackingNumber    string         `gorm:"size:100" json:"tracking_number,omitempty"`
+	Carrier           string         `gorm:"size:50" json:"carrier,omitempty"`
+	EstimatedDelivery *time.Time     `gorm:"type:date" json:"estimated_delivery,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+	Items             []OrderItem    `gorm:"foreignKey:OrderID" json:"items,omitempty"`
 }
 
 // TableName menentukan nama tabel di database
@@ -36,7 +51,12 @@ func (Order) TableName() string {
 
 // IsOwner mengecek apakah user adalah pemilik order
 func (o *Order) IsOwner(userID uint) bool {
-	return o.UserID == userID
+	return !o.IsGuest() && o.UserID == userID
+}
+
+// IsGuest mengecek apakah order dibuat lewat guest checkout (tanpa akun)
+func (o *Order) IsGuest() bool {
+	return o.UserID == 0
 }
 
 // IsPending mengecek apakah order masih pending
@@ -54,6 +74,27 @@ func (o *Order) CanBeCancelled() bool {
 	return o.Status == OrderStatusPending
 }
 
+// CanBeForceCancelled mengecek apakah order bisa dibatalkan paksa oleh admin
+// (order yang sudah dibayar tetapi belum dikirim juga boleh dibatalkan)
+func (o *Order) CanBeForceCancelled() bool {
+	return o.Status == OrderStatusPending || o.Status == OrderStatusPaid
+}
+
+// CanBeReturned mengecek apakah item order bisa dikembalikan (sudah dibayar dan belum selesai)
+func (o *Order) CanBeReturned() bool {
+	return o.Status == OrderStatusPaid || o.Status == OrderStatusShipped
+}
+
+// OutstandingTotal mengembalikan total order setelah dikurangi nilai item yang dikembalikan
+func (o *Order) OutstandingTotal() money.Money {
+	return o.TotalAmount.Sub(o.ReturnedAmount)
+}
+
+// CanBeRefunded mengecek apakah order yang sudah dibayar bisa di-refund
+func (o *Order) CanBeRefunded() bool {
+	return o.Status == OrderStatusPaid || o.Status == OrderStatusShipped || o.Status == OrderStatusCompleted
+}
+
 // CanBeShipped mengecek apakah order bisa dikirim
 func (o *Order) CanBeShipped() bool {
 	return o.Status == OrderStatusPaid
@@ -64,39 +105,71 @@ func (o *Order) CanBeCompleted() bool {
 	return o.Status == OrderStatusShipped
 }
 
-// UpdateStatus mengupdate status order
-func (o *Order) UpdateStatus(newStatus string) bool {
-	switch newStatus {
-	case OrderStatusPaid:
-		if o.IsPending() {
-			o.Status = OrderStatusPaid
-			return true
-		}
-	case OrderStatusShipped:
-		if o.CanBeShipped() {
-			o.Status = OrderStatusShipped
-			return true
-		}
-	case OrderStatusCompleted:
-		if o.CanBeCompleted() {
-			o.Status = OrderStatusCompleted
+// orderTransitions adalah transisi status alur normal yang berlaku untuk semua aktor:
+//
+//	PENDING   -> PAID, CANCELLED
+//	PAID      -> SHIPPED, REFUNDED
+//	SHIPPED   -> COMPLETED, REFUNDED
+//	COMPLETED -> REFUNDED
+//
+// CANCELLED dan REFUNDED adalah status akhir.
+var orderTransitions = map[string][]string{
+	OrderStatusPending:   {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:      {OrderStatusShipped, OrderStatusRefunded},
+	OrderStatusShipped:   {OrderStatusCompleted, OrderStatusRefunded},
+	OrderStatusCompleted: {OrderStatusRefunded},
+}
+
+// adminOrderTransitions adalah transisi tambahan yang hanya boleh dilakukan admin:
+//
+//	PAID -> COMPLETED  (order diserahkan langsung tanpa pengiriman)
+//
+// Order PAID tidak dibatalkan lewat tabel ini, tetapi lewat force-cancel yang juga me-refund payment-nya.
+var adminOrderTransitions = map[string][]string{
+	OrderStatusPaid: {OrderStatusCompleted},
+}
+
+// CanTransition mengecek apakah status from boleh berubah menjadi to.
+// Admin mendapat transisi tambahan dari adminOrderTransitions; transisi ke status
+// yang sama, mundur ke PENDING, atau keluar dari status akhir selalu ditolak.
+func CanTransition(from, to string, isAdmin bool) bool {
+	for _, allowed := range orderTransitions[from] {
+		if allowed == to {
 			return true
 		}
-	case OrderStatusCancelled:
-		if o.CanBeCancelled() {
-			o.Status = OrderStatusCancelled
-			return true
+	}
+	if isAdmin {
+		for _, allowed := range adminOrderTransitions[from] {
+			if allowed == to {
+				return true
+			}
 		}
 	}
 	return false
 }
 
-// CalculateTotal menghitung total dari semua items
-func (o *Order) CalculateTotal() float64 {
-	var total float64
+// TransitionTo mengubah status order jika transisi diizinkan untuk aktor tersebut
+func (o *Order) TransitionTo(newStatus string, isAdmin bool) bool {
+	if !CanTransition(o.Status, newStatus, isAdmin) {
+		return false
+	}
+	o.Status = newStatus
+	return true
+}
+
+// UpdateStatus mengupdate status order mengikuti alur normal (tanpa transisi khusus admin)
+func (o *Order) UpdateStatus(newStatus string) bool {
+	return o.TransitionTo(newStatus, false)
+}
+
+// CalculateTotal menghitung subtotal dari semua items, lalu
+// total = subtotal - diskon + ongkos kirim + pajak
+func (o *Order) CalculateTotal() money.Money {
+	subtotal := money.Zero
 	for _, item := range o.Items {
-		total += item.Subtotal
+		subtotal = subtotal.Add(item.Subtotal)
 	}
-	o.TotalAmount = total
-	return total
+	o.Subtotal = subtotal
+	o.TotalAmount = subtotal.Sub(o.DiscountAmount).Add(o.ShippingFee).Add(o.TaxAmount)
+	return o.TotalAmount
 }
@@ -3,20 +3,32 @@ package entity
 import (
 	"time"
 
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
 	"gorm.io/gorm"
 )
 
 // OrderItem entity untuk tabel order_items
 type OrderItem struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	OrderID   uint           `gorm:"index;not null" json:"order_id"`
-	ProductID uint           `gorm:"index;not null" json:"product_id"`
-	Quantity  int            `gorm:"not null" json:"quantity"`
-	Price     float64        `gorm:"type:decimal(12,2);not null" json:"price"`
-	Subtotal  float64        `gorm:"type:decimal(12,2);not null" json:"subtotal"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID        uint  `gorm:"primaryKey" json:"id"`
+	OrderID   uint  `gorm:"index;not null" json:"order_id"`
+	ProductID uint  `gorm:"index;not null" json:"product_id"`
+	VariantID *uint `gorm:"index" json:"variant_id,omitempty"`
+	// Nama produk dan SKU (SKU varian jika varian dipilih) saat checkout,
+	// tetap tampil walau produk diganti namanya atau dihapus
+	ProductName string      `gorm:"size:200" json:"product_name"`
+	ProductSKU  string      `gorm:"size:100" json:"product_sku"`
+	Quantity    int         `gorm:"not null" json:"quantity"`
+	Price       money.Money `gorm:"type:bigint;not null" json:"price"`
+	Subtotal    money.Money `gorm:"type:bigint;not null" json:"subtotal"`
+	// ReturnedQuantity jumlah unit yang sudah dikembalikan pembeli
+	ReturnedQuantity int            `gorm:"default:0" json:"returned_quantity"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Data produk saat ini (termasuk yang sudah di-soft delete), hanya dibaca lewat join saat memuat item
+	CurrentProductName     string `gorm:"->;-:migration" json:"-"`
+	CurrentProductImageURL string `gorm:"->;-:migration" json:"-"`
 }
 
 // TableName menentukan nama tabel di database
@@ -25,7 +37,7 @@ func (OrderItem) TableName() string {
 }
 
 // CalculateSubtotal menghitung subtotal item
-func (oi *OrderItem) CalculateSubtotal() float64 {
-	oi.Subtotal = oi.Price * float64(oi.Quantity)
+func (oi *OrderItem) CalculateSubtotal() money.Money {
+	oi.Subtotal = oi.Price.Mul(oi.Quantity)
 	return oi.Subtotal
 }
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// OrderNote entity untuk tabel order_notes (catatan bertanggal pada order, hanya bisa ditambah).
+// Catatan internal hanya terlihat oleh seller dan admin, bukan pembeli.
+type OrderNote struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	OrderID   uint      `gorm:"index;not null" json:"order_id"`
+	AuthorID  uint      `gorm:"not null" json:"author_id"`
+	Body      string    `gorm:"type:text;not null" json:"body"`
+	Internal  bool      `gorm:"not null;default:false" json:"internal"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (OrderNote) TableName() string {
+	return "order_notes"
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+)
+
+// OrderReturn entity untuk tabel order_returns (pengembalian sebagian item dari order)
+type OrderReturn struct {
+	ID          uint        `gorm:"primaryKey" json:"id"`
+	OrderID     uint        `gorm:"index;not null" json:"order_id"`
+	OrderItemID uint        `gorm:"index;not null" json:"order_item_id"`
+	UserID      uint        `gorm:"index;not null" json:"user_id"`
+	Quantity    int         `gorm:"not null" json:"quantity"`
+	Amount      money.Money `gorm:"type:bigint;not null" json:"amount"` // harga item x quantity yang dikembalikan
+	Reason      string      `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (OrderReturn) TableName() string {
+	return "order_returns"
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// OrderStatusHistory entity untuk tabel order_status_histories (timeline perubahan status order)
+type OrderStatusHistory struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	OrderID    uint      `gorm:"index;not null" json:"order_id"`
+	FromStatus string    `gorm:"size:20" json:"from_status"`
+	ToStatus   string    `gorm:"size:20;not null" json:"to_status"`
+	ChangedBy  *uint     `json:"changed_by,omitempty"`             // nil jika diubah oleh sistem (mis. Payment Module)
+	Reason     string    `gorm:"size:255" json:"reason,omitempty"` // alasan perubahan, mis. pembatalan paksa oleh admin
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (OrderStatusHistory) TableName() string {
+	return "order_status_histories"
+}
@@ -1,12 +1,18 @@
 package handler
 
 import (
+	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	authEntity "github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
 	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
+	couponService "github.com/akbarwjyy/go-commerce-api/internal/coupon/service"
 	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/order/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,6 +21,9 @@ type OrderHandler struct {
 	orderService service.OrderService
 }
 
+// maxIdempotencyKeyLength sama dengan panjang kolom checkout_idempotency_keys.idempotency_key
+const maxIdempotencyKeyLength = 255
+
 // NewOrderHandler membuat instance baru OrderHandler
 func NewOrderHandler(orderService service.OrderService) *OrderHandler {
 	return &OrderHandler{orderService: orderService}
@@ -22,41 +31,191 @@ func NewOrderHandler(orderService service.OrderService) *OrderHandler {
 
 // Checkout godoc
 // @Summary      Checkout order
-// @Description  Create a new order from cart items
+// @Description  Create a new order from cart items. Retrying with the same Idempotency-Key header (per user, valid for 24 hours) returns the order created by the first request instead of creating a new one
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        Idempotency-Key header string false "Client-generated key to make the checkout safe to retry (max 255 characters)"
 // @Param        request body dto.CheckoutRequest true "Checkout request"
+// @Param        Accept-Version header string false "Response version: v1 (default) or v2 (order amounts grouped under pricing)"
 // @Success      201 {object} response.APIResponse{data=dto.OrderResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
 // @Router       /orders/checkout [post]
 func (h *OrderHandler) Checkout(ctx *gin.Context) {
 	userID, _ := ctx.Get("userID")
 
 	var req dto.CheckoutRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+		response.BindError(ctx, err)
+		return
+	}
+
+	req.IdempotencyKey = strings.TrimSpace(ctx.GetHeader("Idempotency-Key"))
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		response.BadRequest(ctx, "Idempotency-Key must not exceed 255 characters", nil)
 		return
 	}
 
 	result, err := h.orderService.Checkout(userID.(uint), &req)
+	if err != nil {
+		h.handleCheckoutError(ctx, err)
+		return
+	}
+
+	response.Created(ctx, "Order created successfully", versionedOrder(ctx, result))
+}
+
+// CheckoutFromCart godoc
+// @Summary      Checkout from cart
+// @Description  Create a new order from the items stored in the current user's cart, then clear the cart
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.CartCheckoutRequest true "Cart checkout request"
+// @Param        Accept-Version header string false "Response version: v1 (default) or v2 (order amounts grouped under pricing)"
+// @Success      201 {object} response.APIResponse{data=dto.OrderResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /orders/checkout/cart [post]
+func (h *OrderHandler) CheckoutFromCart(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	var req dto.CartCheckoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.orderService.CheckoutFromCart(userID.(uint), &req)
+	if err != nil {
+		h.handleCheckoutError(ctx, err)
+		return
+	}
+
+	response.Created(ctx, "Order created successfully", versionedOrder(ctx, result))
+}
+
+// QuoteCheckout godoc
+// @Summary      Quote checkout
+// @Description  Calculate the subtotal, coupon discount, shipping fee, tax and total of a checkout without creating an order. Runs the same checks as checkout (products, variants, stock, coupon) but does not reserve stock or use up the coupon
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.CheckoutRequest true "Checkout request"
+// @Success      200 {object} response.APIResponse{data=dto.QuoteResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /orders/quote [post]
+func (h *OrderHandler) QuoteCheckout(ctx *gin.Context) {
+	var req dto.CheckoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.orderService.Quote(&req)
+	if err != nil {
+		h.handleCheckoutError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "Checkout quote calculated successfully", result)
+}
+
+// handleCheckoutError memetakan error checkout (termasuk coupon) ke HTTP response
+func (h *OrderHandler) handleCheckoutError(ctx *gin.Context, err error) {
+	switch err {
+	case service.ErrProductNotFound:
+		response.NotFound(ctx, "One or more products not found")
+	case service.ErrInsufficientStock:
+		response.BadRequest(ctx, "Insufficient stock for one or more products", nil)
+	case service.ErrVariantNotFound:
+		response.NotFound(ctx, "One or more product variants not found")
+	case service.ErrVariantRequired:
+		response.BadRequest(ctx, "A variant must be selected for one or more products", nil)
+	case service.ErrEmptyCart:
+		response.BadRequest(ctx, "Cart is empty", nil)
+	case service.ErrMixedCurrency:
+		response.BadRequest(ctx, "All products in an order must use the same currency", nil)
+	case service.ErrCouponCurrency:
+		response.BadRequest(ctx, "Coupons can only be used on orders in the base currency", nil)
+	case couponService.ErrCouponNotFound:
+		response.NotFound(ctx, "Coupon not found")
+	case couponService.ErrCouponExpired:
+		response.BadRequest(ctx, "Coupon has expired", nil)
+	case couponService.ErrCouponMinSpendNotMet:
+		response.BadRequest(ctx, "Order subtotal does not meet the coupon minimum spend", nil)
+	case couponService.ErrCouponUsageLimitReached:
+		response.BadRequest(ctx, "Coupon usage limit has been reached", nil)
+	default:
+		response.InternalServerError(ctx, "Failed to checkout", err.Error())
+	}
+}
+
+// GuestCheckout godoc
+// @Summary      Guest checkout
+// @Description  Create an order without an account. The response contains a lookup token that is needed to view the order later; it is only returned once.
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.GuestCheckoutRequest true "Guest checkout request"
+// @Param        Accept-Version header string false "Response version: v1 (default) or v2 (order amounts grouped under pricing)"
+// @Success      201 {object} response.APIResponse{data=dto.GuestCheckoutResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Failure      429 {object} response.APIResponse
+// @Router       /orders/guest-checkout [post]
+func (h *OrderHandler) GuestCheckout(ctx *gin.Context) {
+	var req dto.GuestCheckoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.orderService.GuestCheckout(&req)
+	if err != nil {
+		h.handleCheckoutError(ctx, err)
+		return
+	}
+
+	response.Created(ctx, "Order created successfully", versionedGuestCheckout(ctx, result))
+}
+
+// GetGuestOrder godoc
+// @Summary      Get guest order
+// @Description  Get an order created through guest checkout using its lookup token
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        token path string true "Lookup token"
+// @Param        Accept-Version header string false "Response version: v1 (default) or v2 (order amounts grouped under pricing)"
+// @Success      200 {object} response.APIResponse{data=dto.OrderResponse}
+// @Failure      404 {object} response.APIResponse
+// @Router       /orders/guest/{token} [get]
+func (h *OrderHandler) GetGuestOrder(ctx *gin.Context) {
+	result, err := h.orderService.GetGuestOrder(ctx.Param("token"))
 	if err != nil {
 		switch err {
-		case service.ErrProductNotFound:
-			response.NotFound(ctx, "One or more products not found")
-		case service.ErrInsufficientStock:
-			response.BadRequest(ctx, "Insufficient stock for one or more products", nil)
-		case service.ErrEmptyCart:
-			response.BadRequest(ctx, "Cart is empty", nil)
+		case service.ErrOrderNotFound:
+			response.NotFound(ctx, "Order not found")
 		default:
-			response.InternalServerError(ctx, "Failed to checkout", err.Error())
+			response.InternalServerError(ctx, "Failed to get order", err.Error())
 		}
 		return
 	}
 
-	response.Created(ctx, "Order created successfully", result)
+	response.OK(ctx, "Order retrieved successfully", versionedOrder(ctx, result))
 }
 
 // GetOrder godoc
@@ -67,6 +226,7 @@ func (h *OrderHandler) Checkout(ctx *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Order ID"
+// @Param        Accept-Version header string false "Response version: v1 (default) or v2 (order amounts grouped under pricing)"
 // @Success      200 {object} response.APIResponse{data=dto.OrderResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
@@ -94,7 +254,69 @@ func (h *OrderHandler) GetOrder(ctx *gin.Context) {
 		return
 	}
 
-	response.OK(ctx, "Order retrieved successfully", result)
+	response.OK(ctx, "Order retrieved successfully", versionedOrder(ctx, result))
+}
+
+// GetOrderHistory godoc
+// @Summary      Get order status history
+// @Description  Get the status timeline of an order (owner or admin)
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Order ID"
+// @Success      200 {object} response.APIResponse{data=[]dto.OrderStatusHistoryResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /orders/{id}/history [get]
+func (h *OrderHandler) GetOrderHistory(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+	userRole, _ := ctx.Get("userRole")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid order ID", nil)
+		return
+	}
+
+	isAdmin := userRole.(string) == authEntity.RoleAdmin
+	result, err := h.orderService.GetOrderHistory(userID.(uint), uint(id), isAdmin)
+	if err != nil {
+		switch err {
+		case service.ErrOrderNotFound:
+			response.NotFound(ctx, "Order not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to view this order")
+		default:
+			response.InternalServerError(ctx, "Failed to get order history", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Order history retrieved successfully", result)
+}
+
+// GetMyOrderStats godoc
+// @Summary      Get my order statistics
+// @Description  Summary of the current user's orders: total orders, total spent (COMPLETED orders only), order count per status, and the date of the most recent order
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.APIResponse{data=dto.MyOrderStatsResponse}
+// @Failure      401 {object} response.APIResponse
+// @Router       /orders/stats [get]
+func (h *OrderHandler) GetMyOrderStats(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	result, err := h.orderService.GetMyOrderStats(userID.(uint))
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get order statistics", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Order statistics retrieved successfully", result)
 }
 
 // GetMyOrders godoc
@@ -106,7 +328,11 @@ func (h *OrderHandler) GetOrder(ctx *gin.Context) {
 // @Security     BearerAuth
 // @Param        page query int false "Page number" default(1)
 // @Param        limit query int false "Items per page" default(10)
-// @Param        status query string false "Filter by status" Enums(PENDING, PAID, SHIPPED, COMPLETED, CANCELLED)
+// @Param        status query string false "Filter by status" Enums(PENDING, PAID, SHIPPED, COMPLETED, CANCELLED, REFUNDED)
+// @Param        sort query string false "Sort order (default created_at_desc)" Enums(created_at_asc, created_at_desc, amount_asc, amount_desc)
+// @Param        from query string false "Created from (YYYY-MM-DD, inclusive)"
+// @Param        to query string false "Created until (YYYY-MM-DD, inclusive)"
+// @Param        Accept-Version header string false "Response version: v1 (default) or v2 (order amounts grouped under pricing)"
 // @Success      200 {object} response.APIResponse{data=dto.OrderListResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      401 {object} response.APIResponse
@@ -122,11 +348,15 @@ func (h *OrderHandler) GetMyOrders(ctx *gin.Context) {
 
 	result, err := h.orderService.GetMyOrders(userID.(uint), &params)
 	if err != nil {
+		if err == service.ErrInvalidDateRange {
+			response.BadRequest(ctx, "Invalid date range", nil)
+			return
+		}
 		response.InternalServerError(ctx, "Failed to get orders", err.Error())
 		return
 	}
 
-	response.OK(ctx, "Orders retrieved successfully", result)
+	response.OK(ctx, "Orders retrieved successfully", versionedOrderList(ctx, result))
 }
 
 // GetAllOrders godoc
@@ -138,7 +368,11 @@ func (h *OrderHandler) GetMyOrders(ctx *gin.Context) {
 // @Security     BearerAuth
 // @Param        page query int false "Page number" default(1)
 // @Param        limit query int false "Items per page" default(10)
-// @Param        status query string false "Filter by status" Enums(PENDING, PAID, SHIPPED, COMPLETED, CANCELLED)
+// @Param        status query string false "Filter by status" Enums(PENDING, PAID, SHIPPED, COMPLETED, CANCELLED, REFUNDED)
+// @Param        sort query string false "Sort order (default created_at_desc)" Enums(created_at_asc, created_at_desc, amount_asc, amount_desc)
+// @Param        from query string false "Created from (YYYY-MM-DD, inclusive)"
+// @Param        to query string false "Created until (YYYY-MM-DD, inclusive)"
+// @Param        Accept-Version header string false "Response version: v1 (default) or v2 (order amounts grouped under pricing)"
 // @Success      200 {object} response.APIResponse{data=dto.OrderListResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
@@ -152,26 +386,100 @@ func (h *OrderHandler) GetAllOrders(ctx *gin.Context) {
 
 	result, err := h.orderService.GetAllOrders(&params)
 	if err != nil {
+		if err == service.ErrInvalidDateRange {
+			response.BadRequest(ctx, "Invalid date range", nil)
+			return
+		}
 		response.InternalServerError(ctx, "Failed to get orders", err.Error())
 		return
 	}
 
-	response.OK(ctx, "Orders retrieved successfully", result)
+	response.OK(ctx, "Orders retrieved successfully", versionedOrderList(ctx, result))
+}
+
+// ExportOrders godoc
+// @Summary      Export orders as CSV (Admin)
+// @Description  Download all orders matching the filters as CSV with columns order_id, user_id, status, total, created_at, item_count. Pagination is ignored
+// @Tags         Admin
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        status query string false "Filter by status" Enums(PENDING, PAID, SHIPPED, COMPLETED, CANCELLED, REFUNDED)
+// @Param        sort query string false "Sort order (default created_at_desc)" Enums(created_at_asc, created_at_desc, amount_asc, amount_desc)
+// @Param        from query string false "Created from (YYYY-MM-DD, inclusive)"
+// @Param        to query string false "Created until (YYYY-MM-DD, inclusive)"
+// @Param        access_token query string false "Access token, used only when the Authorization header is absent (e.g. browser download links)"
+// @Success      200 {file} file
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Router       /admin/orders/export [get]
+func (h *OrderHandler) ExportOrders(ctx *gin.Context) {
+	var params dto.OrderQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("orders-%s.csv", time.Now().Format("20060102-150405"))
+	out := &csvDownloadWriter{ctx: ctx, filename: filename}
+
+	if err := h.orderService.ExportOrders(&params, out); err != nil {
+		if !out.started {
+			if err == service.ErrInvalidDateRange {
+				response.BadRequest(ctx, "Invalid date range", nil)
+				return
+			}
+			response.InternalServerError(ctx, "Failed to export orders", err.Error())
+			return
+		}
+		// Header sudah terkirim sehingga status tidak bisa diubah lagi; file yang diterima client terpotong
+		logger.Error().Err(err).Str("request_id", ctx.GetString(logger.RequestIDKey)).Msg("Order export aborted mid-stream")
+		ctx.Abort()
+		return
+	}
+	if !out.started {
+		out.start()
+	}
+}
+
+// csvDownloadWriter menunda pengiriman header download sampai byte pertama ditulis,
+// sehingga error sebelum itu masih bisa dikirim sebagai response JSON biasa
+type csvDownloadWriter struct {
+	ctx      *gin.Context
+	filename string
+	started  bool
+}
+
+func (w *csvDownloadWriter) start() {
+	w.started = true
+	w.ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	w.ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+	w.ctx.Status(http.StatusOK)
+}
+
+func (w *csvDownloadWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.start()
+	}
+	n, err := w.ctx.Writer.Write(p)
+	w.ctx.Writer.Flush()
+	return n, err
 }
 
 // UpdateOrderStatus godoc
 // @Summary      Update order status
-// @Description  Update the status of an order
+// @Description  Update the status of an order. Allowed transitions: PENDING→PAID/CANCELLED, PAID→SHIPPED/REFUNDED, SHIPPED→COMPLETED/REFUNDED, COMPLETED→REFUNDED; admins may also move PAID→COMPLETED. Paid orders are cancelled with POST /admin/orders/{id}/cancel, which also refunds the payment. Moving to SHIPPED requires a tracking_number (carrier and estimated_delivery optional) and is also allowed for sellers of an item in the order. Non-admin order owners may only set CANCELLED; PAID is set by the payment flow or an admin
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Order ID"
 // @Param        request body dto.UpdateOrderStatusRequest true "Update status request"
+// @Param        Accept-Version header string false "Response version: v1 (default) or v2 (order amounts grouped under pricing)"
 // @Success      200 {object} response.APIResponse{data=dto.OrderResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
 // @Router       /orders/{id}/status [patch]
 func (h *OrderHandler) UpdateOrderStatus(ctx *gin.Context) {
 	userID, _ := ctx.Get("userID")
@@ -185,12 +493,12 @@ func (h *OrderHandler) UpdateOrderStatus(ctx *gin.Context) {
 
 	var req dto.UpdateOrderStatusRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+		response.BindError(ctx, err)
 		return
 	}
 
 	isAdmin := userRole.(string) == authEntity.RoleAdmin
-	result, err := h.orderService.UpdateOrderStatus(userID.(uint), uint(id), req.Status, isAdmin)
+	result, err := h.orderService.UpdateOrderStatus(userID.(uint), uint(id), &req, isAdmin)
 	if err != nil {
 		switch err {
 		case service.ErrOrderNotFound:
@@ -199,13 +507,15 @@ func (h *OrderHandler) UpdateOrderStatus(ctx *gin.Context) {
 			response.Forbidden(ctx, "You are not authorized to update this order")
 		case service.ErrInvalidStatus:
 			response.BadRequest(ctx, "Invalid status transition", nil)
+		case service.ErrTrackingNumberRequired, service.ErrInvalidEstimatedDelivery, service.ErrPaidOrderCancellation:
+			response.BadRequest(ctx, err.Error(), nil)
 		default:
 			response.InternalServerError(ctx, "Failed to update order status", err.Error())
 		}
 		return
 	}
 
-	response.OK(ctx, "Order status updated successfully", result)
+	response.OK(ctx, "Order status updated successfully", versionedOrder(ctx, result))
 }
 
 // CancelOrder godoc
@@ -246,3 +556,283 @@ func (h *OrderHandler) CancelOrder(ctx *gin.Context) {
 
 	response.OK(ctx, "Order cancelled successfully", nil)
 }
+
+// ReturnOrderItem godoc
+// @Summary      Return order item
+// @Description  Return part of an item from a PAID or SHIPPED order. Restores stock and reduces the order's outstanding total
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Order ID"
+// @Param        itemID path int true "Order item ID"
+// @Param        request body dto.ReturnItemRequest true "Return request"
+// @Success      201 {object} response.APIResponse{data=dto.OrderReturnResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /orders/{id}/items/{itemID}/return [post]
+func (h *OrderHandler) ReturnOrderItem(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid order ID", nil)
+		return
+	}
+
+	itemID, err := strconv.ParseUint(ctx.Param("itemID"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid order item ID", nil)
+		return
+	}
+
+	var req dto.ReturnItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.orderService.ReturnOrderItem(userID.(uint), uint(id), uint(itemID), &req)
+	if err != nil {
+		switch err {
+		case service.ErrOrderNotFound:
+			response.NotFound(ctx, "Order not found")
+		case service.ErrOrderItemNotFound:
+			response.NotFound(ctx, "Order item not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to return items from this order")
+		case service.ErrOrderNotReturnable, service.ErrReturnQuantity:
+			response.BadRequest(ctx, err.Error(), nil)
+		default:
+			response.InternalServerError(ctx, "Failed to return order item", err.Error())
+		}
+		return
+	}
+
+	response.Created(ctx, "Order item returned successfully", result)
+}
+
+// AddOrderNote godoc
+// @Summary      Add order note
+// @Description  Append a timestamped note to an order (buyer, seller of an item in the order, or admin). Only sellers and admins can add internal notes, which buyers cannot see
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Order ID"
+// @Param        request body dto.CreateOrderNoteRequest true "Note"
+// @Success      201 {object} response.APIResponse{data=dto.OrderNoteResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /orders/{id}/notes [post]
+func (h *OrderHandler) AddOrderNote(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+	userRole, _ := ctx.Get("userRole")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid order ID", nil)
+		return
+	}
+
+	var req dto.CreateOrderNoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	isAdmin := userRole.(string) == authEntity.RoleAdmin
+	result, err := h.orderService.AddOrderNote(userID.(uint), uint(id), isAdmin, &req)
+	if err != nil {
+		switch err {
+		case service.ErrOrderNotFound:
+			response.NotFound(ctx, "Order not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to add notes to this order")
+		case service.ErrInternalNoteForbidden:
+			response.Forbidden(ctx, err.Error())
+		default:
+			response.InternalServerError(ctx, "Failed to add order note", err.Error())
+		}
+		return
+	}
+
+	response.Created(ctx, "Order note added successfully", result)
+}
+
+// GetOrderNotes godoc
+// @Summary      Get order notes
+// @Description  Get the notes of an order, oldest first. Buyers only see non-internal notes; sellers of an item in the order and admins see all notes
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Order ID"
+// @Success      200 {object} response.APIResponse{data=[]dto.OrderNoteResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /orders/{id}/notes [get]
+func (h *OrderHandler) GetOrderNotes(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+	userRole, _ := ctx.Get("userRole")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid order ID", nil)
+		return
+	}
+
+	isAdmin := userRole.(string) == authEntity.RoleAdmin
+	result, err := h.orderService.GetOrderNotes(userID.(uint), uint(id), isAdmin)
+	if err != nil {
+		switch err {
+		case service.ErrOrderNotFound:
+			response.NotFound(ctx, "Order not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to view this order")
+		default:
+			response.InternalServerError(ctx, "Failed to get order notes", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Order notes retrieved successfully", result)
+}
+
+// GetSellerDashboard godoc
+// @Summary      Get seller sales dashboard
+// @Description  Revenue and order count from COMPLETED orders containing the current seller's products, top 5 best sellers, and current inventory value
+// @Tags         Seller
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from query string false "Start date (YYYY-MM-DD, inclusive)"
+// @Param        to query string false "End date (YYYY-MM-DD, inclusive)"
+// @Success      200 {object} response.APIResponse{data=dto.SellerDashboardResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /seller/dashboard [get]
+func (h *OrderHandler) GetSellerDashboard(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	var params dto.SellerDashboardQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.orderService.GetSellerDashboard(sellerID.(uint), &params)
+	if err != nil {
+		if err == service.ErrInvalidDateRange {
+			response.BadRequest(ctx, "Invalid date range", nil)
+			return
+		}
+		response.InternalServerError(ctx, "Failed to get seller dashboard", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Seller dashboard retrieved successfully", result)
+}
+
+// GetSellerSalesReport godoc
+// @Summary      Get seller sales report
+// @Description  Revenue and order count per day, week (starting Monday) or month (UTC) from paid orders (PAID, SHIPPED, COMPLETED) containing the current seller's products, net of returned items. Periods without sales are returned with zero values. Defaults to the last 30 days; the range may not exceed 366 days
+// @Tags         Seller
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_by query string false "Grouping" Enums(day, week, month) default(day)
+// @Param        from query string false "Start date (YYYY-MM-DD, inclusive)"
+// @Param        to query string false "End date (YYYY-MM-DD, inclusive)"
+// @Success      200 {object} response.APIResponse{data=dto.SellerSalesReportResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /seller/reports/sales [get]
+func (h *OrderHandler) GetSellerSalesReport(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	var params dto.SellerSalesReportQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.orderService.GetSellerSalesReport(sellerID.(uint), &params)
+	if err != nil {
+		switch err {
+		case service.ErrInvalidGroupBy:
+			response.BadRequest(ctx, "group_by must be one of day, week, month", nil)
+		case service.ErrInvalidDateRange:
+			response.BadRequest(ctx, "Invalid date range", nil)
+		case service.ErrDateRangeTooLarge:
+			response.BadRequest(ctx, err.Error(), nil)
+		default:
+			response.InternalServerError(ctx, "Failed to get sales report", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Sales report retrieved successfully", result)
+}
+
+// GetProductSalesStats godoc
+// @Summary      Get product sales stats
+// @Description  Units sold, revenue and number of distinct orders for one product, from paid orders (PAID, SHIPPED, COMPLETED). Cancelled, refunded and unpaid orders are not counted, and returned items are subtracted at their checkout price. A product without sales returns zeros (Owner/Admin only)
+// @Tags         Seller
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Param        from query string false "Start date (YYYY-MM-DD, inclusive)"
+// @Param        to query string false "End date (YYYY-MM-DD, inclusive)"
+// @Success      200 {object} response.APIResponse{data=dto.ProductSalesStatsResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /seller/products/{id}/stats [get]
+func (h *OrderHandler) GetProductSalesStats(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+	userRole, _ := ctx.Get("userRole")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	var params dto.ProductSalesStatsQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	isAdmin := userRole.(string) == authEntity.RoleAdmin
+
+	result, err := h.orderService.GetProductSalesStats(userID.(uint), uint(id), isAdmin, &params)
+	if err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to view this product's sales")
+		case service.ErrInvalidDateRange:
+			response.BadRequest(ctx, "Invalid date range", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to get product sales stats", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Product sales stats retrieved successfully", result)
+}
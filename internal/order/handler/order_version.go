@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/pkg/apiversion"
+	"github.com/gin-gonic/gin"
+)
+
+// versionedOrder mengembalikan order dalam bentuk sesuai Accept-Version request:
+// v1 dengan nominal flat (termasuk total_amount), v2 dengan rincian biaya di pricing
+func versionedOrder(ctx *gin.Context, order *dto.OrderResponse) interface{} {
+	if !apiversion.AtLeast(ctx.Request.Context(), apiversion.V2) {
+		return order
+	}
+	return toOrderResponseV2(order)
+}
+
+// versionedOrderList seperti versionedOrder untuk list order
+func versionedOrderList(ctx *gin.Context, list *dto.OrderListResponse) interface{} {
+	if !apiversion.AtLeast(ctx.Request.Context(), apiversion.V2) {
+		return list
+	}
+	orders := make([]dto.OrderResponseV2, 0, len(list.Orders))
+	for i := range list.Orders {
+		orders = append(orders, *toOrderResponseV2(&list.Orders[i]))
+	}
+	return &dto.OrderListResponseV2{Orders: orders, Meta: list.Meta}
+}
+
+// versionedGuestCheckout seperti versionedOrder untuk hasil checkout guest
+func versionedGuestCheckout(ctx *gin.Context, result *dto.GuestCheckoutResponse) interface{} {
+	if !apiversion.AtLeast(ctx.Request.Context(), apiversion.V2) {
+		return result
+	}
+	return &dto.GuestCheckoutResponseV2{Order: *toOrderResponseV2(&result.Order), LookupToken: result.LookupToken}
+}
+
+func toOrderResponseV2(o *dto.OrderResponse) *dto.OrderResponseV2 {
+	return &dto.OrderResponseV2{
+		ID:         o.ID,
+		UserID:     o.UserID,
+		GuestEmail: o.GuestEmail,
+		Currency:   o.Currency,
+		CouponCode: o.CouponCode,
+		Pricing: dto.OrderPricing{
+			Subtotal:         o.Subtotal,
+			DiscountAmount:   o.DiscountAmount,
+			ShippingFee:      o.ShippingFee,
+			Tax:              o.Tax,
+			Total:            o.Total,
+			ReturnedAmount:   o.ReturnedAmount,
+			OutstandingTotal: o.OutstandingTotal,
+		},
+		Status:            o.Status,
+		ShippingAddress:   o.ShippingAddress,
+		Notes:             o.Notes,
+		TrackingNumber:    o.TrackingNumber,
+		Carrier:           o.Carrier,
+		EstimatedDelivery: o.EstimatedDelivery,
+		Items:             o.Items,
+		CreatedAt:         o.CreatedAt,
+		UpdatedAt:         o.UpdatedAt,
+	}
+}
@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
 	"gorm.io/gorm"
 )
 
@@ -11,11 +15,36 @@ type OrderRepository interface {
 	Create(order *entity.Order) error
 	FindByID(id uint) (*entity.Order, error)
 	FindByIDWithItems(id uint) (*entity.Order, error)
+	FindByGuestTokenHash(tokenHash string) (*entity.Order, error)
 	FindByUserID(userID uint, params *dto.OrderQueryParams) ([]entity.Order, int64, error)
 	FindAll(params *dto.OrderQueryParams) ([]entity.Order, int64, error)
+	ExportAll(params *dto.OrderQueryParams, fn func(row *dto.OrderExportRow) error) error
 	Update(order *entity.Order) error
 	UpdateStatus(id uint, status string) error
+	UpdateStatusIf(id uint, fromStatus string, toStatus string) (bool, error)
+	UpdateShipment(order *entity.Order) error
+	FindPendingCreatedBefore(cutoff time.Time) ([]entity.Order, error)
 	Delete(id uint) error
+	HasCompletedOrderWithProduct(userID uint, productID uint) (bool, error)
+	CreateStatusHistory(history *entity.OrderStatusHistory) error
+	FindStatusHistory(orderID uint) ([]entity.OrderStatusHistory, error)
+	CreateNote(note *entity.OrderNote) error
+	FindNotes(orderID uint, includeInternal bool) ([]entity.OrderNote, error)
+	HasSellerItems(orderID uint, sellerID uint) (bool, error)
+	FindIdempotencyKey(userID uint, key string) (*entity.CheckoutIdempotencyKey, error)
+	CreateIdempotencyKey(idempotencyKey *entity.CheckoutIdempotencyKey) error
+	DeleteIdempotencyKey(id uint) error
+	GetSellerSalesSummary(sellerID uint, from *time.Time, to *time.Time) (money.Money, int64, error)
+	GetProductSales(productID uint, from *time.Time, to *time.Time) (*dto.ProductSalesRow, error)
+	FindTopSellingProducts(sellerID uint, from *time.Time, to *time.Time, limit int) ([]dto.TopProductResponse, error)
+	GetSellerSalesByPeriod(sellerID uint, groupBy string, from time.Time, to time.Time) ([]dto.SalesPeriodRow, error)
+	CountGroupedByStatus() (map[string]int64, error)
+	SummarizeByStatusForUser(userID uint) ([]dto.OrderStatusSummary, error)
+	FindLatestCreatedAtForUser(userID uint) (*time.Time, error)
+	CountDailySince(since time.Time) (map[string]int64, error)
+	CreateReturn(orderReturn *entity.OrderReturn) error
+	IncrementItemReturnedQuantity(itemID uint, quantity int) (bool, error)
+	AddReturnedAmount(orderID uint, amount money.Money) error
 	WithTx(tx *gorm.DB) OrderRepository
 }
 
@@ -39,6 +68,14 @@ func (r *orderRepository) Create(order *entity.Order) error {
 	return r.db.Create(order).Error
 }
 
+// withProductDetails memuat item order beserta nama dan gambar produk saat ini dalam satu join.
+// Produk yang sudah di-soft delete tetap ikut; produk yang sudah dihapus permanen menghasilkan string kosong.
+func withProductDetails(db *gorm.DB) *gorm.DB {
+	return db.
+		Select("order_items.*, COALESCE(products.name, '') AS current_product_name, COALESCE(products.image_url, '') AS current_product_image_url").
+		Joins("LEFT JOIN products ON products.id = order_items.product_id")
+}
+
 // FindByID mencari order berdasarkan ID
 func (r *orderRepository) FindByID(id uint) (*entity.Order, error) {
 	var order entity.Order
@@ -51,23 +88,29 @@ func (r *orderRepository) FindByID(id uint) (*entity.Order, error) {
 // FindByIDWithItems mencari order dengan items
 func (r *orderRepository) FindByIDWithItems(id uint) (*entity.Order, error) {
 	var order entity.Order
-	if err := r.db.Preload("Items").First(&order, id).Error; err != nil {
+	if err := r.db.Preload("Items", withProductDetails).First(&order, id).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// FindByGuestTokenHash mencari order guest berdasarkan hash lookup token beserta item-nya
+func (r *orderRepository) FindByGuestTokenHash(tokenHash string) (*entity.Order, error) {
+	var order entity.Order
+	if err := r.db.Preload("Items", withProductDetails).Where("guest_token_hash = ?", tokenHash).First(&order).Error; err != nil {
 		return nil, err
 	}
 	return &order, nil
 }
 
-// FindByUserID mengambil order berdasarkan user ID dengan pagination
+// FindByUserID mengambil order berdasarkan user ID dengan pagination (order guest tidak pernah ikut)
 func (r *orderRepository) FindByUserID(userID uint, params *dto.OrderQueryParams) ([]entity.Order, int64, error) {
 	var orders []entity.Order
 	var total int64
 
-	query := r.db.Model(&entity.Order{}).Where("user_id = ?", userID)
+	query := r.db.Model(&entity.Order{}).Where("user_id = ? AND guest_token_hash IS NULL", userID)
 
-	// Apply status filter
-	if params.Status != "" {
-		query = query.Where("status = ?", params.Status)
-	}
+	query = applyOrderFilters(query, params)
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -76,7 +119,7 @@ func (r *orderRepository) FindByUserID(userID uint, params *dto.OrderQueryParams
 
 	// Apply pagination
 	offset := (params.Page - 1) * params.Limit
-	if err := query.Preload("Items").Order("created_at DESC").Offset(offset).Limit(params.Limit).Find(&orders).Error; err != nil {
+	if err := query.Preload("Items", withProductDetails).Order(orderSortClause(params.Sort)).Offset(offset).Limit(params.Limit).Find(&orders).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -90,10 +133,7 @@ func (r *orderRepository) FindAll(params *dto.OrderQueryParams) ([]entity.Order,
 
 	query := r.db.Model(&entity.Order{})
 
-	// Apply status filter
-	if params.Status != "" {
-		query = query.Where("status = ?", params.Status)
-	}
+	query = applyOrderFilters(query, params)
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -102,13 +142,72 @@ func (r *orderRepository) FindAll(params *dto.OrderQueryParams) ([]entity.Order,
 
 	// Apply pagination
 	offset := (params.Page - 1) * params.Limit
-	if err := query.Preload("Items").Order("created_at DESC").Offset(offset).Limit(params.Limit).Find(&orders).Error; err != nil {
+	if err := query.Preload("Items", withProductDetails).Order(orderSortClause(params.Sort)).Offset(offset).Limit(params.Limit).Find(&orders).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return orders, total, nil
 }
 
+// orderSortClauses whitelist ORDER BY untuk parameter sort (id sebagai tie-breaker agar pagination deterministik).
+// Input user tidak pernah masuk ke SQL secara langsung.
+var orderSortClauses = map[string]string{
+	dto.OrderSortCreatedAtAsc:  "orders.created_at ASC, orders.id ASC",
+	dto.OrderSortCreatedAtDesc: "orders.created_at DESC, orders.id DESC",
+	dto.OrderSortAmountAsc:     "orders.total_amount ASC, orders.id ASC",
+	dto.OrderSortAmountDesc:    "orders.total_amount DESC, orders.id DESC",
+}
+
+// orderSortClause mengembalikan ORDER BY untuk sort; kosong atau tidak dikenal = terbaru dulu
+func orderSortClause(sort string) string {
+	if clause, ok := orderSortClauses[sort]; ok {
+		return clause
+	}
+	return orderSortClauses[dto.OrderSortCreatedAtDesc]
+}
+
+// applyOrderFilters menerapkan filter status dan rentang created_at.
+// Dipakai bersama oleh list dan export agar hasilnya selalu konsisten.
+func applyOrderFilters(query *gorm.DB, params *dto.OrderQueryParams) *gorm.DB {
+	if params.Status != "" {
+		query = query.Where("orders.status = ?", params.Status)
+	}
+	if params.CreatedFrom != nil {
+		query = query.Where("orders.created_at >= ?", *params.CreatedFrom)
+	}
+	if params.CreatedBefore != nil {
+		query = query.Where("orders.created_at < ?", *params.CreatedBefore)
+	}
+	return query
+}
+
+// ExportAll membaca semua order yang cocok dengan filter baris per baris (tanpa pagination)
+// dan memanggil fn untuk setiap baris, sehingga hasil besar tidak dimuat sekaligus ke memory.
+// Berhenti dan mengembalikan error pertama dari fn.
+func (r *orderRepository) ExportAll(params *dto.OrderQueryParams, fn func(row *dto.OrderExportRow) error) error {
+	query := r.db.Model(&entity.Order{}).
+		Select("orders.id, orders.user_id, orders.status, orders.total_amount, orders.created_at, " +
+			"(SELECT COUNT(*) FROM order_items WHERE order_items.order_id = orders.id AND order_items.deleted_at IS NULL) AS item_count")
+	query = applyOrderFilters(query, params)
+
+	rows, err := query.Order(orderSortClause(params.Sort)).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row dto.OrderExportRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 // Update mengupdate data order
 func (r *orderRepository) Update(order *entity.Order) error {
 	return r.db.Save(order).Error
@@ -119,7 +218,331 @@ func (r *orderRepository) UpdateStatus(id uint, status string) error {
 	return r.db.Model(&entity.Order{}).Where("id = ?", id).Update("status", status).Error
 }
 
+// UpdateStatusIf mengupdate status order hanya jika status saat ini masih fromStatus.
+// Mengembalikan false jika status sudah diubah proses lain lebih dulu.
+func (r *orderRepository) UpdateStatusIf(id uint, fromStatus string, toStatus string) (bool, error) {
+	result := r.db.Model(&entity.Order{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Update("status", toStatus)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateShipment menyimpan info pengiriman order (nomor resi, kurir, estimasi tiba)
+func (r *orderRepository) UpdateShipment(order *entity.Order) error {
+	return r.db.Model(&entity.Order{}).Where("id = ?", order.ID).Updates(map[string]interface{}{
+		"tracking_number":    order.TrackingNumber,
+		"carrier":            order.Carrier,
+		"estimated_delivery": order.EstimatedDelivery,
+	}).Error
+}
+
+// FindPendingCreatedBefore mengambil order PENDING yang dibuat sebelum cutoff (untuk expiry worker)
+func (r *orderRepository) FindPendingCreatedBefore(cutoff time.Time) ([]entity.Order, error) {
+	var orders []entity.Order
+	if err := r.db.Where("status = ? AND created_at < ?", entity.OrderStatusPending, cutoff).
+		Order("created_at ASC").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// HasCompletedOrderWithProduct mengecek apakah user punya order COMPLETED berisi produk tertentu
+func (r *orderRepository) HasCompletedOrderWithProduct(userID uint, productID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.Order{}).
+		Joins("JOIN order_items ON order_items.order_id = orders.id AND order_items.deleted_at IS NULL").
+		Where("orders.user_id = ? AND orders.status = ? AND order_items.product_id = ?",
+			userID, entity.OrderStatusCompleted, productID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateStatusHistory menyimpan satu baris perubahan status order
+func (r *orderRepository) CreateStatusHistory(history *entity.OrderStatusHistory) error {
+	return r.db.Create(history).Error
+}
+
+// FindStatusHistory mengambil timeline status order, urut dari yang paling lama
+func (r *orderRepository) FindStatusHistory(orderID uint) ([]entity.OrderStatusHistory, error) {
+	var histories []entity.OrderStatusHistory
+	if err := r.db.Where("order_id = ?", orderID).Order("created_at ASC, id ASC").Find(&histories).Error; err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// FindIdempotencyKey mencari idempotency key checkout milik user
+func (r *orderRepository) FindIdempotencyKey(userID uint, key string) (*entity.CheckoutIdempotencyKey, error) {
+	var idempotencyKey entity.CheckoutIdempotencyKey
+	if err := r.db.Where("user_id = ? AND idempotency_key = ?", userID, key).First(&idempotencyKey).Error; err != nil {
+		return nil, err
+	}
+	return &idempotencyKey, nil
+}
+
+// CreateIdempotencyKey menyimpan idempotency key checkout; gagal jika user sudah memakai key yang sama
+func (r *orderRepository) CreateIdempotencyKey(idempotencyKey *entity.CheckoutIdempotencyKey) error {
+	return r.db.Create(idempotencyKey).Error
+}
+
+// DeleteIdempotencyKey menghapus idempotency key checkout yang sudah kedaluwarsa
+func (r *orderRepository) DeleteIdempotencyKey(id uint) error {
+	return r.db.Delete(&entity.CheckoutIdempotencyKey{}, id).Error
+}
+
+// CreateNote menyimpan catatan order baru
+func (r *orderRepository) CreateNote(note *entity.OrderNote) error {
+	return r.db.Create(note).Error
+}
+
+// FindNotes mengambil catatan order, urut dari yang paling lama.
+// Catatan internal hanya ikut jika includeInternal true.
+func (r *orderRepository) FindNotes(orderID uint, includeInternal bool) ([]entity.OrderNote, error) {
+	var notes []entity.OrderNote
+	query := r.db.Where("order_id = ?", orderID)
+	if !includeInternal {
+		query = query.Where("internal = ?", false)
+	}
+	if err := query.Order("created_at ASC, id ASC").Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// HasSellerItems mengecek apakah order berisi item dari produk milik seller
+func (r *orderRepository) HasSellerItems(orderID uint, sellerID uint) (bool, error) {
+	var count int64
+	err := r.db.Table("order_items").
+		Joins("JOIN products ON products.id = order_items.product_id").
+		Where("order_items.order_id = ? AND order_items.deleted_at IS NULL AND products.seller_id = ?", orderID, sellerID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// netQuantityExpr dan netRevenueExpr menghitung penjualan setelah dikurangi item yang di-return.
+// Nilai return memakai harga item saat checkout, sama seperti saat return dibuat.
+const (
+	netQuantityExpr = "order_items.quantity - order_items.returned_quantity"
+	netRevenueExpr  = "order_items.subtotal - order_items.price * order_items.returned_quantity"
+)
+
+// sellerSalesQuery membangun query order_items milik produk seller pada order dengan status tertentu.
+// Produk yang sudah dihapus tetap dihitung karena penjualannya sudah terjadi.
+func (r *orderRepository) sellerSalesQuery(sellerID uint, statuses []string, from *time.Time, to *time.Time) *gorm.DB {
+	query := r.db.Table("order_items").
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Joins("JOIN products ON products.id = order_items.product_id").
+		Where("order_items.deleted_at IS NULL AND products.seller_id = ? AND orders.status IN ?",
+			sellerID, statuses)
+
+	if from != nil {
+		query = query.Where("orders.created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("orders.created_at < ?", *to)
+	}
+	return query
+}
+
+// GetSellerSalesSummary menghitung total pendapatan (subtotal item milik seller) dan jumlah order
+func (r *orderRepository) GetSellerSalesSummary(sellerID uint, from *time.Time, to *time.Time) (money.Money, int64, error) {
+	var summary struct {
+		TotalRevenue money.Money
+		TotalOrders  int64
+	}
+	if err := r.sellerSalesQuery(sellerID, []string{entity.OrderStatusCompleted}, from, to).
+		Select("COALESCE(SUM(" + netRevenueExpr + "), 0) AS total_revenue, COUNT(DISTINCT order_items.order_id) AS total_orders").
+		Scan(&summary).Error; err != nil {
+		return money.Zero, 0, err
+	}
+	return summary.TotalRevenue, summary.TotalOrders, nil
+}
+
+// salesReportUnits adalah whitelist group_by laporan penjualan; nilainya dipakai langsung sebagai unit date_trunc
+var salesReportUnits = map[string]string{
+	"day":   "day",
+	"week":  "week",
+	"month": "month",
+}
+
+// GetSellerSalesByPeriod menghitung pendapatan dan jumlah order seller per periode (date_trunc, UTC)
+// untuk order yang sudah dibayar (PAID, SHIPPED, COMPLETED) dalam rentang [from, to).
+// Periode tanpa penjualan tidak ikut dikembalikan.
+func (r *orderRepository) GetSellerSalesByPeriod(sellerID uint, groupBy string, from time.Time, to time.Time) ([]dto.SalesPeriodRow, error) {
+	unit, ok := salesReportUnits[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sales report grouping %q", groupBy)
+	}
+
+	rows := []dto.SalesPeriodRow{}
+	paidStatuses := []string{entity.OrderStatusPaid, entity.OrderStatusShipped, entity.OrderStatusCompleted}
+	if err := r.sellerSalesQuery(sellerID, paidStatuses, &from, &to).
+		Select(fmt.Sprintf("date_trunc('%s', orders.created_at AT TIME ZONE 'UTC') AS period, "+
+			"COALESCE(SUM("+netRevenueExpr+"), 0) AS revenue, COUNT(DISTINCT order_items.order_id) AS order_count", unit)).
+		Group("period").
+		Order("period ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetProductSales menghitung unit terjual, pendapatan (keduanya setelah dikurangi return), dan jumlah
+// order berbeda untuk satu produk dari order yang sudah dibayar (PAID, SHIPPED, COMPLETED) dalam rentang [from, to).
+// Produk tanpa penjualan menghasilkan nilai 0.
+func (r *orderRepository) GetProductSales(productID uint, from *time.Time, to *time.Time) (*dto.ProductSalesRow, error) {
+	query := r.db.Table("order_items").
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Where("order_items.deleted_at IS NULL AND order_items.product_id = ? AND orders.status IN ?", productID,
+			[]string{entity.OrderStatusPaid, entity.OrderStatusShipped, entity.OrderStatusCompleted})
+	if from != nil {
+		query = query.Where("orders.created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("orders.created_at < ?", *to)
+	}
+
+	var row dto.ProductSalesRow
+	if err := query.
+		Select("COALESCE(SUM(" + netQuantityExpr + "), 0) AS units_sold, COALESCE(SUM(" + netRevenueExpr + "), 0) AS revenue, " +
+			"COUNT(DISTINCT order_items.order_id) AS order_count").
+		Scan(&row).Error; err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// FindTopSellingProducts mengambil produk seller dengan jumlah terjual terbanyak
+func (r *orderRepository) FindTopSellingProducts(sellerID uint, from *time.Time, to *time.Time, limit int) ([]dto.TopProductResponse, error) {
+	products := []dto.TopProductResponse{}
+	if err := r.sellerSalesQuery(sellerID, []string{entity.OrderStatusCompleted}, from, to).
+		Select("order_items.product_id, products.name AS product_name, " +
+			"SUM(" + netQuantityExpr + ") AS quantity_sold, SUM(" + netRevenueExpr + ") AS revenue").
+		Group("order_items.product_id, products.name").
+		Order("quantity_sold DESC, order_items.product_id ASC").
+		Limit(limit).
+		Scan(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// CountGroupedByStatus menghitung jumlah order untuk setiap status
+func (r *orderRepository) CountGroupedByStatus() (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	if err := r.db.Model(&entity.Order{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// SummarizeByStatusForUser menghitung jumlah dan total nilai order milik user per status dalam satu query
+func (r *orderRepository) SummarizeByStatusForUser(userID uint) ([]dto.OrderStatusSummary, error) {
+	var rows []dto.OrderStatusSummary
+	if err := r.db.Model(&entity.Order{}).
+		Select("status, COUNT(*) AS count, COALESCE(SUM(total_amount), 0) AS total").
+		Where("user_id = ? AND guest_token_hash IS NULL", userID).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FindLatestCreatedAtForUser mengambil waktu order terbaru milik user; nil jika user belum pernah order
+func (r *orderRepository) FindLatestCreatedAtForUser(userID uint) (*time.Time, error) {
+	var orders []entity.Order
+	if err := r.db.Select("created_at").
+		Where("user_id = ? AND guest_token_hash IS NULL", userID).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, nil
+	}
+	return &orders[0].CreatedAt, nil
+}
+
+// CountDailySince menghitung jumlah order per hari sejak waktu tertentu.
+// Key map berformat YYYY-MM-DD; hari tanpa order tidak ada di map.
+func (r *orderRepository) CountDailySince(since time.Time) (map[string]int64, error) {
+	var rows []struct {
+		Day   string
+		Count int64
+	}
+	if err := r.db.Model(&entity.Order{}).
+		Select("DATE(created_at) AS day, COUNT(*) AS count").
+		Where("created_at >= ?", since).
+		Group("DATE(created_at)").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		// PostgreSQL mengembalikan DATE sebagai timestamp lengkap, SQLite sebagai string tanggal
+		if len(row.Day) >= len(time.DateOnly) {
+			counts[row.Day[:len(time.DateOnly)]] += row.Count
+		}
+	}
+	return counts, nil
+}
+
+// CreateReturn menyimpan catatan pengembalian item
+func (r *orderRepository) CreateReturn(orderReturn *entity.OrderReturn) error {
+	return r.db.Create(orderReturn).Error
+}
+
+// IncrementItemReturnedQuantity menambah returned_quantity item hanya jika totalnya
+// tidak melebihi quantity yang dibeli. Mengembalikan false jika melebihi.
+func (r *orderRepository) IncrementItemReturnedQuantity(itemID uint, quantity int) (bool, error) {
+	result := r.db.Model(&entity.OrderItem{}).
+		Where("id = ? AND returned_quantity + ? <= quantity", itemID, quantity).
+		Update("returned_quantity", gorm.Expr("returned_quantity + ?", quantity))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// AddReturnedAmount menambah nilai item yang dikembalikan pada order
+func (r *orderRepository) AddReturnedAmount(orderID uint, amount money.Money) error {
+	return r.db.Model(&entity.Order{}).Where("id = ?", orderID).
+		Update("returned_amount", gorm.Expr("returned_amount + ?", amount)).Error
+}
+
 // Delete menghapus order (soft delete)
 func (r *orderRepository) Delete(id uint) error {
 	return r.db.Delete(&entity.Order{}, id).Error
 }
+
+// BackfillOrderItemSnapshots mengisi nama dan SKU produk pada item order lama yang dibuat sebelum
+// snapshot ada, memakai data produk saat backfill (termasuk produk yang sudah di-soft delete).
+// Item yang produknya sudah dihapus permanen tetap kosong. Dijalankan setelah AutoMigrate.
+func BackfillOrderItemSnapshots(db *gorm.DB) error {
+	return db.Exec(`UPDATE order_items SET
+		product_name = COALESCE((SELECT products.name FROM products WHERE products.id = order_items.product_id), ''),
+		product_sku = COALESCE(
+			(SELECT product_variants.sku FROM product_variants WHERE product_variants.id = order_items.variant_id),
+			(SELECT products.sku FROM products WHERE products.id = order_items.product_id), '')
+		WHERE product_name IS NULL OR product_name = ''`).Error
+}
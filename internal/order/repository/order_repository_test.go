@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderSortClause(t *testing.T) {
+	assert.Equal(t, "orders.created_at DESC, orders.id DESC", orderSortClause(""))
+	assert.Equal(t, "orders.created_at ASC, orders.id ASC", orderSortClause(dto.OrderSortCreatedAtAsc))
+	assert.Equal(t, "orders.total_amount ASC, orders.id ASC", orderSortClause(dto.OrderSortAmountAsc))
+	assert.Equal(t, "orders.total_amount DESC, orders.id DESC", orderSortClause(dto.OrderSortAmountDesc))
+
+	// Nilai di luar whitelist tidak pernah diteruskan ke SQL
+	assert.Equal(t, "orders.created_at DESC, orders.id DESC", orderSortClause("id; DROP TABLE orders"))
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+
+	cartDTO "github.com/akbarwjyy/go-commerce-api/internal/cart/dto"
+	cartEntity "github.com/akbarwjyy/go-commerce-api/internal/cart/entity"
+	cartRepo "github.com/akbarwjyy/go-commerce-api/internal/cart/repository"
+	cartService "github.com/akbarwjyy/go-commerce-api/internal/cart/service"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productDTO "github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckoutFromCart_VariantProduct(t *testing.T) {
+	db := setupCheckoutDB(t)
+	require.NoError(t, db.AutoMigrate(&cartEntity.Cart{}, &cartEntity.CartItem{}))
+
+	product := &productEntity.Product{Name: "T-Shirt", SKU: "T-SHIRT", Price: money.FromFloat(100), Currency: money.DefaultCurrency, SellerID: 1, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	small, err := productSvc.AddVariant(1, product.ID, &productDTO.CreateVariantRequest{
+		Attributes: map[string]string{"size": "S"}, SKU: "TS-S", Price: money.FromFloat(90), Stock: 5,
+	})
+	require.NoError(t, err)
+	large, err := productSvc.AddVariant(1, product.ID, &productDTO.CreateVariantRequest{
+		Attributes: map[string]string{"size": "L"}, SKU: "TS-L", Price: money.FromFloat(120), Stock: 1,
+	})
+	require.NoError(t, err)
+
+	cartSvc := cartService.NewCartService(cartRepo.NewCartRepository(db), productSvc, "")
+	svc := NewOrderService(OrderServiceDeps{ProductService: productSvc, CartService: cartSvc, DB: db})
+
+	// Produk bervarian tidak bisa masuk cart tanpa varian, dan stok dicek per varian
+	_, err = cartSvc.AddItem(2, &cartDTO.AddCartItemRequest{ProductID: product.ID, Quantity: 1})
+	assert.ErrorIs(t, err, cartService.ErrVariantRequired)
+	_, err = cartSvc.AddItem(2, &cartDTO.AddCartItemRequest{ProductID: product.ID, VariantID: &large.ID, Quantity: 2})
+	assert.ErrorIs(t, err, cartService.ErrInsufficientStock)
+
+	_, err = cartSvc.AddItem(2, &cartDTO.AddCartItemRequest{ProductID: product.ID, VariantID: &small.ID, Quantity: 2})
+	require.NoError(t, err)
+	cart, err := cartSvc.AddItem(2, &cartDTO.AddCartItemRequest{ProductID: product.ID, VariantID: &large.ID, Quantity: 1})
+	require.NoError(t, err)
+	require.Len(t, cart.Items, 2)
+	assert.Equal(t, money.FromFloat(300), cart.TotalAmount)
+
+	result, err := svc.CheckoutFromCart(2, &dto.CartCheckoutRequest{ShippingAddress: "Jl. Sudirman No. 1"})
+	require.NoError(t, err)
+	assert.Equal(t, money.FromFloat(300), result.TotalAmount)
+	require.Len(t, result.Items, 2)
+	for _, item := range result.Items {
+		require.NotNil(t, item.VariantID)
+	}
+
+	var smallVariant, largeVariant productEntity.ProductVariant
+	require.NoError(t, db.First(&smallVariant, small.ID).Error)
+	assert.Equal(t, 2, smallVariant.ReservedStock)
+	require.NoError(t, db.First(&largeVariant, large.ID).Error)
+	assert.Equal(t, 1, largeVariant.ReservedStock)
+
+	items, err := cartSvc.GetCartItems(2)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestCheckoutFromCart_RechecksStockAndKeepsCartOnFailure(t *testing.T) {
+	db := setupCheckoutDB(t)
+	require.NoError(t, db.AutoMigrate(&cartEntity.Cart{}, &cartEntity.CartItem{}))
+
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 5, SellerID: 1, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	cartSvc := cartService.NewCartService(cartRepo.NewCartRepository(db), productSvc, "")
+	svc := NewOrderService(OrderServiceDeps{ProductService: productSvc, CartService: cartSvc, DB: db})
+
+	_, err := svc.CheckoutFromCart(2, &dto.CartCheckoutRequest{ShippingAddress: "Jl. Sudirman No. 1"})
+	assert.ErrorIs(t, err, ErrEmptyCart)
+
+	_, err = cartSvc.AddItem(2, &cartDTO.AddCartItemRequest{ProductID: product.ID, Quantity: 4})
+	require.NoError(t, err)
+
+	// Stok terjual ke pembeli lain setelah item masuk cart
+	require.NoError(t, db.Model(product).Update("stock", 3).Error)
+
+	_, err = svc.CheckoutFromCart(2, &dto.CartCheckoutRequest{ShippingAddress: "Jl. Sudirman No. 1"})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	var orderCount int64
+	require.NoError(t, db.Model(&entity.Order{}).Count(&orderCount).Error)
+	assert.Zero(t, orderCount)
+	items, err := cartSvc.GetCartItems(2)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 4, items[0].Quantity)
+
+	// Setelah jumlah disesuaikan, checkout berhasil dan cart dikosongkan
+	_, err = cartSvc.UpdateItem(2, items[0].ID, &cartDTO.UpdateCartItemRequest{Quantity: 3})
+	require.NoError(t, err)
+	result, err := svc.CheckoutFromCart(2, &dto.CartCheckoutRequest{ShippingAddress: "Jl. Sudirman No. 1"})
+	require.NoError(t, err)
+	assert.Equal(t, money.FromFloat(3000), result.TotalAmount)
+
+	items, err = cartSvc.GetCartItems(2)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
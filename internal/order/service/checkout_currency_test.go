@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newCurrencyTestOrderService(db *gorm.DB) OrderService {
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{
+		DB:           db,
+		BaseCurrency: "IDR",
+	})
+	return NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+		BaseCurrency:   "IDR",
+	})
+}
+
+func TestCheckout_UsesProductCurrency(t *testing.T) {
+	db := setupCheckoutDB(t)
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Currency: "USD", Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+	svc := newCurrencyTestOrderService(db)
+
+	result, err := svc.Checkout(2, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", result.Currency)
+
+	// Coupon dinyatakan dalam base currency sehingga tidak berlaku untuk order USD
+	_, err = svc.Checkout(2, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+		CouponCode:      "HEMAT10",
+	})
+	assert.ErrorIs(t, err, ErrCouponCurrency)
+}
+
+func TestCheckout_RejectsMixedCurrencies(t *testing.T) {
+	db := setupCheckoutDB(t)
+	laptop := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Currency: "IDR", Stock: 10, SellerID: 1}
+	mouse := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(10), Currency: "USD", Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(laptop).Error)
+	require.NoError(t, db.Create(mouse).Error)
+	svc := newCurrencyTestOrderService(db)
+
+	_, err := svc.Checkout(2, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: laptop.ID, Quantity: 1}, {ProductID: mouse.ID, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	assert.ErrorIs(t, err, ErrMixedCurrency)
+
+	var reservations int64
+	require.NoError(t, db.Model(&productEntity.StockReservation{}).Count(&reservations).Error)
+	assert.Zero(t, reservations)
+}
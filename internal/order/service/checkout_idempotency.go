@@ -0,0 +1,50 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"gorm.io/gorm"
+)
+
+// checkoutIdempotencyTTL adalah lama idempotency key checkout berlaku; setelah itu key boleh dipakai ulang
+const checkoutIdempotencyTTL = 24 * time.Hour
+
+// findIdempotentOrder mengembalikan order yang sudah dibuat dengan idempotency key milik user,
+// atau nil jika key belum pernah dipakai. Key yang kedaluwarsa dihapus agar checkout baru bisa memakainya.
+func (s *orderService) findIdempotentOrder(userID uint, key string) (*entity.Order, error) {
+	idempotencyKey, err := s.orderRepo.FindIdempotencyKey(userID, key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if time.Since(idempotencyKey.CreatedAt) > checkoutIdempotencyTTL {
+		if err := s.orderRepo.DeleteIdempotencyKey(idempotencyKey.ID); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	order, err := s.orderRepo.FindByIDWithItems(idempotencyKey.OrderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+// saveIdempotencyKeyTx mencatat idempotency key untuk order baru di dalam transaction checkout.
+// Checkout ganda yang berjalan bersamaan gagal di sini karena unique index (user_id, key).
+func (s *orderService) saveIdempotencyKeyTx(tx *gorm.DB, userID uint, key string, orderID uint) error {
+	return s.orderRepo.WithTx(tx).CreateIdempotencyKey(&entity.CheckoutIdempotencyKey{
+		UserID:  userID,
+		Key:     key,
+		OrderID: orderID,
+	})
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupIdempotentCheckout(t *testing.T) (OrderService, *gorm.DB, *productEntity.Product) {
+	db := setupCheckoutDB(t)
+
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+	return svc, db, product
+}
+
+func idempotentCheckoutRequest(productID uint, key string) *dto.CheckoutRequest {
+	return &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: productID, Quantity: 2}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+		IdempotencyKey:  key,
+	}
+}
+
+func TestCheckout_ReplayWithSameKeyReturnsExistingOrder(t *testing.T) {
+	svc, db, product := setupIdempotentCheckout(t)
+
+	first, err := svc.Checkout(1, idempotentCheckoutRequest(product.ID, "checkout-abc"))
+	require.NoError(t, err)
+	replay, err := svc.Checkout(1, idempotentCheckoutRequest(product.ID, "checkout-abc"))
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, replay.ID)
+
+	var orders int64
+	require.NoError(t, db.Model(&entity.Order{}).Count(&orders).Error)
+	assert.Equal(t, int64(1), orders)
+
+	// Stok hanya direservasi sekali
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 2, reloaded.ReservedStock)
+}
+
+func TestCheckout_IdempotencyKeyIsScopedPerUser(t *testing.T) {
+	svc, _, product := setupIdempotentCheckout(t)
+
+	first, err := svc.Checkout(1, idempotentCheckoutRequest(product.ID, "same-key"))
+	require.NoError(t, err)
+	other, err := svc.Checkout(2, idempotentCheckoutRequest(product.ID, "same-key"))
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, other.ID)
+	assert.Equal(t, uint(2), other.UserID)
+}
+
+func TestCheckout_ExpiredIdempotencyKeyCreatesNewOrder(t *testing.T) {
+	svc, db, product := setupIdempotentCheckout(t)
+
+	first, err := svc.Checkout(1, idempotentCheckoutRequest(product.ID, "old-key"))
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&entity.CheckoutIdempotencyKey{}).Where("order_id = ?", first.ID).
+		Update("created_at", time.Now().Add(-checkoutIdempotencyTTL-time.Minute)).Error)
+
+	second, err := svc.Checkout(1, idempotentCheckoutRequest(product.ID, "old-key"))
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	var keys []entity.CheckoutIdempotencyKey
+	require.NoError(t, db.Find(&keys).Error)
+	require.Len(t, keys, 1)
+	assert.Equal(t, second.ID, keys[0].OrderID)
+}
+
+func TestCheckout_WithoutKeyCreatesSeparateOrders(t *testing.T) {
+	svc, _, product := setupIdempotentCheckout(t)
+
+	first, err := svc.Checkout(1, idempotentCheckoutRequest(product.ID, ""))
+	require.NoError(t, err)
+	second, err := svc.Checkout(1, idempotentCheckoutRequest(product.ID, ""))
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"testing"
+
+	couponEntity "github.com/akbarwjyy/go-commerce-api/internal/coupon/entity"
+	couponRepo "github.com/akbarwjyy/go-commerce-api/internal/coupon/repository"
+	couponService "github.com/akbarwjyy/go-commerce-api/internal/coupon/service"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newQuoteService(t *testing.T, db *gorm.DB) OrderService {
+	require.NoError(t, db.AutoMigrate(&couponEntity.Coupon{}))
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	couponSvc := couponService.NewCouponService(couponRepo.NewCouponRepository(db), "")
+	return NewOrderService(OrderServiceDeps{
+		ProductService:     productSvc,
+		CouponService:      couponSvc,
+		DB:                 db,
+		ShippingCalculator: NewFlatRateShipping(money.FromFloat(15)),
+		TaxPercent:         11,
+	})
+}
+
+func TestQuote_MatchesCheckoutWithoutSideEffects(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := newQuoteService(t, db)
+
+	product := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(100), Currency: money.DefaultCurrency, Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+	coupon := &couponEntity.Coupon{Code: "SAVE10", Type: couponEntity.CouponTypePercent, Value: 10, UsageLimit: 1}
+	require.NoError(t, db.Create(coupon).Error)
+
+	req := &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 1}, {ProductID: product.ID, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+		CouponCode:      "save10",
+	}
+	quote, err := svc.Quote(req)
+	require.NoError(t, err)
+	require.Len(t, quote.Items, 1)
+	assert.Equal(t, 2, quote.Items[0].Quantity)
+	assert.Equal(t, money.FromFloat(200), quote.Subtotal)
+	assert.Equal(t, "SAVE10", quote.CouponCode)
+	assert.Equal(t, money.FromFloat(20), quote.DiscountAmount)
+	assert.Equal(t, money.FromFloat(15), quote.ShippingFee)
+	assert.Equal(t, money.FromFloat(22), quote.Tax)
+	assert.Equal(t, money.FromFloat(217), quote.Total)
+
+	// Quote tidak membuat order, tidak mereservasi stok, dan tidak memakai coupon
+	var orderCount int64
+	require.NoError(t, db.Model(&entity.Order{}).Count(&orderCount).Error)
+	assert.Zero(t, orderCount)
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 0, reloaded.ReservedStock)
+	var reloadedCoupon couponEntity.Coupon
+	require.NoError(t, db.First(&reloadedCoupon, coupon.ID).Error)
+	assert.Equal(t, 0, reloadedCoupon.UsedCount)
+
+	// Checkout dengan request yang sama menghasilkan rincian yang sama
+	order, err := svc.Checkout(1, req)
+	require.NoError(t, err)
+	assert.Equal(t, quote.Subtotal, order.Subtotal)
+	assert.Equal(t, quote.DiscountAmount, order.DiscountAmount)
+	assert.Equal(t, quote.ShippingFee, order.ShippingFee)
+	assert.Equal(t, quote.Tax, order.Tax)
+	assert.Equal(t, quote.Total, order.Total)
+}
+
+func TestQuote_RunsCheckoutValidation(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := newQuoteService(t, db)
+
+	product := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(100), Currency: money.DefaultCurrency, Stock: 2, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	_, err := svc.Quote(&dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 3}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	_, err = svc.Quote(&dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID + 100, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+
+	_, err = svc.Quote(&dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+		CouponCode:      "MISSING",
+	})
+	assert.ErrorIs(t, err, couponService.ErrCouponNotFound)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/repository"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// tamperingOrderRepository menggeser TotalAmount sebelum disimpan untuk mensimulasikan alur yang salah menghitung total
+type tamperingOrderRepository struct {
+	repository.OrderRepository
+	offset money.Money
+}
+
+func (r *tamperingOrderRepository) Create(order *entity.Order) error {
+	order.TotalAmount = order.TotalAmount.Add(r.offset)
+	return r.OrderRepository.Create(order)
+}
+
+func (r *tamperingOrderRepository) WithTx(tx *gorm.DB) repository.OrderRepository {
+	return &tamperingOrderRepository{OrderRepository: r.OrderRepository.WithTx(tx), offset: r.offset}
+}
+
+func newTotalCheckService(t *testing.T, db *gorm.DB, offset money.Money) (OrderService, *productEntity.Product) {
+	category := &productEntity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(category).Error)
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, CategoryID: category.ID, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	orderRepo := &tamperingOrderRepository{OrderRepository: repository.NewOrderRepository(db), offset: offset}
+	return NewOrderService(OrderServiceDeps{
+		OrderRepo:      orderRepo,
+		ProductService: productSvc,
+		DB:             db,
+	}), product
+}
+
+func TestCheckout_RollsBackWhenStoredTotalDiverges(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc, product := newTotalCheckService(t, db, money.FromCents(5))
+
+	_, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 3}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	assert.ErrorIs(t, err, ErrOrderTotalMismatch)
+
+	var orderCount int64
+	require.NoError(t, db.Model(&entity.Order{}).Count(&orderCount).Error)
+	assert.Zero(t, orderCount)
+
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 0, reloaded.ReservedStock)
+}
+
+func TestCheckout_AllowsOneCentRoundingDifference(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc, product := newTotalCheckService(t, db, money.FromCents(1))
+
+	result, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 3}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, money.FromFloat(3000.01), result.TotalAmount)
+}
@@ -0,0 +1,546 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/repository"
+	productDTO "github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database/dbtest"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var errInsertFailed = errors.New("simulated order insert failure")
+
+// failingOrderRepository memaksa Create gagal untuk menguji rollback checkout
+type failingOrderRepository struct {
+	repository.OrderRepository
+}
+
+func (r *failingOrderRepository) Create(order *entity.Order) error {
+	return errInsertFailed
+}
+
+func (r *failingOrderRepository) WithTx(tx *gorm.DB) repository.OrderRepository {
+	return r
+}
+
+func setupCheckoutDB(t *testing.T) *gorm.DB {
+	return dbtest.NewSQLite(t,
+		&productEntity.Category{},
+		&productEntity.Product{},
+		&productEntity.ProductVariant{},
+		&productEntity.InventoryLog{},
+		&productEntity.PriceHistory{},
+		&productEntity.StockReservation{},
+		&productEntity.StockSubscription{},
+		&entity.Order{},
+		&entity.OrderItem{},
+		&entity.OrderStatusHistory{},
+		&entity.OrderNote{},
+		&entity.OrderReturn{},
+		&entity.CheckoutIdempotencyKey{},
+	)
+}
+
+func TestCheckout_RollsBackStockWhenOrderInsertFails(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	category := &productEntity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(category).Error)
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, CategoryID: category.ID, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	orderRepo := &failingOrderRepository{OrderRepository: repository.NewOrderRepository(db)}
+	svc := NewOrderService(OrderServiceDeps{
+		OrderRepo:      orderRepo,
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	_, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 3}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	assert.ErrorIs(t, err, errInsertFailed)
+
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 10, reloaded.Stock)
+}
+
+func TestCheckout_ReservesStockUntilPaid(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	category := &productEntity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(category).Error)
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, CategoryID: category.ID, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	result, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 3}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, money.FromFloat(3000), result.TotalAmount)
+
+	history, err := svc.GetOrderHistory(1, result.ID, false)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, entity.OrderStatusPending, history[0].ToStatus)
+
+	// Checkout hanya menahan stok; stok fisik baru berkurang saat order dibayar
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 10, reloaded.Stock)
+	assert.Equal(t, 3, reloaded.ReservedStock)
+	assert.Equal(t, 7, reloaded.AvailableStock())
+
+	require.NoError(t, svc.MarkAsPaid(result.ID))
+
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 7, reloaded.Stock)
+	assert.Zero(t, reloaded.ReservedStock)
+
+	var reservation productEntity.StockReservation
+	require.NoError(t, db.Where("order_id = ?", result.ID).First(&reservation).Error)
+	assert.Equal(t, productEntity.ReservationStatusCommitted, reservation.Status)
+}
+
+func TestCheckout_AddsShippingAndTax(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	category := &productEntity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(category).Error)
+	product := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(100), Stock: 10, CategoryID: category.ID, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService:     productSvc,
+		DB:                 db,
+		ShippingCalculator: NewFlatRateShipping(money.FromFloat(15)),
+		TaxPercent:         11,
+	})
+
+	result, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 2}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, money.FromFloat(200), result.Subtotal)
+	assert.Equal(t, money.FromFloat(15), result.ShippingFee)
+	assert.Equal(t, money.FromFloat(22), result.Tax)
+	assert.Equal(t, money.FromFloat(237), result.Total)
+	assert.Equal(t, result.Total, result.TotalAmount)
+
+	var stored entity.Order
+	require.NoError(t, db.First(&stored, result.ID).Error)
+	assert.Equal(t, money.FromFloat(237), stored.TotalAmount)
+}
+
+func TestOrderStatusChanges_RecordHistory(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	category := &productEntity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(category).Error)
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, CategoryID: category.ID, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	result, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.MarkAsPaid(result.ID))
+	_, err = svc.UpdateOrderStatus(99, result.ID, &dto.UpdateOrderStatusRequest{Status: entity.OrderStatusShipped, TrackingNumber: "JNE123"}, true)
+	require.NoError(t, err)
+
+	history, err := svc.GetOrderHistory(1, result.ID, false)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, entity.OrderStatusPending, history[0].ToStatus)
+	assert.Equal(t, entity.OrderStatusPending, history[1].FromStatus)
+	assert.Equal(t, entity.OrderStatusPaid, history[1].ToStatus)
+	assert.Nil(t, history[1].ChangedBy)
+	assert.Equal(t, entity.OrderStatusShipped, history[2].ToStatus)
+	assert.Equal(t, uint(99), *history[2].ChangedBy)
+
+	_, err = svc.GetOrderHistory(2, result.ID, false)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestExpireOrder_CancelsAndRestoresStock(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	category := &productEntity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(category).Error)
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, CategoryID: category.ID, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	result, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 4}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+
+	ids, err := svc.GetExpiredPendingOrderIDs(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	ids, err = svc.GetExpiredPendingOrderIDs(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []uint{result.ID}, ids)
+
+	require.NoError(t, svc.ExpireOrder(result.ID))
+	assert.ErrorIs(t, svc.ExpireOrder(result.ID), ErrOrderNotCancellable)
+	assert.ErrorIs(t, svc.MarkAsPaid(result.ID), ErrInvalidStatus)
+
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 10, reloaded.Stock)
+
+	history, err := svc.GetOrderHistory(1, result.ID, false)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, entity.OrderStatusCancelled, history[1].ToStatus)
+	assert.Nil(t, history[1].ChangedBy)
+}
+
+func TestCheckout_VariantStockAndPrice(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	category := &productEntity.Category{Name: "Apparel"}
+	require.NoError(t, db.Create(category).Error)
+	product := &productEntity.Product{Name: "T-Shirt", SKU: "T-SHIRT", Price: money.FromFloat(100), Stock: 0, CategoryID: category.ID, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	small, err := productSvc.AddVariant(1, product.ID, &productDTO.CreateVariantRequest{
+		Attributes: map[string]string{"size": "S"}, SKU: "TS-S", Price: money.FromFloat(90), Stock: 5,
+	})
+	require.NoError(t, err)
+	_, err = productSvc.AddVariant(1, product.ID, &productDTO.CreateVariantRequest{
+		Attributes: map[string]string{"size": "L"}, SKU: "TS-L", Price: money.FromFloat(120), Stock: 3,
+	})
+	require.NoError(t, err)
+
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 8, reloaded.Stock)
+
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	_, err = svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	assert.ErrorIs(t, err, ErrVariantRequired)
+
+	_, err = svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, VariantID: small.ID, Quantity: 6}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	result, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, VariantID: small.ID, Quantity: 2}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, money.FromFloat(180), result.TotalAmount)
+	require.Len(t, result.Items, 1)
+	require.NotNil(t, result.Items[0].VariantID)
+	assert.Equal(t, small.ID, *result.Items[0].VariantID)
+
+	var variant productEntity.ProductVariant
+	require.NoError(t, db.First(&variant, small.ID).Error)
+	assert.Equal(t, 5, variant.Stock)
+	assert.Equal(t, 3, variant.AvailableStock())
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 8, reloaded.Stock)
+	assert.Equal(t, 6, reloaded.AvailableStock())
+
+	require.NoError(t, svc.CancelOrder(1, result.ID))
+
+	require.NoError(t, db.First(&variant, small.ID).Error)
+	assert.Equal(t, 5, variant.Stock)
+	assert.Zero(t, variant.ReservedStock)
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 8, reloaded.Stock)
+	assert.Zero(t, reloaded.ReservedStock)
+}
+
+// countingProductService menghitung pemanggilan ReserveStockTx untuk memastikan validasi terjadi sebelum reservasi stok
+type countingProductService struct {
+	productService.ProductService
+	reserveCalls int
+}
+
+func (s *countingProductService) ReserveStockTx(tx *gorm.DB, orderID uint, productID uint, variantID *uint, quantity int) error {
+	s.reserveCalls++
+	return s.ProductService.ReserveStockTx(tx, orderID, productID, variantID, quantity)
+}
+
+func TestMergeCheckoutItems(t *testing.T) {
+	merged := mergeCheckoutItems([]dto.OrderItemRequest{
+		{ProductID: 1, Quantity: 2},
+		{ProductID: 2, VariantID: 5, Quantity: 1},
+		{ProductID: 1, Quantity: 3},
+		{ProductID: 2, VariantID: 6, Quantity: 1},
+		{ProductID: 2, VariantID: 5, Quantity: 4},
+	})
+	assert.Equal(t, []dto.OrderItemRequest{
+		{ProductID: 1, Quantity: 5},
+		{ProductID: 2, VariantID: 5, Quantity: 5},
+		{ProductID: 2, VariantID: 6, Quantity: 1},
+	}, merged)
+}
+
+func TestCheckout_ValidatesAllItemsBeforeReducingStock(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	laptop := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, SellerID: 1}
+	mouse := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(20), Stock: 5, SellerID: 1}
+	require.NoError(t, db.Create(laptop).Error)
+	require.NoError(t, db.Create(mouse).Error)
+
+	productSvc := &countingProductService{ProductService: productService.NewProductService(productService.ProductServiceDeps{DB: db})}
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	// Mouse dipesan dua baris (3 + 3) melebihi stok 5: ditolak tanpa ada stok yang direservasi
+	_, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items: []dto.OrderItemRequest{
+			{ProductID: laptop.ID, Quantity: 1},
+			{ProductID: mouse.ID, Quantity: 3},
+			{ProductID: mouse.ID, Quantity: 3},
+		},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	assert.Equal(t, ErrInsufficientStock, err)
+	assert.Zero(t, productSvc.reserveCalls)
+
+	// Baris duplikat digabung menjadi satu order item
+	result, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items: []dto.OrderItemRequest{
+			{ProductID: mouse.ID, Quantity: 2},
+			{ProductID: laptop.ID, Quantity: 1},
+			{ProductID: mouse.ID, Quantity: 2},
+		},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, mouse.ID, result.Items[0].ProductID)
+	assert.Equal(t, 4, result.Items[0].Quantity)
+	assert.Equal(t, money.FromFloat(1080), result.TotalAmount)
+	assert.Equal(t, 2, productSvc.reserveCalls)
+
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, mouse.ID).Error)
+	assert.Equal(t, 1, reloaded.AvailableStock())
+}
+
+// failingHistoryRepository memaksa pencatatan status history gagal setelah stok dikurangi
+type failingHistoryRepository struct {
+	repository.OrderRepository
+}
+
+func (r *failingHistoryRepository) CreateStatusHistory(history *entity.OrderStatusHistory) error {
+	return errInsertFailed
+}
+
+func (r *failingHistoryRepository) WithTx(tx *gorm.DB) repository.OrderRepository {
+	return &failingHistoryRepository{OrderRepository: r.OrderRepository.WithTx(tx)}
+}
+
+func TestCheckout_RollsBackStockAndInventoryLogAfterStockReduced(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	orderRepo := &failingHistoryRepository{OrderRepository: repository.NewOrderRepository(db)}
+	svc := NewOrderService(OrderServiceDeps{
+		OrderRepo:      orderRepo,
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	_, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 3}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	assert.ErrorIs(t, err, errInsertFailed)
+
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 10, reloaded.Stock)
+	assert.Zero(t, reloaded.ReservedStock)
+
+	var reservationCount int64
+	require.NoError(t, db.Model(&productEntity.StockReservation{}).Count(&reservationCount).Error)
+	assert.Zero(t, reservationCount)
+}
+
+func TestCancelOrder_NotifiesBackInStockSubscribersAfterCommit(t *testing.T) {
+	db := setupCheckoutDB(t)
+	sender := &recordingSender{}
+	userNotifier := notifier.NewUserNotifier(sender, func(userID uint) (string, error) {
+		return fmt.Sprintf("user%d@example.com", userID), nil
+	})
+
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 2, SellerID: 7}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db, UserNotifier: userNotifier})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	// Checkout mereservasi seluruh stok sehingga user lain berlangganan notifikasi
+	order, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 2}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, productSvc.SubscribeBackInStock(2, product.ID))
+
+	require.NoError(t, svc.CancelOrder(1, order.ID))
+	require.NoError(t, userNotifier.Shutdown(context.Background()))
+
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "user2@example.com", sender.sent[0].To)
+	assert.Equal(t, "Laptop is back in stock", sender.sent[0].Subject)
+}
+
+func TestCheckoutPaymentAndCancel_WriteInventoryLog(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	paid, err := svc.Checkout(2, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 3}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.MarkAsPaid(paid.ID))
+
+	// Order yang dibatalkan hanya melepas reservasi, stok fisik tidak pernah berubah
+	cancelled, err := svc.Checkout(2, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 2}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.CancelOrder(2, cancelled.ID))
+
+	// Order lama tanpa reservasi (stok sudah dikurangi saat checkout) tetap dikembalikan saat dibatalkan
+	legacy := &entity.Order{UserID: 2, Status: entity.OrderStatusPending, ShippingAddr: "Jl. Sudirman No. 1",
+		Items: []entity.OrderItem{{ProductID: product.ID, Quantity: 1, Price: money.FromFloat(1000), Subtotal: money.FromFloat(1000)}}}
+	require.NoError(t, db.Create(legacy).Error)
+	require.NoError(t, svc.CancelOrder(2, legacy.ID))
+
+	var logs []productEntity.InventoryLog
+	require.NoError(t, db.Order("id").Find(&logs).Error)
+	require.Len(t, logs, 2)
+
+	assert.Equal(t, productEntity.InventoryReasonCheckout, logs[0].Reason)
+	assert.Equal(t, -3, logs[0].Delta)
+	assert.Equal(t, productEntity.InventoryRefOrder, logs[0].RefType)
+	require.NotNil(t, logs[0].RefID)
+	assert.Equal(t, paid.ID, *logs[0].RefID)
+	assert.Nil(t, logs[0].ActorID)
+
+	assert.Equal(t, productEntity.InventoryReasonOrderCancelled, logs[1].Reason)
+	assert.Equal(t, 1, logs[1].Delta)
+	assert.Equal(t, legacy.ID, *logs[1].RefID)
+	require.NotNil(t, logs[1].ActorID)
+	assert.Equal(t, uint(2), *logs[1].ActorID)
+
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 8, reloaded.Stock)
+	assert.Zero(t, reloaded.ReservedStock)
+}
+
+func TestCheckout_OrderItemPriceUnaffectedByLaterPriceChange(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	result, err := svc.Checkout(2, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 2}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+
+	newPrice := money.FromFloat(1500)
+	_, err = productSvc.UpdateProduct(1, product.ID, &productDTO.UpdateProductRequest{Price: &newPrice})
+	require.NoError(t, err)
+
+	// Harga di order item adalah snapshot saat checkout
+	order, err := svc.GetOrder(2, result.ID)
+	require.NoError(t, err)
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, money.FromFloat(1000), order.Items[0].Price)
+	assert.Equal(t, money.FromFloat(2000), order.Items[0].Subtotal)
+	assert.Equal(t, money.FromFloat(2000), order.TotalAmount)
+
+	var history []productEntity.PriceHistory
+	require.NoError(t, db.Find(&history).Error)
+	require.Len(t, history, 1)
+	assert.Equal(t, money.FromFloat(1500), history[0].NewPrice)
+}
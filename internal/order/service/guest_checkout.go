@@ -0,0 +1,75 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"gorm.io/gorm"
+)
+
+// GuestCheckout membuat order tanpa akun. Order disimpan dengan user ID 0 dan lookup token acak;
+// hanya hash token yang disimpan, token asli dikembalikan sekali ke guest.
+func (s *orderService) GuestCheckout(req *dto.GuestCheckoutRequest) (*dto.GuestCheckoutResponse, error) {
+	token, err := generateGuestToken()
+	if err != nil {
+		return nil, err
+	}
+	tokenHash := hashGuestToken(token)
+
+	order, err := s.placeOrder(&entity.Order{GuestEmail: req.Email, GuestTokenHash: &tokenHash}, &dto.CheckoutRequest{
+		Items:           req.Items,
+		ShippingAddress: req.ShippingAddress,
+		Notes:           req.Notes,
+		CouponCode:      req.CouponCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifier.NotifyEmail(req.Email,
+		fmt.Sprintf("Order #%d received", order.ID),
+		fmt.Sprintf("Thank you for your order #%d.\n\nTotal: %s\nShipping to: %s\n\nYour order lookup token: %s\nKeep it to check your order status.",
+			order.ID, order.TotalAmount, order.ShippingAddr, token),
+	)
+
+	return &dto.GuestCheckoutResponse{
+		Order:       *s.toOrderResponse(order),
+		LookupToken: token,
+	}, nil
+}
+
+// GetGuestOrder mengambil order guest berdasarkan lookup token
+func (s *orderService) GetGuestOrder(token string) (*dto.OrderResponse, error) {
+	if token == "" {
+		return nil, ErrOrderNotFound
+	}
+
+	order, err := s.orderRepo.FindByGuestTokenHash(hashGuestToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return s.toOrderResponse(order), nil
+}
+
+// generateGuestToken membuat lookup token acak 32 byte (hex) untuk order guest
+func generateGuestToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashGuestToken menghasilkan hash yang disimpan di database, sehingga token asli tidak disimpan
+func hashGuestToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestCheckout_CreatesOrderRetrievableByToken(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	result, err := svc.GuestCheckout(&dto.GuestCheckoutRequest{
+		Email:           "guest@example.com",
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 2}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	assert.Len(t, result.LookupToken, 64)
+	assert.Equal(t, uint(0), result.Order.UserID)
+	assert.Equal(t, "guest@example.com", result.Order.GuestEmail)
+	assert.Equal(t, money.FromFloat(2000), result.Order.Total)
+
+	// Stok direservasi lewat alur checkout yang sama
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 8, reloaded.AvailableStock())
+	var reservation productEntity.StockReservation
+	require.NoError(t, db.First(&reservation).Error)
+	assert.Equal(t, result.Order.ID, reservation.OrderID)
+
+	// Token asli tidak disimpan
+	var stored entity.Order
+	require.NoError(t, db.First(&stored, result.Order.ID).Error)
+	require.NotNil(t, stored.GuestTokenHash)
+	assert.NotEqual(t, result.LookupToken, *stored.GuestTokenHash)
+
+	found, err := svc.GetGuestOrder(result.LookupToken)
+	require.NoError(t, err)
+	assert.Equal(t, result.Order.ID, found.ID)
+	assert.Len(t, found.Items, 1)
+
+	_, err = svc.GetGuestOrder("not-a-valid-token")
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+	_, err = svc.GetGuestOrder("")
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
+
+func TestGuestOrders_ExcludedFromUserOrders(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	product := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(20), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	items := []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 1}}
+	guest, err := svc.GuestCheckout(&dto.GuestCheckoutRequest{Email: "guest@example.com", Items: items, ShippingAddress: "Jl. A"})
+	require.NoError(t, err)
+	own, err := svc.Checkout(3, &dto.CheckoutRequest{Items: items, ShippingAddress: "Jl. B"})
+	require.NoError(t, err)
+
+	list, err := svc.GetMyOrders(3, &dto.OrderQueryParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list.Orders, 1)
+	assert.Equal(t, own.ID, list.Orders[0].ID)
+
+	// Guest order tidak bisa diakses lewat endpoint order milik user
+	_, err = svc.GetOrder(0, guest.Order.ID)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
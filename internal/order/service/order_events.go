@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/events"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+)
+
+// SubscribeToEvents mendaftarkan handler Order Module ke event bus:
+// PaymentSucceeded menandai order sebagai PAID
+func SubscribeToEvents(bus *events.Bus, orderSvc OrderService) {
+	bus.Subscribe(events.NamePaymentSucceeded, func(ctx context.Context, event events.Event) error {
+		payment, ok := event.(events.PaymentSucceeded)
+		if !ok {
+			return nil
+		}
+		return orderSvc.MarkAsPaid(payment.OrderID)
+	})
+}
+
+// publishStatusEvent mempublish OrderPaid atau OrderCancelled sesuai status baru order
+func (s *orderService) publishStatusEvent(order *entity.Order, fromStatus string) {
+	switch order.Status {
+	case entity.OrderStatusPaid:
+		s.publishEvent(events.OrderPaid{
+			OrderID:     order.ID,
+			UserID:      order.UserID,
+			TotalAmount: order.TotalAmount,
+			Currency:    order.Currency,
+		})
+	case entity.OrderStatusCancelled:
+		s.publishEvent(events.OrderCancelled{
+			OrderID:    order.ID,
+			UserID:     order.UserID,
+			FromStatus: fromStatus,
+		})
+	}
+}
+
+// publishEvent mempublish event milik Order Module. Perubahan sudah di-commit,
+// sehingga kegagalan subscriber hanya dicatat dan tidak membatalkan operasi.
+func (s *orderService) publishEvent(event events.Event) {
+	if err := s.events.Publish(context.Background(), event); err != nil {
+		logger.Error().Err(err).Str("event", event.EventName()).Msg("Event subscriber failed")
+	}
+}
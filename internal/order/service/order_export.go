@@ -0,0 +1,67 @@
+package service
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+)
+
+// orderExportHeader kolom CSV export order
+var orderExportHeader = []string{"order_id", "user_id", "status", "total", "created_at", "item_count"}
+
+// orderExportFlushEvery jumlah baris sebelum buffer CSV di-flush ke client
+const orderExportFlushEvery = 500
+
+// ExportOrders menulis semua order yang cocok dengan filter sebagai CSV ke w.
+// Baris dibaca dan ditulis satu per satu; pagination (page/limit) diabaikan.
+// Error rentang tanggal dikembalikan sebelum ada data yang ditulis.
+func (s *orderService) ExportOrders(params *dto.OrderQueryParams, w io.Writer) error {
+	if err := resolveOrderDateRange(params); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(orderExportHeader); err != nil {
+		return err
+	}
+
+	written := 0
+	err := s.orderRepo.ExportAll(params, func(row *dto.OrderExportRow) error {
+		if err := writer.Write([]string{
+			strconv.FormatUint(uint64(row.ID), 10),
+			strconv.FormatUint(uint64(row.UserID), 10),
+			row.Status,
+			row.TotalAmount.String(),
+			row.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.FormatInt(row.ItemCount, 10),
+		}); err != nil {
+			return err
+		}
+		written++
+		if written%orderExportFlushEvery == 0 {
+			writer.Flush()
+			return writer.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// resolveOrderDateRange mengisi CreatedFrom/CreatedBefore dari From/To (YYYY-MM-DD, inklusif)
+func resolveOrderDateRange(params *dto.OrderQueryParams) error {
+	from, to, err := parseDateRange(params.From, params.To)
+	if err != nil {
+		return err
+	}
+	params.CreatedFrom = from
+	params.CreatedBefore = to
+	return nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportOrders_WritesFilteredRows(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := NewOrderService(OrderServiceDeps{DB: db})
+
+	createOrder := func(userID uint, status string, createdAt time.Time, itemCount int) *entity.Order {
+		order := &entity.Order{UserID: userID, Status: status, TotalAmount: money.FromFloat(25.5), CreatedAt: createdAt}
+		for i := 0; i < itemCount; i++ {
+			order.Items = append(order.Items, entity.OrderItem{ProductID: uint(i + 1), Quantity: 1, Price: money.FromFloat(1), Subtotal: money.FromFloat(1)})
+		}
+		require.NoError(t, db.Create(order).Error)
+		return order
+	}
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 10, 0, 0, 0, time.UTC) }
+
+	paid := createOrder(1, entity.OrderStatusPaid, day(10), 2)
+	createOrder(2, entity.OrderStatusPending, day(10), 1)
+	createOrder(3, entity.OrderStatusPaid, day(20), 1)
+
+	var buf bytes.Buffer
+	err := svc.ExportOrders(&dto.OrderQueryParams{Status: entity.OrderStatusPaid, From: "2024-03-01", To: "2024-03-15"}, &buf)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"order_id", "user_id", "status", "total", "created_at", "item_count"}, records[0])
+	assert.Equal(t, []string{fmt.Sprint(paid.ID), "1", "PAID", "25.50", "2024-03-10T10:00:00Z", "2"}, records[1])
+}
+
+func TestExportOrders_RejectsInvalidDateRangeBeforeWriting(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := NewOrderService(OrderServiceDeps{DB: db})
+
+	var buf bytes.Buffer
+	err := svc.ExportOrders(&dto.OrderQueryParams{From: "2024-03-15", To: "2024-03-01"}, &buf)
+	assert.Equal(t, ErrInvalidDateRange, err)
+	assert.Zero(t, buf.Len())
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/repository"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderResponse_IncludesProductDetails(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := newQuoteService(t, db)
+
+	product := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(100), Stock: 10, SellerID: 1, ImageURL: "https://cdn.example.com/mouse.png"}
+	require.NoError(t, db.Create(product).Error)
+
+	order, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, "Mouse", order.Items[0].ProductName)
+	assert.Equal(t, "https://cdn.example.com/mouse.png", order.Items[0].ProductImageURL)
+
+	var item entity.OrderItem
+	require.NoError(t, db.First(&item, order.Items[0].ID).Error)
+	assert.Equal(t, "Mouse", item.ProductName)
+}
+
+func TestOrderResponse_LegacyItemFallsBackToDeletedProductName(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := newQuoteService(t, db)
+
+	product := &productEntity.Product{Name: "Keyboard", SKU: "KEYBOARD", Price: money.FromFloat(50), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	// Item dari sebelum snapshot nama ada, lalu produknya di-soft delete
+	order := &entity.Order{UserID: 1, Status: entity.OrderStatusPaid, ShippingAddr: "Jl. Sudirman No. 1", Items: []entity.OrderItem{
+		{ProductID: product.ID, Quantity: 1, Price: money.FromFloat(50), Subtotal: money.FromFloat(50)},
+	}}
+	order.CalculateTotal()
+	require.NoError(t, db.Create(order).Error)
+	require.NoError(t, db.Delete(product).Error)
+
+	result, err := svc.GetOrder(1, order.ID)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "Keyboard", result.Items[0].ProductName)
+
+	list, err := svc.GetMyOrders(1, &dto.OrderQueryParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list.Orders, 1)
+	assert.Equal(t, "Keyboard", list.Orders[0].Items[0].ProductName)
+}
+
+func TestOrderItemSnapshot_UnaffectedByProductRename(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := newQuoteService(t, db)
+
+	product := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(100), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+	variant := &productEntity.ProductVariant{ProductID: product.ID, SKU: "MOUSE-BLK", Attributes: productEntity.VariantAttributes{"color": "black"}, Price: money.FromFloat(110), Stock: 5}
+	require.NoError(t, db.Create(variant).Error)
+
+	order, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, VariantID: variant.ID, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+
+	// Produk diganti nama dan SKU setelah pembelian
+	require.NoError(t, db.Model(product).Updates(map[string]interface{}{"name": "Wireless Mouse", "sku": "MOUSE-2"}).Error)
+
+	result, err := svc.GetOrder(1, order.ID)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "Mouse", result.Items[0].ProductName)
+	assert.Equal(t, "MOUSE-BLK", result.Items[0].ProductSKU)
+}
+
+func TestBackfillOrderItemSnapshots_FillsLegacyItems(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	product := &productEntity.Product{Name: "Keyboard", SKU: "KEYBOARD", Price: money.FromFloat(50), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+	order := &entity.Order{UserID: 1, Status: entity.OrderStatusPaid, ShippingAddr: "Jl. Sudirman No. 1", Items: []entity.OrderItem{
+		{ProductID: product.ID, Quantity: 1, Price: money.FromFloat(50), Subtotal: money.FromFloat(50)},
+		{ProductID: product.ID, ProductName: "Old Keyboard", ProductSKU: "KB-OLD", Quantity: 1, Price: money.FromFloat(50), Subtotal: money.FromFloat(50)},
+	}}
+	order.CalculateTotal()
+	require.NoError(t, db.Create(order).Error)
+
+	require.NoError(t, repository.BackfillOrderItemSnapshots(db))
+
+	var items []entity.OrderItem
+	require.NoError(t, db.Order("id").Find(&items).Error)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Keyboard", items[0].ProductName)
+	assert.Equal(t, "KEYBOARD", items[0].ProductSKU)
+	// Snapshot yang sudah ada tidak ditimpa
+	assert.Equal(t, "Old Keyboard", items[1].ProductName)
+	assert.Equal(t, "KB-OLD", items[1].ProductSKU)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"gorm.io/gorm"
+)
+
+// AddOrderNote menambah catatan bertanggal pada order.
+// Pembeli hanya bisa menambah catatan biasa; catatan internal khusus seller produk di order dan admin.
+func (s *orderService) AddOrderNote(userID uint, orderID uint, isAdmin bool, req *dto.CreateOrderNoteRequest) (*dto.OrderNoteResponse, error) {
+	canSeeInternal, err := s.checkNoteAccess(userID, orderID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if req.Internal && !canSeeInternal {
+		return nil, ErrInternalNoteForbidden
+	}
+
+	note := &entity.OrderNote{
+		OrderID:  orderID,
+		AuthorID: userID,
+		Body:     req.Body,
+		Internal: req.Internal,
+	}
+	if err := s.orderRepo.CreateNote(note); err != nil {
+		return nil, err
+	}
+	return toOrderNoteResponse(note), nil
+}
+
+// GetOrderNotes mengambil catatan order; pembeli tidak melihat catatan internal
+func (s *orderService) GetOrderNotes(userID uint, orderID uint, isAdmin bool) ([]dto.OrderNoteResponse, error) {
+	canSeeInternal, err := s.checkNoteAccess(userID, orderID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := s.orderRepo.FindNotes(orderID, canSeeInternal)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.OrderNoteResponse, 0, len(notes))
+	for i := range notes {
+		responses = append(responses, *toOrderNoteResponse(&notes[i]))
+	}
+	return responses, nil
+}
+
+// checkNoteAccess memastikan user boleh mengakses catatan order (pembeli, seller produk di order, atau admin).
+// Mengembalikan true jika user juga boleh melihat dan menulis catatan internal.
+func (s *orderService) checkNoteAccess(userID uint, orderID uint, isAdmin bool) (bool, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, ErrOrderNotFound
+		}
+		return false, err
+	}
+	if isAdmin {
+		return true, nil
+	}
+
+	isSeller, err := s.orderRepo.HasSellerItems(orderID, userID)
+	if err != nil {
+		return false, err
+	}
+	if isSeller {
+		return true, nil
+	}
+	if order.IsOwner(userID) {
+		return false, nil
+	}
+	return false, ErrUnauthorized
+}
+
+func toOrderNoteResponse(n *entity.OrderNote) *dto.OrderNoteResponse {
+	return &dto.OrderNoteResponse{
+		ID:        n.ID,
+		OrderID:   n.OrderID,
+		AuthorID:  n.AuthorID,
+		Body:      n.Body,
+		Internal:  n.Internal,
+		CreatedAt: n.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderNotes_VisibilityByRole(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	const buyerID, sellerID, otherID, adminID = uint(1), uint(7), uint(8), uint(99)
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, SellerID: sellerID}
+	require.NoError(t, db.Create(product).Error)
+	order := &entity.Order{UserID: buyerID, Status: entity.OrderStatusPaid, ShippingAddr: "Jl. Sudirman No. 1",
+		Items: []entity.OrderItem{{ProductID: product.ID, Quantity: 1, Price: money.FromFloat(1000), Subtotal: money.FromFloat(1000)}}}
+	require.NoError(t, db.Create(order).Error)
+
+	svc := NewOrderService(OrderServiceDeps{DB: db})
+
+	_, err := svc.AddOrderNote(buyerID, order.ID, false, &dto.CreateOrderNoteRequest{Body: "Please ring the bell"})
+	require.NoError(t, err)
+	_, err = svc.AddOrderNote(sellerID, order.ID, false, &dto.CreateOrderNoteRequest{Body: "Shipped via JNE"})
+	require.NoError(t, err)
+	_, err = svc.AddOrderNote(sellerID, order.ID, false, &dto.CreateOrderNoteRequest{Body: "Packed by warehouse B", Internal: true})
+	require.NoError(t, err)
+
+	// Pembeli tidak bisa menulis catatan internal, user lain tidak bisa mengakses sama sekali
+	_, err = svc.AddOrderNote(buyerID, order.ID, false, &dto.CreateOrderNoteRequest{Body: "secret", Internal: true})
+	assert.ErrorIs(t, err, ErrInternalNoteForbidden)
+	_, err = svc.AddOrderNote(otherID, order.ID, false, &dto.CreateOrderNoteRequest{Body: "hello"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = svc.GetOrderNotes(otherID, order.ID, false)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	buyerNotes, err := svc.GetOrderNotes(buyerID, order.ID, false)
+	require.NoError(t, err)
+	require.Len(t, buyerNotes, 2)
+	assert.Equal(t, "Please ring the bell", buyerNotes[0].Body)
+	assert.Equal(t, "Shipped via JNE", buyerNotes[1].Body)
+	assert.Equal(t, sellerID, buyerNotes[1].AuthorID)
+
+	sellerNotes, err := svc.GetOrderNotes(sellerID, order.ID, false)
+	require.NoError(t, err)
+	require.Len(t, sellerNotes, 3)
+	assert.True(t, sellerNotes[2].Internal)
+
+	adminNotes, err := svc.GetOrderNotes(adminID, order.ID, true)
+	require.NoError(t, err)
+	assert.Len(t, adminNotes, 3)
+
+	_, err = svc.GetOrderNotes(buyerID, 12345, false)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+)
+
+// sendReceipt mengirim email struk (item dan total) ke pembeli setelah order ditandai PAID.
+// Email dikirim asynchronous lewat UserNotifier; kegagalan hanya dicatat dan tidak
+// mengubah status payment maupun order yang sudah di-commit.
+func (s *orderService) sendReceipt(orderID uint) {
+	if s.notifier == nil {
+		return
+	}
+
+	order, err := s.orderRepo.FindByIDWithItems(orderID)
+	if err != nil {
+		logger.Warn().Err(err).Uint("order_id", orderID).Msg("Failed to load order for receipt email")
+		return
+	}
+
+	subject := fmt.Sprintf("Receipt for order #%d", order.ID)
+	body := buildReceiptBody(order)
+	if order.IsGuest() {
+		s.notifier.NotifyEmail(order.GuestEmail, subject, body)
+		return
+	}
+	// Alamat email user dicari Auth Module lewat EmailLookup milik notifier
+	s.notifier.NotifyUser(order.UserID, subject, body)
+}
+
+// buildReceiptBody menyusun isi email struk order dalam plain text
+func buildReceiptBody(order *entity.Order) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "We have received your payment for order #%d.\n\nItems:\n", order.ID)
+
+	itemsTotal := money.Zero
+	for _, item := range order.Items {
+		itemsTotal = itemsTotal.Add(item.Subtotal)
+		// Order lama dibuat sebelum snapshot nama ada, pakai nama produk saat ini
+		name := item.ProductName
+		if name == "" {
+			name = item.CurrentProductName
+		}
+		if item.ProductSKU != "" {
+			name = fmt.Sprintf("%s (%s)", name, item.ProductSKU)
+		}
+		fmt.Fprintf(&b, "- %s x%d @ %s = %s\n", name, item.Quantity, item.Price, item.Subtotal)
+	}
+
+	// Order lama dibuat sebelum kolom subtotal ada, hitung ulang dari item
+	subtotal := order.Subtotal
+	if subtotal == money.Zero {
+		subtotal = itemsTotal
+	}
+	fmt.Fprintf(&b, "\nSubtotal: %s\n", subtotal)
+	if order.DiscountAmount > 0 {
+		fmt.Fprintf(&b, "Discount (%s): -%s\n", order.CouponCode, order.DiscountAmount)
+	}
+	if order.ShippingFee > 0 {
+		fmt.Fprintf(&b, "Shipping: %s\n", order.ShippingFee)
+	}
+	if order.TaxAmount > 0 {
+		fmt.Fprintf(&b, "Tax: %s\n", order.TaxAmount)
+	}
+	fmt.Fprintf(&b, "Total: %s %s\n\nShipping to: %s\n\nYour order is now being prepared for shipment.",
+		order.Currency, order.TotalAmount, order.ShippingAddr)
+	return b.String()
+}
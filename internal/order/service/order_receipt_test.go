@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// recordingSender mencatat email yang dikirim dan bisa dibuat gagal
+type recordingSender struct {
+	mu      sync.Mutex
+	sent    []notifier.Message
+	sendErr error
+}
+
+func (s *recordingSender) Send(ctx context.Context, msg notifier.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, msg)
+	return s.sendErr
+}
+
+// receipts mengembalikan email struk yang sudah dikirim
+func (s *recordingSender) receipts() []notifier.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var receipts []notifier.Message
+	for _, msg := range s.sent {
+		if strings.HasPrefix(msg.Subject, "Receipt") {
+			receipts = append(receipts, msg)
+		}
+	}
+	return receipts
+}
+
+// newReceiptService membuat OrderService dengan notifier yang mengirim ke sender
+func newReceiptService(db *gorm.DB, sender notifier.EmailSender) (OrderService, *notifier.UserNotifier) {
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	userNotifier := notifier.NewUserNotifier(sender, func(userID uint) (string, error) {
+		return "buyer@example.com", nil
+	})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService:     productSvc,
+		DB:                 db,
+		ShippingCalculator: NewFlatRateShipping(money.FromFloat(15)),
+		UserNotifier:       userNotifier,
+	})
+	return svc, userNotifier
+}
+
+func TestMarkAsPaid_SendsReceiptWithItemsAndTotal(t *testing.T) {
+	db := setupCheckoutDB(t)
+	sender := &recordingSender{}
+	svc, userNotifier := newReceiptService(db, sender)
+
+	product := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(100), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+	order, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 2}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.MarkAsPaid(order.ID))
+	require.NoError(t, userNotifier.Shutdown(context.Background()))
+
+	receipts := sender.receipts()
+	require.Len(t, receipts, 1)
+	assert.Equal(t, "buyer@example.com", receipts[0].To)
+	assert.Contains(t, receipts[0].Body, "- Mouse (MOUSE) x2 @ 100.00 = 200.00")
+	assert.Contains(t, receipts[0].Body, "Shipping: 15.00")
+	assert.Contains(t, receipts[0].Body, "Total:")
+	assert.Contains(t, receipts[0].Body, "215.00")
+}
+
+func TestMarkAsPaid_ReceiptFailureKeepsOrderPaid(t *testing.T) {
+	db := setupCheckoutDB(t)
+	sender := &recordingSender{sendErr: errors.New("smtp down")}
+	svc, userNotifier := newReceiptService(db, sender)
+
+	product := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(100), Stock: 10, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+	guest, err := svc.GuestCheckout(&dto.GuestCheckoutRequest{
+		Email:           "guest@example.com",
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.MarkAsPaid(guest.Order.ID))
+	require.NoError(t, userNotifier.Shutdown(context.Background()))
+
+	// Struk order guest dikirim ke email guest; gagal kirim tidak membatalkan status PAID
+	receipts := sender.receipts()
+	require.Len(t, receipts, 1)
+	assert.Equal(t, "guest@example.com", receipts[0].To)
+
+	var reloaded entity.Order
+	require.NoError(t, db.First(&reloaded, guest.Order.ID).Error)
+	assert.Equal(t, entity.OrderStatusPaid, reloaded.Status)
+}
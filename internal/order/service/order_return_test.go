@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturnOrderItem(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	category := &productEntity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(category).Error)
+	product := &productEntity.Product{Name: "Keyboard", SKU: "KEYBOARD", Price: money.FromFloat(100), Stock: 10, CategoryID: category.ID, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	order, err := svc.Checkout(1, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 3}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	itemID := order.Items[0].ID
+
+	// Order PENDING belum bisa di-return
+	_, err = svc.ReturnOrderItem(1, order.ID, itemID, &dto.ReturnItemRequest{Quantity: 1})
+	assert.ErrorIs(t, err, ErrOrderNotReturnable)
+
+	require.NoError(t, svc.MarkAsPaid(order.ID))
+
+	_, err = svc.ReturnOrderItem(2, order.ID, itemID, &dto.ReturnItemRequest{Quantity: 1})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = svc.ReturnOrderItem(1, order.ID, itemID+100, &dto.ReturnItemRequest{Quantity: 1})
+	assert.ErrorIs(t, err, ErrOrderItemNotFound)
+
+	_, err = svc.ReturnOrderItem(1, order.ID, itemID, &dto.ReturnItemRequest{Quantity: 4})
+	assert.ErrorIs(t, err, ErrReturnQuantity)
+
+	result, err := svc.ReturnOrderItem(1, order.ID, itemID, &dto.ReturnItemRequest{Quantity: 2, Reason: "Damaged"})
+	require.NoError(t, err)
+	assert.Equal(t, money.FromFloat(200), result.Amount)
+	assert.Equal(t, money.FromFloat(100), result.OutstandingTotal)
+
+	// Sisa quantity tinggal 1
+	_, err = svc.ReturnOrderItem(1, order.ID, itemID, &dto.ReturnItemRequest{Quantity: 2})
+	assert.ErrorIs(t, err, ErrReturnQuantity)
+
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 9, reloaded.Stock)
+
+	detail, err := svc.GetOrder(1, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, detail.Items[0].ReturnedQuantity)
+	assert.Equal(t, money.FromFloat(200), detail.ReturnedAmount)
+	assert.Equal(t, money.FromFloat(100), detail.OutstandingTotal)
+
+	var returns []entity.OrderReturn
+	require.NoError(t, db.Where("order_id = ?", order.ID).Find(&returns).Error)
+	require.Len(t, returns, 1)
+	assert.Equal(t, "Damaged", returns[0].Reason)
+}
+
+func TestReturnOrderItem_RejectsCancelledOrder(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	order := &entity.Order{
+		UserID:       1,
+		TotalAmount:  money.FromFloat(100),
+		Status:       entity.OrderStatusCancelled,
+		ShippingAddr: "Jl. Sudirman No. 1",
+		Items:        []entity.OrderItem{{ProductID: 1, Quantity: 1, Price: money.FromFloat(100), Subtotal: money.FromFloat(100)}},
+	}
+	require.NoError(t, db.Create(order).Error)
+
+	svc := NewOrderService(OrderServiceDeps{DB: db})
+	_, err := svc.ReturnOrderItem(1, order.ID, order.Items[0].ID, &dto.ReturnItemRequest{Quantity: 1})
+	assert.ErrorIs(t, err, ErrOrderNotReturnable)
+}
@@ -2,135 +2,512 @@ package service
 
 import (
 	"errors"
-	"math"
+	"fmt"
+	"io"
+	"strings"
 	"time"
 
+	cartService "github.com/akbarwjyy/go-commerce-api/internal/cart/service"
+	couponDto "github.com/akbarwjyy/go-commerce-api/internal/coupon/dto"
+	couponService "github.com/akbarwjyy/go-commerce-api/internal/coupon/service"
 	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
 	"github.com/akbarwjyy/go-commerce-api/internal/order/repository"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
 	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	webhookService "github.com/akbarwjyy/go-commerce-api/internal/webhook/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database"
+	"github.com/akbarwjyy/go-commerce-api/pkg/events"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/notifier"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
 	"gorm.io/gorm"
 )
 
 // Common errors
 var (
-	ErrOrderNotFound      = errors.New("order not found")
-	ErrUnauthorized       = errors.New("you are not authorized to perform this action")
-	ErrInvalidStatus      = errors.New("invalid status transition")
-	ErrProductNotFound    = errors.New("product not found")
-	ErrInsufficientStock  = errors.New("insufficient stock for one or more products")
-	ErrEmptyCart          = errors.New("cart is empty")
-	ErrOrderNotCancellable = errors.New("order cannot be cancelled")
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrUnauthorized          = errors.New("you are not authorized to perform this action")
+	ErrInvalidStatus         = errors.New("invalid status transition")
+	ErrProductNotFound       = errors.New("product not found")
+	ErrInsufficientStock     = errors.New("insufficient stock for one or more products")
+	ErrEmptyCart             = errors.New("cart is empty")
+	ErrOrderNotCancellable   = errors.New("order cannot be cancelled")
+	ErrPaidOrderCancellation = errors.New("paid orders must be cancelled with POST /admin/orders/:id/cancel so the payment is refunded")
+	ErrVariantNotFound       = errors.New("product variant not found")
+	ErrVariantRequired       = errors.New("a variant must be selected for one or more products")
+	ErrMixedCurrency         = errors.New("all products in an order must use the same currency")
+	ErrCouponCurrency        = errors.New("coupons can only be used on orders in the base currency")
+	ErrInvalidDateRange      = errors.New("from date must not be after to date")
+	ErrOrderItemNotFound     = errors.New("order item not found")
+	ErrOrderNotReturnable    = errors.New("only PAID or SHIPPED orders can have items returned")
+	ErrReturnQuantity        = errors.New("return quantity exceeds the remaining purchased quantity")
+	ErrOrderTotalMismatch    = errors.New("order total does not match its items")
+
+	ErrInternalNoteForbidden    = errors.New("only sellers and admins can add internal notes")
+	ErrTrackingNumberRequired   = errors.New("tracking number is required when shipping an order")
+	ErrInvalidEstimatedDelivery = errors.New("estimated_delivery must be a date in YYYY-MM-DD format")
+
+	ErrInvalidGroupBy    = errors.New("group_by must be one of day, week, month")
+	ErrDateRangeTooLarge = fmt.Errorf("date range must not exceed %d days", salesReportMaxDays)
 )
 
+// sellerTopProductsLimit adalah jumlah produk terlaris yang ditampilkan di dashboard seller
+const sellerTopProductsLimit = 5
+
 // OrderService interface untuk business logic order
 type OrderService interface {
 	Checkout(userID uint, req *dto.CheckoutRequest) (*dto.OrderResponse, error)
+	CheckoutFromCart(userID uint, req *dto.CartCheckoutRequest) (*dto.OrderResponse, error)
+	Quote(req *dto.CheckoutRequest) (*dto.QuoteResponse, error)
+	GuestCheckout(req *dto.GuestCheckoutRequest) (*dto.GuestCheckoutResponse, error)
 	GetOrder(userID uint, orderID uint) (*dto.OrderResponse, error)
+	GetGuestOrder(token string) (*dto.OrderResponse, error)
 	GetMyOrders(userID uint, params *dto.OrderQueryParams) (*dto.OrderListResponse, error)
+	GetMyOrderStats(userID uint) (*dto.MyOrderStatsResponse, error)
 	GetAllOrders(params *dto.OrderQueryParams) (*dto.OrderListResponse, error)
-	UpdateOrderStatus(userID uint, orderID uint, status string, isAdmin bool) (*dto.OrderResponse, error)
+	ExportOrders(params *dto.OrderQueryParams, w io.Writer) error
+	UpdateOrderStatus(userID uint, orderID uint, req *dto.UpdateOrderStatusRequest, isAdmin bool) (*dto.OrderResponse, error)
 	CancelOrder(userID uint, orderID uint) error
+	GetOrderHistory(userID uint, orderID uint, isAdmin bool) ([]dto.OrderStatusHistoryResponse, error)
+	ReturnOrderItem(userID uint, orderID uint, itemID uint, req *dto.ReturnItemRequest) (*dto.OrderReturnResponse, error)
+	AddOrderNote(userID uint, orderID uint, isAdmin bool, req *dto.CreateOrderNoteRequest) (*dto.OrderNoteResponse, error)
+	GetOrderNotes(userID uint, orderID uint, isAdmin bool) ([]dto.OrderNoteResponse, error)
 
 	// Untuk Payment Module callback
+	GetOrderByID(orderID uint) (*dto.OrderResponse, error)
 	MarkAsPaid(orderID uint) error
+	MarkAsRefundedTx(tx *gorm.DB, orderID uint) (func(), error)
+	ForceCancelTx(tx *gorm.DB, orderID uint, adminID uint, reason string) (func(), error)
+
+	// Untuk expiry worker di Payment Module
+	GetExpiredPendingOrderIDs(cutoff time.Time) ([]uint, error)
+	ExpireOrder(orderID uint) error
+
+	// Untuk Review Module
+	HasCompletedOrderWithProduct(userID uint, productID uint) (bool, error)
+
+	// Dashboard seller
+	GetSellerDashboard(sellerID uint, params *dto.SellerDashboardQueryParams) (*dto.SellerDashboardResponse, error)
+	GetSellerSalesReport(sellerID uint, params *dto.SellerSalesReportQueryParams) (*dto.SellerSalesReportResponse, error)
+	GetProductSalesStats(userID uint, productID uint, isAdmin bool, params *dto.ProductSalesStatsQueryParams) (*dto.ProductSalesStatsResponse, error)
+
+	// Untuk dashboard admin
+	CountOrdersByStatus() (map[string]int64, error)
+	GetDailyOrderCounts(since time.Time) (map[string]int64, error)
 }
 
 // orderService implementasi OrderService
 type orderService struct {
 	orderRepo      repository.OrderRepository
 	productService productService.ProductService
+	cartService    cartService.CartService
+	couponService  couponService.CouponService
 	db             *gorm.DB
+
+	shippingCalculator ShippingCalculator         // nil = gratis ongkir
+	taxPercent         float64                    // persentase pajak dari subtotal item
+	notifier           *notifier.UserNotifier     // nil = tanpa email konfirmasi
+	webhooks           *webhookService.Dispatcher // nil = tanpa webhook
+	baseCurrency       string                     // currency nominal coupon dan laporan agregat
+	events             *events.Bus                // nil = event tidak dipublish
+}
+
+// OrderServiceDeps berisi dependency OrderService. OrderRepo yang nil dibuat dari DB,
+// dan dependency opsional lain boleh dikosongkan untuk menonaktifkan fiturnya.
+type OrderServiceDeps struct {
+	OrderRepo      repository.OrderRepository
+	ProductService productService.ProductService
+	CartService    cartService.CartService
+	CouponService  couponService.CouponService
+	DB             *gorm.DB
+
+	ShippingCalculator ShippingCalculator         // nil = gratis ongkir
+	TaxPercent         float64                    // persentase pajak dari subtotal item
+	UserNotifier       *notifier.UserNotifier     // nil = tanpa email konfirmasi
+	WebhookDispatcher  *webhookService.Dispatcher // nil = tanpa webhook
+	BaseCurrency       string                     // kosong = money.DefaultCurrency
+	EventBus           *events.Bus                // nil = event tidak dipublish
 }
 
 // NewOrderService membuat instance baru OrderService
-func NewOrderService(
-	orderRepo repository.OrderRepository,
-	productSvc productService.ProductService,
-	db *gorm.DB,
-) OrderService {
+func NewOrderService(deps OrderServiceDeps) OrderService {
+	if deps.OrderRepo == nil && deps.DB != nil {
+		deps.OrderRepo = repository.NewOrderRepository(deps.DB)
+	}
+	if deps.BaseCurrency == "" {
+		deps.BaseCurrency = money.DefaultCurrency
+	}
 	return &orderService{
-		orderRepo:      orderRepo,
-		productService: productSvc,
-		db:             db,
+		orderRepo:          deps.OrderRepo,
+		productService:     deps.ProductService,
+		cartService:        deps.CartService,
+		couponService:      deps.CouponService,
+		db:                 deps.DB,
+		shippingCalculator: deps.ShippingCalculator,
+		taxPercent:         deps.TaxPercent,
+		notifier:           deps.UserNotifier,
+		webhooks:           deps.WebhookDispatcher,
+		baseCurrency:       deps.BaseCurrency,
+		events:             deps.EventBus,
 	}
 }
 
-// Checkout membuat order baru dari checkout
+// Checkout membuat order baru dari checkout.
+// Jika req.IdempotencyKey diisi, checkout ulang dengan key yang sama (per user) dalam
+// checkoutIdempotencyTTL mengembalikan order yang sudah dibuat tanpa mengurangi stok lagi.
 func (s *orderService) Checkout(userID uint, req *dto.CheckoutRequest) (*dto.OrderResponse, error) {
+	if req.IdempotencyKey != "" {
+		existing, err := s.findIdempotentOrder(userID, req.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return s.toOrderResponse(existing), nil
+		}
+	}
+
+	order, err := s.placeOrder(&entity.Order{UserID: userID}, req)
+	if err != nil {
+		// Checkout dengan key yang sama bisa selesai lebih dulu di request lain
+		if req.IdempotencyKey != "" {
+			if existing, findErr := s.findIdempotentOrder(userID, req.IdempotencyKey); findErr == nil && existing != nil {
+				return s.toOrderResponse(existing), nil
+			}
+		}
+		return nil, err
+	}
+
+	s.notifier.NotifyUser(userID,
+		fmt.Sprintf("Order #%d received", order.ID),
+		fmt.Sprintf("Thank you for your order #%d.\n\nTotal: %s\nShipping to: %s\n\nPlease complete the payment to process your order.",
+			order.ID, order.TotalAmount, order.ShippingAddr),
+	)
+
+	return s.toOrderResponse(order), nil
+}
+
+// placeOrder memvalidasi item, mengurangi stok, dan menyimpan order dalam satu transaction.
+// owner berisi pemilik order (UserID, atau GuestEmail/GuestTokenHash untuk guest).
+func (s *orderService) placeOrder(owner *entity.Order, req *dto.CheckoutRequest) (*entity.Order, error) {
 	if len(req.Items) == 0 {
 		return nil, ErrEmptyCart
 	}
 
-	// Start transaction
-	tx := s.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
+	// Order guest tidak punya user sebagai pelaku perubahan
+	var actorID *uint
+	if !owner.IsGuest() {
+		actorID = &owner.UserID
+	}
 
-	var orderItems []entity.OrderItem
-	var totalAmount float64
+	// Item dengan produk/varian yang sama digabung agar pengecekan stok memakai total quantity
+	items := mergeCheckoutItems(req.Items)
 
-	// Validate and process each item
-	for _, item := range req.Items {
-		// Get product details
-		product, err := s.productService.GetProductByID(item.ProductID)
+	// Validasi, penyimpanan order, dan reservasi stok dalam satu transaction
+	var order *entity.Order
+	err := database.WithinTransaction(s.db, func(tx *gorm.DB) error {
+		// Pemakaian coupon ikut di-rollback jika checkout gagal
+		built, err := s.buildOrder(owner, req, items, func(code string, subtotal money.Money) (*couponDto.ValidateCouponResponse, error) {
+			return s.couponService.Redeem(tx, code, subtotal)
+		})
 		if err != nil {
-			tx.Rollback()
-			return nil, ErrProductNotFound
+			return err
 		}
+		order = built
 
-		// Check stock
-		if !product.HasStock(item.Quantity) {
-			tx.Rollback()
-			return nil, ErrInsufficientStock
+		orderRepoWithTx := s.orderRepo.WithTx(tx)
+		if err := orderRepoWithTx.Create(order); err != nil {
+			return err
 		}
 
-		// Reduce stock
-		if err := s.productService.ReduceStock(item.ProductID, item.Quantity); err != nil {
-			tx.Rollback()
+		// Pastikan total yang tersimpan konsisten dengan item sebelum stok direservasi
+		if err := s.verifyStoredTotal(orderRepoWithTx, order.ID); err != nil {
+			return err
+		}
+
+		// Dicatat sebelum reservasi agar checkout ganda dengan key yang sama gagal lebih awal
+		if req.IdempotencyKey != "" && !owner.IsGuest() {
+			if err := s.saveIdempotencyKeyTx(tx, owner.UserID, req.IdempotencyKey, order.ID); err != nil {
+				return err
+			}
+		}
+
+		// Pass 2: reservasi stok secara atomik di dalam transaction checkout, setelah order dibuat
+		// agar reservasi bisa merujuk order. Stok fisik baru dikurangi saat order dibayar.
+		// Masih bisa gagal jika stok diambil checkout lain setelah pass 1.
+		for _, item := range items {
+			if err := s.reserveItemStock(tx, order.ID, item); err != nil {
+				switch err {
+				case productService.ErrProductNotFound:
+					return ErrProductNotFound
+				case productService.ErrVariantNotFound:
+					return ErrVariantNotFound
+				case productService.ErrInsufficientStock:
+					return ErrInsufficientStock
+				}
+				return err
+			}
+		}
+
+		// Catat status awal order di timeline
+		return orderRepoWithTx.CreateStatusHistory(&entity.OrderStatusHistory{
+			OrderID:   order.ID,
+			ToStatus:  order.Status,
+			ChangedBy: actorID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Reload order with items
+	if reloaded, err := s.orderRepo.FindByIDWithItems(order.ID); err == nil {
+		order = reloaded
+	}
+	s.publishEvent(events.OrderCreated{
+		OrderID:     order.ID,
+		UserID:      order.UserID,
+		TotalAmount: order.TotalAmount,
+		Currency:    order.Currency,
+		ItemCount:   len(order.Items),
+	})
+	return order, nil
+}
+
+// couponApplier menghitung potongan coupon untuk subtotal. Checkout memakai Redeem (pemakaian
+// coupon bertambah), quote memakai Validate (tanpa efek samping).
+type couponApplier func(code string, subtotal money.Money) (*couponDto.ValidateCouponResponse, error)
+
+// buildOrder memvalidasi produk, varian, dan stok seluruh item lalu menyusun order PENDING beserta
+// rincian biayanya (subtotal, potongan coupon, ongkos kirim, pajak, total) tanpa menyimpan apa pun.
+// Dipakai checkout dan quote agar total keduanya selalu sama.
+func (s *orderService) buildOrder(owner *entity.Order, req *dto.CheckoutRequest, items []dto.OrderItemRequest, applyCoupon couponApplier) (*entity.Order, error) {
+	// Pass 1: validasi produk, varian, dan stok seluruh item sebelum ada stok yang dikurangi
+	var orderItems []entity.OrderItem
+	var currency string
+	subtotal := money.Zero
+	for i, item := range items {
+		orderItem, itemCurrency, err := s.prepareOrderItem(item)
+		if err != nil {
 			return nil, err
 		}
+		// Total order hanya bermakna jika semua item memakai currency yang sama
+		if i == 0 {
+			currency = itemCurrency
+		} else if itemCurrency != currency {
+			return nil, ErrMixedCurrency
+		}
+		orderItems = append(orderItems, *orderItem)
+		subtotal = subtotal.Add(orderItem.Subtotal)
+	}
 
-		// Create order item
-		subtotal := product.Price * float64(item.Quantity)
-		orderItem := entity.OrderItem{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     product.Price,
-			Subtotal:  subtotal,
+	var couponCode string
+	discountAmount := money.Zero
+	if req.CouponCode != "" {
+		// Nominal coupon (min_spend, potongan FIXED) dinyatakan dalam BASE_CURRENCY
+		if currency != s.baseCurrency {
+			return nil, ErrCouponCurrency
+		}
+		redemption, err := applyCoupon(req.CouponCode, subtotal)
+		if err != nil {
+			return nil, err
 		}
-		orderItems = append(orderItems, orderItem)
-		totalAmount += subtotal
+		couponCode = redemption.Code
+		discountAmount = redemption.DiscountAmount
 	}
 
-	// Create order
+	// Ongkos kirim dan pajak (pajak dihitung dari subtotal item)
+	shippingFee := money.Zero
+	if s.shippingCalculator != nil {
+		fee, err := s.shippingCalculator.Calculate(orderItems, req.ShippingAddress)
+		if err != nil {
+			return nil, err
+		}
+		shippingFee = fee
+	}
+	taxAmount := subtotal.Percent(s.taxPercent)
+
 	order := &entity.Order{
-		UserID:       userID,
-		TotalAmount:  totalAmount,
-		Status:       entity.OrderStatusPending,
-		ShippingAddr: req.ShippingAddress,
-		Notes:        req.Notes,
-		Items:        orderItems,
+		UserID:         owner.UserID,
+		GuestEmail:     owner.GuestEmail,
+		GuestTokenHash: owner.GuestTokenHash,
+		Currency:       currency,
+		CouponCode:     couponCode,
+		DiscountAmount: discountAmount,
+		ShippingFee:    shippingFee,
+		TaxAmount:      taxAmount,
+		Status:         entity.OrderStatusPending,
+		ShippingAddr:   req.ShippingAddress,
+		Notes:          req.Notes,
+		Items:          orderItems,
 	}
+	order.CalculateTotal()
+	return order, nil
+}
 
-	orderRepoWithTx := s.orderRepo.WithTx(tx)
-	if err := orderRepoWithTx.Create(order); err != nil {
-		tx.Rollback()
+// Quote menghitung rincian biaya checkout dengan validasi yang sama seperti Checkout (produk,
+// varian, stok, coupon, ongkos kirim, pajak) tanpa menyimpan order, memakai coupon, atau
+// mereservasi stok
+func (s *orderService) Quote(req *dto.CheckoutRequest) (*dto.QuoteResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, ErrEmptyCart
+	}
+
+	order, err := s.buildOrder(&entity.Order{}, req, mergeCheckoutItems(req.Items), func(code string, subtotal money.Money) (*couponDto.ValidateCouponResponse, error) {
+		return s.couponService.Validate(code, subtotal)
+	})
+	if err != nil {
 		return nil, err
 	}
+	return toQuoteResponse(order), nil
+}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
+// orderTotalTolerance adalah selisih maksimum antara total tersimpan dan total hasil hitung ulang
+var orderTotalTolerance = money.FromCents(1)
+
+// verifyStoredTotal membaca ulang order yang baru disimpan beserta item-nya dan memastikan
+// TotalAmount sama dengan hasil CalculateTotal (toleransi satu sen). Melindungi dari alur
+// yang mengisi total secara keliru; checkout dibatalkan jika invariant ini dilanggar.
+func (s *orderService) verifyStoredTotal(orderRepo repository.OrderRepository, orderID uint) error {
+	stored, err := orderRepo.FindByIDWithItems(orderID)
+	if err != nil {
+		return err
+	}
+
+	recomputed := *stored
+	expected := recomputed.CalculateTotal()
+	diff := stored.TotalAmount.Sub(expected)
+	if diff > orderTotalTolerance || diff < -orderTotalTolerance {
+		logger.Error().
+			Uint("order_id", orderID).
+			Str("stored_total", stored.TotalAmount.String()).
+			Str("expected_total", expected.String()).
+			Msg("Order total mismatch, checkout rolled back")
+		return ErrOrderTotalMismatch
+	}
+	return nil
+}
+
+// mergeCheckoutItems menggabungkan item dengan produk dan varian yang sama menjadi satu baris.
+// Urutan kemunculan pertama dipertahankan.
+func mergeCheckoutItems(items []dto.OrderItemRequest) []dto.OrderItemRequest {
+	type itemKey struct {
+		productID uint
+		variantID uint
+	}
+	index := make(map[itemKey]int, len(items))
+	merged := make([]dto.OrderItemRequest, 0, len(items))
+	for _, item := range items {
+		key := itemKey{item.ProductID, item.VariantID}
+		if i, ok := index[key]; ok {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+// prepareOrderItem memvalidasi produk, varian, dan ketersediaan stok satu item tanpa mengubah stok,
+// lalu membuat order item dengan harga saat ini beserta currency produknya
+func (s *orderService) prepareOrderItem(item dto.OrderItemRequest) (*entity.OrderItem, string, error) {
+	product, err := s.productService.GetProductByID(item.ProductID)
+	if err != nil {
+		return nil, "", ErrProductNotFound
+	}
+
+	// Produk bervarian: harga dan stok diambil dari varian yang dipilih
+	price := product.Price
+	sku := product.SKU
+	var variantID *uint
+	if item.VariantID != 0 {
+		variant, err := s.productService.GetVariant(item.ProductID, item.VariantID)
+		if err != nil {
+			if err == productService.ErrVariantNotFound {
+				return nil, "", ErrVariantNotFound
+			}
+			return nil, "", err
+		}
+		if !variant.HasStock(item.Quantity) {
+			return nil, "", ErrInsufficientStock
+		}
+		price = variant.Price
+		sku = variant.SKU
+		variantID = &variant.ID
+	} else {
+		hasVariants, err := s.productService.HasVariants(item.ProductID)
+		if err != nil {
+			return nil, "", err
+		}
+		if hasVariants {
+			return nil, "", ErrVariantRequired
+		}
+		if !product.HasStock(item.Quantity) {
+			return nil, "", ErrInsufficientStock
+		}
+	}
+
+	orderItem := &entity.OrderItem{
+		ProductID:   item.ProductID,
+		VariantID:   variantID,
+		ProductName: product.Name,
+		ProductSKU:  sku,
+		Quantity:    item.Quantity,
+		Price:       price,
+	}
+	orderItem.CalculateSubtotal()
+	return orderItem, product.Currency, nil
+}
+
+// reserveItemStock menahan stok varian jika item memilih varian, selain itu stok produk
+func (s *orderService) reserveItemStock(tx *gorm.DB, orderID uint, item dto.OrderItemRequest) error {
+	var variantID *uint
+	if item.VariantID != 0 {
+		variantID = &item.VariantID
+	}
+	return s.productService.ReserveStockTx(tx, orderID, item.ProductID, variantID, item.Quantity)
+}
+
+// CheckoutFromCart membuat order dari cart milik user lalu mengosongkan cart
+func (s *orderService) CheckoutFromCart(userID uint, req *dto.CartCheckoutRequest) (*dto.OrderResponse, error) {
+	cartItems, err := s.cartService.GetCartItems(userID)
+	if err != nil {
 		return nil, err
 	}
+	if len(cartItems) == 0 {
+		return nil, ErrEmptyCart
+	}
 
-	// Reload order with items
-	order, _ = s.orderRepo.FindByIDWithItems(order.ID)
+	// Stok dicek ulang di Checkout karena bisa berubah sejak item masuk cart
+	checkoutReq := &dto.CheckoutRequest{
+		ShippingAddress: req.ShippingAddress,
+		Notes:           req.Notes,
+		CouponCode:      req.CouponCode,
+	}
+	for _, item := range cartItems {
+		orderItem := dto.OrderItemRequest{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+		if item.VariantID != nil {
+			orderItem.VariantID = *item.VariantID
+		}
+		checkoutReq.Items = append(checkoutReq.Items, orderItem)
+	}
 
-	return s.toOrderResponse(order), nil
+	result, err := s.Checkout(userID, checkoutReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Order sudah tersimpan, kegagalan mengosongkan cart tidak membatalkan checkout
+	if err := s.cartService.ClearCart(userID); err != nil {
+		logger.Error().Err(err).Uint("user_id", userID).Msg("Failed to clear cart after checkout")
+	}
+
+	return result, nil
 }
 
 // GetOrder mengambil order berdasarkan ID
@@ -151,17 +528,55 @@ func (s *orderService) GetOrder(userID uint, orderID uint) (*dto.OrderResponse,
 	return s.toOrderResponse(order), nil
 }
 
-// GetMyOrders mengambil order milik user
-func (s *orderService) GetMyOrders(userID uint, params *dto.OrderQueryParams) (*dto.OrderListResponse, error) {
-	// Set default pagination
-	if params.Page <= 0 {
-		params.Page = 1
+// GetOrderByID mengambil order beserta item tanpa cek kepemilikan (untuk pencarian payment oleh admin)
+func (s *orderService) GetOrderByID(orderID uint) (*dto.OrderResponse, error) {
+	order, err := s.orderRepo.FindByIDWithItems(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return s.toOrderResponse(order), nil
+}
+
+// GetMyOrderStats mengambil ringkasan order milik user: jumlah per status, total belanja
+// (hanya order COMPLETED), dan waktu order terakhir
+func (s *orderService) GetMyOrderStats(userID uint) (*dto.MyOrderStatsResponse, error) {
+	summaries, err := s.orderRepo.SummarizeByStatusForUser(userID)
+	if err != nil {
+		return nil, err
 	}
-	if params.Limit <= 0 {
-		params.Limit = 10
+
+	stats := &dto.MyOrderStatsResponse{
+		TotalSpent:    money.Zero,
+		Currency:      s.baseCurrency,
+		CountByStatus: make(map[string]int64, len(summaries)),
 	}
-	if params.Limit > 100 {
-		params.Limit = 100
+	for _, summary := range summaries {
+		stats.TotalOrders += summary.Count
+		stats.CountByStatus[summary.Status] = summary.Count
+		if summary.Status == entity.OrderStatusCompleted {
+			stats.TotalSpent = summary.Total
+		}
+	}
+
+	lastOrderAt, err := s.orderRepo.FindLatestCreatedAtForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if lastOrderAt != nil {
+		stats.LastOrderAt = lastOrderAt.Format(time.RFC3339)
+	}
+	return stats, nil
+}
+
+// GetMyOrders mengambil order milik user
+func (s *orderService) GetMyOrders(userID uint, params *dto.OrderQueryParams) (*dto.OrderListResponse, error) {
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+	if err := resolveOrderDateRange(params); err != nil {
+		return nil, err
 	}
 
 	orders, total, err := s.orderRepo.FindByUserID(userID, params)
@@ -174,28 +589,17 @@ func (s *orderService) GetMyOrders(userID uint, params *dto.OrderQueryParams) (*
 		orderResponses = append(orderResponses, *s.toOrderResponse(&o))
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(params.Limit)))
-
 	return &dto.OrderListResponse{
-		Orders:     orderResponses,
-		Total:      total,
-		Page:       params.Page,
-		Limit:      params.Limit,
-		TotalPages: totalPages,
+		Orders: orderResponses,
+		Meta:   pagination.BuildMeta(total, params.Page, params.Limit),
 	}, nil
 }
 
 // GetAllOrders mengambil semua order (untuk admin)
 func (s *orderService) GetAllOrders(params *dto.OrderQueryParams) (*dto.OrderListResponse, error) {
-	// Set default pagination
-	if params.Page <= 0 {
-		params.Page = 1
-	}
-	if params.Limit <= 0 {
-		params.Limit = 10
-	}
-	if params.Limit > 100 {
-		params.Limit = 100
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+	if err := resolveOrderDateRange(params); err != nil {
+		return nil, err
 	}
 
 	orders, total, err := s.orderRepo.FindAll(params)
@@ -208,19 +612,20 @@ func (s *orderService) GetAllOrders(params *dto.OrderQueryParams) (*dto.OrderLis
 		orderResponses = append(orderResponses, *s.toOrderResponse(&o))
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(params.Limit)))
-
 	return &dto.OrderListResponse{
-		Orders:     orderResponses,
-		Total:      total,
-		Page:       params.Page,
-		Limit:      params.Limit,
-		TotalPages: totalPages,
+		Orders: orderResponses,
+		Meta:   pagination.BuildMeta(total, params.Page, params.Limit),
 	}, nil
 }
 
-// UpdateOrderStatus mengupdate status order
-func (s *orderService) UpdateOrderStatus(userID uint, orderID uint, status string, isAdmin bool) (*dto.OrderResponse, error) {
+// UpdateOrderStatus mengupdate status order sesuai tabel transisi di entity.CanTransition.
+//
+// Semua aktor mengikuti alur normal: PENDING -> PAID/CANCELLED, PAID -> SHIPPED/REFUNDED,
+// SHIPPED -> COMPLETED/REFUNDED, COMPLETED -> REFUNDED. Seller hanya boleh mengirim (SHIPPED).
+// Admin juga boleh PAID -> COMPLETED. Order PAID dibatalkan lewat ForceCancelOrder agar payment-nya
+// ikut di-refund, sehingga PAID -> CANCELLED di sini ditolak dengan ErrPaidOrderCancellation.
+// Transisi lain, mis. COMPLETED -> PENDING, ditolak dengan ErrInvalidStatus.
+func (s *orderService) UpdateOrderStatus(userID uint, orderID uint, req *dto.UpdateOrderStatusRequest, isAdmin bool) (*dto.OrderResponse, error) {
 	order, err := s.orderRepo.FindByIDWithItems(orderID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -229,24 +634,111 @@ func (s *orderService) UpdateOrderStatus(userID uint, orderID uint, status strin
 		return nil, err
 	}
 
-	// User hanya bisa update status tertentu (cancel)
-	// Admin bisa update semua status
-	if !isAdmin && !order.IsOwner(userID) {
-		return nil, ErrUnauthorized
+	// Admin bisa update semua status, pemilik order hanya bisa membatalkan (CANCELLED),
+	// dan seller produk di order hanya bisa mengirim order (SHIPPED).
+	// PAID diset oleh modul payment (MarkAsPaid) atau admin, bukan oleh pembeli.
+	if !isAdmin {
+		switch {
+		case req.Status == entity.OrderStatusCancelled && order.IsOwner(userID):
+		case req.Status == entity.OrderStatusShipped:
+			isSeller, err := s.orderRepo.HasSellerItems(orderID, userID)
+			if err != nil {
+				return nil, err
+			}
+			if !isSeller {
+				return nil, ErrUnauthorized
+			}
+		default:
+			return nil, ErrUnauthorized
+		}
+	}
+
+	fromStatus := order.Status
+	if isAdmin && fromStatus == entity.OrderStatusPaid && req.Status == entity.OrderStatusCancelled {
+		return nil, ErrPaidOrderCancellation
 	}
 
 	// Validate status transition
-	if !order.UpdateStatus(status) {
+	if !entity.CanTransition(fromStatus, req.Status, isAdmin) {
 		return nil, ErrInvalidStatus
 	}
 
-	if err := s.orderRepo.Update(order); err != nil {
+	// Pembatalan memakai alur yang sama dengan CancelOrder: update status bersyarat, lalu
+	// reservasi dilepas atau stok order lama (tanpa reservasi) dikembalikan
+	if req.Status == entity.OrderStatusCancelled {
+		err := database.WithinTransaction(s.db, func(tx *gorm.DB) error {
+			return s.cancelOrderTx(tx, order, &userID, "")
+		})
+		if err == ErrOrderNotCancellable {
+			return nil, ErrInvalidStatus
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.notifyBackInStock(order)
+		s.notifyStatusChanged(order, fromStatus)
+		return s.toOrderResponse(order), nil
+	}
+
+	if req.Status == entity.OrderStatusShipped {
+		if err := applyShipment(order, req); err != nil {
+			return nil, err
+		}
+	}
+
+	notifyLowStock := func() {}
+	err = database.WithinTransaction(s.db, func(tx *gorm.DB) error {
+		// Update bersyarat agar perubahan status bersamaan (mis. pembayaran) tidak tertimpa
+		ok, err := s.transitionStatus(tx, order, req.Status, &userID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvalidStatus
+		}
+
+		if order.Status == entity.OrderStatusShipped {
+			if err := s.orderRepo.WithTx(tx).UpdateShipment(order); err != nil {
+				return err
+			}
+		}
+
+		// Reservasi stok order PENDING menjadi pengurangan stok saat dibayar manual
+		if fromStatus == entity.OrderStatusPending && order.Status == entity.OrderStatusPaid {
+			notifyLowStock, err = s.commitReservedStock(tx, order.ID, &userID)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
+	notifyLowStock()
+	s.notifyStatusChanged(order, fromStatus)
 
 	return s.toOrderResponse(order), nil
 }
 
+// applyShipment mengisi info pengiriman order yang diubah menjadi SHIPPED
+func applyShipment(order *entity.Order, req *dto.UpdateOrderStatusRequest) error {
+	trackingNumber := strings.TrimSpace(req.TrackingNumber)
+	if trackingNumber == "" {
+		return ErrTrackingNumberRequired
+	}
+
+	order.TrackingNumber = trackingNumber
+	order.Carrier = strings.TrimSpace(req.Carrier)
+	order.EstimatedDelivery = nil
+	if req.EstimatedDelivery != "" {
+		date, err := time.Parse(time.DateOnly, req.EstimatedDelivery)
+		if err != nil {
+			return ErrInvalidEstimatedDelivery
+		}
+		order.EstimatedDelivery = &date
+	}
+	return nil
+}
+
 // CancelOrder membatalkan order dan mengembalikan stok
 func (s *orderService) CancelOrder(userID uint, orderID uint) error {
 	order, err := s.orderRepo.FindByIDWithItems(orderID)
@@ -262,12 +754,171 @@ func (s *orderService) CancelOrder(userID uint, orderID uint) error {
 		return ErrUnauthorized
 	}
 
+	return s.cancelOrder(order, &userID)
+}
+
+// cancelOrder membatalkan order PENDING dan mengembalikan stok dalam satu transaction.
+// changedBy nil berarti dibatalkan oleh sistem (mis. expiry worker).
+func (s *orderService) cancelOrder(order *entity.Order, changedBy *uint) error {
 	// Check if order can be cancelled
 	if !order.CanBeCancelled() {
 		return ErrOrderNotCancellable
 	}
 
-	// Start transaction
+	fromStatus := order.Status
+	if err := database.WithinTransaction(s.db, func(tx *gorm.DB) error {
+		return s.cancelOrderTx(tx, order, changedBy, "")
+	}); err != nil {
+		return err
+	}
+	s.notifyBackInStock(order)
+	s.notifyStatusChanged(order, fromStatus)
+	return nil
+}
+
+// ForceCancelTx dipanggil oleh Payment Module saat admin membatalkan paksa order PENDING atau PAID,
+// di dalam transaction yang sama dengan refund payment. Admin dan alasan pembatalan dicatat di history.
+// Fungsi yang dikembalikan mengirim webhook perubahan status dan harus dipanggil setelah tx di-commit.
+func (s *orderService) ForceCancelTx(tx *gorm.DB, orderID uint, adminID uint, reason string) (func(), error) {
+	order, err := s.orderRepo.WithTx(tx).FindByIDWithItems(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	if !order.CanBeForceCancelled() {
+		return nil, ErrOrderNotCancellable
+	}
+
+	fromStatus := order.Status
+	if err := s.cancelOrderTx(tx, order, &adminID, reason); err != nil {
+		return nil, err
+	}
+	return func() {
+		s.notifyBackInStock(order)
+		s.notifyStatusChanged(order, fromStatus)
+	}, nil
+}
+
+// cancelOrderTx mengubah status order menjadi CANCELLED dan mengembalikan stok di dalam tx.
+// Order PENDING melepas reservasi stoknya; order PAID mengembalikan stok item yang belum di-return.
+func (s *orderService) cancelOrderTx(tx *gorm.DB, order *entity.Order, changedBy *uint, reason string) error {
+	// Update status bersyarat dulu agar order yang baru saja dibayar/dibatalkan tidak diproses ulang
+	fromStatus := order.Status
+	ok, err := s.transitionStatusWithReason(tx, order, entity.OrderStatusCancelled, changedBy, reason)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrOrderNotCancellable
+	}
+
+	// Stok fisik order yang sudah dibayar sudah dikurangi saat pembayaran
+	if fromStatus == entity.OrderStatusPaid {
+		return s.restoreUnreturnedStock(tx, order, productEntity.InventoryReasonOrderCancelled, changedBy)
+	}
+
+	// Lepas reservasi stok; order lama tanpa reservasi sudah mengurangi stok fisik saat checkout
+	reserved, err := s.productService.ReleaseReservationsTx(tx, order.ID)
+	if err != nil {
+		return err
+	}
+	if reserved {
+		return nil
+	}
+
+	// Restore stock for each item
+	stockChange := productService.StockChange{
+		Reason:  productEntity.InventoryReasonOrderCancelled,
+		RefType: productEntity.InventoryRefOrder,
+		RefID:   order.ID,
+		ActorID: changedBy,
+	}
+	for _, item := range order.Items {
+		if err := s.restoreItemStock(tx, item, item.Quantity, stockChange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreUnreturnedStock mengembalikan stok item order yang belum di-return (untuk order yang sudah dibayar)
+func (s *orderService) restoreUnreturnedStock(tx *gorm.DB, order *entity.Order, reason string, changedBy *uint) error {
+	stockChange := productService.StockChange{
+		Reason:  reason,
+		RefType: productEntity.InventoryRefOrder,
+		RefID:   order.ID,
+		ActorID: changedBy,
+	}
+	for _, item := range order.Items {
+		remaining := item.Quantity - item.ReturnedQuantity
+		if remaining <= 0 {
+			continue
+		}
+		if err := s.restoreItemStock(tx, item, remaining, stockChange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyBackInStock memberi tahu pelanggan produk di order yang stoknya kembali tersedia.
+// Dipanggil setelah transaction yang mengembalikan stok atau melepas reservasi di-commit.
+func (s *orderService) notifyBackInStock(order *entity.Order) {
+	productIDs := make([]uint, 0, len(order.Items))
+	for _, item := range order.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	s.productService.NotifyBackInStock(productIDs...)
+}
+
+// restoreItemStock mengembalikan stok varian jika item memilih varian, selain itu stok produk
+func (s *orderService) restoreItemStock(tx *gorm.DB, item entity.OrderItem, quantity int, change productService.StockChange) error {
+	if item.VariantID != nil {
+		return s.productService.RestoreVariantStockTx(tx, item.ProductID, *item.VariantID, quantity, change)
+	}
+	return s.productService.RestoreStockTx(tx, item.ProductID, quantity, change)
+}
+
+// ReturnOrderItem mengembalikan sebagian quantity satu item dari order PAID/SHIPPED.
+// Stok, catatan return, dan total outstanding order diperbarui dalam satu transaction.
+func (s *orderService) ReturnOrderItem(userID uint, orderID uint, itemID uint, req *dto.ReturnItemRequest) (*dto.OrderReturnResponse, error) {
+	order, err := s.orderRepo.FindByIDWithItems(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	// Check ownership
+	if !order.IsOwner(userID) {
+		return nil, ErrUnauthorized
+	}
+
+	if !order.CanBeReturned() {
+		return nil, ErrOrderNotReturnable
+	}
+
+	var item *entity.OrderItem
+	for i := range order.Items {
+		if order.Items[i].ID == itemID {
+			item = &order.Items[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, ErrOrderItemNotFound
+	}
+	if req.Quantity > item.Quantity-item.ReturnedQuantity {
+		return nil, ErrReturnQuantity
+	}
+
+	// Nilai return memakai harga item saat checkout; diskon, ongkir, dan pajak tidak diprorata
+	amount := item.Price.Mul(req.Quantity)
+
 	tx := s.db.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -275,23 +926,140 @@ func (s *orderService) CancelOrder(userID uint, orderID uint) error {
 		}
 	}()
 
-	// Restore stock for each item
-	for _, item := range order.Items {
-		if err := s.productService.RestoreStock(item.ProductID, item.Quantity); err != nil {
-			tx.Rollback()
-			return err
+	txRepo := s.orderRepo.WithTx(tx)
+
+	// Update bersyarat agar dua return bersamaan tidak melebihi quantity yang dibeli
+	ok, err := txRepo.IncrementItemReturnedQuantity(item.ID, req.Quantity)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if !ok {
+		tx.Rollback()
+		return nil, ErrReturnQuantity
+	}
+
+	if err := txRepo.AddReturnedAmount(order.ID, amount); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	orderReturn := &entity.OrderReturn{
+		OrderID:     order.ID,
+		OrderItemID: item.ID,
+		UserID:      userID,
+		Quantity:    req.Quantity,
+		Amount:      amount,
+		Reason:      req.Reason,
+	}
+	if err := txRepo.CreateReturn(orderReturn); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := s.restoreItemStock(tx, *item, req.Quantity, productService.StockChange{
+		Reason:  productEntity.InventoryReasonOrderReturn,
+		RefType: productEntity.InventoryRefOrderReturn,
+		RefID:   orderReturn.ID,
+		ActorID: &userID,
+	}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	s.productService.NotifyBackInStock(item.ProductID)
+
+	order.ReturnedAmount = order.ReturnedAmount.Add(amount)
+	return &dto.OrderReturnResponse{
+		ID:               orderReturn.ID,
+		OrderID:          orderReturn.OrderID,
+		OrderItemID:      orderReturn.OrderItemID,
+		Quantity:         orderReturn.Quantity,
+		Amount:           orderReturn.Amount,
+		Currency:         order.Currency,
+		Reason:           orderReturn.Reason,
+		OutstandingTotal: order.OutstandingTotal(),
+		CreatedAt:        orderReturn.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// GetOrderHistory mengambil timeline perubahan status order
+func (s *orderService) GetOrderHistory(userID uint, orderID uint, isAdmin bool) ([]dto.OrderStatusHistoryResponse, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
 		}
+		return nil, err
+	}
+
+	if !isAdmin && !order.IsOwner(userID) {
+		return nil, ErrUnauthorized
 	}
 
-	// Update status to cancelled
-	order.Status = entity.OrderStatusCancelled
+	histories, err := s.orderRepo.FindStatusHistory(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []dto.OrderStatusHistoryResponse{}
+	for _, h := range histories {
+		responses = append(responses, dto.OrderStatusHistoryResponse{
+			FromStatus: h.FromStatus,
+			ToStatus:   h.ToStatus,
+			ChangedBy:  h.ChangedBy,
+			Reason:     h.Reason,
+			CreatedAt:  h.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return responses, nil
+}
+
+// saveStatusChange menyimpan order dan mencatat history dalam transaction yang sama
+// agar timeline tidak pernah berbeda dengan status order sebenarnya
+func (s *orderService) saveStatusChange(tx *gorm.DB, order *entity.Order, fromStatus string, changedBy *uint) error {
 	orderRepoWithTx := s.orderRepo.WithTx(tx)
 	if err := orderRepoWithTx.Update(order); err != nil {
-		tx.Rollback()
 		return err
 	}
+	return orderRepoWithTx.CreateStatusHistory(&entity.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: fromStatus,
+		ToStatus:   order.Status,
+		ChangedBy:  changedBy,
+	})
+}
+
+// transitionStatus mengubah status order secara bersyarat (hanya dari status saat ini)
+// dan mencatat history dalam transaction yang sama
+func (s *orderService) transitionStatus(tx *gorm.DB, order *entity.Order, toStatus string, changedBy *uint) (bool, error) {
+	return s.transitionStatusWithReason(tx, order, toStatus, changedBy, "")
+}
+
+// transitionStatusWithReason sama dengan transitionStatus, dengan alasan perubahan dicatat di history
+func (s *orderService) transitionStatusWithReason(tx *gorm.DB, order *entity.Order, toStatus string, changedBy *uint, reason string) (bool, error) {
+	orderRepoWithTx := s.orderRepo.WithTx(tx)
+	fromStatus := order.Status
+
+	ok, err := orderRepoWithTx.UpdateStatusIf(order.ID, fromStatus, toStatus)
+	if err != nil || !ok {
+		return false, err
+	}
+	order.Status = toStatus
 
-	return tx.Commit().Error
+	if err := orderRepoWithTx.CreateStatusHistory(&entity.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		ChangedBy:  changedBy,
+		Reason:     reason,
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // MarkAsPaid dipanggil oleh Payment Module untuk update status
@@ -305,33 +1073,278 @@ func (s *orderService) MarkAsPaid(orderID uint) error {
 		return ErrInvalidStatus
 	}
 
-	order.Status = entity.OrderStatusPaid
-	return s.orderRepo.Update(order)
+	fromStatus := order.Status
+	tx := s.db.Begin()
+	ok, err := s.transitionStatus(tx, order, entity.OrderStatusPaid, nil)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if !ok {
+		// Order sudah dibatalkan (mis. oleh expiry worker) di antara pengecekan dan update
+		tx.Rollback()
+		return ErrInvalidStatus
+	}
+	notifyLowStock, err := s.commitReservedStock(tx, order.ID, nil)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := s.commitStatusChange(tx, order, fromStatus); err != nil {
+		return err
+	}
+
+	notifyLowStock()
+	s.sendReceipt(order.ID)
+	return nil
+}
+
+// commitReservedStock mengurangi stok fisik dari reservasi order yang baru dibayar.
+// Fungsi yang dikembalikan mengirim notifikasi stok menipis dan harus dipanggil setelah tx di-commit.
+func (s *orderService) commitReservedStock(tx *gorm.DB, orderID uint, changedBy *uint) (func(), error) {
+	notifyLowStock, err := s.productService.CommitReservationsTx(tx, orderID, productService.StockChange{
+		Reason:  productEntity.InventoryReasonCheckout,
+		RefType: productEntity.InventoryRefOrder,
+		RefID:   orderID,
+		ActorID: changedBy,
+	})
+	if err == productService.ErrInsufficientStock {
+		return nil, ErrInsufficientStock
+	}
+	return notifyLowStock, err
+}
+
+// MarkAsRefundedTx dipanggil oleh Payment Module saat payment di-refund, di dalam transaction yang sama
+// dengan update payment. Order yang belum dikirim (PAID) stoknya dikembalikan; barang yang sudah
+// dikirim kembali ke stok lewat alur return item.
+// Fungsi yang dikembalikan mengirim webhook perubahan status dan harus dipanggil setelah tx di-commit.
+func (s *orderService) MarkAsRefundedTx(tx *gorm.DB, orderID uint) (func(), error) {
+	order, err := s.orderRepo.WithTx(tx).FindByIDWithItems(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	fromStatus := order.Status
+	if !order.UpdateStatus(entity.OrderStatusRefunded) {
+		return nil, ErrInvalidStatus
+	}
+
+	if fromStatus == entity.OrderStatusPaid {
+		if err := s.restoreUnreturnedStock(tx, order, productEntity.InventoryReasonOrderRefunded, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.saveStatusChange(tx, order, fromStatus, nil); err != nil {
+		return nil, err
+	}
+	return func() {
+		if fromStatus == entity.OrderStatusPaid {
+			s.notifyBackInStock(order)
+		}
+		s.notifyStatusChanged(order, fromStatus)
+	}, nil
+}
+
+// GetExpiredPendingOrderIDs mengambil ID order PENDING yang dibuat sebelum cutoff
+func (s *orderService) GetExpiredPendingOrderIDs(cutoff time.Time) ([]uint, error) {
+	orders, err := s.orderRepo.FindPendingCreatedBefore(cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(orders))
+	for _, o := range orders {
+		ids = append(ids, o.ID)
+	}
+	return ids, nil
+}
+
+// ExpireOrder membatalkan order PENDING yang tidak dibayar (dipanggil oleh expiry worker)
+func (s *orderService) ExpireOrder(orderID uint) error {
+	order, err := s.orderRepo.FindByIDWithItems(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrOrderNotFound
+		}
+		return err
+	}
+	return s.cancelOrder(order, nil)
+}
+
+// HasCompletedOrderWithProduct dipanggil oleh Review Module untuk validasi pembeli
+func (s *orderService) HasCompletedOrderWithProduct(userID uint, productID uint) (bool, error) {
+	return s.orderRepo.HasCompletedOrderWithProduct(userID, productID)
+}
+
+// GetSellerDashboard mengambil ringkasan penjualan seller dari order COMPLETED dalam rentang tanggal opsional
+func (s *orderService) GetSellerDashboard(sellerID uint, params *dto.SellerDashboardQueryParams) (*dto.SellerDashboardResponse, error) {
+	from, to, err := parseDateRange(params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+
+	revenue, totalOrders, err := s.orderRepo.GetSellerSalesSummary(sellerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	topProducts, err := s.orderRepo.FindTopSellingProducts(sellerID, from, to, sellerTopProductsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryValue, err := s.productService.GetInventoryValue(sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SellerDashboardResponse{
+		From:           params.From,
+		To:             params.To,
+		TotalRevenue:   revenue,
+		Currency:       s.baseCurrency,
+		TotalOrders:    totalOrders,
+		TopProducts:    topProducts,
+		InventoryValue: inventoryValue,
+	}, nil
+}
+
+// CountOrdersByStatus menghitung jumlah order per status. Status tanpa order tetap muncul dengan nilai 0.
+func (s *orderService) CountOrdersByStatus() (map[string]int64, error) {
+	counts, err := s.orderRepo.CountGroupedByStatus()
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range []string{
+		entity.OrderStatusPending,
+		entity.OrderStatusPaid,
+		entity.OrderStatusShipped,
+		entity.OrderStatusCompleted,
+		entity.OrderStatusCancelled,
+		entity.OrderStatusRefunded,
+	} {
+		if _, ok := counts[status]; !ok {
+			counts[status] = 0
+		}
+	}
+	return counts, nil
+}
+
+// GetDailyOrderCounts menghitung jumlah order per hari (YYYY-MM-DD) sejak waktu tertentu
+func (s *orderService) GetDailyOrderCounts(since time.Time) (map[string]int64, error) {
+	return s.orderRepo.CountDailySince(since)
+}
+
+// parseDateRange mengubah tanggal YYYY-MM-DD menjadi batas [from, to+1 hari) agar tanggal "to" inklusif
+func parseDateRange(fromStr string, toStr string) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+	if fromStr != "" {
+		t, err := time.Parse(time.DateOnly, fromStr)
+		if err != nil {
+			return nil, nil, ErrInvalidDateRange
+		}
+		from = &t
+	}
+	if toStr != "" {
+		t, err := time.Parse(time.DateOnly, toStr)
+		if err != nil {
+			return nil, nil, ErrInvalidDateRange
+		}
+		t = t.AddDate(0, 0, 1)
+		to = &t
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, ErrInvalidDateRange
+	}
+	return from, to, nil
 }
 
 // Helper Functions
 
 func (s *orderService) toOrderResponse(o *entity.Order) *dto.OrderResponse {
 	var items []dto.OrderItemResponse
+	itemsTotal := money.Zero
 	for _, item := range o.Items {
+		itemsTotal = itemsTotal.Add(item.Subtotal)
+		// Order lama dibuat sebelum snapshot nama ada, pakai nama produk saat ini
+		productName := item.ProductName
+		if productName == "" {
+			productName = item.CurrentProductName
+		}
 		items = append(items, dto.OrderItemResponse{
-			ID:        item.ID,
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     item.Price,
-			Subtotal:  item.Subtotal,
+			ID:               item.ID,
+			ProductID:        item.ProductID,
+			VariantID:        item.VariantID,
+			ProductName:      productName,
+			ProductSKU:       item.ProductSKU,
+			ProductImageURL:  item.CurrentProductImageURL,
+			Quantity:         item.Quantity,
+			Price:            item.Price,
+			Subtotal:         item.Subtotal,
+			ReturnedQuantity: item.ReturnedQuantity,
 		})
 	}
 
-	return &dto.OrderResponse{
-		ID:              o.ID,
-		UserID:          o.UserID,
-		TotalAmount:     o.TotalAmount,
-		Status:          o.Status,
-		ShippingAddress: o.ShippingAddr,
-		Notes:           o.Notes,
-		Items:           items,
-		CreatedAt:       o.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:       o.UpdatedAt.Format(time.RFC3339),
+	// Order lama dibuat sebelum kolom subtotal ada, hitung ulang dari item
+	subtotal := o.Subtotal
+	if subtotal == money.Zero {
+		subtotal = itemsTotal
+	}
+
+	resp := &dto.OrderResponse{
+		ID:               o.ID,
+		UserID:           o.UserID,
+		GuestEmail:       o.GuestEmail,
+		Currency:         o.Currency,
+		Subtotal:         subtotal,
+		CouponCode:       o.CouponCode,
+		DiscountAmount:   o.DiscountAmount,
+		ShippingFee:      o.ShippingFee,
+		Tax:              o.TaxAmount,
+		Total:            o.TotalAmount,
+		TotalAmount:      o.TotalAmount,
+		ReturnedAmount:   o.ReturnedAmount,
+		OutstandingTotal: o.OutstandingTotal(),
+		Status:           o.Status,
+		ShippingAddress:  o.ShippingAddr,
+		Notes:            o.Notes,
+		TrackingNumber:   o.TrackingNumber,
+		Carrier:          o.Carrier,
+		Items:            items,
+		CreatedAt:        o.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        o.UpdatedAt.Format(time.RFC3339),
+	}
+	if o.EstimatedDelivery != nil {
+		resp.EstimatedDelivery = o.EstimatedDelivery.Format(time.DateOnly)
+	}
+	return resp
+}
+
+// toQuoteResponse memetakan order hasil buildOrder (belum disimpan) ke rincian biaya quote
+func toQuoteResponse(o *entity.Order) *dto.QuoteResponse {
+	items := make([]dto.QuoteItemResponse, 0, len(o.Items))
+	for _, item := range o.Items {
+		items = append(items, dto.QuoteItemResponse{
+			ProductID:   item.ProductID,
+			VariantID:   item.VariantID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Subtotal:    item.Subtotal,
+		})
+	}
+	return &dto.QuoteResponse{
+		Currency:       o.Currency,
+		Items:          items,
+		Subtotal:       o.Subtotal,
+		CouponCode:     o.CouponCode,
+		DiscountAmount: o.DiscountAmount,
+		ShippingFee:    o.ShippingFee,
+		Tax:            o.TaxAmount,
+		Total:          o.TotalAmount,
 	}
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -72,26 +73,55 @@ func TestOrderEntity_UpdateStatus(t *testing.T) {
 func TestOrderEntity_CalculateTotal(t *testing.T) {
 	order := &entity.Order{
 		Items: []entity.OrderItem{
-			{Price: 100, Quantity: 2, Subtotal: 200},
-			{Price: 50, Quantity: 3, Subtotal: 150},
+			{Price: money.FromFloat(100), Quantity: 2, Subtotal: money.FromFloat(200)},
+			{Price: money.FromFloat(50), Quantity: 3, Subtotal: money.FromFloat(150)},
 		},
 	}
 
 	total := order.CalculateTotal()
-	assert.Equal(t, 350.0, total)
-	assert.Equal(t, 350.0, order.TotalAmount)
+	assert.Equal(t, money.FromFloat(350), total)
+	assert.Equal(t, "350.00", order.TotalAmount.String())
+}
+
+func TestOrderEntity_CalculateTotalWithShippingTaxAndDiscount(t *testing.T) {
+	order := &entity.Order{
+		DiscountAmount: money.FromFloat(50),
+		ShippingFee:    money.FromFloat(10),
+		TaxAmount:      money.FromFloat(35),
+		Items: []entity.OrderItem{
+			{Price: money.FromFloat(100), Quantity: 2, Subtotal: money.FromFloat(200)},
+			{Price: money.FromFloat(50), Quantity: 3, Subtotal: money.FromFloat(150)},
+		},
+	}
+
+	total := order.CalculateTotal()
+	assert.Equal(t, money.FromFloat(350), order.Subtotal)
+	assert.Equal(t, money.FromFloat(345), total)
+}
+
+func TestOrderEntity_RefundTransition(t *testing.T) {
+	for status, allowed := range map[string]bool{
+		entity.OrderStatusPending:   false,
+		entity.OrderStatusPaid:      true,
+		entity.OrderStatusShipped:   true,
+		entity.OrderStatusCompleted: true,
+		entity.OrderStatusCancelled: false,
+	} {
+		order := &entity.Order{Status: status}
+		assert.Equal(t, allowed, order.UpdateStatus(entity.OrderStatusRefunded), status)
+	}
 }
 
 // Test OrderItem Entity
 func TestOrderItemEntity_CalculateSubtotal(t *testing.T) {
 	item := &entity.OrderItem{
-		Price:    100.50,
+		Price:    money.FromFloat(100.50),
 		Quantity: 3,
 	}
 
 	subtotal := item.CalculateSubtotal()
-	assert.Equal(t, 301.50, subtotal)
-	assert.Equal(t, 301.50, item.Subtotal)
+	assert.Equal(t, money.FromFloat(301.50), subtotal)
+	assert.Equal(t, "301.50", item.Subtotal.String())
 }
 
 // Test Checkout Request
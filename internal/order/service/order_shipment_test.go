@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateOrderStatus_ShippedRequiresTracking(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	const buyerID, sellerID, otherSellerID = uint(1), uint(7), uint(8)
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, SellerID: sellerID}
+	require.NoError(t, db.Create(product).Error)
+	order := &entity.Order{UserID: buyerID, Status: entity.OrderStatusPaid, ShippingAddr: "Jl. Sudirman No. 1",
+		Items: []entity.OrderItem{{ProductID: product.ID, Quantity: 1, Price: money.FromFloat(1000), Subtotal: money.FromFloat(1000)}}}
+	require.NoError(t, db.Create(order).Error)
+
+	svc := NewOrderService(OrderServiceDeps{DB: db})
+
+	_, err := svc.UpdateOrderStatus(sellerID, order.ID, &dto.UpdateOrderStatusRequest{Status: entity.OrderStatusShipped, TrackingNumber: "  "}, false)
+	assert.ErrorIs(t, err, ErrTrackingNumberRequired)
+
+	// Seller lain dan seller yang mencoba status selain SHIPPED ditolak
+	_, err = svc.UpdateOrderStatus(otherSellerID, order.ID, &dto.UpdateOrderStatusRequest{Status: entity.OrderStatusShipped, TrackingNumber: "JNE123"}, false)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = svc.UpdateOrderStatus(sellerID, order.ID, &dto.UpdateOrderStatusRequest{Status: entity.OrderStatusCompleted}, false)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	result, err := svc.UpdateOrderStatus(sellerID, order.ID, &dto.UpdateOrderStatusRequest{
+		Status:            entity.OrderStatusShipped,
+		TrackingNumber:    "JNE123",
+		Carrier:           "JNE",
+		EstimatedDelivery: "2026-01-31",
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrderStatusShipped, result.Status)
+
+	// Pembeli melihat info pengiriman di detail order
+	found, err := svc.GetOrder(buyerID, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "JNE123", found.TrackingNumber)
+	assert.Equal(t, "JNE", found.Carrier)
+	assert.Equal(t, "2026-01-31", found.EstimatedDelivery)
+}
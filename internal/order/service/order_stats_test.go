@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMyOrderStats_AggregatesOwnOrders(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	latest := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	orders := []entity.Order{
+		{UserID: 1, Status: entity.OrderStatusCompleted, TotalAmount: money.FromFloat(100), CreatedAt: latest.AddDate(0, 0, -5)},
+		{UserID: 1, Status: entity.OrderStatusCompleted, TotalAmount: money.FromFloat(50.5), CreatedAt: latest.AddDate(0, 0, -3)},
+		{UserID: 1, Status: entity.OrderStatusCancelled, TotalAmount: money.FromFloat(999), CreatedAt: latest},
+		{UserID: 1, Status: entity.OrderStatusPaid, TotalAmount: money.FromFloat(20), CreatedAt: latest.AddDate(0, 0, -1)},
+		{UserID: 2, Status: entity.OrderStatusCompleted, TotalAmount: money.FromFloat(300), CreatedAt: latest.AddDate(0, 0, 1)},
+	}
+	require.NoError(t, db.Create(&orders).Error)
+
+	svc := NewOrderService(OrderServiceDeps{DB: db})
+
+	stats, err := svc.GetMyOrderStats(1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), stats.TotalOrders)
+	// Order CANCELLED dihitung per status tapi tidak masuk total belanja
+	assert.Equal(t, money.FromFloat(150.5), stats.TotalSpent)
+	assert.Equal(t, map[string]int64{
+		entity.OrderStatusCompleted: 2,
+		entity.OrderStatusCancelled: 1,
+		entity.OrderStatusPaid:      1,
+	}, stats.CountByStatus)
+	assert.Equal(t, latest.Format(time.RFC3339), stats.LastOrderAt)
+
+	empty, err := svc.GetMyOrderStats(3)
+	require.NoError(t, err)
+	assert.Zero(t, empty.TotalOrders)
+	assert.Equal(t, money.Zero, empty.TotalSpent)
+	assert.Empty(t, empty.LastOrderAt)
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to    string
+		user, admin bool
+	}{
+		// Alur normal berlaku untuk semua aktor
+		{entity.OrderStatusPending, entity.OrderStatusPaid, true, true},
+		{entity.OrderStatusPending, entity.OrderStatusCancelled, true, true},
+		{entity.OrderStatusPaid, entity.OrderStatusShipped, true, true},
+		{entity.OrderStatusPaid, entity.OrderStatusRefunded, true, true},
+		{entity.OrderStatusShipped, entity.OrderStatusCompleted, true, true},
+		{entity.OrderStatusShipped, entity.OrderStatusRefunded, true, true},
+		{entity.OrderStatusCompleted, entity.OrderStatusRefunded, true, true},
+
+		// Transisi tambahan khusus admin
+		{entity.OrderStatusPaid, entity.OrderStatusCompleted, false, true},
+
+		// Ditolak untuk semua aktor
+		{entity.OrderStatusPending, entity.OrderStatusShipped, false, false},
+		{entity.OrderStatusPending, entity.OrderStatusCompleted, false, false},
+		{entity.OrderStatusPending, entity.OrderStatusRefunded, false, false},
+		{entity.OrderStatusPending, entity.OrderStatusPending, false, false},
+		{entity.OrderStatusPaid, entity.OrderStatusCancelled, false, false},
+		{entity.OrderStatusPaid, entity.OrderStatusPending, false, false},
+		{entity.OrderStatusShipped, entity.OrderStatusPending, false, false},
+		{entity.OrderStatusShipped, entity.OrderStatusPaid, false, false},
+		{entity.OrderStatusShipped, entity.OrderStatusCancelled, false, false},
+		{entity.OrderStatusCompleted, entity.OrderStatusPending, false, false},
+		{entity.OrderStatusCompleted, entity.OrderStatusShipped, false, false},
+		{entity.OrderStatusCompleted, entity.OrderStatusCancelled, false, false},
+		{entity.OrderStatusCancelled, entity.OrderStatusPending, false, false},
+		{entity.OrderStatusCancelled, entity.OrderStatusPaid, false, false},
+		{entity.OrderStatusRefunded, entity.OrderStatusPaid, false, false},
+		{entity.OrderStatusRefunded, entity.OrderStatusCompleted, false, false},
+		{entity.OrderStatusPaid, "UNKNOWN", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.user, entity.CanTransition(tt.from, tt.to, false), "non-admin")
+			assert.Equal(t, tt.admin, entity.CanTransition(tt.from, tt.to, true), "admin")
+		})
+	}
+}
+
+func TestUpdateOrderStatus_PaidOrderCancellationRequiresForceCancel(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, SellerID: 7}
+	require.NoError(t, db.Create(product).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	const buyerID, adminID = uint(1), uint(99)
+	order, err := svc.Checkout(buyerID, &dto.CheckoutRequest{
+		Items:           []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 3}},
+		ShippingAddress: "Jl. Sudirman No. 1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.MarkAsPaid(order.ID))
+
+	// Pemilik order tidak boleh membatalkan order yang sudah dibayar
+	_, err = svc.UpdateOrderStatus(buyerID, order.ID, &dto.UpdateOrderStatusRequest{Status: entity.OrderStatusCancelled}, false)
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+
+	// Admin diarahkan ke force-cancel agar payment SUCCESS ikut di-refund
+	_, err = svc.UpdateOrderStatus(adminID, order.ID, &dto.UpdateOrderStatusRequest{Status: entity.OrderStatusCancelled}, true)
+	assert.ErrorIs(t, err, ErrPaidOrderCancellation)
+
+	var reloadedOrder entity.Order
+	require.NoError(t, db.First(&reloadedOrder, order.ID).Error)
+	assert.Equal(t, entity.OrderStatusPaid, reloadedOrder.Status)
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 7, reloaded.Stock)
+
+	result, err := svc.UpdateOrderStatus(adminID, order.ID, &dto.UpdateOrderStatusRequest{Status: entity.OrderStatusCompleted}, true)
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrderStatusCompleted, result.Status)
+
+	// Status akhir tidak bisa dibuka kembali, bahkan oleh admin
+	_, err = svc.UpdateOrderStatus(adminID, order.ID, &dto.UpdateOrderStatusRequest{Status: entity.OrderStatusPending}, true)
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+}
+
+func TestUpdateOrderStatus_CancelRestoresStockOfPreReservationOrder(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	// Order lama tanpa reservasi: stok fisik sudah dikurangi saat checkout
+	const buyerID = uint(1)
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 7, SellerID: 7}
+	require.NoError(t, db.Create(product).Error)
+	order := &entity.Order{UserID: buyerID, Status: entity.OrderStatusPending, ShippingAddr: "Jl. Sudirman No. 1",
+		Items: []entity.OrderItem{{ProductID: product.ID, Quantity: 3, Price: money.FromFloat(1000), Subtotal: money.FromFloat(3000)}}}
+	require.NoError(t, db.Create(order).Error)
+
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productService.NewProductService(productService.ProductServiceDeps{DB: db}),
+		DB:             db,
+	})
+
+	result, err := svc.UpdateOrderStatus(buyerID, order.ID, &dto.UpdateOrderStatusRequest{Status: entity.OrderStatusCancelled}, false)
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrderStatusCancelled, result.Status)
+
+	var reloaded productEntity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 10, reloaded.Stock)
+
+	// Pembatalan kedua tidak mengembalikan stok dua kali
+	_, err = svc.UpdateOrderStatus(buyerID, order.ID, &dto.UpdateOrderStatusRequest{Status: entity.OrderStatusCancelled}, false)
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 10, reloaded.Stock)
+}
+
+func TestUpdateOrderStatus_OwnerCanOnlyCancel(t *testing.T) {
+	const buyerID, sellerID = uint(1), uint(7)
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr error
+	}{
+		{"cancel pending order", entity.OrderStatusPending, entity.OrderStatusCancelled, nil},
+		{"pay own order", entity.OrderStatusPending, entity.OrderStatusPaid, ErrUnauthorized},
+		{"ship own order", entity.OrderStatusPaid, entity.OrderStatusShipped, ErrUnauthorized},
+		{"complete own order", entity.OrderStatusShipped, entity.OrderStatusCompleted, ErrUnauthorized},
+		{"refund own order", entity.OrderStatusPaid, entity.OrderStatusRefunded, ErrUnauthorized},
+		{"cancel paid order", entity.OrderStatusPaid, entity.OrderStatusCancelled, ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupCheckoutDB(t)
+
+			product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, SellerID: sellerID}
+			require.NoError(t, db.Create(product).Error)
+			order := &entity.Order{UserID: buyerID, Status: tt.from, ShippingAddr: "Jl. Sudirman No. 1",
+				Items: []entity.OrderItem{{ProductID: product.ID, Quantity: 1, Price: money.FromFloat(1000), Subtotal: money.FromFloat(1000)}}}
+			require.NoError(t, db.Create(order).Error)
+
+			svc := NewOrderService(OrderServiceDeps{
+				ProductService: productService.NewProductService(productService.ProductServiceDeps{DB: db}),
+				DB:             db,
+			})
+
+			result, err := svc.UpdateOrderStatus(buyerID, order.ID, &dto.UpdateOrderStatusRequest{Status: tt.to, TrackingNumber: "JNE123"}, false)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				var reloaded entity.Order
+				require.NoError(t, db.First(&reloaded, order.ID).Error)
+				assert.Equal(t, tt.from, reloaded.Status)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, result.Status)
+		})
+	}
+}
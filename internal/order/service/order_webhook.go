@@ -0,0 +1,33 @@
+package service
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	webhookDto "github.com/akbarwjyy/go-commerce-api/internal/webhook/dto"
+	webhookEntity "github.com/akbarwjyy/go-commerce-api/internal/webhook/entity"
+	"gorm.io/gorm"
+)
+
+// commitStatusChange meng-commit tx lalu mengirim webhook dan event perubahan status.
+// Keduanya hanya dikirim setelah commit berhasil agar penerima tidak melihat status yang di-rollback.
+func (s *orderService) commitStatusChange(tx *gorm.DB, order *entity.Order, fromStatus string) error {
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	s.notifyStatusChanged(order, fromStatus)
+	return nil
+}
+
+// notifyStatusChanged mengirim event order.status_changed ke webhook yang berlangganan (asynchronous)
+// dan mempublish OrderPaid/OrderCancelled ke event bus
+func (s *orderService) notifyStatusChanged(order *entity.Order, fromStatus string) {
+	s.publishStatusEvent(order, fromStatus)
+
+	s.webhooks.Dispatch(webhookEntity.EventOrderStatusChanged, webhookDto.OrderStatusChangedData{
+		OrderID:     order.ID,
+		UserID:      order.UserID,
+		FromStatus:  fromStatus,
+		ToStatus:    order.Status,
+		TotalAmount: order.TotalAmount,
+		Currency:    order.Currency,
+	})
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+)
+
+// GetProductSalesStats menghitung unit terjual, pendapatan, dan jumlah order satu produk dari order
+// yang sudah dibayar (PAID, SHIPPED, COMPLETED), opsional dalam rentang tanggal.
+// Hanya pemilik produk atau admin yang boleh melihatnya; produk tanpa penjualan menghasilkan nilai 0.
+func (s *orderService) GetProductSalesStats(userID uint, productID uint, isAdmin bool, params *dto.ProductSalesStatsQueryParams) (*dto.ProductSalesStatsResponse, error) {
+	product, err := s.productService.GetProductByID(productID)
+	if err != nil {
+		return nil, ErrProductNotFound
+	}
+	if !isAdmin && product.SellerID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	from, to, err := parseDateRange(params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+
+	sales, err := s.orderRepo.GetProductSales(productID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ProductSalesStatsResponse{
+		ProductID:  productID,
+		From:       params.From,
+		To:         params.To,
+		UnitsSold:  sales.UnitsSold,
+		Revenue:    sales.Revenue,
+		Currency:   product.Currency,
+		OrderCount: sales.OrderCount,
+	}, nil
+}
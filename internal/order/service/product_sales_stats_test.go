@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProductSalesStats_AggregatesPaidOrders(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := newQuoteService(t, db)
+
+	mouse := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(20), Currency: money.DefaultCurrency, Stock: 50, SellerID: 7}
+	other := &productEntity.Product{Name: "Phone", SKU: "PHONE", Price: money.FromFloat(500), Currency: money.DefaultCurrency, Stock: 10, SellerID: 7}
+	require.NoError(t, db.Create(mouse).Error)
+	require.NoError(t, db.Create(other).Error)
+
+	createOrder := func(status string, items ...entity.OrderItem) {
+		total := money.Zero
+		for i := range items {
+			items[i].Subtotal = items[i].Price.Mul(items[i].Quantity)
+			total = total.Add(items[i].Subtotal)
+		}
+		order := &entity.Order{UserID: 1, Status: status, TotalAmount: total, ShippingAddr: "Jl. Sudirman No. 1", Items: items}
+		require.NoError(t, db.Create(order).Error)
+	}
+	createOrder(entity.OrderStatusCompleted,
+		entity.OrderItem{ProductID: mouse.ID, Quantity: 3, Price: mouse.Price},
+		entity.OrderItem{ProductID: other.ID, Quantity: 1, Price: other.Price},
+	)
+	createOrder(entity.OrderStatusPaid, entity.OrderItem{ProductID: mouse.ID, Quantity: 2, Price: money.FromFloat(18)})
+	// Order yang belum dibayar, dibatalkan, atau di-refund tidak dihitung
+	createOrder(entity.OrderStatusPending, entity.OrderItem{ProductID: mouse.ID, Quantity: 1, Price: mouse.Price})
+	createOrder(entity.OrderStatusCancelled, entity.OrderItem{ProductID: mouse.ID, Quantity: 4, Price: mouse.Price})
+	createOrder(entity.OrderStatusRefunded, entity.OrderItem{ProductID: mouse.ID, Quantity: 5, Price: mouse.Price})
+
+	stats, err := svc.GetProductSalesStats(7, mouse.ID, false, &dto.ProductSalesStatsQueryParams{})
+	require.NoError(t, err)
+	assert.Equal(t, mouse.ID, stats.ProductID)
+	assert.Equal(t, int64(5), stats.UnitsSold)
+	assert.Equal(t, money.FromFloat(96), stats.Revenue)
+	assert.Equal(t, money.DefaultCurrency, stats.Currency)
+	assert.Equal(t, int64(2), stats.OrderCount)
+
+	// Rentang tanggal tanpa order menghasilkan nilai 0
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+	stats, err = svc.GetProductSalesStats(7, mouse.ID, false, &dto.ProductSalesStatsQueryParams{From: tomorrow})
+	require.NoError(t, err)
+	assert.Zero(t, stats.UnitsSold)
+	assert.Equal(t, money.Zero, stats.Revenue)
+	assert.Zero(t, stats.OrderCount)
+
+	_, err = svc.GetProductSalesStats(7, mouse.ID, false, &dto.ProductSalesStatsQueryParams{From: "2024-02-01", To: "2024-01-01"})
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+}
+
+func TestGetProductSalesStats_EnforcesOwnershipAndReturnsZerosWithoutSales(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := newQuoteService(t, db)
+
+	product := &productEntity.Product{Name: "Keyboard", SKU: "KEYBOARD", Price: money.FromFloat(50), Currency: money.DefaultCurrency, Stock: 10, SellerID: 7}
+	require.NoError(t, db.Create(product).Error)
+
+	stats, err := svc.GetProductSalesStats(7, product.ID, false, &dto.ProductSalesStatsQueryParams{})
+	require.NoError(t, err)
+	assert.Zero(t, stats.UnitsSold)
+	assert.Equal(t, money.Zero, stats.Revenue)
+	assert.Zero(t, stats.OrderCount)
+
+	_, err = svc.GetProductSalesStats(8, product.ID, false, &dto.ProductSalesStatsQueryParams{})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	// Admin boleh melihat produk seller mana pun
+	_, err = svc.GetProductSalesStats(1, product.ID, true, &dto.ProductSalesStatsQueryParams{})
+	assert.NoError(t, err)
+
+	_, err = svc.GetProductSalesStats(7, product.ID+100, false, &dto.ProductSalesStatsQueryParams{})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestGetProductSalesStats_SubtractsReturnedItems(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := newQuoteService(t, db)
+
+	mouse := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(20), Currency: money.DefaultCurrency, Stock: 50, SellerID: 7}
+	require.NoError(t, db.Create(mouse).Error)
+	item := entity.OrderItem{ProductID: mouse.ID, Quantity: 3, ReturnedQuantity: 1, Price: mouse.Price, Subtotal: mouse.Price.Mul(3)}
+	order := &entity.Order{UserID: 1, Status: entity.OrderStatusCompleted, TotalAmount: item.Subtotal, ShippingAddr: "Jl. Sudirman No. 1", Items: []entity.OrderItem{item}}
+	require.NoError(t, db.Create(order).Error)
+
+	stats, err := svc.GetProductSalesStats(7, mouse.ID, false, &dto.ProductSalesStatsQueryParams{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.UnitsSold)
+	assert.Equal(t, money.FromFloat(40), stats.Revenue)
+	assert.Equal(t, int64(1), stats.OrderCount)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSellerDashboard_AggregatesCompletedOrdersOfSeller(t *testing.T) {
+	db := setupCheckoutDB(t)
+
+	category := &productEntity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(category).Error)
+	laptop := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 10, CategoryID: category.ID, SellerID: 7}
+	mouse := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(20), Stock: 50, CategoryID: category.ID, SellerID: 7}
+	other := &productEntity.Product{Name: "Phone", SKU: "PHONE", Price: money.FromFloat(500), Stock: 10, CategoryID: category.ID, SellerID: 8}
+	require.NoError(t, db.Create(laptop).Error)
+	require.NoError(t, db.Create(mouse).Error)
+	require.NoError(t, db.Create(other).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+	})
+
+	createOrder := func(status string, items ...entity.OrderItem) {
+		total := money.Zero
+		for i := range items {
+			items[i].Subtotal = items[i].Price.Mul(items[i].Quantity)
+			total = total.Add(items[i].Subtotal)
+		}
+		order := &entity.Order{UserID: 1, Status: status, TotalAmount: total, ShippingAddr: "Jl. Sudirman No. 1", Items: items}
+		require.NoError(t, db.Create(order).Error)
+	}
+	createOrder(entity.OrderStatusCompleted,
+		entity.OrderItem{ProductID: laptop.ID, Quantity: 1, Price: laptop.Price},
+		entity.OrderItem{ProductID: mouse.ID, Quantity: 3, Price: mouse.Price},
+		entity.OrderItem{ProductID: other.ID, Quantity: 1, Price: other.Price},
+	)
+	createOrder(entity.OrderStatusCompleted, entity.OrderItem{ProductID: mouse.ID, Quantity: 2, Price: mouse.Price})
+	createOrder(entity.OrderStatusPending, entity.OrderItem{ProductID: laptop.ID, Quantity: 1, Price: laptop.Price})
+
+	result, err := svc.GetSellerDashboard(7, &dto.SellerDashboardQueryParams{})
+	require.NoError(t, err)
+	assert.Equal(t, money.FromFloat(1100), result.TotalRevenue)
+	assert.Equal(t, int64(2), result.TotalOrders)
+	require.Len(t, result.TopProducts, 2)
+	assert.Equal(t, mouse.ID, result.TopProducts[0].ProductID)
+	assert.Equal(t, "Mouse", result.TopProducts[0].ProductName)
+	assert.Equal(t, int64(5), result.TopProducts[0].QuantitySold)
+	assert.Equal(t, money.FromFloat(100), result.TopProducts[0].Revenue)
+	assert.Equal(t, money.FromFloat(10*1000+50*20), result.InventoryValue)
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+	result, err = svc.GetSellerDashboard(7, &dto.SellerDashboardQueryParams{From: tomorrow})
+	require.NoError(t, err)
+	assert.Equal(t, money.Zero, result.TotalRevenue)
+	assert.Empty(t, result.TopProducts)
+
+	_, err = svc.GetSellerDashboard(7, &dto.SellerDashboardQueryParams{From: "2024-02-01", To: "2024-01-01"})
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+}
+
+func TestGetSellerDashboard_SubtractsReturnedItems(t *testing.T) {
+	db := setupCheckoutDB(t)
+	mouse := &productEntity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(20), Stock: 50, SellerID: 7}
+	require.NoError(t, db.Create(mouse).Error)
+	item := entity.OrderItem{ProductID: mouse.ID, Quantity: 3, ReturnedQuantity: 2, Price: mouse.Price, Subtotal: mouse.Price.Mul(3)}
+	order := &entity.Order{UserID: 1, Status: entity.OrderStatusCompleted, TotalAmount: item.Subtotal, ShippingAddr: "Jl. Sudirman No. 1", Items: []entity.OrderItem{item}}
+	require.NoError(t, db.Create(order).Error)
+
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	svc := NewOrderService(OrderServiceDeps{ProductService: productSvc, DB: db})
+
+	result, err := svc.GetSellerDashboard(7, &dto.SellerDashboardQueryParams{})
+	require.NoError(t, err)
+	assert.Equal(t, money.FromFloat(20), result.TotalRevenue)
+	require.Len(t, result.TopProducts, 1)
+	assert.Equal(t, int64(1), result.TopProducts[0].QuantitySold)
+	assert.Equal(t, money.FromFloat(20), result.TopProducts[0].Revenue)
+}
+
+func TestAdminOrderAggregates(t *testing.T) {
+	db := setupCheckoutDB(t)
+	svc := NewOrderService(OrderServiceDeps{DB: db})
+
+	today := time.Now()
+	threeDaysAgo := today.AddDate(0, 0, -3)
+	for _, o := range []entity.Order{
+		{UserID: 1, Status: entity.OrderStatusCompleted, CreatedAt: today},
+		{UserID: 1, Status: entity.OrderStatusPending, CreatedAt: today},
+		{UserID: 2, Status: entity.OrderStatusPending, CreatedAt: threeDaysAgo},
+		{UserID: 2, Status: entity.OrderStatusCancelled, CreatedAt: today.AddDate(0, 0, -40)},
+	} {
+		order := o
+		require.NoError(t, db.Create(&order).Error)
+	}
+
+	byStatus, err := svc.CountOrdersByStatus()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), byStatus[entity.OrderStatusPending])
+	assert.Equal(t, int64(1), byStatus[entity.OrderStatusCompleted])
+	assert.Equal(t, int64(1), byStatus[entity.OrderStatusCancelled])
+	assert.Equal(t, int64(0), byStatus[entity.OrderStatusShipped])
+
+	daily, err := svc.GetDailyOrderCounts(today.AddDate(0, 0, -29))
+	require.NoError(t, err)
+	assert.Len(t, daily, 2)
+	assert.Equal(t, int64(2), daily[today.UTC().Format(time.DateOnly)])
+	assert.Equal(t, int64(1), daily[threeDaysAgo.UTC().Format(time.DateOnly)])
+}
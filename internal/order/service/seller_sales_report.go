@@ -0,0 +1,107 @@
+package service
+
+import (
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+)
+
+// Batas laporan penjualan seller
+const (
+	salesReportDefaultGroupBy = "day"
+	salesReportDefaultDays    = 30  // rentang default jika from tidak diisi
+	salesReportMaxDays        = 366 // rentang maksimum agar query tidak memindai seluruh histori
+)
+
+// GetSellerSalesReport menghitung pendapatan dan jumlah order seller per hari, minggu, atau bulan (UTC).
+// Hanya order yang sudah dibayar yang dihitung; periode tanpa penjualan tetap muncul dengan nilai 0.
+func (s *orderService) GetSellerSalesReport(sellerID uint, params *dto.SellerSalesReportQueryParams) (*dto.SellerSalesReportResponse, error) {
+	groupBy := params.GroupBy
+	if groupBy == "" {
+		groupBy = salesReportDefaultGroupBy
+	}
+	if _, ok := truncateToPeriod(time.Now(), groupBy); !ok {
+		return nil, ErrInvalidGroupBy
+	}
+
+	from, to, err := parseDateRange(params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+	if to == nil {
+		tomorrow := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+		to = &tomorrow
+	}
+	if from == nil {
+		start := to.AddDate(0, 0, -salesReportDefaultDays)
+		from = &start
+	}
+	if !from.Before(*to) {
+		return nil, ErrInvalidDateRange
+	}
+	if to.Sub(*from) > salesReportMaxDays*24*time.Hour {
+		return nil, ErrDateRangeTooLarge
+	}
+
+	rows, err := s.orderRepo.GetSellerSalesByPeriod(sellerID, groupBy, *from, *to)
+	if err != nil {
+		return nil, err
+	}
+	byPeriod := make(map[string]dto.SalesPeriodRow, len(rows))
+	for _, row := range rows {
+		byPeriod[row.Period.UTC().Format(time.DateOnly)] = row
+	}
+
+	result := &dto.SellerSalesReportResponse{
+		GroupBy:      groupBy,
+		From:         from.Format(time.DateOnly),
+		To:           to.AddDate(0, 0, -1).Format(time.DateOnly),
+		TotalRevenue: money.Zero,
+		Currency:     s.baseCurrency,
+		Buckets:      []dto.SalesReportBucket{},
+	}
+	period, _ := truncateToPeriod(*from, groupBy)
+	for period.Before(*to) {
+		key := period.Format(time.DateOnly)
+		row := byPeriod[key]
+		result.Buckets = append(result.Buckets, dto.SalesReportBucket{
+			Period:     key,
+			Revenue:    row.Revenue,
+			OrderCount: row.OrderCount,
+		})
+		result.TotalRevenue = result.TotalRevenue.Add(row.Revenue)
+		result.TotalOrders += row.OrderCount
+		period = nextPeriod(period, groupBy)
+	}
+	return result, nil
+}
+
+// truncateToPeriod membulatkan waktu ke awal periodenya (UTC), sama seperti date_trunc PostgreSQL.
+// Minggu dimulai hari Senin. ok false jika groupBy tidak dikenal.
+func truncateToPeriod(t time.Time, groupBy string) (time.Time, bool) {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch groupBy {
+	case "day":
+		return day, true
+	case "week":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)), true
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// nextPeriod mengembalikan awal periode berikutnya
+func nextPeriod(period time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case "week":
+		return period.AddDate(0, 0, 7)
+	case "month":
+		return period.AddDate(0, 1, 0)
+	default:
+		return period.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// salesReportRepository mengembalikan agregat tetap karena date_trunc hanya tersedia di PostgreSQL
+type salesReportRepository struct {
+	repository.OrderRepository
+	rows    []dto.SalesPeriodRow
+	groupBy string
+	from    time.Time
+	to      time.Time
+}
+
+func (r *salesReportRepository) GetSellerSalesByPeriod(sellerID uint, groupBy string, from time.Time, to time.Time) ([]dto.SalesPeriodRow, error) {
+	r.groupBy, r.from, r.to = groupBy, from, to
+	return r.rows, nil
+}
+
+func TestGetSellerSalesReport_FillsEmptyDays(t *testing.T) {
+	repo := &salesReportRepository{rows: []dto.SalesPeriodRow{
+		{Period: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Revenue: money.FromFloat(150), OrderCount: 2},
+		{Period: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), Revenue: money.FromFloat(50), OrderCount: 1},
+	}}
+	svc := NewOrderService(OrderServiceDeps{OrderRepo: repo})
+
+	result, err := svc.GetSellerSalesReport(7, &dto.SellerSalesReportQueryParams{From: "2024-01-01", To: "2024-01-05"})
+	require.NoError(t, err)
+	assert.Equal(t, "day", repo.groupBy)
+	assert.Equal(t, time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), repo.to)
+
+	assert.Equal(t, "2024-01-01", result.From)
+	assert.Equal(t, "2024-01-05", result.To)
+	assert.Equal(t, money.FromFloat(200), result.TotalRevenue)
+	assert.Equal(t, int64(3), result.TotalOrders)
+	require.Len(t, result.Buckets, 5)
+	assert.Equal(t, dto.SalesReportBucket{Period: "2024-01-01", Revenue: money.Zero}, result.Buckets[0])
+	assert.Equal(t, dto.SalesReportBucket{Period: "2024-01-02", Revenue: money.FromFloat(150), OrderCount: 2}, result.Buckets[1])
+	assert.Equal(t, money.Zero, result.Buckets[2].Revenue)
+	assert.Equal(t, int64(1), result.Buckets[3].OrderCount)
+}
+
+func TestGetSellerSalesReport_WeekAndMonthBuckets(t *testing.T) {
+	repo := &salesReportRepository{}
+	svc := NewOrderService(OrderServiceDeps{OrderRepo: repo})
+
+	// 2024-01-03 adalah hari Rabu, minggu pertama dimulai Senin 2024-01-01
+	result, err := svc.GetSellerSalesReport(7, &dto.SellerSalesReportQueryParams{GroupBy: "week", From: "2024-01-03", To: "2024-01-20"})
+	require.NoError(t, err)
+	var periods []string
+	for _, bucket := range result.Buckets {
+		periods = append(periods, bucket.Period)
+	}
+	assert.Equal(t, []string{"2024-01-01", "2024-01-08", "2024-01-15"}, periods)
+
+	result, err = svc.GetSellerSalesReport(7, &dto.SellerSalesReportQueryParams{GroupBy: "month", From: "2024-01-15", To: "2024-03-01"})
+	require.NoError(t, err)
+	periods = nil
+	for _, bucket := range result.Buckets {
+		periods = append(periods, bucket.Period)
+	}
+	assert.Equal(t, []string{"2024-01-01", "2024-02-01", "2024-03-01"}, periods)
+}
+
+func TestGetSellerSalesReport_ValidatesParams(t *testing.T) {
+	repo := &salesReportRepository{}
+	svc := NewOrderService(OrderServiceDeps{OrderRepo: repo})
+
+	_, err := svc.GetSellerSalesReport(7, &dto.SellerSalesReportQueryParams{GroupBy: "year"})
+	assert.ErrorIs(t, err, ErrInvalidGroupBy)
+
+	_, err = svc.GetSellerSalesReport(7, &dto.SellerSalesReportQueryParams{From: "2023-01-01", To: "2024-06-30"})
+	assert.ErrorIs(t, err, ErrDateRangeTooLarge)
+
+	_, err = svc.GetSellerSalesReport(7, &dto.SellerSalesReportQueryParams{From: "2024-02-01", To: "2024-01-01"})
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+
+	// Tanpa rentang: 30 hari terakhir sampai hari ini
+	result, err := svc.GetSellerSalesReport(7, &dto.SellerSalesReportQueryParams{})
+	require.NoError(t, err)
+	assert.Len(t, result.Buckets, salesReportDefaultDays)
+	assert.Equal(t, time.Now().UTC().Format(time.DateOnly), result.To)
+}
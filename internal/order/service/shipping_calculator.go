@@ -0,0 +1,27 @@
+package service
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+)
+
+// ShippingCalculator menghitung ongkos kirim sebuah order.
+// Implementasi lain (per berat, per zona, API kurir) cukup memenuhi interface ini.
+type ShippingCalculator interface {
+	Calculate(items []entity.OrderItem, shippingAddress string) (money.Money, error)
+}
+
+// flatRateShipping mengenakan ongkos kirim yang sama untuk setiap order
+type flatRateShipping struct {
+	fee money.Money
+}
+
+// NewFlatRateShipping membuat ShippingCalculator dengan tarif flat
+func NewFlatRateShipping(fee money.Money) ShippingCalculator {
+	return &flatRateShipping{fee: fee}
+}
+
+// Calculate mengembalikan tarif flat tanpa melihat isi order
+func (c *flatRateShipping) Calculate(items []entity.OrderItem, shippingAddress string) (money.Money, error) {
+	return c.fee, nil
+}
@@ -1,5 +1,11 @@
 package dto
 
+import (
+	orderDto "github.com/akbarwjyy/go-commerce-api/internal/order/dto"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+)
+
 // CreatePaymentRequest untuk request membuat payment
 type CreatePaymentRequest struct {
 	OrderID uint   `json:"order_id" binding:"required"`
@@ -8,38 +14,101 @@ type CreatePaymentRequest struct {
 
 // PaymentResponse untuk response data payment
 type PaymentResponse struct {
-	ID            uint    `json:"id"`
-	OrderID       uint    `json:"order_id"`
-	UserID        uint    `json:"user_id"`
-	Amount        float64 `json:"amount"`
-	Method        string  `json:"method"`
-	Status        string  `json:"status"`
-	TransactionID string  `json:"transaction_id"`
-	PaidAt        string  `json:"paid_at,omitempty"`
-	FailedReason  string  `json:"failed_reason,omitempty"`
-	CreatedAt     string  `json:"created_at"`
+	ID            uint        `json:"id"`
+	OrderID       uint        `json:"order_id"`
+	UserID        uint        `json:"user_id"`
+	Amount        money.Money `json:"amount" swaggertype:"string" example:"399.98"`
+	Currency      string      `json:"currency" example:"IDR"`
+	Method        string      `json:"method"`
+	Status        string      `json:"status"`
+	TransactionID string      `json:"transaction_id"`
+	PaidAt        string      `json:"paid_at,omitempty"`
+	FailedReason  string      `json:"failed_reason,omitempty"`
+	ExpiresAt     string      `json:"expires_at,omitempty"`
+	RefundedAt    string      `json:"refunded_at,omitempty"`
+	RefundReason  string      `json:"refund_reason,omitempty"`
+	CreatedAt     string      `json:"created_at"`
+}
+
+// PaymentDetailResponse untuk response payment beserta order-nya (pencarian admin)
+type PaymentDetailResponse struct {
+	PaymentResponse
+	Order *orderDto.OrderResponse `json:"order,omitempty"`
+}
+
+// PaymentStatusResponse untuk response status ringkas payment (polling)
+type PaymentStatusResponse struct {
+	Status        string `json:"status"`
+	TransactionID string `json:"transaction_id"`
+	PaidAt        string `json:"paid_at,omitempty"`
+}
+
+// PaymentStatusQuery untuk query status payment; Wait=true menahan response sampai status final
+type PaymentStatusQuery struct {
+	Wait bool `form:"wait"`
+}
+
+// RefundPaymentRequest untuk request refund payment (Admin)
+type RefundPaymentRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+// ForceCancelOrderRequest untuk request pembatalan paksa order (Admin)
+type ForceCancelOrderRequest struct {
+	Reason string `json:"reason" binding:"required,max=255" example:"Item out of stock at warehouse"`
+}
+
+// ForceCancelOrderResponse untuk response pembatalan paksa order.
+// RefundedPayment diisi jika order sudah dibayar dan payment-nya di-refund.
+type ForceCancelOrderResponse struct {
+	OrderID         uint             `json:"order_id"`
+	Status          string           `json:"status"`
+	CancelledBy     uint             `json:"cancelled_by"`
+	Reason          string           `json:"reason"`
+	RefundedPayment *PaymentResponse `json:"refunded_payment,omitempty"`
 }
 
 // PaymentListResponse untuk response list payment
 type PaymentListResponse struct {
-	Payments   []PaymentResponse `json:"payments"`
-	Total      int64             `json:"total"`
-	Page       int               `json:"page"`
-	Limit      int               `json:"limit"`
-	TotalPages int               `json:"total_pages"`
+	Payments []PaymentResponse `json:"payments"`
+	pagination.Meta
 }
 
 // PaymentQueryParams untuk filter dan pagination
 type PaymentQueryParams struct {
 	Page    int    `form:"page,default=1"`
-	Limit   int    `form:"limit,default=10"`
+	Limit   int    `form:"limit"`
 	Status  string `form:"status"`
 	OrderID uint   `form:"order_id"`
+	UserID  uint   `form:"user_id"` // hanya dipakai list/pencarian admin
+	Sort    string `form:"sort"`    // nilai di luar PaymentSort* diabaikan (default terbaru dulu)
 }
 
+// PaymentSearchQuery untuk pencarian payment oleh admin.
+// TransactionID mencari satu payment; jika kosong, UserID dan/atau OrderID memfilter list.
+type PaymentSearchQuery struct {
+	TransactionID string `form:"transaction_id"`
+	PaymentQueryParams
+}
+
+// Sort options untuk list payment
+const (
+	PaymentSortCreatedAtAsc  = "created_at_asc"
+	PaymentSortCreatedAtDesc = "created_at_desc"
+	PaymentSortAmountAsc     = "amount_asc"
+	PaymentSortAmountDesc    = "amount_desc"
+)
+
 // PaymentCallbackRequest untuk simulasi callback dari payment gateway
 type PaymentCallbackRequest struct {
 	TransactionID string `json:"transaction_id" binding:"required"`
 	Status        string `json:"status" binding:"required,oneof=SUCCESS FAILED"`
 	FailedReason  string `json:"failed_reason,omitempty"`
 }
+
+// PaymentWebhookPayload payload notifikasi yang dikirim payment gateway ke webhook
+type PaymentWebhookPayload struct {
+	TransactionID string `json:"transaction_id" binding:"required"`
+	Status        string `json:"status" binding:"required,oneof=SUCCESS FAILED"`
+	FailedReason  string `json:"failed_reason,omitempty"`
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"time"
 
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
 	"gorm.io/gorm"
 )
 
@@ -12,6 +13,7 @@ const (
 	PaymentStatusProcessing = "PROCESSING"
 	PaymentStatusSuccess    = "SUCCESS"
 	PaymentStatusFailed     = "FAILED"
+	PaymentStatusRefunded   = "REFUNDED"
 )
 
 // Payment method constants
@@ -26,12 +28,16 @@ type Payment struct {
 	ID            uint           `gorm:"primaryKey" json:"id"`
 	OrderID       uint           `gorm:"index;not null" json:"order_id"`
 	UserID        uint           `gorm:"index;not null" json:"user_id"`
-	Amount        float64        `gorm:"type:decimal(12,2);not null" json:"amount"`
+	Amount        money.Money    `gorm:"type:bigint;not null" json:"amount"`
+	Currency      string         `gorm:"size:3" json:"currency"` // ISO 4217, mengikuti order
 	Method        string         `gorm:"size:50;not null" json:"method"`
 	Status        string         `gorm:"size:20;default:PENDING" json:"status"`
 	TransactionID string         `gorm:"size:100;uniqueIndex" json:"transaction_id"`
 	PaidAt        *time.Time     `json:"paid_at,omitempty"`
 	FailedReason  string         `gorm:"size:255" json:"failed_reason,omitempty"`
+	ExpiresAt     *time.Time     `gorm:"index" json:"expires_at,omitempty"`
+	RefundedAt    *time.Time     `json:"refunded_at,omitempty"`
+	RefundReason  string         `gorm:"size:255" json:"refund_reason,omitempty"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
@@ -62,6 +68,26 @@ func (p *Payment) IsFailed() bool {
 	return p.Status == PaymentStatusFailed
 }
 
+// IsRefunded mengecek apakah payment sudah di-refund
+func (p *Payment) IsRefunded() bool {
+	return p.Status == PaymentStatusRefunded
+}
+
+// IsOpen mengecek apakah payment belum difinalisasi (PENDING/PROCESSING)
+func (p *Payment) IsOpen() bool {
+	return IsOpenStatus(p.Status)
+}
+
+// IsOpenStatus mengecek apakah status payment belum final (PENDING/PROCESSING)
+func IsOpenStatus(status string) bool {
+	return status == PaymentStatusPending || status == PaymentStatusProcessing
+}
+
+// IsExpired mengecek apakah payment sudah melewati batas waktu pembayaran
+func (p *Payment) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && now.After(*p.ExpiresAt)
+}
+
 // MarkAsProcessing mengubah status menjadi processing
 func (p *Payment) MarkAsProcessing() {
 	p.Status = PaymentStatusProcessing
@@ -80,6 +106,14 @@ func (p *Payment) MarkAsFailed(reason string) {
 	p.FailedReason = reason
 }
 
+// MarkAsRefunded mengubah status menjadi refunded
+func (p *Payment) MarkAsRefunded(reason string) {
+	p.Status = PaymentStatusRefunded
+	p.RefundReason = reason
+	now := time.Now()
+	p.RefundedAt = &now
+}
+
 // IsValidMethod memvalidasi method payment
 func IsValidMethod(method string) bool {
 	return method == PaymentMethodBankTransfer ||
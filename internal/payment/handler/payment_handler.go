@@ -1,22 +1,39 @@
 package handler
 
 import (
+	"context"
+	"net/http"
 	"strconv"
+	"time"
 
+	authEntity "github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
 	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
 	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/payment/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
+// WebhookSignatureHeader header berisi signature HMAC-SHA256 (hex) dari raw body webhook
+const WebhookSignatureHeader = "X-Signature"
+
 // PaymentHandler menangani HTTP request untuk payment
 type PaymentHandler struct {
 	paymentService service.PaymentService
+	webhookSecret  string
+	statusMaxWait  time.Duration // batas long-polling status payment
+	streamMaxLife  time.Duration // umur maksimum koneksi SSE status payment
 }
 
 // NewPaymentHandler membuat instance baru PaymentHandler
-func NewPaymentHandler(paymentService service.PaymentService) *PaymentHandler {
-	return &PaymentHandler{paymentService: paymentService}
+func NewPaymentHandler(paymentService service.PaymentService, webhookSecret string, statusMaxWait time.Duration, streamMaxLife time.Duration) *PaymentHandler {
+	return &PaymentHandler{
+		paymentService: paymentService,
+		webhookSecret:  webhookSecret,
+		statusMaxWait:  statusMaxWait,
+		streamMaxLife:  streamMaxLife,
+	}
 }
 
 // CreatePayment godoc
@@ -26,24 +43,33 @@ func NewPaymentHandler(paymentService service.PaymentService) *PaymentHandler {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        Idempotency-Key header string false "Unique key to safely retry the request"
 // @Param        request body dto.CreatePaymentRequest true "Create payment request"
 // @Success      201 {object} response.APIResponse{data=dto.PaymentResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      401 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
 // @Router       /payments [post]
 func (h *PaymentHandler) CreatePayment(ctx *gin.Context) {
 	userID, _ := ctx.Get("userID")
 
 	var req dto.CreatePaymentRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+		response.BindError(ctx, err)
 		return
 	}
 
-	result, err := h.paymentService.CreatePayment(userID.(uint), &req)
+	idempotencyKey := ctx.GetHeader("Idempotency-Key")
+
+	result, err := h.paymentService.CreatePayment(ctx.Request.Context(), userID.(uint), &req, idempotencyKey)
 	if err != nil {
 		switch err {
+		case service.ErrIdempotencyInProgress:
+			response.Error(ctx, http.StatusConflict, "A request with this idempotency key is still being processed", nil)
+		case service.ErrIdempotencyKeyMismatch:
+			response.Error(ctx, http.StatusUnprocessableEntity, "Idempotency key was already used for a different order", nil)
 		case service.ErrOrderNotFound:
 			response.NotFound(ctx, "Order not found")
 		case service.ErrOrderNotPending:
@@ -99,6 +125,58 @@ func (h *PaymentHandler) GetPayment(ctx *gin.Context) {
 	response.OK(ctx, "Payment retrieved successfully", result)
 }
 
+// GetPaymentStatus godoc
+// @Summary      Get payment status
+// @Description  Get only the status, transaction ID and paid time of a payment. With wait=true, the request is held until the payment is final (SUCCESS/FAILED/REFUNDED) or the server's maximum wait passes, then the latest status is returned.
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Payment ID"
+// @Param        wait query bool false "Wait for a final status (long-polling)"
+// @Success      200 {object} response.APIResponse{data=dto.PaymentStatusResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /payments/{id}/status [get]
+func (h *PaymentHandler) GetPaymentStatus(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid payment ID", nil)
+		return
+	}
+
+	var query dto.PaymentStatusQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	var wait time.Duration
+	if query.Wait {
+		wait = h.statusMaxWait
+	}
+
+	result, err := h.paymentService.GetPaymentStatus(ctx.Request.Context(), userID.(uint), uint(id), wait)
+	if err != nil {
+		switch err {
+		case service.ErrPaymentNotFound:
+			response.NotFound(ctx, "Payment not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to view this payment")
+		case context.Canceled:
+			// Client sudah memutus koneksi saat menunggu, tidak ada response yang perlu dikirim
+		default:
+			response.InternalServerError(ctx, "Failed to get payment status", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Payment status retrieved successfully", result)
+}
+
 // GetMyPayments godoc
 // @Summary      Get my payments
 // @Description  Get payments belonging to the current user
@@ -108,7 +186,8 @@ func (h *PaymentHandler) GetPayment(ctx *gin.Context) {
 // @Security     BearerAuth
 // @Param        page query int false "Page number" default(1)
 // @Param        limit query int false "Items per page" default(10)
-// @Param        status query string false "Filter by status" Enums(PENDING, PROCESSING, SUCCESS, FAILED)
+// @Param        status query string false "Filter by status" Enums(PENDING, PROCESSING, SUCCESS, FAILED, REFUNDED)
+// @Param        sort query string false "Sort order (default created_at_desc)" Enums(created_at_asc, created_at_desc, amount_asc, amount_desc)
 // @Success      200 {object} response.APIResponse{data=dto.PaymentListResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      401 {object} response.APIResponse
@@ -140,7 +219,10 @@ func (h *PaymentHandler) GetMyPayments(ctx *gin.Context) {
 // @Security     BearerAuth
 // @Param        page query int false "Page number" default(1)
 // @Param        limit query int false "Items per page" default(10)
-// @Param        status query string false "Filter by status" Enums(PENDING, PROCESSING, SUCCESS, FAILED)
+// @Param        status query string false "Filter by status" Enums(PENDING, PROCESSING, SUCCESS, FAILED, REFUNDED)
+// @Param        user_id query int false "Filter by user ID"
+// @Param        order_id query int false "Filter by order ID"
+// @Param        sort query string false "Sort order (default created_at_desc)" Enums(created_at_asc, created_at_desc, amount_asc, amount_desc)
 // @Success      200 {object} response.APIResponse{data=dto.PaymentListResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
@@ -161,6 +243,208 @@ func (h *PaymentHandler) GetAllPayments(ctx *gin.Context) {
 	response.OK(ctx, "Payments retrieved successfully", result)
 }
 
+// SearchPayments godoc
+// @Summary      Search payments (Admin)
+// @Description  Find a payment by its gateway transaction ID, returned with its order. Without transaction_id, user_id and/or order_id filter a paginated payment list (data is then a PaymentListResponse)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        transaction_id query string false "Gateway transaction ID"
+// @Param        user_id query int false "Filter by user ID"
+// @Param        order_id query int false "Filter by order ID"
+// @Param        status query string false "Filter by status" Enums(PENDING, PROCESSING, SUCCESS, FAILED, REFUNDED)
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Param        sort query string false "Sort order (default created_at_desc)" Enums(created_at_asc, created_at_desc, amount_asc, amount_desc)
+// @Success      200 {object} response.APIResponse{data=dto.PaymentDetailResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /admin/payments/search [get]
+func (h *PaymentHandler) SearchPayments(ctx *gin.Context) {
+	var query dto.PaymentSearchQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if query.TransactionID != "" {
+		result, err := h.paymentService.GetPaymentByTransactionID(query.TransactionID)
+		if err != nil {
+			switch err {
+			case service.ErrPaymentNotFound:
+				response.NotFound(ctx, "Payment not found")
+			default:
+				response.InternalServerError(ctx, "Failed to search payments", err.Error())
+			}
+			return
+		}
+		response.OK(ctx, "Payment retrieved successfully", result)
+		return
+	}
+
+	if query.UserID == 0 && query.OrderID == 0 {
+		response.BadRequest(ctx, "transaction_id, user_id or order_id is required", nil)
+		return
+	}
+
+	result, err := h.paymentService.GetAllPayments(&query.PaymentQueryParams)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to search payments", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Payments retrieved successfully", result)
+}
+
+// StreamPaymentStatus godoc
+// @Summary      Stream payment status (SSE)
+// @Description  Server-sent events stream of a payment's status. A "status" event with the current status is sent immediately, then one per change (PROCESSING, SUCCESS, FAILED, REFUNDED). The stream closes once the status is final or after the server's maximum stream lifetime. EventSource clients can authenticate with the access_token query parameter.
+// @Tags         Payments
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        id path int true "Payment ID"
+// @Param        access_token query string false "Access token, used only when the Authorization header is absent"
+// @Success      200 {object} dto.PaymentStatusResponse "Sent as the data of each status event"
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /payments/{id}/stream [get]
+func (h *PaymentHandler) StreamPaymentStatus(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid payment ID", nil)
+		return
+	}
+
+	// Stream yang ditinggalkan client tetap ditutup setelah umur maksimum
+	streamCtx, cancel := context.WithTimeout(ctx.Request.Context(), h.streamMaxLife)
+	defer cancel()
+
+	started := false
+	err = h.paymentService.StreamPaymentStatus(streamCtx, userID.(uint), uint(id), func(status *dto.PaymentStatusResponse) error {
+		if !started {
+			ctx.Header("Content-Type", "text/event-stream")
+			ctx.Header("Cache-Control", "no-cache")
+			ctx.Header("Connection", "keep-alive")
+			ctx.Header("X-Accel-Buffering", "no") // matikan buffering reverse proxy (nginx)
+			ctx.Status(http.StatusOK)
+			started = true
+		}
+		ctx.SSEvent("status", status)
+		ctx.Writer.Flush()
+		return ctx.Request.Context().Err()
+	})
+	if err == nil || started {
+		// Setelah stream dimulai, client putus atau umur stream habis cukup menutup koneksi
+		return
+	}
+
+	switch err {
+	case service.ErrPaymentNotFound:
+		response.NotFound(ctx, "Payment not found")
+	case service.ErrUnauthorized:
+		response.Forbidden(ctx, "You are not authorized to view this payment")
+	case context.Canceled:
+		// Client sudah memutus koneksi, tidak ada response yang perlu dikirim
+	default:
+		response.InternalServerError(ctx, "Failed to stream payment status", err.Error())
+	}
+}
+
+// RefundPayment godoc
+// @Summary      Refund payment (Admin)
+// @Description  Refund a SUCCESS payment and move its order to REFUNDED. Stock is restored if the order has not shipped yet
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Payment ID"
+// @Param        request body dto.RefundPaymentRequest true "Refund reason"
+// @Success      200 {object} response.APIResponse{data=dto.PaymentResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /admin/payments/{id}/refund [post]
+func (h *PaymentHandler) RefundPayment(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid payment ID", nil)
+		return
+	}
+
+	var req dto.RefundPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.paymentService.RefundPayment(uint(id), req.Reason)
+	if err != nil {
+		switch err {
+		case service.ErrPaymentNotFound:
+			response.NotFound(ctx, "Payment not found")
+		case service.ErrPaymentNotRefundable, service.ErrOrderNotRefundable:
+			response.BadRequest(ctx, err.Error(), nil)
+		default:
+			response.InternalServerError(ctx, "Failed to refund payment", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Payment refunded successfully", result)
+}
+
+// ForceCancelOrder godoc
+// @Summary      Force-cancel order (Admin)
+// @Description  Cancel any PENDING or PAID order regardless of owner. Stock is restored and a SUCCESS payment is refunded in the same transaction. The admin and reason are recorded in the order status history
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Order ID"
+// @Param        request body dto.ForceCancelOrderRequest true "Cancellation reason"
+// @Success      200 {object} response.APIResponse{data=dto.ForceCancelOrderResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /admin/orders/{id}/cancel [post]
+func (h *PaymentHandler) ForceCancelOrder(ctx *gin.Context) {
+	adminID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid order ID", nil)
+		return
+	}
+
+	var req dto.ForceCancelOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.paymentService.ForceCancelOrder(adminID.(uint), uint(id), req.Reason)
+	if err != nil {
+		switch err {
+		case service.ErrOrderNotFound:
+			response.NotFound(ctx, "Order not found")
+		case service.ErrOrderNotCancellable:
+			response.BadRequest(ctx, err.Error(), nil)
+		default:
+			response.InternalServerError(ctx, "Failed to cancel order", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Order cancelled successfully", result)
+}
+
 // GetPaymentByOrder godoc
 // @Summary      Get payment by order ID
 // @Description  Get the payment associated with an order
@@ -171,22 +455,31 @@ func (h *PaymentHandler) GetAllPayments(ctx *gin.Context) {
 // @Param        id path int true "Order ID"
 // @Success      200 {object} response.APIResponse{data=dto.PaymentResponse}
 // @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
 // @Router       /orders/{id}/payment [get]
 func (h *PaymentHandler) GetPaymentByOrder(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+	userRole, _ := ctx.Get("userRole")
+
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		response.BadRequest(ctx, "Invalid order ID", nil)
 		return
 	}
 
-	result, err := h.paymentService.GetPaymentByOrderID(uint(id))
+	isAdmin := userRole.(string) == authEntity.RoleAdmin
+
+	result, err := h.paymentService.GetPaymentByOrderID(userID.(uint), uint(id), isAdmin)
 	if err != nil {
-		if err == service.ErrPaymentNotFound {
+		switch err {
+		case service.ErrPaymentNotFound:
 			response.NotFound(ctx, "Payment not found for this order")
-			return
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to view this payment")
+		default:
+			response.InternalServerError(ctx, "Failed to get payment", err.Error())
 		}
-		response.InternalServerError(ctx, "Failed to get payment", err.Error())
 		return
 	}
 
@@ -195,7 +488,7 @@ func (h *PaymentHandler) GetPaymentByOrder(ctx *gin.Context) {
 
 // PaymentCallback godoc
 // @Summary      Payment callback (Testing)
-// @Description  Manual payment callback for testing purposes
+// @Description  Manual payment callback for testing purposes. Admin only and not available in production; payment gateways use /webhooks/payment
 // @Tags         Payments
 // @Accept       json
 // @Produce      json
@@ -203,12 +496,14 @@ func (h *PaymentHandler) GetPaymentByOrder(ctx *gin.Context) {
 // @Param        request body dto.PaymentCallbackRequest true "Payment callback request"
 // @Success      200 {object} response.APIResponse
 // @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
 // @Router       /payments/callback [post]
 func (h *PaymentHandler) PaymentCallback(ctx *gin.Context) {
 	var req dto.PaymentCallbackRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+		response.BindError(ctx, err)
 		return
 	}
 
@@ -218,6 +513,8 @@ func (h *PaymentHandler) PaymentCallback(ctx *gin.Context) {
 			response.NotFound(ctx, "Payment not found")
 		case service.ErrPaymentAlreadyProcessed:
 			response.BadRequest(ctx, "Payment has already been processed", nil)
+		case service.ErrPaymentExpired:
+			response.BadRequest(ctx, "Payment has expired", nil)
 		default:
 			response.InternalServerError(ctx, "Failed to process callback", err.Error())
 		}
@@ -226,3 +523,56 @@ func (h *PaymentHandler) PaymentCallback(ctx *gin.Context) {
 
 	response.OK(ctx, "Payment callback processed successfully", nil)
 }
+
+// PaymentWebhook godoc
+// @Summary      Payment gateway webhook
+// @Description  Receive payment notifications from the gateway. The raw body must be signed with HMAC-SHA256 using the shared webhook secret and sent hex-encoded in the X-Signature header. Duplicate deliveries for an already processed transaction are acknowledged with 200.
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        X-Signature header string true "Hex-encoded HMAC-SHA256 signature of the raw body"
+// @Param        request body dto.PaymentWebhookPayload true "Payment webhook payload"
+// @Success      200 {object} response.APIResponse
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /webhooks/payment [post]
+func (h *PaymentHandler) PaymentWebhook(ctx *gin.Context) {
+	body, err := ctx.GetRawData()
+	if err != nil {
+		response.BadRequest(ctx, "Failed to read request body", err.Error())
+		return
+	}
+
+	// Signature diverifikasi terhadap raw body sebelum payload di-parse
+	if !utils.VerifyHMACSHA256(h.webhookSecret, body, ctx.GetHeader(WebhookSignatureHeader)) {
+		response.Unauthorized(ctx, "Invalid or missing webhook signature")
+		return
+	}
+
+	var payload dto.PaymentWebhookPayload
+	if err := binding.JSON.BindBody(body, &payload); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	if err := h.paymentService.ProcessPaymentCallback(payload.TransactionID, payload.Status, payload.FailedReason); err != nil {
+		switch err {
+		case service.ErrPaymentAlreadyProcessed:
+			// Pengiriman ulang dari gateway: akui tanpa memproses ulang agar gateway berhenti retry
+			response.OK(ctx, "Payment webhook already processed", nil)
+		case service.ErrPaymentExpired:
+			// Payment sudah ditandai FAILED; gateway tidak perlu mengirim ulang
+			response.Error(ctx, http.StatusConflict, "Payment has expired", nil)
+		case service.ErrPaymentNotFound:
+			response.NotFound(ctx, "Payment not found")
+		default:
+			response.InternalServerError(ctx, "Failed to process webhook", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Payment webhook processed successfully", nil)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
 	"gorm.io/gorm"
 )
 
@@ -12,9 +13,15 @@ type PaymentRepository interface {
 	FindByID(id uint) (*entity.Payment, error)
 	FindByOrderID(orderID uint) (*entity.Payment, error)
 	FindByTransactionID(transactionID string) (*entity.Payment, error)
+	FindOpenByOrderID(orderID uint) (*entity.Payment, error)
+	FindSuccessByOrderID(orderID uint) (*entity.Payment, error)
 	FindByUserID(userID uint, params *dto.PaymentQueryParams) ([]entity.Payment, int64, error)
 	FindAll(params *dto.PaymentQueryParams) ([]entity.Payment, int64, error)
 	Update(payment *entity.Payment) error
+	MarkProcessingIfPending(id uint) (bool, error)
+	FinalizeIfOpen(payment *entity.Payment) (bool, error)
+	RefundIfSuccess(payment *entity.Payment) (bool, error)
+	SumAmountByStatus(status string) (money.Money, error)
 	WithTx(tx *gorm.DB) PaymentRepository
 }
 
@@ -56,6 +63,28 @@ func (r *paymentRepository) FindByOrderID(orderID uint) (*entity.Payment, error)
 	return &payment, nil
 }
 
+// FindOpenByOrderID mencari payment PENDING/PROCESSING milik order
+func (r *paymentRepository) FindOpenByOrderID(orderID uint) (*entity.Payment, error) {
+	var payment entity.Payment
+	if err := r.db.Where("order_id = ? AND status IN ?", orderID,
+		[]string{entity.PaymentStatusPending, entity.PaymentStatusProcessing}).
+		Order("created_at DESC").
+		First(&payment).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// FindSuccessByOrderID mencari payment SUCCESS milik order
+func (r *paymentRepository) FindSuccessByOrderID(orderID uint) (*entity.Payment, error) {
+	var payment entity.Payment
+	if err := r.db.Where("order_id = ? AND status = ?", orderID, entity.PaymentStatusSuccess).
+		First(&payment).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
 // FindByTransactionID mencari payment berdasarkan Transaction ID
 func (r *paymentRepository) FindByTransactionID(transactionID string) (*entity.Payment, error) {
 	var payment entity.Payment
@@ -65,6 +94,23 @@ func (r *paymentRepository) FindByTransactionID(transactionID string) (*entity.P
 	return &payment, nil
 }
 
+// paymentSortClauses whitelist ORDER BY untuk parameter sort (id sebagai tie-breaker agar pagination deterministik).
+// Input user tidak pernah masuk ke SQL secara langsung.
+var paymentSortClauses = map[string]string{
+	dto.PaymentSortCreatedAtAsc:  "created_at ASC, id ASC",
+	dto.PaymentSortCreatedAtDesc: "created_at DESC, id DESC",
+	dto.PaymentSortAmountAsc:     "amount ASC, id ASC",
+	dto.PaymentSortAmountDesc:    "amount DESC, id DESC",
+}
+
+// paymentSortClause mengembalikan ORDER BY untuk sort; kosong atau tidak dikenal = terbaru dulu
+func paymentSortClause(sort string) string {
+	if clause, ok := paymentSortClauses[sort]; ok {
+		return clause
+	}
+	return paymentSortClauses[dto.PaymentSortCreatedAtDesc]
+}
+
 // FindByUserID mengambil payment berdasarkan user ID dengan pagination
 func (r *paymentRepository) FindByUserID(userID uint, params *dto.PaymentQueryParams) ([]entity.Payment, int64, error) {
 	var payments []entity.Payment
@@ -87,7 +133,7 @@ func (r *paymentRepository) FindByUserID(userID uint, params *dto.PaymentQueryPa
 
 	// Apply pagination
 	offset := (params.Page - 1) * params.Limit
-	if err := query.Order("created_at DESC").Offset(offset).Limit(params.Limit).Find(&payments).Error; err != nil {
+	if err := query.Order(paymentSortClause(params.Sort)).Offset(offset).Limit(params.Limit).Find(&payments).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -108,6 +154,9 @@ func (r *paymentRepository) FindAll(params *dto.PaymentQueryParams) ([]entity.Pa
 	if params.OrderID > 0 {
 		query = query.Where("order_id = ?", params.OrderID)
 	}
+	if params.UserID > 0 {
+		query = query.Where("user_id = ?", params.UserID)
+	}
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -116,7 +165,7 @@ func (r *paymentRepository) FindAll(params *dto.PaymentQueryParams) ([]entity.Pa
 
 	// Apply pagination
 	offset := (params.Page - 1) * params.Limit
-	if err := query.Order("created_at DESC").Offset(offset).Limit(params.Limit).Find(&payments).Error; err != nil {
+	if err := query.Order(paymentSortClause(params.Sort)).Offset(offset).Limit(params.Limit).Find(&payments).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -127,3 +176,59 @@ func (r *paymentRepository) FindAll(params *dto.PaymentQueryParams) ([]entity.Pa
 func (r *paymentRepository) Update(payment *entity.Payment) error {
 	return r.db.Save(payment).Error
 }
+
+// MarkProcessingIfPending mengubah status payment menjadi PROCESSING hanya jika masih PENDING.
+// Mengembalikan false jika payment sudah diproses lebih dulu (mis. webhook tiba sebelum simulator).
+func (r *paymentRepository) MarkProcessingIfPending(id uint) (bool, error) {
+	result := r.db.Model(&entity.Payment{}).
+		Where("id = ? AND status = ?", id, entity.PaymentStatusPending).
+		Update("status", entity.PaymentStatusProcessing)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FinalizeIfOpen menyimpan status akhir (SUCCESS/FAILED) hanya jika payment masih PENDING/PROCESSING.
+// Mengembalikan false jika payment sudah difinalisasi proses lain (mis. webhook duplikat).
+func (r *paymentRepository) FinalizeIfOpen(payment *entity.Payment) (bool, error) {
+	result := r.db.Model(&entity.Payment{}).
+		Where("id = ? AND status IN ?", payment.ID, []string{entity.PaymentStatusPending, entity.PaymentStatusProcessing}).
+		Updates(map[string]interface{}{
+			"status":        payment.Status,
+			"paid_at":       payment.PaidAt,
+			"failed_reason": payment.FailedReason,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RefundIfSuccess menyimpan status REFUNDED hanya jika payment masih SUCCESS.
+// Mengembalikan false jika payment sudah di-refund proses lain.
+func (r *paymentRepository) RefundIfSuccess(payment *entity.Payment) (bool, error) {
+	result := r.db.Model(&entity.Payment{}).
+		Where("id = ? AND status = ?", payment.ID, entity.PaymentStatusSuccess).
+		Updates(map[string]interface{}{
+			"status":        payment.Status,
+			"refunded_at":   payment.RefundedAt,
+			"refund_reason": payment.RefundReason,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SumAmountByStatus menjumlahkan nominal payment dengan status tertentu
+func (r *paymentRepository) SumAmountByStatus(status string) (money.Money, error) {
+	var total money.Money
+	if err := r.db.Model(&entity.Payment{}).
+		Where("status = ?", status).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error; err != nil {
+		return money.Zero, err
+	}
+	return total, nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentSortClause(t *testing.T) {
+	assert.Equal(t, "created_at DESC, id DESC", paymentSortClause(""))
+	assert.Equal(t, "created_at ASC, id ASC", paymentSortClause(dto.PaymentSortCreatedAtAsc))
+	assert.Equal(t, "amount ASC, id ASC", paymentSortClause(dto.PaymentSortAmountAsc))
+	assert.Equal(t, "amount DESC, id DESC", paymentSortClause(dto.PaymentSortAmountDesc))
+
+	// Nilai di luar whitelist tidak pernah diteruskan ke SQL
+	assert.Equal(t, "created_at DESC, id DESC", paymentSortClause("amount; DROP TABLE payments"))
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/order/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// RunExpiryWorker menjalankan ExpireUnpaidOrders secara berkala sampai ctx dibatalkan.
+// Dijalankan sebagai goroutine dari main.go.
+func (s *paymentService) RunExpiryWorker(ctx context.Context, interval time.Duration) {
+	if s.orderExpiry <= 0 || interval <= 0 {
+		logger.Info().Msg("Order expiry worker disabled")
+		return
+	}
+
+	logger.Info().Dur("expiry", s.orderExpiry).Dur("interval", interval).Msg("Order expiry worker started")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if expired, err := s.ExpireUnpaidOrders(); err != nil {
+				logger.Error().Err(err).Msg("Order expiry run failed")
+			} else if expired > 0 {
+				logger.Info().Int("expired", expired).Msg("Expired unpaid orders")
+			}
+		case <-ctx.Done():
+			logger.Info().Msg("Order expiry worker stopped")
+			return
+		}
+	}
+}
+
+// ExpireUnpaidOrders membatalkan order PENDING yang lebih tua dari orderExpiry
+// (stok dikembalikan) dan menandai payment yang masih terbuka sebagai FAILED.
+// Mengembalikan jumlah order yang berhasil dibatalkan.
+func (s *paymentService) ExpireUnpaidOrders() (int, error) {
+	now := time.Now()
+	orderIDs, err := s.orderService.GetExpiredPendingOrderIDs(now.Add(-s.orderExpiry))
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, orderID := range orderIDs {
+		payment, err := s.paymentRepo.FindOpenByOrderID(orderID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error().Err(err).Uint("order_id", orderID).Msg("Failed to find open payment for expired order")
+			continue
+		}
+
+		if payment != nil {
+			// Payment yang dibuat belakangan masih punya waktu sampai ExpiresAt-nya sendiri
+			if payment.ExpiresAt != nil && !payment.IsExpired(now) {
+				continue
+			}
+
+			payment.MarkAsFailed(paymentExpiredReason)
+			ok, err := s.paymentRepo.FinalizeIfOpen(payment)
+			if err != nil {
+				logger.Error().Err(err).
+					Str("transaction_id", payment.TransactionID).
+					Uint("payment_id", payment.ID).
+					Uint("order_id", orderID).
+					Msg("Failed to expire payment")
+				continue
+			}
+			if !ok {
+				// Payment baru saja difinalisasi (mis. callback sukses), biarkan order apa adanya
+				continue
+			}
+			if err := s.publishPaymentFinalized(context.Background(), payment); err != nil {
+				logger.Error().Err(err).
+					Str("transaction_id", payment.TransactionID).
+					Uint("payment_id", payment.ID).
+					Uint("order_id", orderID).
+					Msg("Failed to handle payment event")
+			}
+		}
+
+		if err := s.orderService.ExpireOrder(orderID); err != nil {
+			if !errors.Is(err, service.ErrOrderNotCancellable) {
+				logger.Error().Err(err).Uint("order_id", orderID).Msg("Failed to expire order")
+			}
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
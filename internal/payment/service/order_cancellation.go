@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/order/service"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ForceCancelOrder membatalkan paksa order PENDING atau PAID atas nama admin tanpa cek kepemilikan.
+// Stok dikembalikan dan payment SUCCESS milik order di-refund dalam satu transaction, sehingga
+// order tidak pernah berstatus CANCELLED dengan dana yang belum dikembalikan.
+func (s *paymentService) ForceCancelOrder(adminID uint, orderID uint, reason string) (*dto.ForceCancelOrderResponse, error) {
+	// Order PENDING atau order lama tanpa payment tidak memiliki dana yang perlu dikembalikan
+	payment, err := s.paymentRepo.FindSuccessByOrderID(orderID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	notifyOrderCancelled, err := s.orderService.ForceCancelTx(tx, orderID, adminID, reason)
+	if err != nil {
+		tx.Rollback()
+		switch err {
+		case service.ErrOrderNotFound:
+			return nil, ErrOrderNotFound
+		case service.ErrOrderNotCancellable:
+			return nil, ErrOrderNotCancellable
+		}
+		return nil, err
+	}
+
+	if payment != nil {
+		payment.MarkAsRefunded(reason)
+		// Update bersyarat agar refund yang datang bersamaan tidak diproses dua kali
+		ok, err := s.paymentRepo.WithTx(tx).RefundIfSuccess(payment)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if !ok {
+			tx.Rollback()
+			return nil, ErrOrderNotCancellable
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	notifyOrderCancelled()
+
+	result := &dto.ForceCancelOrderResponse{
+		OrderID:     orderID,
+		Status:      orderEntity.OrderStatusCancelled,
+		CancelledBy: adminID,
+		Reason:      reason,
+	}
+	event := logger.Info().Uint("order_id", orderID).Uint("admin_id", adminID)
+	if payment != nil {
+		s.publishStatusChange(payment)
+		result.RefundedPayment = s.toPaymentResponse(payment)
+		event = event.Uint("payment_id", payment.ID).Str("transaction_id", payment.TransactionID)
+	}
+	event.Msg("Order force-cancelled by admin")
+
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"testing"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForceCancelOrder_PaidOrderRefundsAndRestoresStock(t *testing.T) {
+	db := setupPaymentDB(t)
+	order, payment, product := seedOrderWithPayment(t, db, orderEntity.OrderStatusPaid, entity.PaymentStatusSuccess)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+
+	result, err := svc.ForceCancelOrder(99, order.ID, "Warehouse cannot fulfil")
+	require.NoError(t, err)
+	assert.Equal(t, orderEntity.OrderStatusCancelled, result.Status)
+	assert.Equal(t, uint(99), result.CancelledBy)
+	require.NotNil(t, result.RefundedPayment)
+	assert.Equal(t, entity.PaymentStatusRefunded, result.RefundedPayment.Status)
+	assert.Equal(t, "Warehouse cannot fulfil", result.RefundedPayment.RefundReason)
+
+	var reloadedOrder orderEntity.Order
+	require.NoError(t, db.First(&reloadedOrder, order.ID).Error)
+	assert.Equal(t, orderEntity.OrderStatusCancelled, reloadedOrder.Status)
+
+	var reloadedPayment entity.Payment
+	require.NoError(t, db.First(&reloadedPayment, payment.ID).Error)
+	assert.Equal(t, entity.PaymentStatusRefunded, reloadedPayment.Status)
+
+	var reloadedProduct productEntity.Product
+	require.NoError(t, db.First(&reloadedProduct, product.ID).Error)
+	assert.Equal(t, 10, reloadedProduct.Stock)
+
+	// Admin dan alasan pembatalan tercatat di timeline order
+	var history orderEntity.OrderStatusHistory
+	require.NoError(t, db.Where("order_id = ?", order.ID).Last(&history).Error)
+	assert.Equal(t, orderEntity.OrderStatusPaid, history.FromStatus)
+	assert.Equal(t, orderEntity.OrderStatusCancelled, history.ToStatus)
+	require.NotNil(t, history.ChangedBy)
+	assert.Equal(t, uint(99), *history.ChangedBy)
+	assert.Equal(t, "Warehouse cannot fulfil", history.Reason)
+}
+
+func TestForceCancelOrder_PendingOrderWithoutRefund(t *testing.T) {
+	db := setupPaymentDB(t)
+	order, payment, product := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusPending)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+
+	result, err := svc.ForceCancelOrder(99, order.ID, "Fraud suspected")
+	require.NoError(t, err)
+	assert.Nil(t, result.RefundedPayment)
+
+	var reloadedPayment entity.Payment
+	require.NoError(t, db.First(&reloadedPayment, payment.ID).Error)
+	assert.Equal(t, entity.PaymentStatusPending, reloadedPayment.Status)
+
+	// Order lama tanpa reservasi: stok fisik dikembalikan
+	var reloadedProduct productEntity.Product
+	require.NoError(t, db.First(&reloadedProduct, product.ID).Error)
+	assert.Equal(t, 10, reloadedProduct.Stock)
+}
+
+func TestForceCancelOrder_RejectsShippedOrder(t *testing.T) {
+	db := setupPaymentDB(t)
+	order, payment, product := seedOrderWithPayment(t, db, orderEntity.OrderStatusShipped, entity.PaymentStatusSuccess)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+
+	_, err := svc.ForceCancelOrder(99, order.ID, "Too late")
+	assert.ErrorIs(t, err, ErrOrderNotCancellable)
+
+	var reloadedPayment entity.Payment
+	require.NoError(t, db.First(&reloadedPayment, payment.ID).Error)
+	assert.Equal(t, entity.PaymentStatusSuccess, reloadedPayment.Status)
+
+	var reloadedProduct productEntity.Product
+	require.NoError(t, db.First(&reloadedProduct, product.ID).Error)
+	assert.Equal(t, 7, reloadedProduct.Stock)
+}
+
+func TestForceCancelOrder_OrderNotFound(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+
+	_, err := svc.ForceCancelOrder(99, 12345, "Missing")
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPaymentByOrderID_EnforcesOwnership(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+	// Order dan payment milik user 1
+	order, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPaid, entity.PaymentStatusSuccess)
+
+	// User lain tidak bisa membaca payment lewat order ID
+	_, err := svc.GetPaymentByOrderID(2, order.ID, false)
+	assert.Equal(t, ErrUnauthorized, err)
+
+	owned, err := svc.GetPaymentByOrderID(1, order.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, payment.ID, owned.ID)
+
+	// Admin boleh membaca payment siapa pun
+	asAdmin, err := svc.GetPaymentByOrderID(2, order.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, payment.ID, asAdmin.ID)
+
+	_, err = svc.GetPaymentByOrderID(1, order.ID+100, false)
+	assert.Equal(t, ErrPaymentNotFound, err)
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	orderService "github.com/akbarwjyy/go-commerce-api/internal/order/service"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/repository"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newEventTestPaymentService seperti newTestPaymentService, tetapi event bus-nya dikembalikan
+// dan Order Module belum di-subscribe agar test bisa mengatur urutan subscriber sendiri
+func newEventTestPaymentService(db *gorm.DB) (PaymentService, orderService.OrderService, *events.Bus) {
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	bus := events.NewBus()
+	orderSvc := orderService.NewOrderService(orderService.OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+		EventBus:       bus,
+	})
+	return NewPaymentService(repository.NewPaymentRepository(db), orderSvc, nil, db, 0, DefaultSimulatorConfig(), bus), orderSvc, bus
+}
+
+func TestProcessPaymentCallback_PublishesPaymentThenOrderEvents(t *testing.T) {
+	db := setupPaymentDB(t)
+	order, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusProcessing)
+	svc, orderSvc, bus := newEventTestPaymentService(db)
+
+	var published []events.Event
+	bus.SubscribeAll(func(ctx context.Context, event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+	orderService.SubscribeToEvents(bus, orderSvc)
+
+	require.NoError(t, svc.ProcessPaymentCallback(payment.TransactionID, entity.PaymentStatusSuccess, ""))
+
+	// OrderPaid dipublish dari dalam handler PaymentSucceeded milik Order Module
+	require.Len(t, published, 2)
+	succeeded, ok := published[0].(events.PaymentSucceeded)
+	require.True(t, ok)
+	assert.Equal(t, payment.ID, succeeded.PaymentID)
+	assert.Equal(t, order.ID, succeeded.OrderID)
+	assert.Equal(t, events.OrderPaid{OrderID: order.ID, UserID: order.UserID, TotalAmount: order.TotalAmount, Currency: order.Currency}, published[1])
+
+	var reloaded orderEntity.Order
+	require.NoError(t, db.First(&reloaded, order.ID).Error)
+	assert.Equal(t, orderEntity.OrderStatusPaid, reloaded.Status)
+}
+
+func TestProcessPaymentCallback_FailureDoesNotTouchOrder(t *testing.T) {
+	db := setupPaymentDB(t)
+	order, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusProcessing)
+	svc, orderSvc, bus := newEventTestPaymentService(db)
+	orderService.SubscribeToEvents(bus, orderSvc)
+
+	var failed []events.PaymentFailed
+	bus.Subscribe(events.NamePaymentFailed, func(ctx context.Context, event events.Event) error {
+		failed = append(failed, event.(events.PaymentFailed))
+		return nil
+	})
+
+	require.NoError(t, svc.ProcessPaymentCallback(payment.TransactionID, entity.PaymentStatusFailed, "Card declined"))
+	require.Len(t, failed, 1)
+	assert.Equal(t, "Card declined", failed[0].FailedReason)
+
+	var reloaded orderEntity.Order
+	require.NoError(t, db.First(&reloaded, order.ID).Error)
+	assert.Equal(t, orderEntity.OrderStatusPending, reloaded.Status)
+}
+
+func TestProcessPaymentCallback_ReturnsSubscriberError(t *testing.T) {
+	db := setupPaymentDB(t)
+	_, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusProcessing)
+	svc, _, bus := newEventTestPaymentService(db)
+
+	errSubscriber := errors.New("subscriber failed")
+	bus.Subscribe(events.NamePaymentSucceeded, func(ctx context.Context, event events.Event) error {
+		return errSubscriber
+	})
+
+	assert.ErrorIs(t, svc.ProcessPaymentCallback(payment.TransactionID, entity.PaymentStatusSuccess, ""), errSubscriber)
+
+	// Status payment sudah di-commit sebelum event dipublish
+	var reloaded entity.Payment
+	require.NoError(t, db.First(&reloaded, payment.ID).Error)
+	assert.Equal(t, entity.PaymentStatusSuccess, reloaded.Status)
+}
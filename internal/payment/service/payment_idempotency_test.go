@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newIdempotencyService membuat PaymentService dengan Redis dari miniredis dan gateway yang langsung sukses
+func newIdempotencyService(t *testing.T, db *gorm.DB) (*paymentService, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+
+	var delay time.Duration
+	svc := newTestPaymentService(db, SimulatorConfig{
+		SuccessRate: 1,
+		Random:      func() float64 { return 0 },
+		After:       instantAfter(&delay),
+	}).(*paymentService)
+	svc.redisClient = redisClient
+	t.Cleanup(func() { svc.Shutdown(context.Background()) })
+	return svc, mr
+}
+
+// seedPendingOrder membuat order PENDING milik user 1 tanpa payment
+func seedPendingOrder(t *testing.T, db *gorm.DB) *orderEntity.Order {
+	order := &orderEntity.Order{UserID: 1, TotalAmount: money.FromFloat(500), Status: orderEntity.OrderStatusPending, ShippingAddr: "Jl. Sudirman No. 1"}
+	require.NoError(t, db.Create(order).Error)
+	return order
+}
+
+func idempotencyRedisKey(userID uint, key string) string {
+	return database.RedisKey(fmt.Sprintf("idempotency:payment:%d:%s", userID, key))
+}
+
+func TestCreatePayment_IdempotencyKeyReplaysSamePayment(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc, mr := newIdempotencyService(t, db)
+	order := seedPendingOrder(t, db)
+	other := seedPendingOrder(t, db)
+	req := &dto.CreatePaymentRequest{OrderID: order.ID, Method: entity.PaymentMethodBankTransfer}
+
+	first, err := svc.CreatePayment(context.Background(), 1, req, "key-1")
+	require.NoError(t, err)
+
+	// Key menyimpan ID payment dengan TTL idempotency
+	stored, err := mr.Get(idempotencyRedisKey(1, "key-1"))
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatUint(uint64(first.ID), 10), stored)
+	assert.Equal(t, idempotencyKeyTTL, mr.TTL(idempotencyRedisKey(1, "key-1")))
+
+	replayed, err := svc.CreatePayment(context.Background(), 1, req, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, replayed.ID)
+
+	var count int64
+	require.NoError(t, db.Model(&entity.Payment{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// Key yang sama untuk order lain ditolak
+	_, err = svc.CreatePayment(context.Background(), 1, &dto.CreatePaymentRequest{OrderID: other.ID, Method: entity.PaymentMethodBankTransfer}, "key-1")
+	assert.ErrorIs(t, err, ErrIdempotencyKeyMismatch)
+}
+
+func TestCreatePayment_IdempotencyKeyInProgress(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc, mr := newIdempotencyService(t, db)
+	order := seedPendingOrder(t, db)
+
+	// Request pertama dengan key ini belum selesai
+	require.NoError(t, mr.Set(idempotencyRedisKey(1, "key-1"), idempotencyPending))
+
+	_, err := svc.CreatePayment(context.Background(), 1, &dto.CreatePaymentRequest{OrderID: order.ID, Method: entity.PaymentMethodBankTransfer}, "key-1")
+	assert.ErrorIs(t, err, ErrIdempotencyInProgress)
+
+	var count int64
+	require.NoError(t, db.Model(&entity.Payment{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestCreatePayment_IdempotencyKeyReleasedOnError(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc, mr := newIdempotencyService(t, db)
+	order := seedPendingOrder(t, db)
+	require.NoError(t, db.Model(order).Update("status", orderEntity.OrderStatusCancelled).Error)
+	req := &dto.CreatePaymentRequest{OrderID: order.ID, Method: entity.PaymentMethodBankTransfer}
+
+	_, err := svc.CreatePayment(context.Background(), 1, req, "key-1")
+	assert.ErrorIs(t, err, ErrOrderNotPending)
+	assert.False(t, mr.Exists(idempotencyRedisKey(1, "key-1")))
+
+	// Client bisa mencoba lagi dengan key yang sama setelah masalahnya diperbaiki
+	require.NoError(t, db.Model(order).Update("status", orderEntity.OrderStatusPending).Error)
+	result, err := svc.CreatePayment(context.Background(), 1, req, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, order.ID, result.OrderID)
+}
+
+// expireOnceHook menghapus key tepat setelah SetNX pertama, seolah key kedaluwarsa sebelum sempat dibaca
+type expireOnceHook struct {
+	mr    *miniredis.Miniredis
+	key   string
+	fired bool
+}
+
+func (h *expireOnceHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *expireOnceHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if cmd.Name() == "set" && !h.fired {
+			h.fired = true
+			h.mr.Del(h.key)
+		}
+		return err
+	}
+}
+
+func (h *expireOnceHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestCreatePayment_IdempotencyKeyExpiredBeforeReplay(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc, mr := newIdempotencyService(t, db)
+	order := seedPendingOrder(t, db)
+
+	key := idempotencyRedisKey(1, "key-1")
+	require.NoError(t, mr.Set(key, idempotencyPending))
+	svc.redisClient.AddHook(&expireOnceHook{mr: mr, key: key})
+
+	// SetNX gagal karena key masih ada, lalu key hilang sebelum Get: reservasi diulang dan payment dibuat
+	result, err := svc.CreatePayment(context.Background(), 1, &dto.CreatePaymentRequest{OrderID: order.ID, Method: entity.PaymentMethodBankTransfer}, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, order.ID, result.OrderID)
+
+	stored, err := mr.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatUint(uint64(result.ID), 10), stored)
+}
+
+// pendingTTLHook mencatat TTL key tepat setelah SET pertama (reservasi pending)
+type pendingTTLHook struct {
+	mr  *miniredis.Miniredis
+	key string
+	ttl time.Duration
+}
+
+func (h *pendingTTLHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *pendingTTLHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if cmd.Name() == "set" && h.ttl == 0 {
+			h.ttl = h.mr.TTL(h.key)
+		}
+		return err
+	}
+}
+
+func (h *pendingTTLHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestCreatePayment_PendingMarkerExpiresQuickly(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc, mr := newIdempotencyService(t, db)
+	order := seedPendingOrder(t, db)
+
+	key := idempotencyRedisKey(1, "key-1")
+	hook := &pendingTTLHook{mr: mr, key: key}
+	svc.redisClient.AddHook(hook)
+
+	_, err := svc.CreatePayment(context.Background(), 1, &dto.CreatePaymentRequest{OrderID: order.ID, Method: entity.PaymentMethodBankTransfer}, "key-1")
+	require.NoError(t, err)
+
+	// Marker pending hanya berlaku singkat, lalu diperpanjang setelah payment tersimpan
+	assert.Equal(t, idempotencyPendingTTL, hook.ttl)
+	assert.Equal(t, idempotencyKeyTTL, mr.TTL(key))
+}
+
+func TestCreatePayment_IdempotencyKeyWithoutRedisFallsBackToOrderDedupe(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc, _ := newIdempotencyService(t, db)
+	svc.redisClient = nil
+	order := seedPendingOrder(t, db)
+	req := &dto.CreatePaymentRequest{OrderID: order.ID, Method: entity.PaymentMethodBankTransfer}
+
+	_, err := svc.CreatePayment(context.Background(), 1, req, "key-1")
+	require.NoError(t, err)
+
+	// Tanpa Redis key diabaikan, payment kedua untuk order yang sama tetap ditolak
+	_, err = svc.CreatePayment(context.Background(), 1, req, "key-1")
+	assert.ErrorIs(t, err, ErrPaymentAlreadyExists)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPaymentByTransactionID_IncludesOrder(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+	order, payment, product := seedOrderWithPayment(t, db, orderEntity.OrderStatusPaid, entity.PaymentStatusSuccess)
+
+	result, err := svc.GetPaymentByTransactionID(payment.TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, payment.ID, result.ID)
+	assert.Equal(t, entity.PaymentStatusSuccess, result.Status)
+	require.NotNil(t, result.Order)
+	assert.Equal(t, order.ID, result.Order.ID)
+	assert.Equal(t, orderEntity.OrderStatusPaid, result.Order.Status)
+	require.Len(t, result.Order.Items, 1)
+	assert.Equal(t, product.ID, result.Order.Items[0].ProductID)
+
+	_, err = svc.GetPaymentByTransactionID("TXN-MISSING")
+	assert.Equal(t, ErrPaymentNotFound, err)
+}
+
+func TestGetAllPayments_FiltersByUserAndOrder(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+	order, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPaid, entity.PaymentStatusSuccess)
+
+	other := &entity.Payment{OrderID: order.ID + 100, UserID: 2, Amount: money.FromFloat(50), Method: entity.PaymentMethodBankTransfer, Status: entity.PaymentStatusFailed, TransactionID: "TXN-OTHER"}
+	require.NoError(t, db.Create(other).Error)
+
+	byUser, err := svc.GetAllPayments(&dto.PaymentQueryParams{UserID: 1})
+	require.NoError(t, err)
+	require.Len(t, byUser.Payments, 1)
+	assert.Equal(t, payment.ID, byUser.Payments[0].ID)
+
+	byOrder, err := svc.GetAllPayments(&dto.PaymentQueryParams{OrderID: other.OrderID})
+	require.NoError(t, err)
+	require.Len(t, byOrder.Payments, 1)
+	assert.Equal(t, other.ID, byOrder.Payments[0].ID)
+
+	none, err := svc.GetAllPayments(&dto.PaymentQueryParams{UserID: 2, OrderID: order.ID})
+	require.NoError(t, err)
+	assert.Empty(t, none.Payments)
+}
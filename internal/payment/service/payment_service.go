@@ -1,70 +1,237 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
-	"math"
-	"math/rand"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/akbarwjyy/go-commerce-api/internal/order/service"
 	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
 	"github.com/akbarwjyy/go-commerce-api/internal/payment/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database"
+	"github.com/akbarwjyy/go-commerce-api/pkg/events"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
+// idempotencyKeyTTL adalah masa berlaku Idempotency-Key untuk pembuatan payment
+const idempotencyKeyTTL = 24 * time.Hour
+
+// idempotencyPending menandai request dengan key yang sama sedang diproses
+const idempotencyPending = "pending"
+
+// idempotencyPendingTTL dibuat singkat agar key dari request yang mati di tengah jalan
+// tidak memblokir retry dengan key yang sama selama idempotencyKeyTTL
+const idempotencyPendingTTL = 30 * time.Second
+
+// idempotencyReserveAttempts membatasi percobaan reservasi ulang saat key hilang sebelum sempat dibaca
+const idempotencyReserveAttempts = 3
+
 // Common errors
 var (
-	ErrPaymentNotFound       = errors.New("payment not found")
-	ErrOrderNotFound         = errors.New("order not found")
-	ErrOrderNotPending       = errors.New("order is not in pending status")
-	ErrPaymentAlreadyExists  = errors.New("payment already exists for this order")
-	ErrInvalidPaymentMethod  = errors.New("invalid payment method")
-	ErrUnauthorized          = errors.New("you are not authorized to perform this action")
+	ErrPaymentNotFound         = errors.New("payment not found")
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrOrderNotPending         = errors.New("order is not in pending status")
+	ErrPaymentAlreadyExists    = errors.New("payment already exists for this order")
+	ErrInvalidPaymentMethod    = errors.New("invalid payment method")
+	ErrUnauthorized            = errors.New("you are not authorized to perform this action")
 	ErrPaymentAlreadyProcessed = errors.New("payment has already been processed")
+	ErrIdempotencyInProgress   = errors.New("a request with this idempotency key is still being processed")
+	ErrIdempotencyKeyMismatch  = errors.New("idempotency key was already used for a different order")
+	ErrPaymentExpired          = errors.New("payment has expired")
+	ErrPaymentNotRefundable    = errors.New("only successful payments can be refunded")
+	ErrOrderNotRefundable      = errors.New("order cannot be refunded in its current status")
+	ErrOrderNotCancellable     = errors.New("only PENDING or PAID orders can be force-cancelled")
 )
 
+// paymentExpiredReason adalah FailedReason untuk payment yang melewati batas waktu
+const paymentExpiredReason = "Payment expired"
+
 // PaymentService interface untuk business logic payment
 type PaymentService interface {
-	CreatePayment(userID uint, req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error)
+	CreatePayment(ctx context.Context, userID uint, req *dto.CreatePaymentRequest, idempotencyKey string) (*dto.PaymentResponse, error)
 	GetPayment(userID uint, paymentID uint) (*dto.PaymentResponse, error)
-	GetPaymentByOrderID(orderID uint) (*dto.PaymentResponse, error)
+	GetPaymentStatus(ctx context.Context, userID uint, paymentID uint, wait time.Duration) (*dto.PaymentStatusResponse, error)
+	StreamPaymentStatus(ctx context.Context, userID uint, paymentID uint, send func(*dto.PaymentStatusResponse) error) error
+	GetPaymentByOrderID(userID uint, orderID uint, isAdmin bool) (*dto.PaymentResponse, error)
 	GetMyPayments(userID uint, params *dto.PaymentQueryParams) (*dto.PaymentListResponse, error)
 	GetAllPayments(params *dto.PaymentQueryParams) (*dto.PaymentListResponse, error)
+	GetPaymentByTransactionID(transactionID string) (*dto.PaymentDetailResponse, error)
 
 	// Untuk callback simulasi
 	ProcessPaymentCallback(transactionID string, status string, failedReason string) error
+
+	// Untuk admin
+	RefundPayment(paymentID uint, reason string) (*dto.PaymentResponse, error)
+	ForceCancelOrder(adminID uint, orderID uint, reason string) (*dto.ForceCancelOrderResponse, error)
+
+	// Untuk dashboard admin
+	GetSuccessfulPaymentVolume() (money.Money, error)
+
+	// Untuk expiry worker
+	ExpireUnpaidOrders() (int, error)
+	RunExpiryWorker(ctx context.Context, interval time.Duration)
+
+	// Shutdown menunggu proses payment async yang masih berjalan
+	Shutdown(ctx context.Context) error
 }
 
 // paymentService implementasi PaymentService
 type paymentService struct {
 	paymentRepo  repository.PaymentRepository
 	orderService service.OrderService
+	redisClient  *redis.Client
 	db           *gorm.DB
+	orderExpiry  time.Duration
+	simulator    SimulatorConfig
+	events       *events.Bus
+
+	// Melacak goroutine processPaymentAsync agar bisa di-drain saat shutdown
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewPaymentService membuat instance baru PaymentService
 func NewPaymentService(
 	paymentRepo repository.PaymentRepository,
 	orderSvc service.OrderService,
+	redisClient *redis.Client,
 	db *gorm.DB,
+	orderExpiry time.Duration,
+	simulator SimulatorConfig,
+	eventBus *events.Bus,
 ) PaymentService {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &paymentService{
 		paymentRepo:  paymentRepo,
 		orderService: orderSvc,
+		redisClient:  redisClient,
 		db:           db,
+		orderExpiry:  orderExpiry,
+		simulator:    simulator.withDefaults(),
+		events:       eventBus,
+		ctx:          ctx,
+		cancel:       cancel,
 	}
 }
 
-// CreatePayment membuat payment baru dan memulai proses async
-func (s *paymentService) CreatePayment(userID uint, req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error) {
+// Shutdown menunggu semua payment async selesai. Jika ctx habis lebih dulu,
+// payment yang masih menunggu gateway dibatalkan dan tetap berstatus PROCESSING.
+func (s *paymentService) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return ctx.Err()
+	}
+}
+
+// CreatePayment membuat payment baru dan memulai proses async.
+// Jika idempotencyKey diisi, request ulang dengan key yang sama mengembalikan payment yang sama.
+// Request ID pada ctx diteruskan ke proses async agar lifecycle payment bisa ditelusuri.
+func (s *paymentService) CreatePayment(ctx context.Context, userID uint, req *dto.CreatePaymentRequest, idempotencyKey string) (*dto.PaymentResponse, error) {
 	// Validate payment method
 	if !entity.IsValidMethod(req.Method) {
 		return nil, ErrInvalidPaymentMethod
 	}
 
+	// Tanpa key atau tanpa Redis, gunakan dedupe berdasarkan order (FindByOrderID)
+	if idempotencyKey == "" || s.redisClient == nil {
+		return s.createPayment(ctx, userID, req)
+	}
+
+	redisKey := database.RedisKey(fmt.Sprintf("idempotency:payment:%d:%s", userID, idempotencyKey))
+
+	for attempt := 0; attempt < idempotencyReserveAttempts; attempt++ {
+		// Reservasi key secara atomik agar request paralel tidak membuat payment ganda
+		reserved, err := s.redisClient.SetNX(ctx, redisKey, idempotencyPending, idempotencyPendingTTL).Result()
+		if err != nil {
+			return s.createPayment(ctx, userID, req)
+		}
+		if reserved {
+			return s.createReservedPayment(ctx, redisKey, userID, req)
+		}
+
+		result, err := s.replayPayment(ctx, redisKey, req)
+		if errors.Is(err, redis.Nil) {
+			// Key kedaluwarsa atau dilepas di antara SetNX dan Get, coba reservasi ulang
+			continue
+		}
+		return result, err
+	}
+	return nil, ErrIdempotencyInProgress
+}
+
+// createReservedPayment membuat payment untuk Idempotency-Key yang sudah direservasi,
+// lalu menyimpan ID payment-nya agar request ulang mendapat payment yang sama
+func (s *paymentService) createReservedPayment(ctx context.Context, redisKey string, userID uint, req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error) {
+	result, err := s.createPayment(ctx, userID, req)
+	if err != nil {
+		// Lepas key agar client bisa mencoba lagi dengan key yang sama
+		s.redisClient.Del(ctx, redisKey)
+		return nil, err
+	}
+
+	// Payment sudah dibuat, jadi kegagalan menyimpan key tidak menggagalkan request:
+	// setelah marker pending kedaluwarsa, retry ditolak oleh dedupe berdasarkan order
+	if err := s.redisClient.Set(ctx, redisKey, result.ID, idempotencyKeyTTL).Err(); err != nil {
+		logger.Warn().Err(err).
+			Str("request_id", logger.RequestIDFromContext(ctx)).
+			Uint("payment_id", result.ID).
+			Msg("Failed to store idempotency key")
+	}
+	return result, nil
+}
+
+// replayPayment mengembalikan payment yang sudah dibuat dengan Idempotency-Key yang sama
+func (s *paymentService) replayPayment(ctx context.Context, redisKey string, req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error) {
+	value, err := s.redisClient.Get(ctx, redisKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	if value == idempotencyPending {
+		return nil, ErrIdempotencyInProgress
+	}
+
+	paymentID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.paymentRepo.FindByID(uint(paymentID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	if payment.OrderID != req.OrderID {
+		return nil, ErrIdempotencyKeyMismatch
+	}
+
+	return s.toPaymentResponse(payment), nil
+}
+
+// createPayment berisi logika utama pembuatan payment
+func (s *paymentService) createPayment(ctx context.Context, userID uint, req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error) {
 	// Check if payment already exists for this order
 	existingPayment, _ := s.paymentRepo.FindByOrderID(req.OrderID)
 	if existingPayment != nil && !existingPayment.IsFailed() {
@@ -82,97 +249,196 @@ func (s *paymentService) CreatePayment(userID uint, req *dto.CreatePaymentReques
 		return nil, ErrOrderNotPending
 	}
 
-	// Generate transaction ID
-	transactionID := generateTransactionID()
-
 	// Create payment record
 	payment := &entity.Payment{
 		OrderID:       req.OrderID,
 		UserID:        userID,
 		Amount:        order.TotalAmount,
+		Currency:      order.Currency,
 		Method:        req.Method,
 		Status:        entity.PaymentStatusPending,
-		TransactionID: transactionID,
+		TransactionID: generateTransactionID(),
+	}
+	if s.orderExpiry > 0 {
+		expiresAt := time.Now().Add(s.orderExpiry)
+		payment.ExpiresAt = &expiresAt
 	}
 
 	if err := s.paymentRepo.Create(payment); err != nil {
-		return nil, err
+		if !database.IsUniqueViolation(err) {
+			return nil, err
+		}
+		// Tabrakan transaction ID (unique index) sangat jarang, cukup generate ulang sekali
+		payment.ID = 0
+		payment.TransactionID = generateTransactionID()
+		if err := s.paymentRepo.Create(payment); err != nil {
+			return nil, err
+		}
 	}
+	transactionID := payment.TransactionID
 
-	// Start async payment processing (Goroutine)
-	go s.processPaymentAsync(payment.ID, transactionID)
+	// Start async payment processing (Goroutine). Context request tidak diteruskan karena
+	// dibatalkan setelah response dikirim; cukup request ID-nya.
+	requestID := logger.RequestIDFromContext(ctx)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.processPaymentAsync(requestID, payment.ID, transactionID)
+	}()
 
 	return s.toPaymentResponse(payment), nil
 }
 
 // processPaymentAsync memproses payment secara async dengan Goroutine
-func (s *paymentService) processPaymentAsync(paymentID uint, transactionID string) {
-	log.Printf("[Payment] Starting async processing for transaction: %s", transactionID)
+func (s *paymentService) processPaymentAsync(requestID string, paymentID uint, transactionID string) {
+	logger.Info().
+		Str("request_id", requestID).
+		Str("transaction_id", transactionID).
+		Uint("payment_id", paymentID).
+		Msg("Starting async payment processing")
 
 	// Update status to PROCESSING
 	payment, err := s.paymentRepo.FindByID(paymentID)
 	if err != nil {
-		log.Printf("[Payment] Error finding payment: %v", err)
+		logger.Error().Err(err).
+			Str("request_id", requestID).
+			Str("transaction_id", transactionID).
+			Uint("payment_id", paymentID).
+			Msg("Failed to find payment")
+		return
+	}
+	// Update bersyarat agar status akhir dari webhook yang tiba lebih dulu tidak tertimpa PROCESSING
+	if ok, err := s.paymentRepo.MarkProcessingIfPending(paymentID); err != nil || !ok {
+		logger.Warn().Err(err).
+			Str("request_id", requestID).
+			Str("transaction_id", transactionID).
+			Uint("payment_id", paymentID).
+			Uint("order_id", payment.OrderID).
+			Msg("Payment not processed by simulator (already processed or error)")
 		return
 	}
 	payment.MarkAsProcessing()
-	s.paymentRepo.Update(payment)
-
-	// Simulate payment gateway delay (2-5 seconds)
-	delay := time.Duration(2+rand.Intn(4)) * time.Second
-	log.Printf("[Payment] Processing payment %s, waiting %v...", transactionID, delay)
-	time.Sleep(delay)
+	s.publishStatusChange(payment)
+
+	// Simulate payment gateway delay
+	delay := s.simulator.delay()
+	logger.Info().
+		Str("request_id", requestID).
+		Str("transaction_id", transactionID).
+		Uint("payment_id", paymentID).
+		Uint("order_id", payment.OrderID).
+		Dur("delay", delay).
+		Msg("Processing payment")
+	select {
+	case <-s.simulator.After(delay):
+	case <-s.ctx.Done():
+		logger.Warn().
+			Str("request_id", requestID).
+			Str("transaction_id", transactionID).
+			Uint("payment_id", paymentID).
+			Uint("order_id", payment.OrderID).
+			Msg("Payment processing aborted by shutdown")
+		return
+	}
 
-	// Simulate success/failure (90% success rate)
-	isSuccess := rand.Float32() < 0.9
+	// Simulate success/failure sesuai SuccessRate
+	isSuccess := s.simulator.succeeds()
 
 	if isSuccess {
 		// Mark payment as success
 		payment.MarkAsSuccess()
-		if err := s.paymentRepo.Update(payment); err != nil {
-			log.Printf("[Payment] Error updating payment status: %v", err)
-			return
-		}
-
-		// Callback to Order Module - Mark order as PAID
-		if err := s.orderService.MarkAsPaid(payment.OrderID); err != nil {
-			log.Printf("[Payment] Error marking order as paid: %v", err)
-			return
-		}
-
-		log.Printf("[Payment] Payment %s SUCCESS! Order %d marked as PAID", transactionID, payment.OrderID)
 	} else {
 		// Mark payment as failed
 		payment.MarkAsFailed("Payment declined by gateway (simulated)")
-		if err := s.paymentRepo.Update(payment); err != nil {
-			log.Printf("[Payment] Error updating payment status: %v", err)
-			return
-		}
+	}
 
-		log.Printf("[Payment] Payment %s FAILED!", transactionID)
+	if ok, err := s.paymentRepo.FinalizeIfOpen(payment); err != nil || !ok {
+		logger.Warn().Err(err).
+			Str("request_id", requestID).
+			Str("transaction_id", transactionID).
+			Uint("payment_id", paymentID).
+			Uint("order_id", payment.OrderID).
+			Msg("Payment not finalized by simulator (already processed or error)")
+		return
 	}
+
+	// Order Module men-subscribe PaymentSucceeded untuk menandai order sebagai PAID
+	ctx := logger.WithRequestID(context.Background(), requestID)
+	if err := s.publishPaymentFinalized(ctx, payment); err != nil {
+		logger.Error().Err(err).
+			Str("request_id", requestID).
+			Str("transaction_id", transactionID).
+			Uint("payment_id", paymentID).
+			Uint("order_id", payment.OrderID).
+			Msg("Failed to handle payment event")
+		return
+	}
+
+	if !isSuccess {
+		logger.Info().
+			Str("request_id", requestID).
+			Str("transaction_id", transactionID).
+			Uint("payment_id", paymentID).
+			Uint("order_id", payment.OrderID).
+			Msg("Payment FAILED")
+		return
+	}
+
+	logger.Info().
+		Str("request_id", requestID).
+		Str("transaction_id", transactionID).
+		Uint("payment_id", paymentID).
+		Uint("order_id", payment.OrderID).
+		Msg("Payment SUCCESS, order marked as PAID")
+}
+
+// publishPaymentFinalized mempublish PaymentSucceeded / PaymentFailed setelah status payment di-commit.
+// Error dari subscriber (mis. order gagal ditandai PAID) dikembalikan ke pemanggil.
+func (s *paymentService) publishPaymentFinalized(ctx context.Context, payment *entity.Payment) error {
+	// Stream status diberi tahu setelah subscriber selesai, agar client melihat SUCCESS saat order sudah PAID
+	defer s.publishStatusChange(payment)
+
+	if payment.IsSuccess() {
+		return s.events.Publish(ctx, events.PaymentSucceeded{
+			PaymentID:     payment.ID,
+			OrderID:       payment.OrderID,
+			UserID:        payment.UserID,
+			TransactionID: payment.TransactionID,
+			Amount:        payment.Amount,
+			Currency:      payment.Currency,
+			Method:        payment.Method,
+		})
+	}
+	return s.events.Publish(ctx, events.PaymentFailed{
+		PaymentID:     payment.ID,
+		OrderID:       payment.OrderID,
+		UserID:        payment.UserID,
+		TransactionID: payment.TransactionID,
+		Amount:        payment.Amount,
+		Currency:      payment.Currency,
+		Method:        payment.Method,
+		FailedReason:  payment.FailedReason,
+	})
+}
+
+// GetSuccessfulPaymentVolume menjumlahkan nominal seluruh payment SUCCESS
+func (s *paymentService) GetSuccessfulPaymentVolume() (money.Money, error) {
+	return s.paymentRepo.SumAmountByStatus(entity.PaymentStatusSuccess)
 }
 
 // GetPayment mengambil payment berdasarkan ID
 func (s *paymentService) GetPayment(userID uint, paymentID uint) (*dto.PaymentResponse, error) {
-	payment, err := s.paymentRepo.FindByID(paymentID)
+	payment, err := s.findOwnedPayment(userID, paymentID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrPaymentNotFound
-		}
 		return nil, err
 	}
 
-	// Check ownership
-	if payment.UserID != userID {
-		return nil, ErrUnauthorized
-	}
-
 	return s.toPaymentResponse(payment), nil
 }
 
-// GetPaymentByOrderID mengambil payment berdasarkan Order ID
-func (s *paymentService) GetPaymentByOrderID(orderID uint) (*dto.PaymentResponse, error) {
+// GetPaymentByOrderID mengambil payment berdasarkan Order ID.
+// Selain admin, hanya pemilik payment yang boleh melihatnya.
+func (s *paymentService) GetPaymentByOrderID(userID uint, orderID uint, isAdmin bool) (*dto.PaymentResponse, error) {
 	payment, err := s.paymentRepo.FindByOrderID(orderID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -180,21 +446,18 @@ func (s *paymentService) GetPaymentByOrderID(orderID uint) (*dto.PaymentResponse
 		}
 		return nil, err
 	}
+
+	// Check ownership
+	if !isAdmin && payment.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
 	return s.toPaymentResponse(payment), nil
 }
 
 // GetMyPayments mengambil payment milik user
 func (s *paymentService) GetMyPayments(userID uint, params *dto.PaymentQueryParams) (*dto.PaymentListResponse, error) {
-	// Set default pagination
-	if params.Page <= 0 {
-		params.Page = 1
-	}
-	if params.Limit <= 0 {
-		params.Limit = 10
-	}
-	if params.Limit > 100 {
-		params.Limit = 100
-	}
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
 
 	payments, total, err := s.paymentRepo.FindByUserID(userID, params)
 	if err != nil {
@@ -206,29 +469,15 @@ func (s *paymentService) GetMyPayments(userID uint, params *dto.PaymentQueryPara
 		paymentResponses = append(paymentResponses, *s.toPaymentResponse(&p))
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(params.Limit)))
-
 	return &dto.PaymentListResponse{
-		Payments:   paymentResponses,
-		Total:      total,
-		Page:       params.Page,
-		Limit:      params.Limit,
-		TotalPages: totalPages,
+		Payments: paymentResponses,
+		Meta:     pagination.BuildMeta(total, params.Page, params.Limit),
 	}, nil
 }
 
 // GetAllPayments mengambil semua payment (untuk admin)
 func (s *paymentService) GetAllPayments(params *dto.PaymentQueryParams) (*dto.PaymentListResponse, error) {
-	// Set default pagination
-	if params.Page <= 0 {
-		params.Page = 1
-	}
-	if params.Limit <= 0 {
-		params.Limit = 10
-	}
-	if params.Limit > 100 {
-		params.Limit = 100
-	}
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
 
 	payments, total, err := s.paymentRepo.FindAll(params)
 	if err != nil {
@@ -240,14 +489,31 @@ func (s *paymentService) GetAllPayments(params *dto.PaymentQueryParams) (*dto.Pa
 		paymentResponses = append(paymentResponses, *s.toPaymentResponse(&p))
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(params.Limit)))
-
 	return &dto.PaymentListResponse{
-		Payments:   paymentResponses,
-		Total:      total,
-		Page:       params.Page,
-		Limit:      params.Limit,
-		TotalPages: totalPages,
+		Payments: paymentResponses,
+		Meta:     pagination.BuildMeta(total, params.Page, params.Limit),
+	}, nil
+}
+
+// GetPaymentByTransactionID mencari payment berdasarkan Transaction ID gateway beserta order-nya (untuk admin).
+// Order yang sudah tidak ada tidak menggagalkan pencarian; field order dikosongkan.
+func (s *paymentService) GetPaymentByTransactionID(transactionID string) (*dto.PaymentDetailResponse, error) {
+	payment, err := s.paymentRepo.FindByTransactionID(transactionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	order, err := s.orderService.GetOrderByID(payment.OrderID)
+	if err != nil && !errors.Is(err, service.ErrOrderNotFound) {
+		return nil, err
+	}
+
+	return &dto.PaymentDetailResponse{
+		PaymentResponse: *s.toPaymentResponse(payment),
+		Order:           order,
 	}, nil
 }
 
@@ -263,16 +529,88 @@ func (s *paymentService) ProcessPaymentCallback(transactionID string, status str
 		return ErrPaymentAlreadyProcessed
 	}
 
-	if status == "SUCCESS" {
+	// Payment yang sudah lewat batas waktu tidak boleh lagi berhasil
+	expired := status == entity.PaymentStatusSuccess && payment.IsExpired(time.Now())
+
+	if expired {
+		payment.MarkAsFailed(paymentExpiredReason)
+	} else if status == entity.PaymentStatusSuccess {
 		payment.MarkAsSuccess()
-		if err := s.paymentRepo.Update(payment); err != nil {
-			return err
-		}
-		return s.orderService.MarkAsPaid(payment.OrderID)
 	} else {
 		payment.MarkAsFailed(failedReason)
-		return s.paymentRepo.Update(payment)
 	}
+
+	// Update bersyarat agar callback duplikat yang datang bersamaan hanya diproses sekali
+	ok, err := s.paymentRepo.FinalizeIfOpen(payment)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrPaymentAlreadyProcessed
+	}
+	if err := s.publishPaymentFinalized(context.Background(), payment); err != nil {
+		return err
+	}
+
+	if expired {
+		return ErrPaymentExpired
+	}
+	return nil
+}
+
+// RefundPayment mengembalikan dana payment SUCCESS dan mengubah order menjadi REFUNDED.
+// Status payment dan order diperbarui dalam satu transaction.
+func (s *paymentService) RefundPayment(paymentID uint, reason string) (*dto.PaymentResponse, error) {
+	payment, err := s.paymentRepo.FindByID(paymentID)
+	if err != nil {
+		return nil, ErrPaymentNotFound
+	}
+
+	if !payment.IsSuccess() {
+		return nil, ErrPaymentNotRefundable
+	}
+	payment.MarkAsRefunded(reason)
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Update bersyarat agar refund ganda yang datang bersamaan hanya diproses sekali
+	ok, err := s.paymentRepo.WithTx(tx).RefundIfSuccess(payment)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if !ok {
+		tx.Rollback()
+		return nil, ErrPaymentNotRefundable
+	}
+
+	notifyOrderRefunded, err := s.orderService.MarkAsRefundedTx(tx, payment.OrderID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, service.ErrInvalidStatus) {
+			return nil, ErrOrderNotRefundable
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	notifyOrderRefunded()
+	s.publishStatusChange(payment)
+
+	logger.Info().
+		Str("transaction_id", payment.TransactionID).
+		Uint("payment_id", payment.ID).
+		Uint("order_id", payment.OrderID).
+		Msg("Payment REFUNDED, order marked as REFUNDED")
+
+	return s.toPaymentResponse(payment), nil
 }
 
 // Helper Functions
@@ -283,6 +621,7 @@ func (s *paymentService) toPaymentResponse(p *entity.Payment) *dto.PaymentRespon
 		OrderID:       p.OrderID,
 		UserID:        p.UserID,
 		Amount:        p.Amount,
+		Currency:      p.Currency,
 		Method:        p.Method,
 		Status:        p.Status,
 		TransactionID: p.TransactionID,
@@ -293,13 +632,19 @@ func (s *paymentService) toPaymentResponse(p *entity.Payment) *dto.PaymentRespon
 	if p.PaidAt != nil {
 		resp.PaidAt = p.PaidAt.Format(time.RFC3339)
 	}
+	if p.ExpiresAt != nil {
+		resp.ExpiresAt = p.ExpiresAt.Format(time.RFC3339)
+	}
+	if p.RefundedAt != nil {
+		resp.RefundedAt = p.RefundedAt.Format(time.RFC3339)
+		resp.RefundReason = p.RefundReason
+	}
 
 	return resp
 }
 
-// generateTransactionID membuat transaction ID unik
+// generateTransactionID membuat transaction ID unik dari UUID v4 (crypto/rand),
+// aman dipanggil paralel dan tidak berulang setelah restart
 func generateTransactionID() string {
-	timestamp := time.Now().UnixNano()
-	random := rand.Intn(10000)
-	return fmt.Sprintf("TXN-%d-%04d", timestamp, random)
+	return "TXN-" + uuid.NewString()
 }
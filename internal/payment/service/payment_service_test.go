@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentService_ShutdownWaitsForInFlightPayments(t *testing.T) {
+	svc := NewPaymentService(nil, nil, nil, nil, 0, DefaultSimulatorConfig(), nil).(*paymentService)
+
+	svc.wg.Add(1)
+	go func() {
+		defer svc.wg.Done()
+		time.Sleep(50 * time.Millisecond)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, svc.Shutdown(ctx))
+}
+
+func TestPaymentService_ShutdownTimesOut(t *testing.T) {
+	svc := NewPaymentService(nil, nil, nil, nil, 0, DefaultSimulatorConfig(), nil).(*paymentService)
+
+	// Goroutine yang menunggu gateway berhenti saat context service dibatalkan
+	svc.wg.Add(1)
+	go func() {
+		defer svc.wg.Done()
+		<-svc.ctx.Done()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, svc.Shutdown(ctx), context.DeadlineExceeded)
+	assert.Error(t, svc.ctx.Err())
+}
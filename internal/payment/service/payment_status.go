@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"gorm.io/gorm"
+)
+
+// statusPollInterval adalah jeda antar pengecekan status saat long-polling (var agar bisa dipercepat di test)
+var statusPollInterval = 500 * time.Millisecond
+
+// GetPaymentStatus mengambil status ringkas payment milik user. Jika wait > 0 dan payment
+// masih PENDING/PROCESSING, request ditahan sampai status final, wait habis, ctx dibatalkan
+// (client putus) atau server shutdown; status terakhir tetap dikembalikan saat wait habis.
+func (s *paymentService) GetPaymentStatus(ctx context.Context, userID uint, paymentID uint, wait time.Duration) (*dto.PaymentStatusResponse, error) {
+	payment, err := s.findOwnedPayment(userID, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if wait <= 0 || !payment.IsOpen() {
+		return toPaymentStatusResponse(payment), nil
+	}
+
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(statusPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.ctx.Done():
+			return toPaymentStatusResponse(payment), nil
+		case <-deadline.C:
+			return toPaymentStatusResponse(payment), nil
+		case <-ticker.C:
+			payment, err = s.paymentRepo.FindByID(paymentID)
+			if err != nil {
+				return nil, err
+			}
+			if !payment.IsOpen() {
+				return toPaymentStatusResponse(payment), nil
+			}
+		}
+	}
+}
+
+// findOwnedPayment mengambil payment dan memastikan payment milik userID
+func (s *paymentService) findOwnedPayment(userID uint, paymentID uint) (*entity.Payment, error) {
+	payment, err := s.paymentRepo.FindByID(paymentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+	if payment.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return payment, nil
+}
+
+// toPaymentStatusResponse mengubah payment menjadi response status ringkas
+func toPaymentStatusResponse(p *entity.Payment) *dto.PaymentStatusResponse {
+	resp := &dto.PaymentStatusResponse{
+		Status:        p.Status,
+		TransactionID: p.TransactionID,
+	}
+	if p.PaidAt != nil {
+		resp.PaidAt = p.PaidAt.Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useFastStatusPolling(t *testing.T) {
+	previous := statusPollInterval
+	statusPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { statusPollInterval = previous })
+}
+
+func TestGetPaymentStatus_ReturnsImmediatelyWithoutWait(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+	_, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusProcessing)
+
+	status, err := svc.GetPaymentStatus(context.Background(), 1, payment.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, entity.PaymentStatusProcessing, status.Status)
+	assert.Equal(t, payment.TransactionID, status.TransactionID)
+	assert.Empty(t, status.PaidAt)
+
+	_, err = svc.GetPaymentStatus(context.Background(), 2, payment.ID, 0)
+	assert.Equal(t, ErrUnauthorized, err)
+
+	_, err = svc.GetPaymentStatus(context.Background(), 1, payment.ID+100, 0)
+	assert.Equal(t, ErrPaymentNotFound, err)
+}
+
+func TestGetPaymentStatus_WaitsForFinalStatus(t *testing.T) {
+	useFastStatusPolling(t)
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+	_, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusProcessing)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		payment.MarkAsSuccess()
+		db.Save(payment)
+	}()
+
+	status, err := svc.GetPaymentStatus(context.Background(), 1, payment.ID, 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, entity.PaymentStatusSuccess, status.Status)
+	assert.NotEmpty(t, status.PaidAt)
+}
+
+func TestGetPaymentStatus_ReturnsLatestStatusWhenWaitExpires(t *testing.T) {
+	useFastStatusPolling(t)
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+	_, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusProcessing)
+
+	start := time.Now()
+	status, err := svc.GetPaymentStatus(context.Background(), 1, payment.ID, 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, entity.PaymentStatusProcessing, status.Status)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Client yang memutus koneksi menghentikan penantian
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = svc.GetPaymentStatus(ctx, 1, payment.ID, 5*time.Second)
+	assert.ErrorIs(t, err, context.Canceled)
+}
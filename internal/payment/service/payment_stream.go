@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+)
+
+// paymentStatusChannel adalah channel Redis pub/sub untuk perubahan status satu payment
+func paymentStatusChannel(paymentID uint) string {
+	return database.RedisKey(fmt.Sprintf("payment:status:%d", paymentID))
+}
+
+// publishStatusChange mempublish status terbaru payment ke Redis agar stream SSE yang terbuka
+// menerima update. Tanpa Redis tidak ada yang dipublish dan stream memakai polling database.
+func (s *paymentService) publishStatusChange(payment *entity.Payment) {
+	if s.redisClient == nil {
+		return
+	}
+
+	payload, err := json.Marshal(toPaymentStatusResponse(payment))
+	if err != nil {
+		return
+	}
+	if err := s.redisClient.Publish(context.Background(), paymentStatusChannel(payment.ID), payload).Err(); err != nil {
+		logger.Warn().Err(err).
+			Uint("payment_id", payment.ID).
+			Str("status", payment.Status).
+			Msg("Failed to publish payment status")
+	}
+}
+
+// StreamPaymentStatus mengirim status payment milik user ke send: status saat ini, lalu setiap
+// perubahan status sampai status final, ctx selesai (client putus atau umur stream habis), atau
+// server shutdown. Error dari send (mis. koneksi client terputus) menghentikan stream.
+func (s *paymentService) StreamPaymentStatus(ctx context.Context, userID uint, paymentID uint, send func(*dto.PaymentStatusResponse) error) error {
+	payment, err := s.findOwnedPayment(userID, paymentID)
+	if err != nil {
+		return err
+	}
+	if err := send(toPaymentStatusResponse(payment)); err != nil {
+		return err
+	}
+	if !payment.IsOpen() {
+		return nil
+	}
+
+	if s.redisClient == nil {
+		return s.pollStatusChanges(ctx, payment, send)
+	}
+	return s.subscribeStatusChanges(ctx, payment, send)
+}
+
+// subscribeStatusChanges meneruskan status dari channel Redis payment ke send
+func (s *paymentService) subscribeStatusChanges(ctx context.Context, payment *entity.Payment, send func(*dto.PaymentStatusResponse) error) error {
+	pubsub := s.redisClient.Subscribe(ctx, paymentStatusChannel(payment.ID))
+	defer pubsub.Close()
+
+	// Tunggu konfirmasi subscribe; jika Redis tidak bisa dipakai, stream jatuh ke polling
+	if _, err := pubsub.Receive(ctx); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logger.Warn().Err(err).Uint("payment_id", payment.ID).Msg("Payment status subscribe failed, falling back to polling")
+		return s.pollStatusChanges(ctx, payment, send)
+	}
+
+	// Status bisa berubah antara pembacaan awal dan subscribe, sehingga dibaca ulang sekali
+	lastStatus := payment.Status
+	current, err := s.paymentRepo.FindByID(payment.ID)
+	if err != nil {
+		return err
+	}
+	if current.Status != lastStatus {
+		if err := send(toPaymentStatusResponse(current)); err != nil {
+			return err
+		}
+		if !current.IsOpen() {
+			return nil
+		}
+		lastStatus = current.Status
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			var status dto.PaymentStatusResponse
+			if err := json.Unmarshal([]byte(msg.Payload), &status); err != nil || status.Status == lastStatus {
+				continue
+			}
+			if err := send(&status); err != nil {
+				return err
+			}
+			if !entity.IsOpenStatus(status.Status) {
+				return nil
+			}
+			lastStatus = status.Status
+		}
+	}
+}
+
+// pollStatusChanges membaca status payment dari database setiap statusPollInterval (tanpa Redis)
+func (s *paymentService) pollStatusChanges(ctx context.Context, payment *entity.Payment, send func(*dto.PaymentStatusResponse) error) error {
+	ticker := time.NewTicker(statusPollInterval)
+	defer ticker.Stop()
+
+	lastStatus := payment.Status
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.ctx.Done():
+			return nil
+		case <-ticker.C:
+			current, err := s.paymentRepo.FindByID(payment.ID)
+			if err != nil {
+				return err
+			}
+			if current.Status == lastStatus {
+				continue
+			}
+			if err := send(toPaymentStatusResponse(current)); err != nil {
+				return err
+			}
+			if !current.IsOpen() {
+				return nil
+			}
+			lastStatus = current.Status
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectStatuses mengembalikan fungsi send yang mencatat setiap status yang dikirim stream
+func collectStatuses(statuses *[]string) func(*dto.PaymentStatusResponse) error {
+	return func(status *dto.PaymentStatusResponse) error {
+		*statuses = append(*statuses, status.Status)
+		return nil
+	}
+}
+
+func TestStreamPaymentStatus_PushesEachTransitionUntilFinal(t *testing.T) {
+	useFastStatusPolling(t)
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+	_, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusPending)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		payment.MarkAsProcessing()
+		db.Save(payment)
+		time.Sleep(50 * time.Millisecond)
+		payment.MarkAsSuccess()
+		db.Save(payment)
+	}()
+
+	var statuses []string
+	err := svc.StreamPaymentStatus(context.Background(), 1, payment.ID, collectStatuses(&statuses))
+	require.NoError(t, err)
+	assert.Equal(t, []string{entity.PaymentStatusPending, entity.PaymentStatusProcessing, entity.PaymentStatusSuccess}, statuses)
+}
+
+func TestStreamPaymentStatus_FinalPaymentClosesImmediately(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+	_, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPaid, entity.PaymentStatusSuccess)
+
+	var statuses []string
+	require.NoError(t, svc.StreamPaymentStatus(context.Background(), 1, payment.ID, collectStatuses(&statuses)))
+	assert.Equal(t, []string{entity.PaymentStatusSuccess}, statuses)
+}
+
+func TestStreamPaymentStatus_EnforcesOwnership(t *testing.T) {
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+	_, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusProcessing)
+
+	var statuses []string
+	err := svc.StreamPaymentStatus(context.Background(), 2, payment.ID, collectStatuses(&statuses))
+	assert.Equal(t, ErrUnauthorized, err)
+	assert.Empty(t, statuses)
+
+	err = svc.StreamPaymentStatus(context.Background(), 1, payment.ID+100, collectStatuses(&statuses))
+	assert.Equal(t, ErrPaymentNotFound, err)
+}
+
+func TestStreamPaymentStatus_StopsWhenLifetimeExpires(t *testing.T) {
+	useFastStatusPolling(t)
+	db := setupPaymentDB(t)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+	_, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusProcessing)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	var statuses []string
+	err := svc.StreamPaymentStatus(ctx, 1, payment.ID, collectStatuses(&statuses))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{entity.PaymentStatusProcessing}, statuses)
+	assert.Less(t, time.Since(start), time.Second)
+}
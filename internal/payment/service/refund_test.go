@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	orderService "github.com/akbarwjyy/go-commerce-api/internal/order/service"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/repository"
+	productEntity "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database/dbtest"
+	"github.com/akbarwjyy/go-commerce-api/pkg/events"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupPaymentDB(t *testing.T) *gorm.DB {
+	return dbtest.NewSQLite(t,
+		&productEntity.Category{},
+		&productEntity.Product{},
+		&productEntity.ProductVariant{},
+		&productEntity.InventoryLog{},
+		&productEntity.PriceHistory{},
+		&productEntity.StockReservation{},
+		&orderEntity.Order{},
+		&orderEntity.OrderItem{},
+		&orderEntity.OrderStatusHistory{},
+		&entity.Payment{},
+	)
+}
+
+// newTestPaymentService membuat PaymentService dengan Order dan Product Module asli di atas db
+func newTestPaymentService(db *gorm.DB, simulator SimulatorConfig) PaymentService {
+	productSvc := productService.NewProductService(productService.ProductServiceDeps{DB: db})
+	bus := events.NewBus()
+	orderSvc := orderService.NewOrderService(orderService.OrderServiceDeps{
+		ProductService: productSvc,
+		DB:             db,
+		EventBus:       bus,
+	})
+	orderService.SubscribeToEvents(bus, orderSvc)
+	return NewPaymentService(repository.NewPaymentRepository(db), orderSvc, nil, db, 0, simulator, bus)
+}
+
+// seedOrderWithPayment membuat produk, order berisi 3 unit, dan payment untuk order tersebut
+func seedOrderWithPayment(t *testing.T, db *gorm.DB, orderStatus string, paymentStatus string) (*orderEntity.Order, *entity.Payment, *productEntity.Product) {
+	category := &productEntity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(category).Error)
+	product := &productEntity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(1000), Stock: 7, CategoryID: category.ID, SellerID: 1}
+	require.NoError(t, db.Create(product).Error)
+
+	order := &orderEntity.Order{
+		UserID:       1,
+		TotalAmount:  money.FromFloat(3000),
+		Status:       orderStatus,
+		ShippingAddr: "Jl. Sudirman No. 1",
+		Items: []orderEntity.OrderItem{
+			{ProductID: product.ID, Quantity: 3, Price: money.FromFloat(1000), Subtotal: money.FromFloat(3000)},
+		},
+	}
+	require.NoError(t, db.Create(order).Error)
+
+	payment := &entity.Payment{
+		OrderID:       order.ID,
+		UserID:        1,
+		Amount:        order.TotalAmount,
+		Method:        entity.PaymentMethodBankTransfer,
+		Status:        paymentStatus,
+		TransactionID: "TXN-" + t.Name(),
+	}
+	if paymentStatus == entity.PaymentStatusSuccess {
+		payment.MarkAsSuccess()
+	}
+	require.NoError(t, db.Create(payment).Error)
+	return order, payment, product
+}
+
+func TestRefundPayment_PaidOrderRestoresStock(t *testing.T) {
+	db := setupPaymentDB(t)
+	order, payment, product := seedOrderWithPayment(t, db, orderEntity.OrderStatusPaid, entity.PaymentStatusSuccess)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+
+	result, err := svc.RefundPayment(payment.ID, "Customer cancelled")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PaymentStatusRefunded, result.Status)
+	assert.Equal(t, "Customer cancelled", result.RefundReason)
+	assert.NotEmpty(t, result.RefundedAt)
+
+	var reloadedOrder orderEntity.Order
+	require.NoError(t, db.First(&reloadedOrder, order.ID).Error)
+	assert.Equal(t, orderEntity.OrderStatusRefunded, reloadedOrder.Status)
+
+	var reloadedProduct productEntity.Product
+	require.NoError(t, db.First(&reloadedProduct, product.ID).Error)
+	assert.Equal(t, 10, reloadedProduct.Stock)
+
+	// Refund kedua ditolak karena payment sudah REFUNDED
+	_, err = svc.RefundPayment(payment.ID, "again")
+	assert.ErrorIs(t, err, ErrPaymentNotRefundable)
+}
+
+func TestRefundPayment_RollsBackWhenOrderNotRefundable(t *testing.T) {
+	db := setupPaymentDB(t)
+	_, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusCancelled, entity.PaymentStatusSuccess)
+	svc := newTestPaymentService(db, DefaultSimulatorConfig())
+
+	_, err := svc.RefundPayment(payment.ID, "Customer cancelled")
+	assert.ErrorIs(t, err, ErrOrderNotRefundable)
+
+	var reloaded entity.Payment
+	require.NoError(t, db.First(&reloaded, payment.ID).Error)
+	assert.Equal(t, entity.PaymentStatusSuccess, reloaded.Status)
+	assert.Nil(t, reloaded.RefundedAt)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"math/rand"
+	"time"
+)
+
+// SimulatorConfig mengatur perilaku simulasi payment gateway di processPaymentAsync.
+// Random dan After bisa diganti di test agar hasil dan delay deterministik.
+type SimulatorConfig struct {
+	SuccessRate float64       // peluang payment berhasil, 0..1
+	MinDelay    time.Duration // delay minimum gateway
+	MaxDelay    time.Duration // delay maksimum gateway
+
+	Random func() float64                         // sumber angka acak [0, 1), nil = math/rand
+	After  func(d time.Duration) <-chan time.Time // clock untuk delay, nil = time.After
+}
+
+// DefaultSimulatorConfig mengembalikan perilaku simulasi bawaan: 90% sukses dengan delay 2-5 detik
+func DefaultSimulatorConfig() SimulatorConfig {
+	return SimulatorConfig{
+		SuccessRate: 0.9,
+		MinDelay:    2 * time.Second,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// withDefaults mengisi sumber acak dan clock yang belum di-inject
+func (c SimulatorConfig) withDefaults() SimulatorConfig {
+	if c.Random == nil {
+		c.Random = rand.Float64
+	}
+	if c.After == nil {
+		c.After = time.After
+	}
+	return c
+}
+
+// delay memilih delay acak di antara MinDelay dan MaxDelay
+func (c SimulatorConfig) delay() time.Duration {
+	if c.MaxDelay <= c.MinDelay {
+		return c.MinDelay
+	}
+	return c.MinDelay + time.Duration(c.Random()*float64(c.MaxDelay-c.MinDelay))
+}
+
+// succeeds menentukan apakah payment simulasi berhasil
+func (c SimulatorConfig) succeeds() bool {
+	return c.Random() < c.SuccessRate
+}
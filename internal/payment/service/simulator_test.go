@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	orderService "github.com/akbarwjyy/go-commerce-api/internal/order/service"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// instantAfter adalah clock test yang langsung melewati delay dan mencatat durasinya
+func instantAfter(recorded *time.Duration) func(time.Duration) <-chan time.Time {
+	return func(d time.Duration) <-chan time.Time {
+		*recorded = d
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+}
+
+func TestSimulatorConfig_Delay(t *testing.T) {
+	sim := SimulatorConfig{MinDelay: time.Second, MaxDelay: 3 * time.Second, Random: func() float64 { return 0.5 }}
+	assert.Equal(t, 2*time.Second, sim.delay())
+
+	sim.MaxDelay = 0
+	assert.Equal(t, time.Second, sim.delay())
+}
+
+func TestProcessPaymentAsync_ForcedSuccess(t *testing.T) {
+	db := setupPaymentDB(t)
+	order, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusPending)
+
+	var delay time.Duration
+	svc := newTestPaymentService(db, SimulatorConfig{
+		SuccessRate: 1,
+		Random:      func() float64 { return 0.99 },
+		After:       instantAfter(&delay),
+	}).(*paymentService)
+
+	svc.processPaymentAsync("req-1", payment.ID, payment.TransactionID)
+	assert.Equal(t, time.Duration(0), delay)
+
+	var reloaded entity.Payment
+	require.NoError(t, db.First(&reloaded, payment.ID).Error)
+	assert.Equal(t, entity.PaymentStatusSuccess, reloaded.Status)
+
+	var reloadedOrder orderEntity.Order
+	require.NoError(t, db.First(&reloadedOrder, order.ID).Error)
+	assert.Equal(t, orderEntity.OrderStatusPaid, reloadedOrder.Status)
+}
+
+func TestProcessPaymentAsync_ForcedFailure(t *testing.T) {
+	db := setupPaymentDB(t)
+	order, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusPending)
+
+	var delay time.Duration
+	svc := newTestPaymentService(db, SimulatorConfig{
+		SuccessRate: 0,
+		Random:      func() float64 { return 0 },
+		After:       instantAfter(&delay),
+	}).(*paymentService)
+
+	svc.processPaymentAsync("req-2", payment.ID, payment.TransactionID)
+
+	var reloaded entity.Payment
+	require.NoError(t, db.First(&reloaded, payment.ID).Error)
+	assert.Equal(t, entity.PaymentStatusFailed, reloaded.Status)
+	assert.NotEmpty(t, reloaded.FailedReason)
+
+	var reloadedOrder orderEntity.Order
+	require.NoError(t, db.First(&reloadedOrder, order.ID).Error)
+	assert.Equal(t, orderEntity.OrderStatusPending, reloadedOrder.Status)
+}
+
+func TestProcessPaymentAsync_KeepsPaymentFinalizedByWebhook(t *testing.T) {
+	db := setupPaymentDB(t)
+	order, payment, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusPending)
+	paymentSvc, orderSvc, bus := newEventTestPaymentService(db)
+	orderService.SubscribeToEvents(bus, orderSvc)
+
+	var failed []events.PaymentFailed
+	bus.Subscribe(events.NamePaymentFailed, func(ctx context.Context, event events.Event) error {
+		failed = append(failed, event.(events.PaymentFailed))
+		return nil
+	})
+
+	// Webhook gateway tiba sebelum goroutine simulator sempat berjalan
+	require.NoError(t, paymentSvc.ProcessPaymentCallback(payment.TransactionID, entity.PaymentStatusSuccess, ""))
+
+	var delay time.Duration
+	svc := paymentSvc.(*paymentService)
+	svc.simulator = SimulatorConfig{SuccessRate: 0, Random: func() float64 { return 0 }, After: instantAfter(&delay)}
+	svc.processPaymentAsync("req-3", payment.ID, payment.TransactionID)
+
+	var reloaded entity.Payment
+	require.NoError(t, db.First(&reloaded, payment.ID).Error)
+	assert.Equal(t, entity.PaymentStatusSuccess, reloaded.Status)
+	assert.NotNil(t, reloaded.PaidAt)
+	assert.Empty(t, failed)
+
+	var reloadedOrder orderEntity.Order
+	require.NoError(t, db.First(&reloadedOrder, order.ID).Error)
+	assert.Equal(t, orderEntity.OrderStatusPaid, reloadedOrder.Status)
+}
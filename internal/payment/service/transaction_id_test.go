@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/payment/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// collidingPaymentRepository menolak Create pertama seolah transaction ID sudah dipakai
+type collidingPaymentRepository struct {
+	repository.PaymentRepository
+	attempts []string
+}
+
+func (r *collidingPaymentRepository) Create(payment *entity.Payment) error {
+	r.attempts = append(r.attempts, payment.TransactionID)
+	if len(r.attempts) == 1 {
+		return fmt.Errorf("insert payment: %w", gorm.ErrDuplicatedKey)
+	}
+	return r.PaymentRepository.Create(payment)
+}
+
+func TestGenerateTransactionID_UniqueUnderConcurrency(t *testing.T) {
+	const workers, perWorker = 50, 200
+
+	var mu sync.Mutex
+	seen := make(map[string]struct{}, workers*perWorker)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				id := generateTransactionID()
+				mu.Lock()
+				seen[id] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, seen, workers*perWorker)
+	for id := range seen {
+		require.True(t, strings.HasPrefix(id, "TXN-"), id)
+	}
+}
+
+func TestCreatePayment_RegeneratesTransactionIDOnCollision(t *testing.T) {
+	db := setupPaymentDB(t)
+	// Payment lama gagal sehingga order boleh dibayar ulang
+	order, _, _ := seedOrderWithPayment(t, db, orderEntity.OrderStatusPending, entity.PaymentStatusFailed)
+
+	var delay time.Duration
+	svc := newTestPaymentService(db, SimulatorConfig{
+		SuccessRate: 1,
+		Random:      func() float64 { return 0 },
+		After:       instantAfter(&delay),
+	}).(*paymentService)
+	repo := &collidingPaymentRepository{PaymentRepository: svc.paymentRepo}
+	svc.paymentRepo = repo
+
+	resp, err := svc.CreatePayment(context.Background(), 1, &dto.CreatePaymentRequest{
+		OrderID: order.ID,
+		Method:  entity.PaymentMethodBankTransfer,
+	}, "")
+	require.NoError(t, err)
+	require.NoError(t, svc.Shutdown(context.Background()))
+
+	require.Len(t, repo.attempts, 2)
+	assert.NotEqual(t, repo.attempts[0], repo.attempts[1])
+	assert.Equal(t, repo.attempts[1], resp.TransactionID)
+
+	var stored entity.Payment
+	require.NoError(t, db.First(&stored, resp.ID).Error)
+	assert.Equal(t, repo.attempts[1], stored.TransactionID)
+}
@@ -1,24 +1,50 @@
 package dto
 
+import (
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+)
+
 // CreateProductRequest untuk request membuat produk baru
 type CreateProductRequest struct {
-	Name        string  `json:"name" binding:"required,min=2,max=200"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"gte=0"`
-	CategoryID  uint    `json:"category_id"`
-	ImageURL    string  `json:"image_url"`
+	Name        string      `json:"name" binding:"required,min=2,max=200"`
+	SKU         string      `json:"sku" binding:"required,max=100,sku" example:"LAPTOP-15-BLK"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price" binding:"required,gt=0" swaggertype:"string" example:"199.99"`
+	Currency    string      `json:"currency" binding:"omitempty,iso4217" example:"IDR"` // kosong = BASE_CURRENCY
+	Stock       int         `json:"stock" binding:"gte=0"`
+	CategoryID  uint        `json:"category_id"`
+	ImageURL    string      `json:"image_url"`
+	// Kosong = memakai default LOW_STOCK_THRESHOLD
+	LowStockThreshold *int `json:"low_stock_threshold,omitempty" binding:"omitempty,gte=0"`
+}
+
+// ProductImportRowError untuk satu baris CSV yang gagal diimport
+type ProductImportRowError struct {
+	Line  int    `json:"line" example:"3"`
+	Error string `json:"error" example:"price must be a positive amount"`
+}
+
+// ProductImportResult untuk ringkasan hasil import produk dari CSV
+type ProductImportResult struct {
+	TotalRows  int                     `json:"total_rows"`
+	Created    int                     `json:"created"`
+	ProductIDs []uint                  `json:"product_ids"`
+	Errors     []ProductImportRowError `json:"errors"`
 }
 
-// UpdateProductRequest untuk request update produk
+// UpdateProductRequest untuk request update produk (PUT/PATCH).
+// Field nil berarti tidak diubah; nilai kosong/nol yang dikirim diterapkan apa adanya.
 type UpdateProductRequest struct {
-	Name        string  `json:"name" binding:"omitempty,min=2,max=200"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"omitempty,gt=0"`
-	Stock       int     `json:"stock" binding:"omitempty,gte=0"`
-	CategoryID  uint    `json:"category_id"`
-	ImageURL    string  `json:"image_url"`
-	IsActive    *bool   `json:"is_active"`
+	Name              *string      `json:"name" binding:"omitnil,min=2,max=200"`
+	SKU               *string      `json:"sku" binding:"omitnil,max=100,sku" example:"LAPTOP-15-BLK"`
+	Description       *string      `json:"description"`
+	Price             *money.Money `json:"price" binding:"omitnil,gt=0" swaggertype:"string" example:"199.99"`
+	Stock             *int         `json:"stock" binding:"omitnil,gte=0"`
+	CategoryID        *uint        `json:"category_id" binding:"omitnil,gt=0"`
+	ImageURL          *string      `json:"image_url"`
+	IsActive          *bool        `json:"is_active"`
+	LowStockThreshold *int         `json:"low_stock_threshold,omitempty" binding:"omitnil,gte=0"`
 }
 
 // UpdateStockRequest untuk request update stok
@@ -27,39 +53,186 @@ type UpdateStockRequest struct {
 	Action   string `json:"action" binding:"required,oneof=add reduce"`
 }
 
+// AdminSetStockRequest untuk request admin mengoreksi stok produk ke nilai absolut
+type AdminSetStockRequest struct {
+	Stock  *int   `json:"stock" binding:"required,gte=0"`
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
 // ProductResponse untuk response data produk
 type ProductResponse struct {
-	ID          uint              `json:"id"`
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	Price       float64           `json:"price"`
-	Stock       int               `json:"stock"`
-	CategoryID  uint              `json:"category_id"`
-	Category    *CategoryResponse `json:"category,omitempty"`
-	SellerID    uint              `json:"seller_id"`
-	ImageURL    string            `json:"image_url,omitempty"`
-	IsActive    bool              `json:"is_active"`
+	ID                uint              `json:"id"`
+	Name              string            `json:"name"`
+	SKU               string            `json:"sku"`
+	Description       string            `json:"description"`
+	Price             money.Money       `json:"price" swaggertype:"string" example:"199.99"`
+	Currency          string            `json:"currency" example:"IDR"`
+	Stock             int               `json:"stock"`
+	AvailableStock    int               `json:"available_stock"` // stok dikurangi reservasi order PENDING
+	CategoryID        uint              `json:"category_id"`
+	Category          *CategoryResponse `json:"category,omitempty"`
+	SellerID          uint              `json:"seller_id"`
+	ImageURL          string            `json:"image_url,omitempty"`
+	IsActive          bool              `json:"is_active"`
+	AverageRating     float64           `json:"average_rating"`
+	ReviewCount       int               `json:"review_count"`
+	LowStockThreshold int               `json:"low_stock_threshold"`
+	Variants          []VariantResponse `json:"variants,omitempty"`
+	Tags              []string          `json:"tags,omitempty"`
+}
+
+// ProductTagsRequest untuk request memasang atau melepas tag produk
+type ProductTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,min=1,max=20,dive,required,max=50" example:"sale,eco"`
+}
+
+// CreateVariantRequest untuk request menambah varian produk
+type CreateVariantRequest struct {
+	Attributes map[string]string `json:"attributes" binding:"required,min=1" example:"size:M,color:red"`
+	SKU        string            `json:"sku" binding:"required,max=100"`
+	Price      money.Money       `json:"price" binding:"required,gt=0" swaggertype:"string" example:"149.99"`
+	Stock      int               `json:"stock" binding:"gte=0"`
+}
+
+// UpdateVariantRequest untuk request update varian produk
+type UpdateVariantRequest struct {
+	Attributes map[string]string `json:"attributes,omitempty"`
+	SKU        string            `json:"sku" binding:"omitempty,max=100"`
+	Price      money.Money       `json:"price" binding:"omitempty,gt=0" swaggertype:"string" example:"149.99"`
+	Stock      *int              `json:"stock,omitempty" binding:"omitempty,gte=0"`
+}
+
+// VariantResponse untuk response data varian produk
+type VariantResponse struct {
+	ID             uint              `json:"id"`
+	ProductID      uint              `json:"product_id"`
+	Attributes     map[string]string `json:"attributes"`
+	SKU            string            `json:"sku"`
+	Price          money.Money       `json:"price" swaggertype:"string" example:"149.99"`
+	Currency       string            `json:"currency" example:"IDR"`
+	Stock          int               `json:"stock"`
+	AvailableStock int               `json:"available_stock"` // stok dikurangi reservasi order PENDING
+}
+
+// CheckAvailabilityRequest untuk request cek ketersediaan stok beberapa produk sekaligus
+type CheckAvailabilityRequest struct {
+	Items []AvailabilityItemRequest `json:"items" binding:"required,min=1,max=100,dive"`
+}
+
+// AvailabilityItemRequest untuk satu produk yang dicek ketersediaannya
+type AvailabilityItemRequest struct {
+	ProductID uint `json:"product_id" binding:"required"`
+	Quantity  int  `json:"quantity" binding:"required,min=1"`
+}
+
+// Stock status constants untuk perbandingan produk
+const (
+	StockStatusInStock    = "in_stock"
+	StockStatusLowStock   = "low_stock"
+	StockStatusOutOfStock = "out_of_stock"
+)
+
+// CompareProductsQuery untuk query perbandingan produk (ids dipisah koma, mis. 1,2,3)
+type CompareProductsQuery struct {
+	IDs string `form:"ids" binding:"required"`
+}
+
+// ProductComparisonItem untuk satu produk di perbandingan beserta field turunan untuk dibandingkan
+type ProductComparisonItem struct {
+	Product       ProductResponse `json:"product"`
+	StockStatus   string          `json:"stock_status" example:"in_stock"` // in_stock, low_stock, out_of_stock
+	AverageRating *float64        `json:"average_rating"`                  // null jika belum ada review
+}
+
+// ProductComparisonResponse untuk response perbandingan produk
+type ProductComparisonResponse struct {
+	Products    []ProductComparisonItem `json:"products"`      // urutan sesuai ids
+	NotFoundIDs []uint                  `json:"not_found_ids"` // ID yang tidak ditemukan (dilewati)
+}
+
+// AvailabilityItemResponse untuk hasil cek ketersediaan satu produk
+type AvailabilityItemResponse struct {
+	ProductID      uint `json:"product_id"`
+	Quantity       int  `json:"quantity"`
+	Found          bool `json:"found"`
+	IsActive       bool `json:"is_active"`
+	AvailableStock int  `json:"available_stock"` // stok dikurangi reservasi order PENDING
+	Available      bool `json:"available"`
+}
+
+// CheckAvailabilityResponse untuk response cek ketersediaan, urutan item sama dengan request
+type CheckAvailabilityResponse struct {
+	Items []AvailabilityItemResponse `json:"items"`
 }
 
 // ProductListResponse untuk response list produk dengan pagination
 type ProductListResponse struct {
-	Products   []ProductResponse `json:"products"`
-	Total      int64             `json:"total"`
-	Page       int               `json:"page"`
-	Limit      int               `json:"limit"`
-	TotalPages int               `json:"total_pages"`
+	Products []ProductResponse `json:"products"`
+	pagination.Meta
 }
 
 // CreateCategoryRequest untuk request membuat kategori baru
 type CreateCategoryRequest struct {
 	Name        string `json:"name" binding:"required,min=2,max=100"`
 	Description string `json:"description"`
+	ParentID    *uint  `json:"parent_id,omitempty"`
+}
+
+// BatchCreateCategoryRequest untuk request membuat banyak kategori sekaligus
+type BatchCreateCategoryRequest struct {
+	Categories []CreateCategoryRequest `json:"categories" binding:"required,min=1,max=100,dive"`
+}
+
+// CategoryBatchConflictResponse untuk response 409 saat ada nama kategori yang bentrok
+type CategoryBatchConflictResponse struct {
+	Names []string `json:"names"` // nama yang sudah ada atau muncul lebih dari sekali dalam batch
 }
 
 // UpdateCategoryRequest untuk request update kategori
 type UpdateCategoryRequest struct {
 	Name        string `json:"name" binding:"omitempty,min=2,max=100"`
 	Description string `json:"description"`
+	// ParentID nil = tidak diubah, 0 = jadikan kategori root
+	ParentID *uint `json:"parent_id,omitempty"`
+}
+
+// DeleteCategoryParams untuk query parameter hapus kategori yang masih memiliki produk.
+// Pilih salah satu: ReassignTo memindahkan produk ke kategori lain, Unassign mengosongkan kategorinya.
+type DeleteCategoryParams struct {
+	ReassignTo uint `form:"reassign_to"`
+	Unassign   bool `form:"unassign"`
+}
+
+// CategoryInUseResponse untuk response 409 saat kategori masih memiliki produk
+type CategoryInUseResponse struct {
+	ProductCount int64 `json:"product_count"`
+}
+
+// AdminDeleteProductParams untuk query parameter hapus produk oleh admin
+type AdminDeleteProductParams struct {
+	Hard bool `form:"hard"` // true = hapus permanen, default soft delete
+}
+
+// BulkDeleteProductsRequest untuk request seller menghapus banyak produk sekaligus
+type BulkDeleteProductsRequest struct {
+	ProductIDs []uint `json:"product_ids" binding:"required,min=1,max=100,dive,gt=0"`
+}
+
+// BulkDeleteSkippedProduct untuk produk yang tidak dihapus karena masih ada di order yang belum dibatalkan
+type BulkDeleteSkippedProduct struct {
+	ProductID  uint  `json:"product_id"`
+	OrderCount int64 `json:"order_count"`
+}
+
+// BulkDeleteProductsResult untuk hasil hapus banyak produk
+type BulkDeleteProductsResult struct {
+	DeletedIDs []uint                     `json:"deleted_ids"`
+	Skipped    []BulkDeleteSkippedProduct `json:"skipped"`
+}
+
+// ProductInUseResponse untuk response 409 saat produk yang akan dihapus permanen masih ada di order
+type ProductInUseResponse struct {
+	OrderCount int64 `json:"order_count"`
 }
 
 // CategoryResponse untuk response data kategori
@@ -67,16 +240,87 @@ type CategoryResponse struct {
 	ID          uint   `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	ParentID    *uint  `json:"parent_id,omitempty"`
+	// Jumlah produk yang tampil di listing publik; hanya diisi di list dan detail kategori
+	ProductCount *int64             `json:"product_count,omitempty"`
+	Children     []CategoryResponse `json:"children,omitempty"`
 }
 
 // ProductQueryParams untuk filter dan pagination
 type ProductQueryParams struct {
-	Page       int    `form:"page,default=1"`
-	Limit      int    `form:"limit,default=10"`
-	Search     string `form:"search"`
-	CategoryID uint   `form:"category_id"`
-	SellerID   uint   `form:"seller_id"`
-	MinPrice   float64 `form:"min_price"`
-	MaxPrice   float64 `form:"max_price"`
-	IsActive   *bool  `form:"is_active"`
+	Page       int         `form:"page,default=1"`
+	Limit      int         `form:"limit"`
+	Search     string      `form:"search"`
+	CategoryID uint        `form:"category_id"`
+	SellerID   uint        `form:"seller_id"`
+	MinPrice   money.Money `form:"min_price"`
+	MaxPrice   money.Money `form:"max_price"`
+	IsActive   *bool       `form:"-"` // diisi service; listing publik selalu hanya produk aktif
+	Sort       string      `form:"sort" binding:"omitempty,oneof=price_asc price_desc newest relevance"`
+	Tags       string      `form:"tags" example:"sale,eco"`                    // nama tag dipisah koma
+	TagMode    string      `form:"tag_mode" binding:"omitempty,oneof=any all"` // any (default) = salah satu tag, all = semua tag
+	TagNames   []string    `form:"-"`                                          // diisi service dari Tags yang sudah dinormalisasi
+}
+
+// Mode filter tag pada list produk
+const (
+	TagModeAny = "any"
+	TagModeAll = "all"
+)
+
+// Sort options untuk list produk
+const (
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+	SortNewest    = "newest"
+	SortRelevance = "relevance"
+)
+
+// InventoryLogResponse untuk response satu entri riwayat perubahan stok
+type InventoryLogResponse struct {
+	ID        uint   `json:"id"`
+	ProductID uint   `json:"product_id"`
+	VariantID *uint  `json:"variant_id,omitempty"`
+	Delta     int    `json:"delta"`
+	Reason    string `json:"reason"`
+	RefType   string `json:"ref_type,omitempty"`
+	RefID     *uint  `json:"ref_id,omitempty"`
+	ActorID   *uint  `json:"actor_id,omitempty"`
+	Note      string `json:"note,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// InventoryLogListResponse untuk response riwayat stok dengan pagination
+type InventoryLogListResponse struct {
+	Logs []InventoryLogResponse `json:"logs"`
+	pagination.Meta
+}
+
+// InventoryLogQueryParams untuk pagination riwayat stok
+type InventoryLogQueryParams struct {
+	Page  int `form:"page,default=1"`
+	Limit int `form:"limit,default=20"`
+}
+
+// PriceHistoryResponse untuk response satu entri riwayat perubahan harga
+type PriceHistoryResponse struct {
+	ID        uint        `json:"id"`
+	ProductID uint        `json:"product_id"`
+	OldPrice  money.Money `json:"old_price" swaggertype:"string" example:"199.99"`
+	NewPrice  money.Money `json:"new_price" swaggertype:"string" example:"179.99"`
+	Currency  string      `json:"currency" example:"IDR"`
+	ChangedBy uint        `json:"changed_by"`
+	CreatedAt string      `json:"created_at"`
+}
+
+// PriceHistoryListResponse untuk response riwayat harga dengan pagination
+type PriceHistoryListResponse struct {
+	History []PriceHistoryResponse `json:"history"`
+	pagination.Meta
+}
+
+// PriceHistoryQueryParams untuk pagination riwayat harga
+type PriceHistoryQueryParams struct {
+	Page  int `form:"page,default=1"`
+	Limit int `form:"limit,default=20"`
 }
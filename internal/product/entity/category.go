@@ -11,13 +11,20 @@ type Category struct {
 	ID          uint           `gorm:"primaryKey" json:"id"`
 	Name        string         `gorm:"size:100;not null;uniqueIndex" json:"name"`
 	Description string         `gorm:"size:255" json:"description"`
+	ParentID    *uint          `gorm:"index" json:"parent_id,omitempty"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 	Products    []Product      `gorm:"foreignKey:CategoryID" json:"products,omitempty"`
+	Children    []Category     `gorm:"foreignKey:ParentID" json:"children,omitempty"`
 }
 
 // TableName menentukan nama tabel di database
 func (Category) TableName() string {
 	return "categories"
 }
+
+// IsRoot mengecek apakah kategori tidak memiliki parent
+func (c *Category) IsRoot() bool {
+	return c.ParentID == nil
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// Inventory log reason constants
+const (
+	InventoryReasonCheckout       = "CHECKOUT"
+	InventoryReasonOrderCancelled = "ORDER_CANCELLED"
+	InventoryReasonOrderReturn    = "ORDER_RETURN"
+	InventoryReasonOrderRefunded  = "ORDER_REFUNDED"
+	InventoryReasonManualAdd      = "MANUAL_ADD"
+	InventoryReasonManualReduce   = "MANUAL_REDUCE"
+	InventoryReasonAdjustment     = "ADJUSTMENT" // stok di-set langsung (update produk/varian)
+	InventoryReasonAdminSet       = "ADMIN_SET"  // stok dikoreksi admin, alasannya di Note
+)
+
+// Inventory log reference type constants
+const (
+	InventoryRefOrder       = "order"
+	InventoryRefOrderReturn = "order_return"
+)
+
+// InventoryLog entity untuk tabel inventory_logs (jejak audit setiap perubahan stok produk)
+type InventoryLog struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ProductID uint      `gorm:"index;not null" json:"product_id"`
+	VariantID *uint     `json:"variant_id,omitempty"`
+	Delta     int       `gorm:"not null" json:"delta"` // positif = stok bertambah, negatif = stok berkurang
+	Reason    string    `gorm:"size:30;not null" json:"reason"`
+	RefType   string    `gorm:"size:30" json:"ref_type,omitempty"`
+	RefID     *uint     `json:"ref_id,omitempty"`
+	ActorID   *uint     `json:"actor_id,omitempty"` // nil jika diubah oleh sistem (mis. expiry worker)
+	Note      string    `gorm:"size:255" json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (InventoryLog) TableName() string {
+	return "inventory_logs"
+}
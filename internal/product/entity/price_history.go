@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+)
+
+// PriceHistory entity untuk tabel price_histories (jejak audit setiap perubahan harga produk).
+// Harga di order item adalah snapshot tersendiri, sehingga riwayat ini hanya untuk audit.
+type PriceHistory struct {
+	ID        uint        `gorm:"primaryKey" json:"id"`
+	ProductID uint        `gorm:"index;not null" json:"product_id"`
+	OldPrice  money.Money `gorm:"type:bigint;not null" json:"old_price"`
+	NewPrice  money.Money `gorm:"type:bigint;not null" json:"new_price"`
+	ChangedBy uint        `gorm:"not null" json:"changed_by"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (PriceHistory) TableName() string {
+	return "price_histories"
+}
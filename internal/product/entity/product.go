@@ -3,24 +3,40 @@ package entity
 import (
 	"time"
 
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
 	"gorm.io/gorm"
 )
 
 // Product entity untuk tabel products
 type Product struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	Name        string         `gorm:"size:200;not null" json:"name"`
-	Description string         `gorm:"type:text" json:"description"`
-	Price       float64        `gorm:"type:decimal(12,2);not null" json:"price"`
-	Stock       int            `gorm:"not null;default:0" json:"stock"`
-	CategoryID  uint           `gorm:"index" json:"category_id"`
-	SellerID    uint           `gorm:"index;not null" json:"seller_id"`
-	ImageURL    string         `gorm:"size:255" json:"image_url,omitempty"`
-	IsActive    bool           `gorm:"default:true" json:"is_active"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
-	Category    *Category      `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	ID          uint        `gorm:"primaryKey" json:"id"`
+	Name        string      `gorm:"size:200;not null" json:"name"`
+	SKU         string      `gorm:"size:100;uniqueIndex;not null" json:"sku"` // kode bisnis unik, termasuk terhadap produk yang sudah di-soft delete
+	Description string      `gorm:"type:text" json:"description"`
+	Price       money.Money `gorm:"type:bigint;not null" json:"price"`
+	Currency    string      `gorm:"size:3" json:"currency"` // ISO 4217, berlaku juga untuk harga varian
+	Stock       int         `gorm:"not null;default:0" json:"stock"`
+	// Stok yang ditahan order PENDING (lihat StockReservation); belum mengurangi stok fisik
+	ReservedStock int    `gorm:"not null;default:0" json:"reserved_stock"`
+	CategoryID    uint   `gorm:"index" json:"category_id"`
+	SellerID      uint   `gorm:"index;not null" json:"seller_id"`
+	ImageURL      string `gorm:"size:255" json:"image_url,omitempty"`
+	IsActive      bool   `gorm:"default:true" json:"is_active"`
+	// Batas stok menipis; nil berarti memakai default dari config
+	LowStockThreshold *int `json:"low_stock_threshold,omitempty"`
+	// Ringkasan rating, dihitung ulang oleh Review Module
+	AverageRating float64 `gorm:"type:decimal(3,2);not null;default:0" json:"average_rating"`
+	ReviewCount   int     `gorm:"not null;default:0" json:"review_count"`
+	// Dinaikkan setiap kali baris produk diubah; dipakai Update untuk optimistic locking
+	Version uint `gorm:"not null;default:0" json:"version"`
+	// Dokumen full-text search (name + description), generated column PostgreSQL (lihat repository.MigrateSearchVector)
+	SearchVector string           `gorm:"->;type:tsvector" json:"-"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt   `gorm:"index" json:"-"`
+	Category     *Category        `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Variants     []ProductVariant `gorm:"foreignKey:ProductID" json:"variants,omitempty"`
+	Tags         []Tag            `gorm:"many2many:product_tags" json:"tags,omitempty"`
 }
 
 // TableName menentukan nama tabel di database
@@ -33,9 +49,17 @@ func (p *Product) IsOwner(userID uint) bool {
 	return p.SellerID == userID
 }
 
-// HasStock mengecek apakah stok tersedia
+// AvailableStock mengembalikan stok yang masih bisa dijual (stok fisik dikurangi reservasi aktif)
+func (p *Product) AvailableStock() int {
+	if p.ReservedStock >= p.Stock {
+		return 0
+	}
+	return p.Stock - p.ReservedStock
+}
+
+// HasStock mengecek apakah stok yang bisa dijual mencukupi
 func (p *Product) HasStock(quantity int) bool {
-	return p.Stock >= quantity
+	return p.AvailableStock() >= quantity
 }
 
 // ReduceStock mengurangi stok produk
@@ -47,6 +71,14 @@ func (p *Product) ReduceStock(quantity int) bool {
 	return true
 }
 
+// EffectiveLowStockThreshold mengembalikan threshold produk atau default jika belum diset
+func (p *Product) EffectiveLowStockThreshold(defaultThreshold int) int {
+	if p.LowStockThreshold != nil {
+		return *p.LowStockThreshold
+	}
+	return defaultThreshold
+}
+
 // AddStock menambah stok produk
 func (p *Product) AddStock(quantity int) {
 	p.Stock += quantity
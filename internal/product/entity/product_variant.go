@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"gorm.io/gorm"
+)
+
+// VariantAttributes menyimpan atribut varian (mis. {"size": "M", "color": "red"}) sebagai JSON
+type VariantAttributes map[string]string
+
+// Value mengimplementasikan driver.Valuer
+func (a VariantAttributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan mengimplementasikan sql.Scanner
+func (a *VariantAttributes) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*a = VariantAttributes{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for VariantAttributes")
+	}
+	return json.Unmarshal(data, a)
+}
+
+// ProductVariant entity untuk tabel product_variants (mis. ukuran/warna dengan stok dan harga sendiri)
+type ProductVariant struct {
+	ID         uint              `gorm:"primaryKey" json:"id"`
+	ProductID  uint              `gorm:"index;not null" json:"product_id"`
+	Attributes VariantAttributes `gorm:"type:jsonb;not null" json:"attributes"`
+	SKU        string            `gorm:"size:100;uniqueIndex;not null" json:"sku"`
+	Price      money.Money       `gorm:"type:bigint;not null" json:"price"`
+	Stock      int               `gorm:"not null;default:0" json:"stock"`
+	// Stok yang ditahan order PENDING, belum mengurangi stok fisik
+	ReservedStock int            `gorm:"not null;default:0" json:"reserved_stock"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName menentukan nama tabel di database
+func (ProductVariant) TableName() string {
+	return "product_variants"
+}
+
+// AvailableStock mengembalikan stok varian yang masih bisa dijual (stok fisik dikurangi reservasi aktif)
+func (v *ProductVariant) AvailableStock() int {
+	if v.ReservedStock >= v.Stock {
+		return 0
+	}
+	return v.Stock - v.ReservedStock
+}
+
+// HasStock mengecek apakah stok varian yang bisa dijual mencukupi
+func (v *ProductVariant) HasStock(quantity int) bool {
+	return v.AvailableStock() >= quantity
+}
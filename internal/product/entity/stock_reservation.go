@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// Stock reservation status constants
+const (
+	ReservationStatusActive    = "ACTIVE"    // stok sedang ditahan untuk order PENDING
+	ReservationStatusCommitted = "COMMITTED" // order dibayar, stok fisik sudah dikurangi
+	ReservationStatusReleased  = "RELEASED"  // order batal/kedaluwarsa, stok kembali bisa dijual
+)
+
+// StockReservation entity untuk tabel stock_reservations.
+// Menahan stok untuk order PENDING tanpa mengurangi stok fisik sampai order dibayar.
+type StockReservation struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	ProductID uint       `gorm:"index;not null" json:"product_id"`
+	VariantID *uint      `json:"variant_id,omitempty"`
+	OrderID   uint       `gorm:"index;not null" json:"order_id"`
+	Quantity  int        `gorm:"not null" json:"quantity"`
+	Status    string     `gorm:"size:20;index;not null;default:'ACTIVE'" json:"status"`
+	ExpiresAt *time.Time `gorm:"index" json:"expires_at,omitempty"` // nil = tidak kedaluwarsa
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (StockReservation) TableName() string {
+	return "stock_reservations"
+}
+
+// IsActive mengecek apakah reservasi masih menahan stok
+func (r *StockReservation) IsActive() bool {
+	return r.Status == ReservationStatusActive
+}
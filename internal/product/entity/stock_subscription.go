@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// StockSubscription entity untuk tabel stock_subscriptions.
+// User yang berlangganan diberi tahu sekali ketika produk yang habis kembali tersedia,
+// lalu langganannya dihapus.
+type StockSubscription struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"uniqueIndex:idx_stock_subscription_user_product;not null" json:"user_id"`
+	ProductID uint      `gorm:"uniqueIndex:idx_stock_subscription_user_product;index;not null" json:"product_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (StockSubscription) TableName() string {
+	return "stock_subscriptions"
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// Tag entity untuk tabel tags. Berbeda dengan kategori (satu per produk), satu produk
+// bisa memiliki banyak tag lewat tabel penghubung product_tags.
+type Tag struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:50;uniqueIndex;not null" json:"name"` // lowercase, mis. "sale"
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (Tag) TableName() string {
+	return "tags"
+}
@@ -1,164 +1,1035 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
+	authEntity "github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
 	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
 	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/product/service"
 	"github.com/gin-gonic/gin"
 )
 
+// importMultipartOverhead memberi ruang untuk boundary dan header multipart di atas ukuran file
+const importMultipartOverhead = 64 * 1024
+
 // ProductHandler menangani HTTP request untuk produk
 type ProductHandler struct {
-	productService service.ProductService
+	productService  service.ProductService
+	importMaxBytes  int64 // ukuran maksimum file CSV import produk
+	imageMaxBytes   int64 // ukuran maksimum gambar produk
+	compareMaxItems int   // jumlah produk maksimum per perbandingan
+}
+
+// NewProductHandler membuat instance baru ProductHandler
+func NewProductHandler(productService service.ProductService, importMaxBytes int64, imageMaxBytes int64, compareMaxItems int) *ProductHandler {
+	return &ProductHandler{
+		productService:  productService,
+		importMaxBytes:  importMaxBytes,
+		imageMaxBytes:   imageMaxBytes,
+		compareMaxItems: compareMaxItems,
+	}
+}
+
+// ========================================
+// Product Handlers
+// ========================================
+
+// CreateProduct godoc
+// @Summary      Create a new product
+// @Description  Create a new product (Seller/Admin only). Sellers must be approved by an admin first.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.CreateProductRequest true "Create product request"
+// @Success      201 {object} response.APIResponse{data=dto.ProductResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /products [post]
+func (h *ProductHandler) CreateProduct(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	var req dto.CreateProductRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.productService.CreateProduct(sellerID.(uint), &req)
+	if err != nil {
+		switch err {
+		case service.ErrCategoryNotFound:
+			response.NotFound(ctx, "Category not found")
+		case service.ErrSKUExists:
+			response.Error(ctx, http.StatusConflict, "Product SKU already exists", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to create product", err.Error())
+		}
+		return
+	}
+
+	response.Created(ctx, "Product created successfully", result)
+}
+
+// GetProduct godoc
+// @Summary      Get product by ID
+// @Description  Get a single product by its ID
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Product ID"
+// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /products/{id} [get]
+func (h *ProductHandler) GetProduct(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	result, err := h.productService.GetProduct(uint(id))
+	if err != nil {
+		if err == service.ErrProductNotFound {
+			response.NotFound(ctx, "Product not found")
+			return
+		}
+		response.InternalServerError(ctx, "Failed to get product", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Product retrieved successfully", result)
+}
+
+// GetProductBySKU godoc
+// @Summary      Get product by SKU
+// @Description  Get a single product by its SKU
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        sku path string true "Product SKU"
+// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Failure      404 {object} response.APIResponse
+// @Router       /products/sku/{sku} [get]
+func (h *ProductHandler) GetProductBySKU(ctx *gin.Context) {
+	result, err := h.productService.GetProductBySKU(ctx.Param("sku"))
+	if err != nil {
+		if err == service.ErrProductNotFound {
+			response.NotFound(ctx, "Product not found")
+			return
+		}
+		response.InternalServerError(ctx, "Failed to get product", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Product retrieved successfully", result)
+}
+
+// CheckAvailability godoc
+// @Summary      Check product availability
+// @Description  Check whether each item could be bought with the requested quantity, without checking out. A product listed more than once is checked against its total quantity. Unknown products are returned with found=false.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CheckAvailabilityRequest true "Items to check"
+// @Success      200 {object} response.APIResponse{data=dto.CheckAvailabilityResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /products/check-availability [post]
+func (h *ProductHandler) CheckAvailability(ctx *gin.Context) {
+	var req dto.CheckAvailabilityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.productService.CheckAvailability(&req)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to check availability", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Availability checked successfully", result)
+}
+
+// CompareProducts godoc
+// @Summary      Compare products
+// @Description  Get several products side by side, with their category, stock status (in_stock, low_stock, out_of_stock based on available stock) and average rating (null without reviews). Repeated IDs are counted once. IDs that do not exist are skipped and listed in not_found_ids. At most PRODUCT_COMPARE_MAX_ITEMS distinct IDs are allowed.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        ids query string true "Comma-separated product IDs" example(1,2,3)
+// @Success      200 {object} response.APIResponse{data=dto.ProductComparisonResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /products/compare [get]
+func (h *ProductHandler) CompareProducts(ctx *gin.Context) {
+	var query dto.CompareProductsQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	ids, err := parseIDList(query.IDs)
+	if err != nil {
+		response.BadRequest(ctx, "ids must be a comma-separated list of product IDs", nil)
+		return
+	}
+	if countDistinct(ids) > h.compareMaxItems {
+		response.BadRequest(ctx, fmt.Sprintf("At most %d products can be compared", h.compareMaxItems), nil)
+		return
+	}
+
+	result, err := h.productService.CompareProducts(ids)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to compare products", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Products compared successfully", result)
+}
+
+// GetAllProducts godoc
+// @Summary      Get all products
+// @Description  Get active products with filters and pagination
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Param        search query string false "Full-text search on name and description"
+// @Param        category_id query int false "Filter by category ID"
+// @Param        seller_id query int false "Filter by seller ID"
+// @Param        min_price query number false "Minimum price"
+// @Param        max_price query number false "Maximum price"
+// @Param        sort query string false "Sort order" Enums(price_asc, price_desc, newest, relevance)
+// @Param        tags query string false "Comma-separated tag names, e.g. sale,eco"
+// @Param        tag_mode query string false "Match any (default) or all of the tags" Enums(any, all)
+// @Success      200 {object} response.APIResponse{data=dto.ProductListResponse}
+// @Failure      400 {object} response.APIResponse
+// @Router       /products [get]
+func (h *ProductHandler) GetAllProducts(ctx *gin.Context) {
+	var params dto.ProductQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.productService.GetAllProducts(&params)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get products", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Products retrieved successfully", result)
+}
+
+// GetSellerProducts godoc
+// @Summary      Get seller storefront products
+// @Description  Get a seller's active products with the same filters and pagination as the product list
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Seller ID"
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Param        search query string false "Full-text search on name and description"
+// @Param        category_id query int false "Filter by category ID"
+// @Param        min_price query number false "Minimum price"
+// @Param        max_price query number false "Maximum price"
+// @Param        sort query string false "Sort order" Enums(price_asc, price_desc, newest, relevance)
+// @Param        tags query string false "Comma-separated tag names, e.g. sale,eco"
+// @Param        tag_mode query string false "Match any (default) or all of the tags" Enums(any, all)
+// @Success      200 {object} response.APIResponse{data=dto.ProductListResponse}
+// @Failure      400 {object} response.APIResponse
+// @Router       /sellers/{id}/products [get]
+func (h *ProductHandler) GetSellerProducts(ctx *gin.Context) {
+	sellerID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid seller ID", nil)
+		return
+	}
+
+	var params dto.ProductQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.productService.GetSellerProducts(uint(sellerID), &params)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get products", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Products retrieved successfully", result)
+}
+
+// GetMyProducts godoc
+// @Summary      Get my products
+// @Description  Get products owned by the current seller
+// @Tags         Seller
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.APIResponse{data=[]dto.ProductResponse}
+// @Failure      401 {object} response.APIResponse
+// @Router       /seller/products [get]
+func (h *ProductHandler) GetMyProducts(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	result, err := h.productService.GetMyProducts(sellerID.(uint))
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get products", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Products retrieved successfully", result)
+}
+
+// ImportProducts godoc
+// @Summary      Import products from CSV
+// @Description  Bulk-create products for the current seller from a CSV file with header: name, sku, description, price, stock, category_id, image_url. Valid rows are created in one transaction; invalid rows are skipped and reported with their line number
+// @Tags         Seller
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file formData file true "CSV file"
+// @Success      201 {object} response.APIResponse{data=dto.ProductImportResult}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      413 {object} response.APIResponse
+// @Router       /seller/products/import [post]
+func (h *ProductHandler) ImportProducts(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	// Batasi body request agar file besar ditolak sebelum selesai dibaca
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.importMaxBytes+importMultipartOverhead)
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.Error(ctx, http.StatusRequestEntityTooLarge, fmt.Sprintf("File must not exceed %d KB", h.importMaxBytes/1024), nil)
+			return
+		}
+		response.BadRequest(ctx, "A CSV file is required in the 'file' field", nil)
+		return
+	}
+	if fileHeader.Size > h.importMaxBytes {
+		response.Error(ctx, http.StatusRequestEntityTooLarge, fmt.Sprintf("File must not exceed %d KB", h.importMaxBytes/1024), nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(ctx, "Failed to read uploaded file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	result, err := h.productService.ImportProducts(sellerID.(uint), file)
+	if err != nil {
+		switch err {
+		case service.ErrImportInvalidHeader, service.ErrImportEmpty, service.ErrImportTooManyRows, service.ErrImportMalformed:
+			response.BadRequest(ctx, err.Error(), nil)
+		default:
+			response.InternalServerError(ctx, "Failed to import products", err.Error())
+		}
+		return
+	}
+
+	response.Created(ctx, "Products imported", result)
+}
+
+// GetLowStockProducts godoc
+// @Summary      Get my low-stock products
+// @Description  Get products owned by the current seller whose stock is at or below their low-stock threshold
+// @Tags         Seller
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.APIResponse{data=[]dto.ProductResponse}
+// @Failure      401 {object} response.APIResponse
+// @Router       /seller/products/low-stock [get]
+func (h *ProductHandler) GetLowStockProducts(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	result, err := h.productService.GetLowStockProducts(sellerID.(uint))
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get low-stock products", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Low-stock products retrieved successfully", result)
 }
 
-// NewProductHandler membuat instance baru ProductHandler
-func NewProductHandler(productService service.ProductService) *ProductHandler {
-	return &ProductHandler{productService: productService}
-}
+// UpdateProduct godoc
+// @Summary      Update product
+// @Description  Partially update a product (Owner only). Omitted fields are left unchanged; fields sent with an empty or zero value are applied, e.g. an empty description clears it
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Param        request body dto.UpdateProductRequest true "Update product request"
+// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /products/{id} [put]
+// @Router       /products/{id} [patch]
+func (h *ProductHandler) UpdateProduct(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	var req dto.UpdateProductRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.productService.UpdateProduct(sellerID.(uint), uint(id), &req)
+	if err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to update this product")
+		case service.ErrCategoryNotFound:
+			response.NotFound(ctx, "Category not found")
+		case service.ErrSKUExists:
+			response.Error(ctx, http.StatusConflict, "Product SKU already exists", nil)
+		case service.ErrStockBelowReserved:
+			response.BadRequest(ctx, "Stock cannot be lower than the quantity reserved by pending orders", nil)
+		case service.ErrConcurrentModification:
+			response.Error(ctx, http.StatusConflict, "Product was modified by another request, please refetch and retry", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to update product", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Product updated successfully", result)
+}
+
+// UploadProductImage godoc
+// @Summary      Upload product image
+// @Description  Upload a JPEG, PNG, WebP or GIF image for a product (Owner only). The previous uploaded image is deleted
+// @Tags         Products
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Param        image formData file true "Image file"
+// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      413 {object} response.APIResponse
+// @Failure      415 {object} response.APIResponse
+// @Router       /products/{id}/image [post]
+func (h *ProductHandler) UploadProductImage(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	// Batasi body request agar file besar ditolak sebelum selesai dibaca
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.imageMaxBytes+importMultipartOverhead)
+
+	fileHeader, err := ctx.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.Error(ctx, http.StatusRequestEntityTooLarge, fmt.Sprintf("Image must not exceed %d KB", h.imageMaxBytes/1024), nil)
+			return
+		}
+		response.BadRequest(ctx, "An image file is required in the 'image' field", nil)
+		return
+	}
+	if fileHeader.Size > h.imageMaxBytes {
+		response.Error(ctx, http.StatusRequestEntityTooLarge, fmt.Sprintf("Image must not exceed %d KB", h.imageMaxBytes/1024), nil)
+		return
+	}
+	if fileHeader.Size == 0 {
+		response.BadRequest(ctx, "Image file is empty", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(ctx, "Failed to read uploaded file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	result, err := h.productService.UploadProductImage(sellerID.(uint), uint(id), file, fileHeader.Size)
+	if err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to update this product")
+		case service.ErrInvalidImageType:
+			response.Error(ctx, http.StatusUnsupportedMediaType, err.Error(), nil)
+		case service.ErrImageStorageDisabled:
+			response.Error(ctx, http.StatusServiceUnavailable, err.Error(), nil)
+		default:
+			response.InternalServerError(ctx, "Failed to upload product image", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Product image uploaded successfully", result)
+}
+
+// AttachProductTags godoc
+// @Summary      Attach tags to product
+// @Description  Attach one or more tags to a product (Owner only). Tag names are trimmed and lowercased; unknown tags are created and tags already attached are ignored
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Param        request body dto.ProductTagsRequest true "Tags to attach"
+// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /products/{id}/tags [post]
+func (h *ProductHandler) AttachProductTags(ctx *gin.Context) {
+	h.changeProductTags(ctx, h.productService.AttachTags, "Tags attached successfully")
+}
+
+// DetachProductTags godoc
+// @Summary      Detach tags from product
+// @Description  Detach one or more tags from a product (Owner only). Tags that are not attached are ignored
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Param        request body dto.ProductTagsRequest true "Tags to detach"
+// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /products/{id}/tags [delete]
+func (h *ProductHandler) DetachProductTags(ctx *gin.Context) {
+	h.changeProductTags(ctx, h.productService.DetachTags, "Tags detached successfully")
+}
+
+// changeProductTags menjalankan attach/detach tag dengan parsing dan mapping error yang sama
+func (h *ProductHandler) changeProductTags(
+	ctx *gin.Context,
+	change func(sellerID uint, productID uint, req *dto.ProductTagsRequest) (*dto.ProductResponse, error),
+	message string,
+) {
+	sellerID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	var req dto.ProductTagsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := change(sellerID.(uint), uint(id), &req)
+	if err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to update this product")
+		case service.ErrInvalidTags:
+			response.BadRequest(ctx, "At least one non-empty tag is required", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to update product tags", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, message, result)
+}
+
+// DeleteProduct godoc
+// @Summary      Delete product
+// @Description  Delete a product (Owner only)
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Success      200 {object} response.APIResponse
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /products/{id} [delete]
+func (h *ProductHandler) DeleteProduct(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	if err := h.productService.DeleteProduct(sellerID.(uint), uint(id)); err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to delete this product")
+		default:
+			response.InternalServerError(ctx, "Failed to delete product", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Product deleted successfully", nil)
+}
+
+// BulkDeleteProducts godoc
+// @Summary      Bulk delete my products
+// @Description  Soft-delete many of the current seller's products in one transaction. The whole batch is rejected if any ID does not exist or is owned by another seller. Products still contained in orders that are not cancelled are skipped and reported with their order count.
+// @Tags         Seller
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.BulkDeleteProductsRequest true "Product IDs to delete (max 100)"
+// @Success      200 {object} response.APIResponse{data=dto.BulkDeleteProductsResult}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /seller/products/bulk [delete]
+func (h *ProductHandler) BulkDeleteProducts(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	var req dto.BulkDeleteProductsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.productService.BulkDeleteProducts(sellerID.(uint), &req)
+	if err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "One or more products not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to delete one or more of these products")
+		default:
+			response.InternalServerError(ctx, "Failed to delete products", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Products deleted successfully", result)
+}
+
+// AdminDeleteProduct godoc
+// @Summary      Delete any product (Admin)
+// @Description  Delete a product regardless of its owner. Soft delete by default. With hard=true the product is removed permanently together with its variants, inventory log, price history and stock reservations (also works on an already soft-deleted product); this is refused with 409 while orders that are not cancelled still contain the product.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Param        hard query bool false "Delete permanently"
+// @Success      200 {object} response.APIResponse
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse{error=dto.ProductInUseResponse}
+// @Router       /admin/products/{id} [delete]
+func (h *ProductHandler) AdminDeleteProduct(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	var params dto.AdminDeleteProductParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	orderCount, err := h.productService.AdminDeleteProduct(uint(id), &params)
+	if err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrProductInOrders:
+			response.Error(ctx, http.StatusConflict, "Product is still referenced by orders that are not cancelled",
+				dto.ProductInUseResponse{OrderCount: orderCount})
+		default:
+			response.InternalServerError(ctx, "Failed to delete product", err.Error())
+		}
+		return
+	}
+
+	if params.Hard {
+		response.OK(ctx, "Product permanently deleted", nil)
+		return
+	}
+	response.OK(ctx, "Product deleted successfully", nil)
+}
+
+// UpdateStock godoc
+// @Summary      Update product stock
+// @Description  Add or reduce product stock (Owner only)
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Param        request body dto.UpdateStockRequest true "Update stock request"
+// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /products/{id}/stock [patch]
+func (h *ProductHandler) UpdateStock(ctx *gin.Context) {
+	sellerID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	var req dto.UpdateStockRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.productService.UpdateStock(sellerID.(uint), uint(id), &req)
+	if err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to update this product")
+		case service.ErrInsufficientStock:
+			response.BadRequest(ctx, "Insufficient stock", nil)
+		case service.ErrInvalidStockAction:
+			response.BadRequest(ctx, "Invalid stock action. Use 'add' or 'reduce'", nil)
+		case service.ErrProductHasVariants:
+			response.BadRequest(ctx, "Stock of a product with variants is managed per variant", nil)
+		case service.ErrConcurrentModification:
+			response.Error(ctx, http.StatusConflict, "Product was modified by another request, please refetch and retry", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to update stock", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Stock updated successfully", result)
+}
+
+// AdminSetStock godoc
+// @Summary      Set product stock (Admin)
+// @Description  Set the stock of any product to an absolute value, e.g. to correct inventory during a dispute. The reason is required and stored in the inventory log. The stock cannot be lower than the quantity reserved by pending orders. Products with variants are managed per variant.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Param        request body dto.AdminSetStockRequest true "Set stock request"
+// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /admin/products/{id}/stock [patch]
+func (h *ProductHandler) AdminSetStock(ctx *gin.Context) {
+	adminID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	var req dto.AdminSetStockRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.productService.AdminSetStock(adminID.(uint), uint(id), &req)
+	if err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrProductHasVariants:
+			response.BadRequest(ctx, "Stock of a product with variants is managed per variant", nil)
+		case service.ErrStockBelowReserved:
+			response.BadRequest(ctx, "Stock cannot be lower than the quantity reserved by pending orders", nil)
+		case service.ErrStockConflict:
+			response.Error(ctx, http.StatusConflict, "Stock was changed concurrently, please retry", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to set stock", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Stock updated successfully", result)
+}
+
+// GetInventoryLog godoc
+// @Summary      Get product inventory log
+// @Description  Get the paginated stock change history of a product, newest first (Owner/Admin only)
+// @Tags         Seller
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(20)
+// @Success      200 {object} response.APIResponse{data=dto.InventoryLogListResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /seller/products/{id}/inventory-log [get]
+func (h *ProductHandler) GetInventoryLog(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+	userRole, _ := ctx.Get("userRole")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	var params dto.InventoryLogQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
 
-// ========================================
-// Product Handlers
-// ========================================
+	isAdmin := userRole.(string) == authEntity.RoleAdmin
 
-// CreateProduct godoc
-// @Summary      Create a new product
-// @Description  Create a new product (Seller/Admin only)
+	result, err := h.productService.GetInventoryLog(userID.(uint), uint(id), isAdmin, &params)
+	if err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to view this product's inventory log")
+		default:
+			response.InternalServerError(ctx, "Failed to get inventory log", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Inventory log retrieved successfully", result)
+}
+
+// GetPriceHistory godoc
+// @Summary      Get product price history
+// @Description  Get the paginated price change history of a product, newest first (Owner/Admin only)
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        request body dto.CreateProductRequest true "Create product request"
-// @Success      201 {object} response.APIResponse{data=dto.ProductResponse}
+// @Param        id path int true "Product ID"
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(20)
+// @Success      200 {object} response.APIResponse{data=dto.PriceHistoryListResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
-// @Router       /products [post]
-func (h *ProductHandler) CreateProduct(ctx *gin.Context) {
-	sellerID, _ := ctx.Get("userID")
+// @Router       /products/{id}/price-history [get]
+func (h *ProductHandler) GetPriceHistory(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+	userRole, _ := ctx.Get("userRole")
 
-	var req dto.CreateProductRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
 		return
 	}
 
-	result, err := h.productService.CreateProduct(sellerID.(uint), &req)
+	var params dto.PriceHistoryQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	isAdmin := userRole.(string) == authEntity.RoleAdmin
+
+	result, err := h.productService.GetPriceHistory(userID.(uint), uint(id), isAdmin, &params)
 	if err != nil {
 		switch err {
-		case service.ErrCategoryNotFound:
-			response.NotFound(ctx, "Category not found")
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to view this product's price history")
 		default:
-			response.InternalServerError(ctx, "Failed to create product", err.Error())
+			response.InternalServerError(ctx, "Failed to get price history", err.Error())
 		}
 		return
 	}
 
-	response.Created(ctx, "Product created successfully", result)
+	response.OK(ctx, "Price history retrieved successfully", result)
 }
 
-// GetProduct godoc
-// @Summary      Get product by ID
-// @Description  Get a single product by its ID
+// SubscribeBackInStock godoc
+// @Summary      Subscribe to back-in-stock notification
+// @Description  Get an email once when an out-of-stock product becomes available again. The subscription is removed after the email is sent. Subscribing again is a no-op
 // @Tags         Products
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id path int true "Product ID"
-// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Success      200 {object} response.APIResponse
 // @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
-// @Router       /products/{id} [get]
-func (h *ProductHandler) GetProduct(ctx *gin.Context) {
+// @Failure      409 {object} response.APIResponse
+// @Router       /products/{id}/stock-subscription [post]
+func (h *ProductHandler) SubscribeBackInStock(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		response.BadRequest(ctx, "Invalid product ID", nil)
 		return
 	}
 
-	result, err := h.productService.GetProduct(uint(id))
-	if err != nil {
-		if err == service.ErrProductNotFound {
+	if err := h.productService.SubscribeBackInStock(userID.(uint), uint(id)); err != nil {
+		switch err {
+		case service.ErrProductNotFound:
 			response.NotFound(ctx, "Product not found")
-			return
+		case service.ErrProductInStock:
+			response.Error(ctx, http.StatusConflict, "Product is in stock", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to subscribe to stock notification", err.Error())
 		}
-		response.InternalServerError(ctx, "Failed to get product", err.Error())
 		return
 	}
 
-	response.OK(ctx, "Product retrieved successfully", result)
+	response.OK(ctx, "You will be notified when the product is back in stock", nil)
 }
 
-// GetAllProducts godoc
-// @Summary      Get all products
-// @Description  Get all products with filters and pagination
+// UnsubscribeBackInStock godoc
+// @Summary      Unsubscribe from back-in-stock notification
+// @Description  Cancel the back-in-stock notification for a product
 // @Tags         Products
 // @Accept       json
 // @Produce      json
-// @Param        page query int false "Page number" default(1)
-// @Param        limit query int false "Items per page" default(10)
-// @Param        search query string false "Search by name"
-// @Param        category_id query int false "Filter by category ID"
-// @Param        min_price query number false "Minimum price"
-// @Param        max_price query number false "Maximum price"
-// @Success      200 {object} response.APIResponse{data=dto.ProductListResponse}
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Success      200 {object} response.APIResponse
 // @Failure      400 {object} response.APIResponse
-// @Router       /products [get]
-func (h *ProductHandler) GetAllProducts(ctx *gin.Context) {
-	var params dto.ProductQueryParams
-	if err := ctx.ShouldBindQuery(&params); err != nil {
-		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+// @Failure      401 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /products/{id}/stock-subscription [delete]
+func (h *ProductHandler) UnsubscribeBackInStock(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
 		return
 	}
 
-	result, err := h.productService.GetAllProducts(&params)
-	if err != nil {
-		response.InternalServerError(ctx, "Failed to get products", err.Error())
+	if err := h.productService.UnsubscribeBackInStock(userID.(uint), uint(id)); err != nil {
+		switch err {
+		case service.ErrSubscriptionNotFound:
+			response.NotFound(ctx, "Stock subscription not found")
+		default:
+			response.InternalServerError(ctx, "Failed to unsubscribe from stock notification", err.Error())
+		}
 		return
 	}
 
-	response.OK(ctx, "Products retrieved successfully", result)
+	response.OK(ctx, "Stock subscription cancelled", nil)
 }
 
-// GetMyProducts godoc
-// @Summary      Get my products
-// @Description  Get products owned by the current seller
-// @Tags         Seller
+// ========================================
+// Variant Handlers
+// ========================================
+
+// ListVariants godoc
+// @Summary      Get product variants
+// @Description  Get all variants (e.g. size/color) of a product
+// @Tags         Products
 // @Accept       json
 // @Produce      json
-// @Security     BearerAuth
-// @Success      200 {object} response.APIResponse{data=[]dto.ProductResponse}
-// @Failure      401 {object} response.APIResponse
-// @Router       /seller/products [get]
-func (h *ProductHandler) GetMyProducts(ctx *gin.Context) {
-	sellerID, _ := ctx.Get("userID")
+// @Param        id path int true "Product ID"
+// @Success      200 {object} response.APIResponse{data=[]dto.VariantResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /products/{id}/variants [get]
+func (h *ProductHandler) ListVariants(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
 
-	result, err := h.productService.GetMyProducts(sellerID.(uint))
+	result, err := h.productService.ListVariants(uint(id))
 	if err != nil {
-		response.InternalServerError(ctx, "Failed to get products", err.Error())
+		h.handleVariantError(ctx, err, "Failed to get variants")
 		return
 	}
 
-	response.OK(ctx, "Products retrieved successfully", result)
+	response.OK(ctx, "Variants retrieved successfully", result)
 }
 
-// UpdateProduct godoc
-// @Summary      Update product
-// @Description  Update a product (Owner only)
+// AddVariant godoc
+// @Summary      Add product variant
+// @Description  Add a variant with its own price and stock to a product (Owner only). Product stock becomes the sum of variant stock.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Product ID"
-// @Param        request body dto.UpdateProductRequest true "Update product request"
-// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Param        request body dto.CreateVariantRequest true "Create variant request"
+// @Success      201 {object} response.APIResponse{data=dto.VariantResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
-// @Router       /products/{id} [put]
-func (h *ProductHandler) UpdateProduct(ctx *gin.Context) {
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /products/{id}/variants [post]
+func (h *ProductHandler) AddVariant(ctx *gin.Context) {
 	sellerID, _ := ctx.Get("userID")
 
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -167,44 +1038,39 @@ func (h *ProductHandler) UpdateProduct(ctx *gin.Context) {
 		return
 	}
 
-	var req dto.UpdateProductRequest
+	var req dto.CreateVariantRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+		response.BindError(ctx, err)
 		return
 	}
 
-	result, err := h.productService.UpdateProduct(sellerID.(uint), uint(id), &req)
+	result, err := h.productService.AddVariant(sellerID.(uint), uint(id), &req)
 	if err != nil {
-		switch err {
-		case service.ErrProductNotFound:
-			response.NotFound(ctx, "Product not found")
-		case service.ErrUnauthorized:
-			response.Forbidden(ctx, "You are not authorized to update this product")
-		case service.ErrCategoryNotFound:
-			response.NotFound(ctx, "Category not found")
-		default:
-			response.InternalServerError(ctx, "Failed to update product", err.Error())
-		}
+		h.handleVariantError(ctx, err, "Failed to add variant")
 		return
 	}
 
-	response.OK(ctx, "Product updated successfully", result)
+	response.Created(ctx, "Variant added successfully", result)
 }
 
-// DeleteProduct godoc
-// @Summary      Delete product
-// @Description  Delete a product (Owner only)
+// UpdateVariant godoc
+// @Summary      Update product variant
+// @Description  Update a product variant (Owner only)
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Product ID"
-// @Success      200 {object} response.APIResponse
+// @Param        variantId path int true "Variant ID"
+// @Param        request body dto.UpdateVariantRequest true "Update variant request"
+// @Success      200 {object} response.APIResponse{data=dto.VariantResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
-// @Router       /products/{id} [delete]
-func (h *ProductHandler) DeleteProduct(ctx *gin.Context) {
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /products/{id}/variants/{variantId} [put]
+func (h *ProductHandler) UpdateVariant(ctx *gin.Context) {
 	sellerID, _ := ctx.Get("userID")
 
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -212,37 +1078,42 @@ func (h *ProductHandler) DeleteProduct(ctx *gin.Context) {
 		response.BadRequest(ctx, "Invalid product ID", nil)
 		return
 	}
+	variantID, err := strconv.ParseUint(ctx.Param("variantId"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid variant ID", nil)
+		return
+	}
 
-	if err := h.productService.DeleteProduct(sellerID.(uint), uint(id)); err != nil {
-		switch err {
-		case service.ErrProductNotFound:
-			response.NotFound(ctx, "Product not found")
-		case service.ErrUnauthorized:
-			response.Forbidden(ctx, "You are not authorized to delete this product")
-		default:
-			response.InternalServerError(ctx, "Failed to delete product", err.Error())
-		}
+	var req dto.UpdateVariantRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
 		return
 	}
 
-	response.OK(ctx, "Product deleted successfully", nil)
+	result, err := h.productService.UpdateVariant(sellerID.(uint), uint(id), uint(variantID), &req)
+	if err != nil {
+		h.handleVariantError(ctx, err, "Failed to update variant")
+		return
+	}
+
+	response.OK(ctx, "Variant updated successfully", result)
 }
 
-// UpdateStock godoc
-// @Summary      Update product stock
-// @Description  Add or reduce product stock (Owner only)
+// DeleteVariant godoc
+// @Summary      Delete product variant
+// @Description  Delete a product variant (Owner only)
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Product ID"
-// @Param        request body dto.UpdateStockRequest true "Update stock request"
-// @Success      200 {object} response.APIResponse{data=dto.ProductResponse}
+// @Param        variantId path int true "Variant ID"
+// @Success      200 {object} response.APIResponse
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
-// @Router       /products/{id}/stock [patch]
-func (h *ProductHandler) UpdateStock(ctx *gin.Context) {
+// @Router       /products/{id}/variants/{variantId} [delete]
+func (h *ProductHandler) DeleteVariant(ctx *gin.Context) {
 	sellerID, _ := ctx.Get("userID")
 
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -250,31 +1121,34 @@ func (h *ProductHandler) UpdateStock(ctx *gin.Context) {
 		response.BadRequest(ctx, "Invalid product ID", nil)
 		return
 	}
-
-	var req dto.UpdateStockRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+	variantID, err := strconv.ParseUint(ctx.Param("variantId"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid variant ID", nil)
 		return
 	}
 
-	result, err := h.productService.UpdateStock(sellerID.(uint), uint(id), &req)
-	if err != nil {
-		switch err {
-		case service.ErrProductNotFound:
-			response.NotFound(ctx, "Product not found")
-		case service.ErrUnauthorized:
-			response.Forbidden(ctx, "You are not authorized to update this product")
-		case service.ErrInsufficientStock:
-			response.BadRequest(ctx, "Insufficient stock", nil)
-		case service.ErrInvalidStockAction:
-			response.BadRequest(ctx, "Invalid stock action. Use 'add' or 'reduce'", nil)
-		default:
-			response.InternalServerError(ctx, "Failed to update stock", err.Error())
-		}
+	if err := h.productService.DeleteVariant(sellerID.(uint), uint(id), uint(variantID)); err != nil {
+		h.handleVariantError(ctx, err, "Failed to delete variant")
 		return
 	}
 
-	response.OK(ctx, "Stock updated successfully", result)
+	response.OK(ctx, "Variant deleted successfully", nil)
+}
+
+// handleVariantError memetakan error operasi varian ke HTTP response
+func (h *ProductHandler) handleVariantError(ctx *gin.Context, err error, fallbackMessage string) {
+	switch err {
+	case service.ErrProductNotFound:
+		response.NotFound(ctx, "Product not found")
+	case service.ErrVariantNotFound:
+		response.NotFound(ctx, "Variant not found")
+	case service.ErrUnauthorized:
+		response.Forbidden(ctx, "You are not authorized to update this product")
+	case service.ErrVariantSKUExists:
+		response.Error(ctx, http.StatusConflict, "Variant SKU already exists", nil)
+	default:
+		response.InternalServerError(ctx, fallbackMessage, err.Error())
+	}
 }
 
 // ========================================
@@ -292,31 +1166,75 @@ func (h *ProductHandler) UpdateStock(ctx *gin.Context) {
 // @Success      201 {object} response.APIResponse{data=dto.CategoryResponse}
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
 // @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
 // @Router       /categories [post]
 func (h *ProductHandler) CreateCategory(ctx *gin.Context) {
 	var req dto.CreateCategoryRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+		response.BindError(ctx, err)
 		return
 	}
 
 	result, err := h.productService.CreateCategory(&req)
 	if err != nil {
-		if err == service.ErrCategoryExists {
+		switch err {
+		case service.ErrCategoryExists:
 			response.Error(ctx, http.StatusConflict, "Category already exists", nil)
-			return
+		case service.ErrParentCategoryNotFound:
+			response.NotFound(ctx, "Parent category not found")
+		default:
+			response.InternalServerError(ctx, "Failed to create category", err.Error())
 		}
-		response.InternalServerError(ctx, "Failed to create category", err.Error())
 		return
 	}
 
 	response.Created(ctx, "Category created successfully", result)
 }
 
+// CreateCategories godoc
+// @Summary      Create categories in batch
+// @Description  Create up to 100 categories at once (Admin only). All categories are created in one transaction: if any name already exists or appears twice in the batch, nothing is created and 409 lists the colliding names. Parents must be existing categories.
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.BatchCreateCategoryRequest true "Categories to create"
+// @Success      201 {object} response.APIResponse{data=[]dto.CategoryResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse{error=dto.CategoryBatchConflictResponse}
+// @Failure      422 {object} response.APIResponse
+// @Router       /categories/batch [post]
+func (h *ProductHandler) CreateCategories(ctx *gin.Context) {
+	var req dto.BatchCreateCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, conflicts, err := h.productService.CreateCategories(&req)
+	if err != nil {
+		switch err {
+		case service.ErrCategoryExists:
+			response.Error(ctx, http.StatusConflict, "Some category names already exist",
+				dto.CategoryBatchConflictResponse{Names: conflicts})
+		case service.ErrParentCategoryNotFound:
+			response.NotFound(ctx, "Parent category not found")
+		default:
+			response.InternalServerError(ctx, "Failed to create categories", err.Error())
+		}
+		return
+	}
+
+	response.Created(ctx, "Categories created successfully", result)
+}
+
 // GetAllCategories godoc
 // @Summary      Get all categories
-// @Description  Get all product categories
+// @Description  Get all product categories with `product_count`, the number of active products visible in public listings
 // @Tags         Categories
 // @Accept       json
 // @Produce      json
@@ -332,9 +1250,27 @@ func (h *ProductHandler) GetAllCategories(ctx *gin.Context) {
 	response.OK(ctx, "Categories retrieved successfully", result)
 }
 
+// GetCategoryTree godoc
+// @Summary      Get category tree
+// @Description  Get all product categories as a nested tree
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} response.APIResponse{data=[]dto.CategoryResponse}
+// @Router       /categories/tree [get]
+func (h *ProductHandler) GetCategoryTree(ctx *gin.Context) {
+	result, err := h.productService.GetCategoryTree()
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get category tree", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Category tree retrieved successfully", result)
+}
+
 // GetCategory godoc
 // @Summary      Get category by ID
-// @Description  Get a single category by its ID
+// @Description  Get a single category by its ID with `product_count`, the number of active products visible in public listings
 // @Tags         Categories
 // @Accept       json
 // @Produce      json
@@ -376,6 +1312,7 @@ func (h *ProductHandler) GetCategory(ctx *gin.Context) {
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
 // @Router       /categories/{id} [put]
 func (h *ProductHandler) UpdateCategory(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -386,17 +1323,22 @@ func (h *ProductHandler) UpdateCategory(ctx *gin.Context) {
 
 	var req dto.UpdateCategoryRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(ctx, "Invalid request body", err.Error())
+		response.BindError(ctx, err)
 		return
 	}
 
 	result, err := h.productService.UpdateCategory(uint(id), &req)
 	if err != nil {
-		if err == service.ErrCategoryNotFound {
+		switch err {
+		case service.ErrCategoryNotFound:
 			response.NotFound(ctx, "Category not found")
-			return
+		case service.ErrParentCategoryNotFound:
+			response.NotFound(ctx, "Parent category not found")
+		case service.ErrCategoryCycle:
+			response.BadRequest(ctx, "Category cannot be moved under itself or its descendants", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to update category", err.Error())
 		}
-		response.InternalServerError(ctx, "Failed to update category", err.Error())
 		return
 	}
 
@@ -405,16 +1347,20 @@ func (h *ProductHandler) UpdateCategory(ctx *gin.Context) {
 
 // DeleteCategory godoc
 // @Summary      Delete category
-// @Description  Delete a product category (Admin only)
+// @Description  Delete a product category (Admin only). If products still use it, pass reassign_to to move them to another category or unassign=true to clear their category; otherwise 409 is returned with the number of affected products
 // @Tags         Categories
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Category ID"
+// @Param        reassign_to query int false "Move the category's products to this category"
+// @Param        unassign query bool false "Clear the category of the category's products"
 // @Success      200 {object} response.APIResponse
 // @Failure      400 {object} response.APIResponse
 // @Failure      403 {object} response.APIResponse
 // @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse{error=dto.CategoryInUseResponse}
+// @Failure      422 {object} response.APIResponse
 // @Router       /categories/{id} [delete]
 func (h *ProductHandler) DeleteCategory(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -423,14 +1369,54 @@ func (h *ProductHandler) DeleteCategory(ctx *gin.Context) {
 		return
 	}
 
-	if err := h.productService.DeleteCategory(uint(id)); err != nil {
-		if err == service.ErrCategoryNotFound {
+	var params dto.DeleteCategoryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	productCount, err := h.productService.DeleteCategory(uint(id), &params)
+	if err != nil {
+		switch err {
+		case service.ErrCategoryNotFound:
 			response.NotFound(ctx, "Category not found")
-			return
+		case service.ErrReassignCategoryNotFound:
+			response.NotFound(ctx, "Target category not found")
+		case service.ErrInvalidCategoryReassign:
+			response.BadRequest(ctx, err.Error(), nil)
+		case service.ErrCategoryHasChildren:
+			response.Error(ctx, http.StatusConflict, "Category still has subcategories", nil)
+		case service.ErrCategoryHasProducts:
+			response.Error(ctx, http.StatusConflict, "Category still has products, use reassign_to or unassign",
+				dto.CategoryInUseResponse{ProductCount: productCount})
+		default:
+			response.InternalServerError(ctx, "Failed to delete category", err.Error())
 		}
-		response.InternalServerError(ctx, "Failed to delete category", err.Error())
 		return
 	}
 
 	response.OK(ctx, "Category deleted successfully", nil)
 }
+
+// parseIDList mengubah daftar ID dipisah koma (mis. "1, 2,3") menjadi slice ID
+func parseIDList(raw string) ([]uint, error) {
+	parts := strings.Split(raw, ",")
+	ids := make([]uint, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("invalid product ID %q", part)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// countDistinct menghitung jumlah ID berbeda
+func countDistinct(ids []uint) int {
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	return len(seen)
+}
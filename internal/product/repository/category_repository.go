@@ -10,9 +10,15 @@ type CategoryRepository interface {
 	Create(category *entity.Category) error
 	FindByID(id uint) (*entity.Category, error)
 	FindByName(name string) (*entity.Category, error)
+	FindExistingNames(names []string) ([]string, error)
 	FindAll() ([]entity.Category, error)
+	FindChildren(parentID uint) ([]entity.Category, error)
+	CountProducts(id uint) (int64, error)
+	CountVisibleProducts(ids []uint) (map[uint]int64, error)
+	ReassignProducts(fromID uint, toID uint) ([]uint, error)
 	Update(category *entity.Category) error
 	Delete(id uint) error
+	WithTx(tx *gorm.DB) CategoryRepository
 }
 
 // categoryRepository implementasi CategoryRepository
@@ -25,6 +31,11 @@ func NewCategoryRepository(db *gorm.DB) CategoryRepository {
 	return &categoryRepository{db: db}
 }
 
+// WithTx mengembalikan repository dengan transaction
+func (r *categoryRepository) WithTx(tx *gorm.DB) CategoryRepository {
+	return &categoryRepository{db: tx}
+}
+
 // Create menyimpan kategori baru ke database
 func (r *categoryRepository) Create(category *entity.Category) error {
 	return r.db.Create(category).Error
@@ -48,6 +59,19 @@ func (r *categoryRepository) FindByName(name string) (*entity.Category, error) {
 	return &category, nil
 }
 
+// FindExistingNames mengembalikan nama dari names yang sudah dipakai, termasuk oleh kategori
+// yang sudah di-soft delete (unique index tetap berlaku untuk baris tersebut)
+func (r *categoryRepository) FindExistingNames(names []string) ([]string, error) {
+	var existing []string
+	if len(names) == 0 {
+		return existing, nil
+	}
+	if err := r.db.Unscoped().Model(&entity.Category{}).Where("name IN ?", names).Pluck("name", &existing).Error; err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
 // FindAll mengambil semua kategori
 func (r *categoryRepository) FindAll() ([]entity.Category, error) {
 	var categories []entity.Category
@@ -57,6 +81,72 @@ func (r *categoryRepository) FindAll() ([]entity.Category, error) {
 	return categories, nil
 }
 
+// FindChildren mengambil sub-kategori langsung dari sebuah kategori
+func (r *categoryRepository) FindChildren(parentID uint) ([]entity.Category, error) {
+	var categories []entity.Category
+	if err := r.db.Where("parent_id = ?", parentID).Order("name ASC").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// CountProducts menghitung produk (yang belum dihapus) di kategori
+func (r *categoryRepository) CountProducts(id uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.Product{}).Where("category_id = ?", id).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountVisibleProducts menghitung produk yang tampil di listing publik (aktif, belum dihapus, dan
+// seller-nya tidak dinonaktifkan) per kategori dalam satu query GROUP BY.
+// Kategori tanpa produk tidak muncul di map.
+func (r *categoryRepository) CountVisibleProducts(ids []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(ids))
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		CategoryID uint
+		Count      int64
+	}
+	err := r.db.Model(&entity.Product{}).
+		Select("category_id, COUNT(*) AS count").
+		Where("category_id IN ? AND is_active = ?", ids, true).
+		Where("NOT EXISTS (SELECT 1 FROM users WHERE users.id = products.seller_id AND users.is_active = ?)", false).
+		Group("category_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
+
+// ReassignProducts memindahkan seluruh produk kategori fromID ke toID (0 = tanpa kategori).
+// Produk yang sudah di-soft delete ikut dipindahkan agar tidak menunjuk kategori yang dihapus jika dipulihkan.
+// Mengembalikan ID produk yang dipindahkan.
+func (r *categoryRepository) ReassignProducts(fromID uint, toID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Unscoped().Model(&entity.Product{}).Where("category_id = ?", fromID).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+	err := r.db.Unscoped().Model(&entity.Product{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"category_id": toID,
+			"version":     gorm.Expr("version + 1"),
+		}).Error
+	return ids, err
+}
+
 // Update mengupdate data kategori
 func (r *categoryRepository) Update(category *entity.Category) error {
 	return r.db.Save(category).Error
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"gorm.io/gorm"
+)
+
+// InventoryLogRepository interface untuk akses data inventory log
+type InventoryLogRepository interface {
+	Create(log *entity.InventoryLog) error
+	FindByProductID(productID uint, params *dto.InventoryLogQueryParams) ([]entity.InventoryLog, int64, error)
+	WithTx(tx *gorm.DB) InventoryLogRepository
+}
+
+// inventoryLogRepository implementasi InventoryLogRepository
+type inventoryLogRepository struct {
+	db *gorm.DB
+}
+
+// NewInventoryLogRepository membuat instance baru InventoryLogRepository
+func NewInventoryLogRepository(db *gorm.DB) InventoryLogRepository {
+	return &inventoryLogRepository{db: db}
+}
+
+// WithTx mengembalikan repository dengan transaction
+func (r *inventoryLogRepository) WithTx(tx *gorm.DB) InventoryLogRepository {
+	return &inventoryLogRepository{db: tx}
+}
+
+// Create menyimpan entri inventory log
+func (r *inventoryLogRepository) Create(log *entity.InventoryLog) error {
+	return r.db.Create(log).Error
+}
+
+// FindByProductID mengambil riwayat perubahan stok produk, terbaru lebih dulu
+func (r *inventoryLogRepository) FindByProductID(productID uint, params *dto.InventoryLogQueryParams) ([]entity.InventoryLog, int64, error) {
+	var logs []entity.InventoryLog
+	var total int64
+
+	query := r.db.Model(&entity.InventoryLog{}).Where("product_id = ?", productID)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (params.Page - 1) * params.Limit
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(params.Limit).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
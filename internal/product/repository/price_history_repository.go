@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"gorm.io/gorm"
+)
+
+// PriceHistoryRepository interface untuk akses data riwayat harga produk
+type PriceHistoryRepository interface {
+	Create(history *entity.PriceHistory) error
+	FindByProductID(productID uint, params *dto.PriceHistoryQueryParams) ([]entity.PriceHistory, int64, error)
+	WithTx(tx *gorm.DB) PriceHistoryRepository
+}
+
+// priceHistoryRepository implementasi PriceHistoryRepository
+type priceHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceHistoryRepository membuat instance baru PriceHistoryRepository
+func NewPriceHistoryRepository(db *gorm.DB) PriceHistoryRepository {
+	return &priceHistoryRepository{db: db}
+}
+
+// WithTx mengembalikan repository dengan transaction
+func (r *priceHistoryRepository) WithTx(tx *gorm.DB) PriceHistoryRepository {
+	return &priceHistoryRepository{db: tx}
+}
+
+// Create menyimpan entri riwayat harga
+func (r *priceHistoryRepository) Create(history *entity.PriceHistory) error {
+	return r.db.Create(history).Error
+}
+
+// FindByProductID mengambil riwayat perubahan harga produk, terbaru lebih dulu
+func (r *priceHistoryRepository) FindByProductID(productID uint, params *dto.PriceHistoryQueryParams) ([]entity.PriceHistory, int64, error) {
+	var histories []entity.PriceHistory
+	var total int64
+
+	query := r.db.Model(&entity.PriceHistory{}).Where("product_id = ?", productID)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (params.Page - 1) * params.Limit
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(params.Limit).Find(&histories).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return histories, total, nil
+}
@@ -1,21 +1,49 @@
 package repository
 
 import (
+	"errors"
+	"strings"
+	"unicode"
+
 	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrStaleProduct dikembalikan Update jika produk sudah diubah request lain sejak dibaca
+var ErrStaleProduct = errors.New("product was modified since it was read")
+
 // ProductRepository interface untuk akses data produk
 type ProductRepository interface {
 	Create(product *entity.Product) error
 	FindByID(id uint) (*entity.Product, error)
 	FindByIDWithCategory(id uint) (*entity.Product, error)
+	FindByIDs(ids []uint) ([]entity.Product, error)
+	FindByIDsWithCategory(ids []uint) ([]entity.Product, error)
+	FindBySKU(sku string) (*entity.Product, error)
+	SKUExists(sku string) (bool, error)
 	FindAll(params *dto.ProductQueryParams) ([]entity.Product, int64, error)
 	FindBySellerID(sellerID uint) ([]entity.Product, error)
+	FindLowStockBySellerID(sellerID uint, defaultThreshold int) ([]entity.Product, error)
+	SumInventoryValueBySellerID(sellerID uint) (money.Money, error)
+	Count() (int64, error)
 	Update(product *entity.Product) error
 	Delete(id uint) error
+	FindByIDUnscoped(id uint) (*entity.Product, error)
+	CountOpenOrderReferences(id uint) (int64, error)
+	CountOpenOrderReferencesByIDs(ids []uint) (map[uint]int64, error)
+	DeleteByIDs(ids []uint) error
+	HardDelete(id uint) error
 	UpdateStock(id uint, quantity int) error
+	SetStockAtomic(id uint, expected int, stock int) (bool, error)
+	SyncStockFromVariants(id uint) error
+	ReduceStockAtomic(id uint, quantity int) (bool, error)
+	ReserveStockAtomic(id uint, quantity int) (bool, error)
+	ReleaseReservedStock(id uint, quantity int) error
+	UpdateRatingSummary(id uint, average float64, count int) error
+	UpdateImageURL(id uint, imageURL string) error
 	WithTx(tx *gorm.DB) ProductRepository
 }
 
@@ -48,15 +76,58 @@ func (r *productRepository) FindByID(id uint) (*entity.Product, error) {
 	return &product, nil
 }
 
+// FindByIDs mengambil beberapa produk sekaligus dalam satu query; ID yang tidak ditemukan dilewati
+func (r *productRepository) FindByIDs(ids []uint) ([]entity.Product, error) {
+	var products []entity.Product
+	if len(ids) == 0 {
+		return products, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// FindByIDsWithCategory sama dengan FindByIDs, dengan relasi kategori ikut dimuat
+func (r *productRepository) FindByIDsWithCategory(ids []uint) ([]entity.Product, error) {
+	var products []entity.Product
+	if len(ids) == 0 {
+		return products, nil
+	}
+	if err := r.db.Preload("Category").Preload("Tags", orderTags).Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
 // FindByIDWithCategory mencari produk dengan relasi kategori
 func (r *productRepository) FindByIDWithCategory(id uint) (*entity.Product, error) {
 	var product entity.Product
-	if err := r.db.Preload("Category").First(&product, id).Error; err != nil {
+	if err := r.db.Preload("Category").Preload("Variants").Preload("Tags", orderTags).First(&product, id).Error; err != nil {
 		return nil, err
 	}
 	return &product, nil
 }
 
+// FindBySKU mencari produk (belum dihapus) berdasarkan SKU beserta kategori dan variannya
+func (r *productRepository) FindBySKU(sku string) (*entity.Product, error) {
+	var product entity.Product
+	if err := r.db.Preload("Category").Preload("Variants").Preload("Tags", orderTags).Where("sku = ?", sku).First(&product).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// SKUExists mengecek apakah SKU sudah dipakai, termasuk oleh produk yang sudah di-soft delete
+// (unique index tetap berlaku untuk baris tersebut)
+func (r *productRepository) SKUExists(sku string) (bool, error) {
+	var count int64
+	if err := r.db.Unscoped().Model(&entity.Product{}).Where("sku = ?", sku).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // FindAll mengambil semua produk dengan filter dan pagination
 func (r *productRepository) FindAll(params *dto.ProductQueryParams) ([]entity.Product, int64, error) {
 	var products []entity.Product
@@ -65,8 +136,9 @@ func (r *productRepository) FindAll(params *dto.ProductQueryParams) ([]entity.Pr
 	query := r.db.Model(&entity.Product{})
 
 	// Apply filters
-	if params.Search != "" {
-		query = query.Where("name ILIKE ?", "%"+params.Search+"%")
+	tsQuery := buildTSQuery(params.Search)
+	if tsQuery != "" {
+		query = query.Where("search_vector @@ to_tsquery('simple', ?)", tsQuery)
 	}
 	if params.CategoryID > 0 {
 		query = query.Where("category_id = ?", params.CategoryID)
@@ -83,33 +155,142 @@ func (r *productRepository) FindAll(params *dto.ProductQueryParams) ([]entity.Pr
 	if params.IsActive != nil {
 		query = query.Where("is_active = ?", *params.IsActive)
 	}
+	// Filter tag berupa join ke product_tags yang sudah di-group per produk, sehingga setiap produk
+	// tetap satu baris dan filter lain tetap berlaku. Mode all: produk harus punya semua tag.
+	if len(params.TagNames) > 0 {
+		minMatches := 1
+		if params.TagMode == dto.TagModeAll {
+			minMatches = len(params.TagNames)
+		}
+		query = query.Joins(`JOIN (SELECT product_tags.product_id FROM product_tags
+			JOIN tags ON tags.id = product_tags.tag_id
+			WHERE tags.name IN ?
+			GROUP BY product_tags.product_id
+			HAVING COUNT(DISTINCT tags.id) >= ?) AS tagged ON tagged.product_id = products.id`,
+			params.TagNames, minMatches)
+	}
+	// Produk milik seller yang akunnya dinonaktifkan tidak tampil di listing publik
+	query = query.Where("NOT EXISTS (SELECT 1 FROM users WHERE users.id = products.seller_id AND users.is_active = ?)", false)
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	// Apply sorting (id sebagai tie-breaker agar pagination deterministik)
+	switch resolveSort(params.Sort, tsQuery != "") {
+	case dto.SortPriceAsc:
+		query = query.Order("price ASC")
+	case dto.SortPriceDesc:
+		query = query.Order("price DESC")
+	case dto.SortRelevance:
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(search_vector, to_tsquery('simple', ?)) DESC",
+			Vars: []interface{}{tsQuery},
+		}})
+	default:
+		query = query.Order("created_at DESC")
+	}
+	query = query.Order("id DESC")
+
 	// Apply pagination
 	offset := (params.Page - 1) * params.Limit
-	if err := query.Preload("Category").Offset(offset).Limit(params.Limit).Find(&products).Error; err != nil {
+	if err := query.Preload("Category").Preload("Tags", orderTags).Offset(offset).Limit(params.Limit).Find(&products).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return products, total, nil
 }
 
+// orderTags mengurutkan tag produk yang di-preload berdasarkan nama
+func orderTags(db *gorm.DB) *gorm.DB {
+	return db.Order("tags.name")
+}
+
+// resolveSort menentukan urutan list produk. Default relevance saat ada search, selain itu newest.
+// Relevance tanpa search tidak bermakna sehingga jatuh ke newest.
+func resolveSort(sort string, hasSearch bool) string {
+	switch {
+	case sort == "" && hasSearch:
+		return dto.SortRelevance
+	case sort == "", sort == dto.SortRelevance && !hasSearch:
+		return dto.SortNewest
+	}
+	return sort
+}
+
 // FindBySellerID mengambil produk berdasarkan seller ID
 func (r *productRepository) FindBySellerID(sellerID uint) ([]entity.Product, error) {
 	var products []entity.Product
-	if err := r.db.Where("seller_id = ?", sellerID).Preload("Category").Find(&products).Error; err != nil {
+	if err := r.db.Where("seller_id = ?", sellerID).Preload("Category").Preload("Tags", orderTags).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// FindLowStockBySellerID mengambil produk seller yang stoknya <= threshold (per produk atau default)
+func (r *productRepository) FindLowStockBySellerID(sellerID uint, defaultThreshold int) ([]entity.Product, error) {
+	var products []entity.Product
+	if err := r.db.Where("seller_id = ? AND stock <= COALESCE(low_stock_threshold, ?)", sellerID, defaultThreshold).
+		Preload("Category").
+		Order("stock ASC").
+		Find(&products).Error; err != nil {
 		return nil, err
 	}
 	return products, nil
 }
 
-// Update mengupdate data produk
+// SumInventoryValueBySellerID menghitung total nilai stok (price * stock) seluruh produk seller
+func (r *productRepository) SumInventoryValueBySellerID(sellerID uint) (money.Money, error) {
+	var total money.Money
+	if err := r.db.Model(&entity.Product{}).
+		Where("seller_id = ?", sellerID).
+		Select("COALESCE(SUM(price * stock), 0)").
+		Scan(&total).Error; err != nil {
+		return money.Zero, err
+	}
+	return total, nil
+}
+
+// Update mengupdate data produk dengan optimistic locking: baris hanya diubah jika version-nya
+// masih sama dengan saat produk dibaca, lalu version dinaikkan. Mengembalikan ErrStaleProduct
+// jika produk sudah diubah request lain di antaranya.
 func (r *productRepository) Update(product *entity.Product) error {
-	return r.db.Save(product).Error
+	expected := product.Version
+	product.Version++
+	// reserved_stock hanya diubah lewat reservasi agar tidak tertimpa nilai lama.
+	// Select("*") membuat Save selalu UPDATE, tanpa fallback INSERT saat tidak ada baris yang cocok.
+	result := r.db.Select("*").Omit("reserved_stock").Where("version = ?", expected).Save(product)
+	if result.Error != nil {
+		product.Version = expected
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		product.Version = expected
+		return ErrStaleProduct
+	}
+	return nil
+}
+
+// buildTSQuery mengubah input search bebas menjadi query to_tsquery yang aman,
+// setiap kata di-AND dan dicocokkan sebagai prefix (mis. "iphone 15" -> "iphone:* & 15:*")
+func buildTSQuery(search string) string {
+	terms := strings.FieldsFunc(strings.ToLower(search), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	})
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// Count menghitung jumlah seluruh produk (tidak termasuk yang sudah dihapus)
+func (r *productRepository) Count() (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.Product{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
 }
 
 // Delete menghapus produk (soft delete)
@@ -117,9 +298,227 @@ func (r *productRepository) Delete(id uint) error {
 	return r.db.Delete(&entity.Product{}, id).Error
 }
 
+// FindByIDUnscoped mencari produk berdasarkan ID, termasuk yang sudah di-soft delete
+func (r *productRepository) FindByIDUnscoped(id uint) (*entity.Product, error) {
+	var product entity.Product
+	if err := r.db.Unscoped().First(&product, id).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// CountOpenOrderReferences menghitung order yang belum dibatalkan dan memuat produk ini.
+// Tabel order dibaca langsung karena Product Module tidak bergantung pada Order Module.
+func (r *productRepository) CountOpenOrderReferences(id uint) (int64, error) {
+	var count int64
+	err := r.db.Raw(`SELECT COUNT(DISTINCT order_items.order_id) FROM order_items
+		JOIN orders ON orders.id = order_items.order_id
+		WHERE order_items.product_id = ? AND orders.status <> ?
+		AND order_items.deleted_at IS NULL AND orders.deleted_at IS NULL`,
+		id, "CANCELLED").Scan(&count).Error
+	return count, err
+}
+
+// CountOpenOrderReferencesByIDs seperti CountOpenOrderReferences untuk banyak produk sekaligus.
+// Produk yang tidak ada di order yang belum dibatalkan tidak muncul di map.
+func (r *productRepository) CountOpenOrderReferencesByIDs(ids []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ProductID  uint
+		OrderCount int64
+	}
+	err := r.db.Raw(`SELECT order_items.product_id, COUNT(DISTINCT order_items.order_id) AS order_count FROM order_items
+		JOIN orders ON orders.id = order_items.order_id
+		WHERE order_items.product_id IN ? AND orders.status <> ?
+		AND order_items.deleted_at IS NULL AND orders.deleted_at IS NULL
+		GROUP BY order_items.product_id`,
+		ids, "CANCELLED").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ProductID] = row.OrderCount
+	}
+	return counts, nil
+}
+
+// DeleteByIDs menghapus banyak produk sekaligus (soft delete)
+func (r *productRepository) DeleteByIDs(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Delete(&entity.Product{}, ids).Error
+}
+
+// HardDelete menghapus produk secara permanen beserta varian, riwayat stok, riwayat harga,
+// reservasi, dan tag yang terpasang. Harus dipanggil di dalam transaction (lihat WithTx).
+func (r *productRepository) HardDelete(id uint) error {
+	for _, model := range []interface{}{
+		&entity.ProductVariant{},
+		&entity.InventoryLog{},
+		&entity.PriceHistory{},
+		&entity.StockReservation{},
+		&entity.StockSubscription{},
+	} {
+		if err := r.db.Unscoped().Where("product_id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	if err := r.db.Exec("DELETE FROM product_tags WHERE product_id = ?", id).Error; err != nil {
+		return err
+	}
+	return r.db.Unscoped().Delete(&entity.Product{}, id).Error
+}
+
+// UpdateRatingSummary menyimpan rata-rata rating dan jumlah review produk
+func (r *productRepository) UpdateRatingSummary(id uint, average float64, count int) error {
+	return r.db.Model(&entity.Product{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"average_rating": average,
+			"review_count":   count,
+			"version":        gorm.Expr("version + 1"),
+		}).Error
+}
+
+// UpdateImageURL mengganti URL gambar produk tanpa menyentuh kolom lain
+func (r *productRepository) UpdateImageURL(id uint, imageURL string) error {
+	return r.db.Model(&entity.Product{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"image_url": imageURL,
+			"version":   gorm.Expr("version + 1"),
+		}).Error
+}
+
 // UpdateStock mengupdate stok produk dengan row-level locking
 func (r *productRepository) UpdateStock(id uint, quantity int) error {
 	return r.db.Model(&entity.Product{}).
 		Where("id = ?", id).
-		Update("stock", gorm.Expr("stock + ?", quantity)).Error
+		Updates(map[string]interface{}{
+			"stock":   gorm.Expr("stock + ?", quantity),
+			"version": gorm.Expr("version + 1"),
+		}).Error
+}
+
+// SetStockAtomic mengganti stok dengan nilai absolut hanya jika stok saat ini masih expected
+// dan tidak lebih kecil dari stok yang direservasi. Mengembalikan false jika salah satunya tidak terpenuhi.
+func (r *productRepository) SetStockAtomic(id uint, expected int, stock int) (bool, error) {
+	result := r.db.Model(&entity.Product{}).
+		Where("id = ? AND stock = ? AND reserved_stock <= ?", id, expected, stock).
+		Updates(map[string]interface{}{
+			"stock":   stock,
+			"version": gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SyncStockFromVariants mengisi ulang stok dan stok yang direservasi produk dengan total seluruh variannya
+func (r *productRepository) SyncStockFromVariants(id uint) error {
+	return r.db.Model(&entity.Product{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"stock": gorm.Expr(
+				"(SELECT COALESCE(SUM(stock), 0) FROM product_variants WHERE product_id = ? AND deleted_at IS NULL)", id,
+			),
+			"reserved_stock": gorm.Expr(
+				"(SELECT COALESCE(SUM(reserved_stock), 0) FROM product_variants WHERE product_id = ? AND deleted_at IS NULL)", id,
+			),
+			"version": gorm.Expr("version + 1"),
+		}).Error
+}
+
+// ReduceStockAtomic mengurangi stok dalam satu UPDATE bersyarat agar stok yang bisa dijual
+// (stok dikurangi reservasi) tidak bisa negatif saat checkout berjalan paralel.
+// Mengembalikan false jika stok tidak mencukupi.
+func (r *productRepository) ReduceStockAtomic(id uint, quantity int) (bool, error) {
+	result := r.db.Model(&entity.Product{}).
+		Where("id = ? AND stock - reserved_stock >= ?", id, quantity).
+		Updates(map[string]interface{}{
+			"stock":   gorm.Expr("stock - ?", quantity),
+			"version": gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReserveStockAtomic menambah reserved_stock hanya jika stok yang bisa dijual mencukupi.
+// Version ikut naik agar Update yang membaca produk sebelum reservasi ini ditolak sebagai stale.
+// Mengembalikan false jika produk tidak ada atau stok kurang.
+func (r *productRepository) ReserveStockAtomic(id uint, quantity int) (bool, error) {
+	result := r.db.Model(&entity.Product{}).
+		Where("id = ? AND stock - reserved_stock >= ?", id, quantity).
+		Updates(map[string]interface{}{
+			"reserved_stock": gorm.Expr("reserved_stock + ?", quantity),
+			"version":        gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseReservedStock mengurangi reserved_stock (tidak pernah di bawah nol)
+func (r *productRepository) ReleaseReservedStock(id uint, quantity int) error {
+	return r.db.Model(&entity.Product{}).
+		Where("id = ?", id).
+		Update("reserved_stock", gorm.Expr(
+			"CASE WHEN reserved_stock > ? THEN reserved_stock - ? ELSE 0 END", quantity, quantity,
+		)).Error
+}
+
+// searchVectorExpression adalah dokumen full-text search: name (bobot A) dan description (bobot B)
+const searchVectorExpression = `setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+	setweight(to_tsvector('simple', coalesce(description, '')), 'B')`
+
+// MigrateSearchVector menjadikan search_vector generated column (dihitung PostgreSQL dari name dan description)
+// beserta GIN index-nya, sehingga produk lama dan semua jalur update selalu ikut ter-index.
+// Kolom tsvector biasa dari versi sebelumnya diganti sekali. Khusus PostgreSQL; dilewati di dialect lain.
+func MigrateSearchVector(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	var generated int64
+	if err := db.Raw(`SELECT COUNT(*) FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'products'
+		AND column_name = 'search_vector' AND is_generated = 'ALWAYS'`).Scan(&generated).Error; err != nil {
+		return err
+	}
+	if generated == 0 {
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("ALTER TABLE products DROP COLUMN IF EXISTS search_vector").Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE products ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (" +
+				searchVectorExpression + ") STORED").Error
+		}); err != nil {
+			return err
+		}
+	}
+
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector)").Error
+}
+
+// BackfillProductSKUs menyiapkan kolom sku sebelum AutoMigrate menambahkan NOT NULL dan unique index.
+// Produk lama yang belum punya SKU diberi placeholder PRD-<id> yang bisa diganti seller lewat update produk.
+func BackfillProductSKUs(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&entity.Product{}) {
+		return nil
+	}
+	if !migrator.HasColumn(&entity.Product{}, "sku") {
+		if err := db.Exec("ALTER TABLE products ADD COLUMN sku varchar(100)").Error; err != nil {
+			return err
+		}
+	}
+	return db.Exec("UPDATE products SET sku = 'PRD-' || CAST(id AS varchar(20)) WHERE sku IS NULL OR sku = ''").Error
 }
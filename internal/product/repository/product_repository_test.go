@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTSQuery(t *testing.T) {
+	tests := []struct {
+		search   string
+		expected string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"Laptop", "laptop:*"},
+		{"iphone 15 pro", "iphone:* & 15:* & pro:*"},
+		{"kopi' | !susu & (gula)", "kopi:* & susu:* & gula:*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildTSQuery(tt.search))
+		})
+	}
+}
+
+func TestResolveSort(t *testing.T) {
+	assert.Equal(t, dto.SortNewest, resolveSort("", false))
+	assert.Equal(t, dto.SortRelevance, resolveSort("", true))
+	assert.Equal(t, dto.SortNewest, resolveSort(dto.SortRelevance, false))
+	assert.Equal(t, dto.SortPriceAsc, resolveSort(dto.SortPriceAsc, true))
+	assert.Equal(t, dto.SortPriceDesc, resolveSort(dto.SortPriceDesc, false))
+}
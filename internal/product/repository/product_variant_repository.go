@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"gorm.io/gorm"
+)
+
+// ProductVariantRepository interface untuk akses data varian produk
+type ProductVariantRepository interface {
+	Create(variant *entity.ProductVariant) error
+	FindByID(id uint) (*entity.ProductVariant, error)
+	FindBySKU(sku string) (*entity.ProductVariant, error)
+	FindByProductID(productID uint) ([]entity.ProductVariant, error)
+	CountByProductID(productID uint) (int64, error)
+	Update(variant *entity.ProductVariant) error
+	Delete(id uint) error
+	UpdateStock(id uint, quantity int) error
+	ReduceStockAtomic(id uint, productID uint, quantity int) (bool, error)
+	ReserveStockAtomic(id uint, productID uint, quantity int) (bool, error)
+	ReleaseReservedStock(id uint, quantity int) error
+	WithTx(tx *gorm.DB) ProductVariantRepository
+}
+
+// productVariantRepository implementasi ProductVariantRepository
+type productVariantRepository struct {
+	db *gorm.DB
+}
+
+// NewProductVariantRepository membuat instance baru ProductVariantRepository
+func NewProductVariantRepository(db *gorm.DB) ProductVariantRepository {
+	return &productVariantRepository{db: db}
+}
+
+// WithTx mengembalikan repository dengan transaction
+func (r *productVariantRepository) WithTx(tx *gorm.DB) ProductVariantRepository {
+	return &productVariantRepository{db: tx}
+}
+
+// Create menyimpan varian baru ke database
+func (r *productVariantRepository) Create(variant *entity.ProductVariant) error {
+	return r.db.Create(variant).Error
+}
+
+// FindByID mencari varian berdasarkan ID
+func (r *productVariantRepository) FindByID(id uint) (*entity.ProductVariant, error) {
+	var variant entity.ProductVariant
+	if err := r.db.First(&variant, id).Error; err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// FindBySKU mencari varian berdasarkan SKU
+func (r *productVariantRepository) FindBySKU(sku string) (*entity.ProductVariant, error) {
+	var variant entity.ProductVariant
+	if err := r.db.Where("sku = ?", sku).First(&variant).Error; err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// FindByProductID mengambil semua varian milik produk
+func (r *productVariantRepository) FindByProductID(productID uint) ([]entity.ProductVariant, error) {
+	var variants []entity.ProductVariant
+	if err := r.db.Where("product_id = ?", productID).Order("id ASC").Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+// CountByProductID menghitung jumlah varian milik produk
+func (r *productVariantRepository) CountByProductID(productID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.ProductVariant{}).Where("product_id = ?", productID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Update mengupdate data varian
+func (r *productVariantRepository) Update(variant *entity.ProductVariant) error {
+	// reserved_stock hanya diubah lewat reservasi agar tidak tertimpa nilai lama
+	return r.db.Omit("reserved_stock").Save(variant).Error
+}
+
+// Delete menghapus varian (soft delete)
+func (r *productVariantRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.ProductVariant{}, id).Error
+}
+
+// UpdateStock menambah stok varian (quantity bisa negatif)
+func (r *productVariantRepository) UpdateStock(id uint, quantity int) error {
+	return r.db.Model(&entity.ProductVariant{}).
+		Where("id = ?", id).
+		Update("stock", gorm.Expr("stock + ?", quantity)).Error
+}
+
+// ReduceStockAtomic mengurangi stok varian hanya jika stok yang bisa dijual mencukupi.
+// Mengembalikan false jika varian tidak ada, bukan milik produk, atau stok kurang.
+func (r *productVariantRepository) ReduceStockAtomic(id uint, productID uint, quantity int) (bool, error) {
+	result := r.db.Model(&entity.ProductVariant{}).
+		Where("id = ? AND product_id = ? AND stock - reserved_stock >= ?", id, productID, quantity).
+		Update("stock", gorm.Expr("stock - ?", quantity))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReserveStockAtomic menambah reserved_stock varian hanya jika stok yang bisa dijual mencukupi.
+// Mengembalikan false jika varian tidak ada, bukan milik produk, atau stok kurang.
+func (r *productVariantRepository) ReserveStockAtomic(id uint, productID uint, quantity int) (bool, error) {
+	result := r.db.Model(&entity.ProductVariant{}).
+		Where("id = ? AND product_id = ? AND stock - reserved_stock >= ?", id, productID, quantity).
+		Update("reserved_stock", gorm.Expr("reserved_stock + ?", quantity))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseReservedStock mengurangi reserved_stock varian (tidak pernah di bawah nol)
+func (r *productVariantRepository) ReleaseReservedStock(id uint, quantity int) error {
+	return r.db.Model(&entity.ProductVariant{}).
+		Where("id = ?", id).
+		Update("reserved_stock", gorm.Expr(
+			"CASE WHEN reserved_stock > ? THEN reserved_stock - ? ELSE 0 END", quantity, quantity,
+		)).Error
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"gorm.io/gorm"
+)
+
+// StockReservationRepository interface untuk akses data reservasi stok
+type StockReservationRepository interface {
+	Create(reservation *entity.StockReservation) error
+	FindByOrderID(orderID uint) ([]entity.StockReservation, error)
+	FindExpiredActive(before time.Time, limit int) ([]entity.StockReservation, error)
+	UpdateStatusIf(id uint, fromStatus, toStatus string) (bool, error)
+	WithTx(tx *gorm.DB) StockReservationRepository
+}
+
+// stockReservationRepository implementasi StockReservationRepository
+type stockReservationRepository struct {
+	db *gorm.DB
+}
+
+// NewStockReservationRepository membuat instance baru StockReservationRepository
+func NewStockReservationRepository(db *gorm.DB) StockReservationRepository {
+	return &stockReservationRepository{db: db}
+}
+
+// WithTx mengembalikan repository dengan transaction
+func (r *stockReservationRepository) WithTx(tx *gorm.DB) StockReservationRepository {
+	return &stockReservationRepository{db: tx}
+}
+
+// Create menyimpan reservasi stok baru
+func (r *stockReservationRepository) Create(reservation *entity.StockReservation) error {
+	return r.db.Create(reservation).Error
+}
+
+// FindByOrderID mengambil seluruh reservasi milik order (semua status)
+func (r *stockReservationRepository) FindByOrderID(orderID uint) ([]entity.StockReservation, error) {
+	var reservations []entity.StockReservation
+	err := r.db.Where("order_id = ?", orderID).Order("id ASC").Find(&reservations).Error
+	return reservations, err
+}
+
+// FindExpiredActive mengambil reservasi ACTIVE yang sudah melewati ExpiresAt, terlama lebih dulu
+func (r *stockReservationRepository) FindExpiredActive(before time.Time, limit int) ([]entity.StockReservation, error) {
+	var reservations []entity.StockReservation
+	err := r.db.
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", entity.ReservationStatusActive, before).
+		Order("expires_at ASC, id ASC").
+		Limit(limit).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// UpdateStatusIf mengubah status reservasi hanya jika statusnya masih fromStatus.
+// Mengembalikan false jika reservasi sudah diproses lebih dulu (mis. oleh worker atau pembayaran).
+func (r *stockReservationRepository) UpdateStatusIf(id uint, fromStatus, toStatus string) (bool, error) {
+	result := r.db.Model(&entity.StockReservation{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Update("status", toStatus)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
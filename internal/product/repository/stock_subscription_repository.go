@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StockSubscriptionRepository interface untuk akses data langganan notifikasi stok kembali tersedia
+type StockSubscriptionRepository interface {
+	Create(subscription *entity.StockSubscription) error
+	Delete(userID uint, productID uint) (bool, error)
+	ClaimByProductID(productID uint) ([]uint, error)
+	WithTx(tx *gorm.DB) StockSubscriptionRepository
+}
+
+// stockSubscriptionRepository implementasi StockSubscriptionRepository
+type stockSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewStockSubscriptionRepository membuat instance baru StockSubscriptionRepository
+func NewStockSubscriptionRepository(db *gorm.DB) StockSubscriptionRepository {
+	return &stockSubscriptionRepository{db: db}
+}
+
+// WithTx mengembalikan repository dengan transaction
+func (r *stockSubscriptionRepository) WithTx(tx *gorm.DB) StockSubscriptionRepository {
+	return &stockSubscriptionRepository{db: tx}
+}
+
+// Create menyimpan langganan baru; langganan yang sudah ada untuk user dan produk yang sama diabaikan
+func (r *stockSubscriptionRepository) Create(subscription *entity.StockSubscription) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "product_id"}},
+		DoNothing: true,
+	}).Create(subscription).Error
+}
+
+// Delete menghapus langganan user pada produk; false jika langganannya tidak ada
+func (r *stockSubscriptionRepository) Delete(userID uint, productID uint) (bool, error) {
+	result := r.db.Where("user_id = ? AND product_id = ?", userID, productID).
+		Delete(&entity.StockSubscription{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ClaimByProductID mengambil ID user yang berlangganan produk lalu menghapus langganannya.
+// Hanya baris yang benar-benar terhapus yang dikembalikan, sehingga dua proses yang mengklaim
+// bersamaan tidak mengirim notifikasi ganda ke user yang sama.
+func (r *stockSubscriptionRepository) ClaimByProductID(productID uint) ([]uint, error) {
+	var subscriptions []entity.StockSubscription
+	if err := r.db.Where("product_id = ?", productID).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uint, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result := r.db.Where("id = ?", subscription.ID).Delete(&entity.StockSubscription{})
+		if result.Error != nil {
+			return userIDs, result.Error
+		}
+		if result.RowsAffected > 0 {
+			userIDs = append(userIDs, subscription.UserID)
+		}
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository interface untuk akses data tag dan relasinya dengan produk (tabel product_tags)
+type TagRepository interface {
+	FindOrCreateByNames(names []string) ([]entity.Tag, error)
+	AttachToProduct(productID uint, tagIDs []uint) error
+	DetachFromProduct(productID uint, names []string) error
+	WithTx(tx *gorm.DB) TagRepository
+}
+
+// tagRepository implementasi TagRepository
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository membuat instance baru TagRepository
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// WithTx mengembalikan repository dengan transaction
+func (r *tagRepository) WithTx(tx *gorm.DB) TagRepository {
+	return &tagRepository{db: tx}
+}
+
+// FindOrCreateByNames mengambil tag berdasarkan nama dan membuat yang belum ada.
+// Insert memakai ON CONFLICT DO NOTHING agar request paralel dengan tag baru yang sama tidak bentrok.
+func (r *tagRepository) FindOrCreateByNames(names []string) ([]entity.Tag, error) {
+	var tags []entity.Tag
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	newTags := make([]entity.Tag, 0, len(names))
+	for _, name := range names {
+		newTags = append(newTags, entity.Tag{Name: name})
+	}
+	if err := r.db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(&newTags).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Where("name IN ?", names).Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// AttachToProduct menghubungkan tag ke produk; tag yang sudah terpasang dilewati
+func (r *tagRepository) AttachToProduct(productID uint, tagIDs []uint) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	rows := make([]map[string]interface{}, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		rows = append(rows, map[string]interface{}{"product_id": productID, "tag_id": tagID})
+	}
+	return r.db.Table("product_tags").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(rows).Error
+}
+
+// DetachFromProduct melepas tag (berdasarkan nama) dari produk. Tag-nya sendiri tetap ada.
+func (r *tagRepository) DetachFromProduct(productID uint, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	return r.db.Exec(`DELETE FROM product_tags WHERE product_id = ?
+		AND tag_id IN (SELECT id FROM tags WHERE name IN ?)`, productID, names).Error
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// Errors langganan stok kembali tersedia
+var (
+	ErrProductInStock       = errors.New("product is in stock, subscriptions are only for out-of-stock products")
+	ErrSubscriptionNotFound = errors.New("stock subscription not found")
+)
+
+// SubscribeBackInStock mendaftarkan user untuk diberi tahu saat produk yang sedang habis kembali tersedia.
+// Berlangganan ulang pada produk yang sama tidak membuat langganan ganda.
+func (s *productService) SubscribeBackInStock(userID uint, productID uint) error {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProductNotFound
+		}
+		return err
+	}
+	if product.AvailableStock() > 0 {
+		return ErrProductInStock
+	}
+
+	return s.subscriptions.Create(&entity.StockSubscription{UserID: userID, ProductID: productID})
+}
+
+// UnsubscribeBackInStock membatalkan langganan user pada produk
+func (s *productService) UnsubscribeBackInStock(userID uint, productID uint) error {
+	deleted, err := s.subscriptions.Delete(userID, productID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// NotifyBackInStock memberi tahu pelanggan produk yang stoknya kembali tersedia setelah stok dikembalikan
+// di dalam transaction milik pemanggil (cancel order, return, reservasi dilepas). Harus dipanggil setelah
+// tx di-commit agar notifikasi tidak terkirim untuk perubahan stok yang di-rollback.
+func (s *productService) NotifyBackInStock(productIDs ...uint) {
+	seen := make(map[uint]bool, len(productIDs))
+	for _, productID := range productIDs {
+		if seen[productID] {
+			continue
+		}
+		seen[productID] = true
+
+		product, err := s.productRepo.FindByID(productID)
+		if err != nil {
+			logger.Error().Err(err).Uint("product_id", productID).Msg("Failed to load product for back-in-stock check")
+			continue
+		}
+		// Langganan hanya dibuat saat produk habis, jadi stok sebelumnya dianggap 0
+		s.checkBackInStock(product, 0)
+	}
+}
+
+// checkBackInStock memberi tahu semua pelanggan jika stok tersedia produk baru saja naik dari 0,
+// lalu menghapus langganan mereka. Kegagalan hanya dicatat agar perubahan stok tetap berhasil.
+func (s *productService) checkBackInStock(product *entity.Product, previousAvailable int) {
+	if previousAvailable > 0 || product.AvailableStock() <= 0 {
+		return
+	}
+
+	userIDs, err := s.subscriptions.ClaimByProductID(product.ID)
+	if err != nil {
+		logger.Error().Err(err).Uint("product_id", product.ID).Msg("Failed to claim back-in-stock subscriptions")
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	subject := fmt.Sprintf("%s is back in stock", product.Name)
+	body := fmt.Sprintf("Good news! %s is available again.\n\nPrice: %s %s\n\nStock is limited, so order soon if you still want it.",
+		product.Name, product.Currency, product.Price)
+	for _, userID := range userIDs {
+		s.userNotifier.NotifyUser(userID, subject, body)
+	}
+	logger.Info().Uint("product_id", product.ID).Int("subscribers", len(userIDs)).Msg("Back-in-stock notifications sent")
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// stockEmailSender mencatat penerima email yang dikirim
+type stockEmailSender struct {
+	mu   sync.Mutex
+	sent []notifier.Message
+}
+
+func (s *stockEmailSender) Send(ctx context.Context, msg notifier.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func (s *stockEmailSender) recipients() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var to []string
+	for _, msg := range s.sent {
+		to = append(to, msg.To)
+	}
+	sort.Strings(to)
+	return to
+}
+
+// newBackInStockService membuat ProductService dengan notifier yang mengirim ke sender.
+// Email user ID n adalah "user<n>@example.com".
+func newBackInStockService(t *testing.T, sender notifier.EmailSender) (ProductService, *gorm.DB, *notifier.UserNotifier) {
+	_, db := setupProductService(t)
+	userNotifier := notifier.NewUserNotifier(sender, func(userID uint) (string, error) {
+		return fmt.Sprintf("user%d@example.com", userID), nil
+	})
+	svc := NewProductService(ProductServiceDeps{
+		DB:           db,
+		UserNotifier: userNotifier,
+	})
+	return svc, db, userNotifier
+}
+
+func TestSubscribeBackInStock_OnlyForOutOfStockProducts(t *testing.T) {
+	svc, db, _ := newBackInStockService(t, &stockEmailSender{})
+	soldOut := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 0, SellerID: 7, IsActive: true}
+	inStock := &entity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(10), Stock: 3, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(soldOut).Error)
+	require.NoError(t, db.Create(inStock).Error)
+
+	require.NoError(t, svc.SubscribeBackInStock(1, soldOut.ID))
+	// Berlangganan ulang tidak membuat langganan ganda
+	require.NoError(t, svc.SubscribeBackInStock(1, soldOut.ID))
+	var count int64
+	require.NoError(t, db.Model(&entity.StockSubscription{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	assert.ErrorIs(t, svc.SubscribeBackInStock(1, inStock.ID), ErrProductInStock)
+	assert.ErrorIs(t, svc.SubscribeBackInStock(1, soldOut.ID+100), ErrProductNotFound)
+
+	// Stok yang seluruhnya direservasi checkout dianggap habis
+	require.NoError(t, db.Model(inStock).Update("reserved_stock", 3).Error)
+	assert.NoError(t, svc.SubscribeBackInStock(1, inStock.ID))
+
+	require.NoError(t, svc.UnsubscribeBackInStock(1, soldOut.ID))
+	assert.ErrorIs(t, svc.UnsubscribeBackInStock(1, soldOut.ID), ErrSubscriptionNotFound)
+}
+
+func TestUpdateStock_NotifiesSubscribersOnceWhenBackInStock(t *testing.T) {
+	sender := &stockEmailSender{}
+	svc, db, userNotifier := newBackInStockService(t, sender)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 0, SellerID: 7, IsActive: true}
+	other := &entity.Product{Name: "Phone", SKU: "PHONE", Price: money.FromFloat(50), Stock: 0, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+	require.NoError(t, db.Create(other).Error)
+	require.NoError(t, svc.SubscribeBackInStock(1, product.ID))
+	require.NoError(t, svc.SubscribeBackInStock(2, product.ID))
+	require.NoError(t, svc.SubscribeBackInStock(3, other.ID))
+
+	_, err := svc.UpdateStock(7, product.ID, &dto.UpdateStockRequest{Action: "add", Quantity: 5})
+	require.NoError(t, err)
+	// Stok naik lagi saat sudah tersedia tidak mengirim email baru
+	_, err = svc.UpdateStock(7, product.ID, &dto.UpdateStockRequest{Action: "add", Quantity: 5})
+	require.NoError(t, err)
+	require.NoError(t, userNotifier.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"user1@example.com", "user2@example.com"}, sender.recipients())
+	require.Len(t, sender.sent, 2)
+	assert.Equal(t, "Laptop is back in stock", sender.sent[0].Subject)
+
+	// Langganan produk yang sudah dikirimi email dihapus, langganan produk lain tetap ada
+	var remaining []entity.StockSubscription
+	require.NoError(t, db.Find(&remaining).Error)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, other.ID, remaining[0].ProductID)
+}
+
+func TestAdminSetStock_NotifiesSubscribersWhenBackInStock(t *testing.T) {
+	sender := &stockEmailSender{}
+	svc, db, userNotifier := newBackInStockService(t, sender)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 0, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+	require.NoError(t, svc.SubscribeBackInStock(1, product.ID))
+
+	// Koreksi yang tetap 0 tidak mengirim email
+	_, err := svc.AdminSetStock(9, product.ID, &dto.AdminSetStockRequest{Stock: ptr(0), Reason: "Recount"})
+	require.NoError(t, err)
+	_, err = svc.AdminSetStock(9, product.ID, &dto.AdminSetStockRequest{Stock: ptr(4), Reason: "Recount"})
+	require.NoError(t, err)
+	require.NoError(t, userNotifier.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"user1@example.com"}, sender.recipients())
+}
+
+func TestUpdateProduct_NotifiesSubscribersWhenBackInStock(t *testing.T) {
+	sender := &stockEmailSender{}
+	svc, db, userNotifier := newBackInStockService(t, sender)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 0, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+	require.NoError(t, svc.SubscribeBackInStock(1, product.ID))
+
+	// Update tanpa perubahan stok tidak mengirim email
+	_, err := svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Name: ptr("Laptop Pro")})
+	require.NoError(t, err)
+	_, err = svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Stock: ptr(3)})
+	require.NoError(t, err)
+	require.NoError(t, userNotifier.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"user1@example.com"}, sender.recipients())
+}
+
+func TestReleaseAndRestoreStock_NotifySubscribersAfterCommit(t *testing.T) {
+	sender := &stockEmailSender{}
+	svc, db, userNotifier := newBackInStockService(t, sender)
+	reserved := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 2, SellerID: 7, IsActive: true}
+	restored := &entity.Product{Name: "Phone", SKU: "PHONE", Price: money.FromFloat(50), Stock: 0, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(reserved).Error)
+	require.NoError(t, db.Create(restored).Error)
+
+	// Seluruh stok direservasi checkout sehingga produk dianggap habis
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return svc.ReserveStockTx(tx, 1, reserved.ID, nil, 2)
+	}))
+	require.NoError(t, svc.SubscribeBackInStock(1, reserved.ID))
+	require.NoError(t, svc.SubscribeBackInStock(2, restored.ID))
+
+	require.NoError(t, db.Model(&entity.StockReservation{}).Where("order_id = ?", 1).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	released, err := svc.ReleaseExpiredReservations()
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+
+	// Stok yang dikembalikan di transaction yang di-rollback tidak mengirim email
+	rollback := errors.New("rollback")
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := svc.RestoreStockTx(tx, restored.ID, 1, StockChange{Reason: entity.InventoryReasonOrderCancelled}); err != nil {
+			return err
+		}
+		return rollback
+	})
+	require.ErrorIs(t, err, rollback)
+	svc.NotifyBackInStock(restored.ID)
+
+	require.NoError(t, svc.RestoreStock(restored.ID, 1, StockChange{Reason: entity.InventoryReasonOrderCancelled}))
+	require.NoError(t, userNotifier.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"user1@example.com", "user2@example.com"}, sender.recipients())
+}
+
+func TestUpdateVariant_NotifiesSubscribersWhenBackInStock(t *testing.T) {
+	sender := &stockEmailSender{}
+	svc, db, userNotifier := newBackInStockService(t, sender)
+	product := &entity.Product{Name: "Shirt", SKU: "SHIRT", Price: money.FromFloat(20), Stock: 0, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+	variant, err := svc.AddVariant(7, product.ID, &dto.CreateVariantRequest{
+		Attributes: map[string]string{"size": "M"}, SKU: "SHIRT-M", Price: money.FromFloat(20), Stock: 0,
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.SubscribeBackInStock(1, product.ID))
+
+	_, err = svc.UpdateVariant(7, product.ID, variant.ID, &dto.UpdateVariantRequest{Stock: ptr(3)})
+	require.NoError(t, err)
+	require.NoError(t, userNotifier.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"user1@example.com"}, sender.recipients())
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCategories_CreatesAllInOrder(t *testing.T) {
+	svc, db := setupProductService(t)
+	electronics := &entity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(electronics).Error)
+
+	created, conflicts, err := svc.CreateCategories(&dto.BatchCreateCategoryRequest{Categories: []dto.CreateCategoryRequest{
+		{Name: "Phones", ParentID: &electronics.ID},
+		{Name: "Books", Description: "Printed books"},
+	}})
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+	require.Len(t, created, 2)
+	assert.Equal(t, "Phones", created[0].Name)
+	assert.Equal(t, &electronics.ID, created[0].ParentID)
+	assert.Equal(t, "Books", created[1].Name)
+	assert.NotZero(t, created[1].ID)
+}
+
+func TestCreateCategories_RejectsWholeBatchOnConflict(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.Create(&entity.Category{Name: "Phones"}).Error)
+	// Nama kategori yang sudah di-soft delete tetap terpakai karena unique index
+	archived := &entity.Category{Name: "Archived"}
+	require.NoError(t, db.Create(archived).Error)
+	require.NoError(t, db.Delete(archived).Error)
+
+	_, conflicts, err := svc.CreateCategories(&dto.BatchCreateCategoryRequest{Categories: []dto.CreateCategoryRequest{
+		{Name: "Books"},
+		{Name: "Phones"},
+		{Name: "Toys"},
+		{Name: "Toys"},
+		{Name: "Archived"},
+	}})
+	assert.ErrorIs(t, err, ErrCategoryExists)
+	assert.ElementsMatch(t, []string{"Phones", "Toys", "Archived"}, conflicts)
+
+	var count int64
+	require.NoError(t, db.Model(&entity.Category{}).Where("name IN ?", []string{"Books", "Toys"}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestCreateCategories_RollsBackWhenParentMissing(t *testing.T) {
+	svc, db := setupProductService(t)
+	missing := uint(999)
+
+	_, _, err := svc.CreateCategories(&dto.BatchCreateCategoryRequest{Categories: []dto.CreateCategoryRequest{
+		{Name: "Books"},
+		{Name: "Comics", ParentID: &missing},
+	}})
+	assert.ErrorIs(t, err, ErrParentCategoryNotFound)
+
+	var count int64
+	require.NoError(t, db.Model(&entity.Category{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+
+	authEntity "github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllCategories_CountsOnlyPubliclyVisibleProducts(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.AutoMigrate(&authEntity.User{}))
+
+	seller := &authEntity.User{Name: "Seller", Email: "seller@example.com", Password: "x", Role: authEntity.RoleSeller, IsActive: true}
+	banned := &authEntity.User{Name: "Banned", Email: "banned@example.com", Password: "x", Role: authEntity.RoleSeller, IsActive: true}
+	require.NoError(t, db.Create(seller).Error)
+	require.NoError(t, db.Create(banned).Error)
+	require.NoError(t, db.Model(banned).Update("is_active", false).Error)
+
+	phones := &entity.Category{Name: "Phones"}
+	empty := &entity.Category{Name: "Empty"}
+	require.NoError(t, db.Create(phones).Error)
+	require.NoError(t, db.Create(empty).Error)
+
+	products := []*entity.Product{
+		{Name: "Phone A", SKU: "PHONE-A", Price: money.FromFloat(100), CategoryID: phones.ID, SellerID: seller.ID, IsActive: true},
+		{Name: "Phone B", SKU: "PHONE-B", Price: money.FromFloat(100), CategoryID: phones.ID, SellerID: seller.ID, IsActive: true},
+		{Name: "Hidden", SKU: "HIDDEN", Price: money.FromFloat(100), CategoryID: phones.ID, SellerID: seller.ID, IsActive: true},
+		{Name: "Deleted", SKU: "DELETED", Price: money.FromFloat(100), CategoryID: phones.ID, SellerID: seller.ID, IsActive: true},
+		{Name: "Banned", SKU: "BANNED", Price: money.FromFloat(100), CategoryID: phones.ID, SellerID: banned.ID, IsActive: true},
+	}
+	for _, p := range products {
+		require.NoError(t, db.Create(p).Error)
+	}
+	require.NoError(t, db.Model(products[2]).Update("is_active", false).Error)
+	require.NoError(t, db.Delete(products[3]).Error)
+
+	categories, err := svc.GetAllCategories()
+	require.NoError(t, err)
+	require.Len(t, categories, 2)
+	counts := map[string]int64{}
+	for _, c := range categories {
+		require.NotNil(t, c.ProductCount)
+		counts[c.Name] = *c.ProductCount
+	}
+	assert.Equal(t, map[string]int64{"Phones": 2, "Empty": 0}, counts)
+
+	category, err := svc.GetCategory(phones.ID)
+	require.NoError(t, err)
+	require.NotNil(t, category.ProductCount)
+	assert.Equal(t, int64(2), *category.ProductCount)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteCategory_RequiresReassignOrUnassignWhenProductsExist(t *testing.T) {
+	svc, db := setupProductService(t)
+	phones := &entity.Category{Name: "Phones"}
+	gadgets := &entity.Category{Name: "Gadgets"}
+	require.NoError(t, db.Create(phones).Error)
+	require.NoError(t, db.Create(gadgets).Error)
+
+	active := &entity.Product{Name: "Phone", SKU: "PHONE", Price: money.FromFloat(100), CategoryID: phones.ID, SellerID: 7, IsActive: true}
+	deleted := &entity.Product{Name: "Old Phone", SKU: "OLD-PHONE", Price: money.FromFloat(50), CategoryID: phones.ID, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(active).Error)
+	require.NoError(t, db.Create(deleted).Error)
+	require.NoError(t, db.Delete(deleted).Error)
+
+	// Tanpa opsi: ditolak dengan jumlah produk aktif yang terdampak
+	count, err := svc.DeleteCategory(phones.ID, &dto.DeleteCategoryParams{})
+	assert.ErrorIs(t, err, ErrCategoryHasProducts)
+	assert.Equal(t, int64(1), count)
+
+	_, err = svc.DeleteCategory(phones.ID, &dto.DeleteCategoryParams{ReassignTo: gadgets.ID, Unassign: true})
+	assert.ErrorIs(t, err, ErrInvalidCategoryReassign)
+	_, err = svc.DeleteCategory(phones.ID, &dto.DeleteCategoryParams{ReassignTo: phones.ID})
+	assert.ErrorIs(t, err, ErrInvalidCategoryReassign)
+	_, err = svc.DeleteCategory(phones.ID, &dto.DeleteCategoryParams{ReassignTo: 999})
+	assert.ErrorIs(t, err, ErrReassignCategoryNotFound)
+
+	count, err = svc.DeleteCategory(phones.ID, &dto.DeleteCategoryParams{ReassignTo: gadgets.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// Produk yang sudah di-soft delete ikut dipindahkan
+	var moved []entity.Product
+	require.NoError(t, db.Unscoped().Order("id").Find(&moved).Error)
+	require.Len(t, moved, 2)
+	for _, p := range moved {
+		assert.Equal(t, gadgets.ID, p.CategoryID)
+	}
+	_, err = svc.GetCategory(phones.ID)
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+
+	// Unassign mengosongkan kategori produk
+	count, err = svc.DeleteCategory(gadgets.ID, &dto.DeleteCategoryParams{Unassign: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	var reloaded entity.Product
+	require.NoError(t, db.First(&reloaded, active.ID).Error)
+	assert.Zero(t, reloaded.CategoryID)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// StockChange menjelaskan penyebab perubahan stok untuk dicatat di inventory log
+type StockChange struct {
+	Reason  string // entity.InventoryReason*
+	RefType string // entity.InventoryRef*, kosong jika tidak ada referensi
+	RefID   uint
+	ActorID *uint  // nil jika diubah oleh sistem
+	Note    string // keterangan bebas, mis. alasan koreksi stok oleh admin
+}
+
+// logStockChange mencatat perubahan stok di dalam transaction yang sama dengan perubahan stoknya
+func (s *productService) logStockChange(tx *gorm.DB, productID uint, variantID *uint, delta int, change StockChange) error {
+	log := &entity.InventoryLog{
+		ProductID: productID,
+		VariantID: variantID,
+		Delta:     delta,
+		Reason:    change.Reason,
+		RefType:   change.RefType,
+		ActorID:   change.ActorID,
+		Note:      change.Note,
+	}
+	if change.RefID != 0 {
+		refID := change.RefID
+		log.RefID = &refID
+	}
+	return s.inventoryLog.WithTx(tx).Create(log)
+}
+
+// GetInventoryLog mengambil riwayat perubahan stok produk (pemilik produk atau admin)
+func (s *productService) GetInventoryLog(userID uint, productID uint, isAdmin bool, params *dto.InventoryLogQueryParams) (*dto.InventoryLogListResponse, error) {
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	if !isAdmin && !product.IsOwner(userID) {
+		return nil, ErrUnauthorized
+	}
+
+	logs, total, err := s.inventoryLog.FindByProductID(productID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.InventoryLogResponse, 0, len(logs))
+	for _, l := range logs {
+		responses = append(responses, dto.InventoryLogResponse{
+			ID:        l.ID,
+			ProductID: l.ProductID,
+			VariantID: l.VariantID,
+			Delta:     l.Delta,
+			Reason:    l.Reason,
+			RefType:   l.RefType,
+			RefID:     l.RefID,
+			ActorID:   l.ActorID,
+			Note:      l.Note,
+			CreatedAt: l.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &dto.InventoryLogListResponse{
+		Logs: responses,
+		Meta: pagination.BuildMeta(total, params.Page, params.Limit),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateStock_WritesInventoryLog(t *testing.T) {
+	svc, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 10, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	_, err := svc.UpdateStock(7, product.ID, &dto.UpdateStockRequest{Action: "add", Quantity: 5})
+	require.NoError(t, err)
+	_, err = svc.UpdateStock(7, product.ID, &dto.UpdateStockRequest{Action: "reduce", Quantity: 2})
+	require.NoError(t, err)
+
+	// Pengurangan yang ditolak tidak dicatat
+	_, err = svc.UpdateStock(7, product.ID, &dto.UpdateStockRequest{Action: "reduce", Quantity: 100})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	result, err := svc.GetInventoryLog(7, product.ID, false, &dto.InventoryLogQueryParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Logs, 2)
+	assert.Equal(t, int64(2), result.Total)
+
+	// Terbaru lebih dulu
+	assert.Equal(t, entity.InventoryReasonManualReduce, result.Logs[0].Reason)
+	assert.Equal(t, -2, result.Logs[0].Delta)
+	assert.Equal(t, entity.InventoryReasonManualAdd, result.Logs[1].Reason)
+	assert.Equal(t, 5, result.Logs[1].Delta)
+	require.NotNil(t, result.Logs[1].ActorID)
+	assert.Equal(t, uint(7), *result.Logs[1].ActorID)
+}
+
+func TestVariantChanges_WriteAdjustmentLog(t *testing.T) {
+	svc, db := setupProductService(t)
+	product := &entity.Product{Name: "T-Shirt", SKU: "T-SHIRT", Price: money.FromFloat(20), SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	variant, err := svc.AddVariant(7, product.ID, &dto.CreateVariantRequest{
+		Attributes: map[string]string{"size": "M"},
+		SKU:        "TS-M",
+		Price:      money.FromFloat(20),
+		Stock:      4,
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteVariant(7, product.ID, variant.ID))
+
+	var logs []entity.InventoryLog
+	require.NoError(t, db.Order("id").Find(&logs).Error)
+	require.Len(t, logs, 2)
+	for i, delta := range []int{4, -4} {
+		assert.Equal(t, entity.InventoryReasonAdjustment, logs[i].Reason)
+		assert.Equal(t, delta, logs[i].Delta)
+		require.NotNil(t, logs[i].VariantID)
+		assert.Equal(t, variant.ID, *logs[i].VariantID)
+	}
+}
+
+func TestGetInventoryLog_OwnerOrAdminOnly(t *testing.T) {
+	svc, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 10, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	params := &dto.InventoryLogQueryParams{Page: 1, Limit: 10}
+
+	_, err := svc.GetInventoryLog(8, product.ID, false, params)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = svc.GetInventoryLog(8, product.ID, true, params)
+	assert.NoError(t, err)
+
+	_, err = svc.GetInventoryLog(7, product.ID+100, false, params)
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// logPriceChange mencatat perubahan harga di dalam transaction yang sama dengan update produknya
+func (s *productService) logPriceChange(tx *gorm.DB, productID uint, oldPrice, newPrice money.Money, changedBy uint) error {
+	return s.priceHistory.WithTx(tx).Create(&entity.PriceHistory{
+		ProductID: productID,
+		OldPrice:  oldPrice,
+		NewPrice:  newPrice,
+		ChangedBy: changedBy,
+	})
+}
+
+// GetPriceHistory mengambil riwayat perubahan harga produk (pemilik produk atau admin)
+func (s *productService) GetPriceHistory(userID uint, productID uint, isAdmin bool, params *dto.PriceHistoryQueryParams) (*dto.PriceHistoryListResponse, error) {
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	if !isAdmin && !product.IsOwner(userID) {
+		return nil, ErrUnauthorized
+	}
+
+	histories, total, err := s.priceHistory.FindByProductID(productID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.PriceHistoryResponse, 0, len(histories))
+	for _, h := range histories {
+		responses = append(responses, dto.PriceHistoryResponse{
+			ID:        h.ID,
+			ProductID: h.ProductID,
+			OldPrice:  h.OldPrice,
+			NewPrice:  h.NewPrice,
+			Currency:  product.Currency,
+			ChangedBy: h.ChangedBy,
+			CreatedAt: h.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &dto.PriceHistoryListResponse{
+		History: responses,
+		Meta:    pagination.BuildMeta(total, params.Page, params.Limit),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateProduct_RecordsPriceHistoryOnlyWhenPriceChanges(t *testing.T) {
+	svc, db := setupProductService(t)
+
+	product, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100), Stock: 5})
+	require.NoError(t, err)
+
+	// Harga sama dan update tanpa harga tidak dicatat
+	_, err = svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Price: ptr(money.FromFloat(100)), Stock: ptr(5)})
+	require.NoError(t, err)
+	_, err = svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Name: ptr("Laptop Pro"), Stock: ptr(5)})
+	require.NoError(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&entity.PriceHistory{}).Count(&count).Error)
+	assert.Zero(t, count)
+
+	_, err = svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Price: ptr(money.FromFloat(120)), Stock: ptr(5)})
+	require.NoError(t, err)
+	_, err = svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Price: ptr(money.FromFloat(90)), Stock: ptr(5)})
+	require.NoError(t, err)
+
+	history, err := svc.GetPriceHistory(7, product.ID, false, &dto.PriceHistoryQueryParams{})
+	require.NoError(t, err)
+	require.Len(t, history.History, 2)
+	assert.Equal(t, int64(2), history.Total)
+
+	// Terbaru lebih dulu
+	assert.Equal(t, money.FromFloat(120), history.History[0].OldPrice)
+	assert.Equal(t, money.FromFloat(90), history.History[0].NewPrice)
+	assert.Equal(t, money.FromFloat(100), history.History[1].OldPrice)
+	assert.Equal(t, money.FromFloat(120), history.History[1].NewPrice)
+	assert.Equal(t, uint(7), history.History[1].ChangedBy)
+}
+
+func TestGetPriceHistory_OwnerOrAdminOnly(t *testing.T) {
+	svc, _ := setupProductService(t)
+
+	product, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100)})
+	require.NoError(t, err)
+
+	_, err = svc.GetPriceHistory(8, product.ID, false, &dto.PriceHistoryQueryParams{})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = svc.GetPriceHistory(8, product.ID, true, &dto.PriceHistoryQueryParams{})
+	assert.NoError(t, err)
+
+	_, err = svc.GetPriceHistory(7, 999, false, &dto.PriceHistoryQueryParams{})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
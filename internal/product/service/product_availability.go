@@ -0,0 +1,46 @@
+package service
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+)
+
+// CheckAvailability mengecek apakah setiap item bisa dibeli tanpa melakukan checkout.
+// Semua produk diambil dalam satu query. Produk yang muncul lebih dari sekali dicek terhadap
+// total quantity-nya, sama seperti item yang digabung saat checkout.
+func (s *productService) CheckAvailability(req *dto.CheckAvailabilityRequest) (*dto.CheckAvailabilityResponse, error) {
+	ids := make([]uint, 0, len(req.Items))
+	requested := make(map[uint]int, len(req.Items))
+	for _, item := range req.Items {
+		if _, ok := requested[item.ProductID]; !ok {
+			ids = append(ids, item.ProductID)
+		}
+		requested[item.ProductID] += item.Quantity
+	}
+
+	products, err := s.productRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*entity.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	items := make([]dto.AvailabilityItemResponse, 0, len(req.Items))
+	for _, item := range req.Items {
+		result := dto.AvailabilityItemResponse{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+		if product, ok := byID[item.ProductID]; ok {
+			result.Found = true
+			result.IsActive = product.IsActive
+			result.AvailableStock = product.AvailableStock()
+			result.Available = product.IsActive && product.HasStock(requested[item.ProductID])
+		}
+		items = append(items, result)
+	}
+
+	return &dto.CheckAvailabilityResponse{Items: items}, nil
+}
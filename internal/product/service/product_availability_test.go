@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAvailability(t *testing.T) {
+	svc, db := setupProductService(t)
+
+	laptop, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100), Stock: 5})
+	require.NoError(t, err)
+	mouse, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Mouse", SKU: "MOUSE-1", Price: money.FromFloat(10), Stock: 10})
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&entity.Product{}).Where("id = ?", mouse.ID).Update("is_active", false).Error)
+	// 2 unit laptop sedang ditahan order PENDING
+	require.NoError(t, db.Model(&entity.Product{}).Where("id = ?", laptop.ID).Update("reserved_stock", 2).Error)
+
+	result, err := svc.CheckAvailability(&dto.CheckAvailabilityRequest{Items: []dto.AvailabilityItemRequest{
+		{ProductID: laptop.ID, Quantity: 3},
+		{ProductID: mouse.ID, Quantity: 1},
+		{ProductID: 999, Quantity: 1},
+	}})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 3)
+
+	assert.Equal(t, dto.AvailabilityItemResponse{ProductID: laptop.ID, Quantity: 3, Found: true, IsActive: true, AvailableStock: 3, Available: true}, result.Items[0])
+	assert.Equal(t, dto.AvailabilityItemResponse{ProductID: mouse.ID, Quantity: 1, Found: true, IsActive: false, AvailableStock: 10, Available: false}, result.Items[1])
+	assert.Equal(t, dto.AvailabilityItemResponse{ProductID: 999, Quantity: 1}, result.Items[2])
+
+	// Produk yang sama dua kali dicek terhadap total quantity
+	result, err = svc.CheckAvailability(&dto.CheckAvailabilityRequest{Items: []dto.AvailabilityItemRequest{
+		{ProductID: laptop.ID, Quantity: 2},
+		{ProductID: laptop.ID, Quantity: 2},
+	}})
+	require.NoError(t, err)
+	assert.False(t, result.Items[0].Available)
+	assert.False(t, result.Items[1].Available)
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkDeleteProducts_SkipsProductsInOpenOrders(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.AutoMigrate(&orderEntity.Order{}, &orderEntity.OrderItem{}))
+
+	var ids []uint
+	for _, sku := range []string{"BULK-1", "BULK-2", "BULK-3", "BULK-4"} {
+		product, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Produk " + sku, SKU: sku, Price: money.FromFloat(100), Stock: 5})
+		require.NoError(t, err)
+		ids = append(ids, product.ID)
+	}
+	// Produk kedua masih di order aktif, produk ketiga hanya di order yang dibatalkan
+	seedProductOrder(t, db, ids[1], orderEntity.OrderStatusPaid)
+	seedProductOrder(t, db, ids[1], orderEntity.OrderStatusCompleted)
+	seedProductOrder(t, db, ids[2], orderEntity.OrderStatusCancelled)
+
+	result, err := svc.BulkDeleteProducts(7, &dto.BulkDeleteProductsRequest{ProductIDs: []uint{ids[0], ids[1], ids[2], ids[0]}})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{ids[0], ids[2]}, result.DeletedIDs)
+	assert.Equal(t, []dto.BulkDeleteSkippedProduct{{ProductID: ids[1], OrderCount: 2}}, result.Skipped)
+
+	var remaining []uint
+	require.NoError(t, db.Model(&entity.Product{}).Order("id").Pluck("id", &remaining).Error)
+	assert.Equal(t, []uint{ids[1], ids[3]}, remaining)
+
+	// Produk yang sudah dihapus tidak bisa dihapus lagi
+	_, err = svc.BulkDeleteProducts(7, &dto.BulkDeleteProductsRequest{ProductIDs: []uint{ids[0]}})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestBulkDeleteProducts_RejectsWholeBatch(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.AutoMigrate(&orderEntity.Order{}, &orderEntity.OrderItem{}))
+
+	mine, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Milik Saya", SKU: "MINE", Price: money.FromFloat(100), Stock: 5})
+	require.NoError(t, err)
+	other, err := svc.CreateProduct(8, &dto.CreateProductRequest{Name: "Milik Lain", SKU: "OTHER", Price: money.FromFloat(100), Stock: 5})
+	require.NoError(t, err)
+
+	_, err = svc.BulkDeleteProducts(7, &dto.BulkDeleteProductsRequest{ProductIDs: []uint{mine.ID, other.ID}})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = svc.BulkDeleteProducts(7, &dto.BulkDeleteProductsRequest{ProductIDs: []uint{mine.ID, other.ID + 100}})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+
+	// Tidak ada produk yang terhapus
+	var count int64
+	require.NoError(t, db.Model(&entity.Product{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
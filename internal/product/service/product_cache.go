@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+)
+
+// productCacheTTL sengaja pendek: invalidasi di dalam transaction checkout terjadi sebelum commit,
+// sehingga pembacaan di sela itu bisa meng-cache data lama paling lama selama TTL ini.
+const productCacheTTL = 30 * time.Second
+
+// productCacheKey mengembalikan key Redis untuk detail produk
+func productCacheKey(id uint) string {
+	return database.RedisKey(fmt.Sprintf("product:%d", id))
+}
+
+// getCachedProduct mengambil ProductResponse dari cache. Cache miss, error Redis,
+// atau Redis nil dianggap miss agar pemanggil kembali ke database.
+func (s *productService) getCachedProduct(id uint) (*dto.ProductResponse, bool) {
+	if s.redisClient == nil {
+		return nil, false
+	}
+
+	data, err := s.redisClient.Get(context.Background(), productCacheKey(id)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	var resp dto.ProductResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, false
+	}
+	return &resp, true
+}
+
+// cacheProduct menyimpan ProductResponse ke cache
+func (s *productService) cacheProduct(resp *dto.ProductResponse) {
+	if s.redisClient == nil {
+		return
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	if err := s.redisClient.Set(context.Background(), productCacheKey(resp.ID), data, productCacheTTL).Err(); err != nil {
+		logger.Warn().Err(err).Uint("product_id", resp.ID).Msg("Failed to cache product")
+	}
+}
+
+// invalidateProductCache menghapus cache detail produk setelah data produk berubah
+func (s *productService) invalidateProductCache(id uint) {
+	if s.redisClient == nil {
+		return
+	}
+
+	if err := s.redisClient.Del(context.Background(), productCacheKey(id)).Err(); err != nil {
+		logger.Warn().Err(err).Uint("product_id", id).Msg("Failed to invalidate product cache")
+	}
+}
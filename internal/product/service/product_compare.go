@@ -0,0 +1,65 @@
+package service
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+)
+
+// CompareProducts mengambil beberapa produk (beserta kategori) untuk dibandingkan berdampingan.
+// ID ganda hanya dihitung sekali dan urutan mengikuti kemunculan pertamanya; ID yang tidak
+// ditemukan dilewati dan dilaporkan di NotFoundIDs.
+func (s *productService) CompareProducts(ids []uint) (*dto.ProductComparisonResponse, error) {
+	unique := make([]uint, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	products, err := s.productRepo.FindByIDsWithCategory(unique)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*entity.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	result := &dto.ProductComparisonResponse{
+		Products:    make([]dto.ProductComparisonItem, 0, len(products)),
+		NotFoundIDs: []uint{},
+	}
+	for _, id := range unique {
+		product, ok := byID[id]
+		if !ok {
+			result.NotFoundIDs = append(result.NotFoundIDs, id)
+			continue
+		}
+
+		item := dto.ProductComparisonItem{
+			Product:     *s.toProductResponse(product),
+			StockStatus: s.stockStatus(product),
+		}
+		if product.ReviewCount > 0 {
+			rating := product.AverageRating
+			item.AverageRating = &rating
+		}
+		result.Products = append(result.Products, item)
+	}
+	return result, nil
+}
+
+// stockStatus mengelompokkan stok yang bisa dijual terhadap threshold stok menipis produk
+func (s *productService) stockStatus(product *entity.Product) string {
+	available := product.AvailableStock()
+	switch {
+	case available <= 0:
+		return dto.StockStatusOutOfStock
+	case available <= product.EffectiveLowStockThreshold(s.lowStockThreshold):
+		return dto.StockStatusLowStock
+	default:
+		return dto.StockStatusInStock
+	}
+}
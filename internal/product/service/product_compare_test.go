@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareProducts_DeduplicatesAndReportsMissingIDs(t *testing.T) {
+	svc, db := setupProductService(t)
+	category := &entity.Category{Name: "Laptops"}
+	require.NoError(t, db.Create(category).Error)
+
+	threshold := 3
+	inStock := &entity.Product{Name: "Laptop A", SKU: "LAPTOP-A", Price: money.FromFloat(1000), Stock: 20, CategoryID: category.ID, SellerID: 7, IsActive: true,
+		AverageRating: 4.5, ReviewCount: 2}
+	lowStock := &entity.Product{Name: "Laptop B", SKU: "LAPTOP-B", Price: money.FromFloat(900), Stock: 5, ReservedStock: 3, CategoryID: category.ID, SellerID: 7, IsActive: true,
+		LowStockThreshold: &threshold}
+	soldOut := &entity.Product{Name: "Laptop C", SKU: "LAPTOP-C", Price: money.FromFloat(800), Stock: 2, ReservedStock: 2, CategoryID: category.ID, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(inStock).Error)
+	require.NoError(t, db.Create(lowStock).Error)
+	require.NoError(t, db.Create(soldOut).Error)
+
+	result, err := svc.CompareProducts([]uint{lowStock.ID, 999, inStock.ID, lowStock.ID, soldOut.ID})
+	require.NoError(t, err)
+
+	require.Len(t, result.Products, 3)
+	assert.Equal(t, []uint{999}, result.NotFoundIDs)
+
+	// Urutan mengikuti ids, ID ganda hanya muncul sekali
+	assert.Equal(t, lowStock.ID, result.Products[0].Product.ID)
+	assert.Equal(t, inStock.ID, result.Products[1].Product.ID)
+	assert.Equal(t, soldOut.ID, result.Products[2].Product.ID)
+
+	assert.Equal(t, dto.StockStatusLowStock, result.Products[0].StockStatus)
+	assert.Equal(t, dto.StockStatusInStock, result.Products[1].StockStatus)
+	assert.Equal(t, dto.StockStatusOutOfStock, result.Products[2].StockStatus)
+
+	require.NotNil(t, result.Products[1].AverageRating)
+	assert.Equal(t, 4.5, *result.Products[1].AverageRating)
+	assert.Nil(t, result.Products[0].AverageRating)
+
+	require.NotNil(t, result.Products[0].Product.Category)
+	assert.Equal(t, "Laptops", result.Products[0].Product.Category.Name)
+	assert.Equal(t, money.FromFloat(900), result.Products[0].Product.Price)
+}
+
+func TestCompareProducts_AllMissing(t *testing.T) {
+	svc, _ := setupProductService(t)
+
+	result, err := svc.CompareProducts([]uint{5, 6})
+	require.NoError(t, err)
+	assert.Empty(t, result.Products)
+	assert.Equal(t, []uint{5, 6}, result.NotFoundIDs)
+}
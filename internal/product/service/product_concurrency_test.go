@@ -0,0 +1,112 @@
+package service
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// interleavingProductRepository menjalankan afterRead sekali setelah FindByID pertama,
+// untuk mensimulasikan request lain yang mengubah produk setelah produk dibaca
+type interleavingProductRepository struct {
+	repository.ProductRepository
+	afterRead func()
+	once      *sync.Once
+}
+
+func (r *interleavingProductRepository) FindByID(id uint) (*entity.Product, error) {
+	product, err := r.ProductRepository.FindByID(id)
+	r.once.Do(r.afterRead)
+	return product, err
+}
+
+func (r *interleavingProductRepository) WithTx(tx *gorm.DB) repository.ProductRepository {
+	return &interleavingProductRepository{ProductRepository: r.ProductRepository.WithTx(tx), afterRead: r.afterRead, once: r.once}
+}
+
+func newInterleavingService(db *gorm.DB, afterRead func()) ProductService {
+	return NewProductService(ProductServiceDeps{
+		ProductRepo: &interleavingProductRepository{ProductRepository: repository.NewProductRepository(db), afterRead: afterRead, once: &sync.Once{}},
+		DB:          db,
+	})
+}
+
+func TestUpdateProduct_ConcurrentUpdateReturnsConflict(t *testing.T) {
+	otherSvc, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Description: "Fast laptop", Price: money.FromFloat(100), Stock: 5, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	// Seller lain (mis. tab kedua) menyimpan perubahan setelah request ini membaca produk
+	svc := newInterleavingService(db, func() {
+		_, err := otherSvc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Description: ptr("Updated in another tab")})
+		require.NoError(t, err)
+	})
+
+	_, err := svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Price: ptr(money.FromFloat(90))})
+	assert.ErrorIs(t, err, ErrConcurrentModification)
+
+	// Perubahan yang menang tidak tertimpa nilai lama
+	var reloaded entity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, "Updated in another tab", reloaded.Description)
+	assert.Equal(t, money.FromFloat(100), reloaded.Price)
+	assert.Equal(t, uint(1), reloaded.Version)
+
+	var priceChanges int64
+	require.NoError(t, db.Model(&entity.PriceHistory{}).Count(&priceChanges).Error)
+	assert.Zero(t, priceChanges)
+
+	// Setelah membaca ulang, update yang sama berhasil dan version naik lagi
+	updated, err := otherSvc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Price: ptr(money.FromFloat(90))})
+	require.NoError(t, err)
+	assert.Equal(t, money.FromFloat(90), updated.Price)
+	assert.Equal(t, "Updated in another tab", updated.Description)
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, uint(2), reloaded.Version)
+}
+
+func TestUpdateStock_StockChangedByCheckoutReturnsConflict(t *testing.T) {
+	_, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 10, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	// Checkout mengurangi stok di antara pembacaan dan penyimpanan stok oleh seller
+	productRepo := repository.NewProductRepository(db)
+	svc := newInterleavingService(db, func() {
+		ok, err := productRepo.ReduceStockAtomic(product.ID, 3)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	_, err := svc.UpdateStock(7, product.ID, &dto.UpdateStockRequest{Action: "add", Quantity: 5})
+	assert.ErrorIs(t, err, ErrConcurrentModification)
+
+	var reloaded entity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 7, reloaded.Stock)
+
+	var logCount int64
+	require.NoError(t, db.Model(&entity.InventoryLog{}).Count(&logCount).Error)
+	assert.Zero(t, logCount)
+}
+
+func TestUpdateProduct_VariantStockIsResyncedWithoutConflict(t *testing.T) {
+	svc, db := setupProductService(t)
+	product := &entity.Product{Name: "T-Shirt", SKU: "TSHIRT", Price: money.FromFloat(20), Stock: 0, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+	require.NoError(t, db.Create(&entity.ProductVariant{ProductID: product.ID, SKU: "TSHIRT-M", Attributes: entity.VariantAttributes{"size": "M"}, Price: money.FromFloat(20), Stock: 4}).Error)
+	require.NoError(t, db.Create(&entity.ProductVariant{ProductID: product.ID, SKU: "TSHIRT-L", Attributes: entity.VariantAttributes{"size": "L"}, Price: money.FromFloat(20), Stock: 6}).Error)
+
+	// Stok dari request diabaikan; stok produk bervarian tetap total stok varian
+	updated, err := svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Name: ptr("Cotton T-Shirt"), Stock: ptr(99)})
+	require.NoError(t, err)
+	assert.Equal(t, "Cotton T-Shirt", updated.Name)
+	assert.Equal(t, 10, updated.Stock)
+}
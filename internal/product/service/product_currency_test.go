@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateProduct_DefaultsToBaseCurrency(t *testing.T) {
+	svc, _ := setupProductService(t)
+
+	local, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100)})
+	require.NoError(t, err)
+	assert.Equal(t, money.DefaultCurrency, local.Currency)
+
+	imported, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Imported Laptop", SKU: "LAPTOP-2", Price: money.FromFloat(100), Currency: "USD"})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", imported.Currency)
+
+	// Varian memakai currency produk induknya
+	variant, err := svc.AddVariant(7, imported.ID, &dto.CreateVariantRequest{SKU: "LAPTOP-2-16GB", Price: money.FromFloat(120), Attributes: map[string]string{"ram": "16GB"}})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", variant.Currency)
+
+	variants, err := svc.ListVariants(imported.ID)
+	require.NoError(t, err)
+	require.Len(t, variants, 1)
+	assert.Equal(t, "USD", variants[0].Currency)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"testing"
+
+	orderEntity "github.com/akbarwjyy/go-commerce-api/internal/order/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedProductOrder membuat order berstatus status yang memuat productID
+func seedProductOrder(t *testing.T, db *gorm.DB, productID uint, status string) {
+	require.NoError(t, db.Create(&orderEntity.Order{
+		UserID:       1,
+		Status:       status,
+		ShippingAddr: "Jl. Sudirman No. 1",
+		Items:        []orderEntity.OrderItem{{ProductID: productID, Quantity: 1, Price: money.FromFloat(100), Subtotal: money.FromFloat(100)}},
+	}).Error)
+}
+
+func TestAdminDeleteProduct_SoftDeleteByDefault(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.AutoMigrate(&orderEntity.Order{}, &orderEntity.OrderItem{}))
+
+	product, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100), Stock: 5})
+	require.NoError(t, err)
+
+	// Admin boleh menghapus produk seller lain; order aktif tidak menghalangi soft delete
+	seedProductOrder(t, db, product.ID, orderEntity.OrderStatusPaid)
+	_, err = svc.AdminDeleteProduct(product.ID, &dto.AdminDeleteProductParams{})
+	require.NoError(t, err)
+
+	var count int64
+	require.NoError(t, db.Unscoped().Model(&entity.Product{}).Where("id = ?", product.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	_, err = svc.AdminDeleteProduct(product.ID, &dto.AdminDeleteProductParams{})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestAdminDeleteProduct_HardDeleteBlockedByOpenOrders(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.AutoMigrate(&orderEntity.Order{}, &orderEntity.OrderItem{}))
+
+	product, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100), Stock: 5})
+	require.NoError(t, err)
+	seedProductOrder(t, db, product.ID, orderEntity.OrderStatusPending)
+	seedProductOrder(t, db, product.ID, orderEntity.OrderStatusCompleted)
+	seedProductOrder(t, db, product.ID, orderEntity.OrderStatusCancelled)
+
+	orderCount, err := svc.AdminDeleteProduct(product.ID, &dto.AdminDeleteProductParams{Hard: true})
+	assert.ErrorIs(t, err, ErrProductInOrders)
+	assert.Equal(t, int64(2), orderCount)
+
+	var count int64
+	require.NoError(t, db.Unscoped().Model(&entity.Product{}).Where("id = ?", product.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestAdminDeleteProduct_HardDeletePurgesProductData(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.AutoMigrate(&orderEntity.Order{}, &orderEntity.OrderItem{}))
+
+	product, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100), Stock: 5})
+	require.NoError(t, err)
+	_, err = svc.AddVariant(7, product.ID, &dto.CreateVariantRequest{Attributes: map[string]string{"ram": "16GB"}, SKU: "LAPTOP-1-16", Price: money.FromFloat(120), Stock: 2})
+	require.NoError(t, err)
+	// Order yang sudah dibatalkan tidak menghalangi hard delete
+	seedProductOrder(t, db, product.ID, orderEntity.OrderStatusCancelled)
+
+	// Produk yang sudah di-soft delete tetap bisa dihapus permanen
+	require.NoError(t, db.Delete(&entity.Product{}, product.ID).Error)
+
+	_, err = svc.AdminDeleteProduct(product.ID, &dto.AdminDeleteProductParams{Hard: true})
+	require.NoError(t, err)
+
+	for _, model := range []interface{}{&entity.Product{}, &entity.ProductVariant{}, &entity.InventoryLog{}} {
+		var count int64
+		column := "product_id"
+		if _, ok := model.(*entity.Product); ok {
+			column = "id"
+		}
+		require.NoError(t, db.Unscoped().Model(model).Where(column+" = ?", product.ID).Count(&count).Error)
+		assert.Zero(t, count)
+	}
+
+	// SKU bisa dipakai lagi setelah dihapus permanen
+	_, err = svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100)})
+	assert.NoError(t, err)
+
+	_, err = svc.AdminDeleteProduct(product.ID, &dto.AdminDeleteProductParams{Hard: true})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// imageUploadTimeout membatasi lama upload ke storage (terutama untuk S3)
+const imageUploadTimeout = 60 * time.Second
+
+var (
+	ErrInvalidImageType     = errors.New("image must be a JPEG, PNG, WebP or GIF file")
+	ErrImageStorageDisabled = errors.New("image storage is not configured")
+)
+
+// allowedImageTypes memetakan content type hasil sniffing ke ekstensi file
+var allowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// UploadProductImage menyimpan gambar produk ke storage dan mengganti image_url produk.
+// Content type ditentukan dari isi file, bukan dari header yang dikirim client.
+// Gambar lama dihapus dari storage jika disimpan oleh storage yang sama.
+func (s *productService) UploadProductImage(sellerID uint, productID uint, r io.Reader, size int64) (*dto.ProductResponse, error) {
+	if s.imageStorage == nil {
+		return nil, ErrImageStorageDisabled
+	}
+
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	// Check ownership
+	if !product.IsOwner(sellerID) {
+		return nil, ErrUnauthorized
+	}
+
+	// Peek 512 byte pertama untuk sniffing tanpa kehilangan isi stream
+	reader := bufio.NewReaderSize(r, 512)
+	head, err := reader.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	contentType := http.DetectContentType(head)
+	ext, ok := allowedImageTypes[contentType]
+	if !ok {
+		return nil, ErrInvalidImageType
+	}
+
+	key, err := newImageKey(productID, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), imageUploadTimeout)
+	defer cancel()
+
+	url, err := s.imageStorage.Put(ctx, key, reader, size, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store image: %w", err)
+	}
+
+	oldImageURL := product.ImageURL
+	if err := s.productRepo.UpdateImageURL(productID, url); err != nil {
+		// Jangan tinggalkan file yatim jika update database gagal
+		if delErr := s.imageStorage.Delete(ctx, key); delErr != nil {
+			logger.Warn().Err(delErr).Str("key", key).Msg("Failed to clean up uploaded product image")
+		}
+		return nil, err
+	}
+	s.invalidateProductCache(productID)
+
+	// Hapus gambar lama secara best-effort; kegagalan tidak membatalkan upload.
+	// Hanya key di folder produk ini yang dihapus, karena image_url bisa diisi seller dengan URL
+	// gambar milik produk lain lewat UpdateProduct.
+	if oldKey, ok := s.imageStorage.KeyFromURL(oldImageURL); ok && oldKey != key && strings.HasPrefix(oldKey, imageKeyPrefix(productID)) {
+		if err := s.imageStorage.Delete(ctx, oldKey); err != nil {
+			logger.Warn().Err(err).Uint("product_id", productID).Str("key", oldKey).Msg("Failed to delete old product image")
+		}
+	}
+
+	product, err = s.productRepo.FindByIDWithCategory(productID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toProductResponse(product), nil
+}
+
+// newImageKey membuat key unik agar URL lama tidak ter-cache oleh browser/CDN setelah diganti
+func newImageKey(productID uint, ext string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return imageKeyPrefix(productID) + hex.EncodeToString(buf) + ext, nil
+}
+
+// imageKeyPrefix adalah folder penyimpanan gambar milik satu produk
+func imageKeyPrefix(productID uint) string {
+	return fmt.Sprintf("products/%d/", productID)
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// pngHeader cukup untuk dikenali http.DetectContentType sebagai image/png
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func setupImageService(t *testing.T) (ProductService, *gorm.DB, string) {
+	db := setupProductDB(t)
+
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir, "/uploads")
+	require.NoError(t, err)
+
+	svc := NewProductService(ProductServiceDeps{
+		DB:           db,
+		ImageStorage: store,
+	})
+	return svc, db, dir
+}
+
+func TestUploadProductImage_ReplacesOldImage(t *testing.T) {
+	svc, db, dir := setupImageService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 1, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	first, err := svc.UploadProductImage(7, product.ID, bytes.NewReader(pngHeader), int64(len(pngHeader)))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first.ImageURL, fmt.Sprintf("/uploads/products/%d/", product.ID)))
+	assert.True(t, strings.HasSuffix(first.ImageURL, ".png"))
+	firstPath := filepath.Join(dir, strings.TrimPrefix(first.ImageURL, "/uploads/"))
+	assert.FileExists(t, firstPath)
+
+	second, err := svc.UploadProductImage(7, product.ID, bytes.NewReader(pngHeader), int64(len(pngHeader)))
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ImageURL, second.ImageURL)
+	assert.FileExists(t, filepath.Join(dir, strings.TrimPrefix(second.ImageURL, "/uploads/")))
+	assert.NoFileExists(t, firstPath)
+
+	var stored entity.Product
+	require.NoError(t, db.First(&stored, product.ID).Error)
+	assert.Equal(t, second.ImageURL, stored.ImageURL)
+}
+
+func TestUploadProductImage_KeepsExternalImageURL(t *testing.T) {
+	svc, db, _ := setupImageService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 1, SellerID: 7, IsActive: true,
+		ImageURL: "https://example.com/laptop.png"}
+	require.NoError(t, db.Create(product).Error)
+
+	result, err := svc.UploadProductImage(7, product.ID, bytes.NewReader(pngHeader), int64(len(pngHeader)))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(result.ImageURL, "/uploads/"))
+}
+
+func TestUploadProductImage_KeepsOtherProductsImage(t *testing.T) {
+	svc, db, dir := setupImageService(t)
+	victim := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 1, SellerID: 8, IsActive: true}
+	product := &entity.Product{Name: "Mouse", SKU: "MOUSE", Price: money.FromFloat(10), Stock: 1, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(victim).Error)
+	require.NoError(t, db.Create(product).Error)
+
+	victimImage, err := svc.UploadProductImage(8, victim.ID, bytes.NewReader(pngHeader), int64(len(pngHeader)))
+	require.NoError(t, err)
+	victimPath := filepath.Join(dir, strings.TrimPrefix(victimImage.ImageURL, "/uploads/"))
+
+	// Seller lain memakai URL gambar produk victim sebagai image_url produknya sendiri
+	require.NoError(t, db.Model(product).Update("image_url", victimImage.ImageURL).Error)
+	_, err = svc.UploadProductImage(7, product.ID, bytes.NewReader(pngHeader), int64(len(pngHeader)))
+	require.NoError(t, err)
+
+	assert.FileExists(t, victimPath)
+}
+
+func TestUploadProductImage_RejectsNonOwnerAndInvalidType(t *testing.T) {
+	svc, db, dir := setupImageService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 1, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	_, err := svc.UploadProductImage(8, product.ID, bytes.NewReader(pngHeader), int64(len(pngHeader)))
+	assert.Equal(t, ErrUnauthorized, err)
+
+	_, err = svc.UploadProductImage(7, product.ID, strings.NewReader("<html><body>hi</body></html>"), 28)
+	assert.Equal(t, ErrInvalidImageType, err)
+
+	_, err = svc.UploadProductImage(7, product.ID+100, bytes.NewReader(pngHeader), int64(len(pngHeader)))
+	assert.Equal(t, ErrProductNotFound, err)
+
+	// Tidak ada file yang tersimpan dari upload yang ditolak
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/validator"
+	"gorm.io/gorm"
+)
+
+// productImportMaxRows membatasi jumlah baris data per file import
+const productImportMaxRows = 1000
+
+// productImportColumns adalah kolom wajib pada header CSV import (urutan bebas)
+var productImportColumns = []string{"name", "sku", "description", "price", "stock", "category_id", "image_url"}
+
+// skuValidator memvalidasi SKU baris CSV dengan aturan yang sama seperti binding CreateProductRequest
+var skuValidator = validator.New().GetValidator()
+
+// Errors import CSV
+var (
+	ErrImportInvalidHeader = errors.New("CSV header must contain exactly these columns: name, sku, description, price, stock, category_id, image_url")
+	ErrImportEmpty         = errors.New("CSV file has no product rows")
+	ErrImportTooManyRows   = fmt.Errorf("CSV file must not contain more than %d product rows", productImportMaxRows)
+	ErrImportMalformed     = errors.New("CSV file is malformed")
+)
+
+// ImportProducts membuat produk secara massal dari CSV untuk seller.
+// Baris yang valid dibuat dalam satu transaction; baris tidak valid dilewati dan dilaporkan beserta nomor barisnya.
+func (s *productService) ImportProducts(sellerID uint, r io.Reader) (*dto.ProductImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrImportEmpty
+		}
+		return nil, ErrImportMalformed
+	}
+	columns, err := parseImportHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ProductImportResult{Errors: []dto.ProductImportRowError{}}
+	var products []*entity.Product
+	categoryExists := make(map[uint]bool)
+	seenSKUs := make(map[string]bool)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		result.TotalRows++
+		if result.TotalRows > productImportMaxRows {
+			return nil, ErrImportTooManyRows
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				result.Errors = append(result.Errors, dto.ProductImportRowError{Line: parseErr.StartLine, Error: parseErr.Err.Error()})
+				continue
+			}
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		product, rowErr := s.parseImportRow(record, columns, categoryExists, seenSKUs)
+		if rowErr != nil {
+			result.Errors = append(result.Errors, dto.ProductImportRowError{Line: line, Error: rowErr.Error()})
+			continue
+		}
+		product.SellerID = sellerID
+		products = append(products, product)
+	}
+
+	if result.TotalRows == 0 {
+		return nil, ErrImportEmpty
+	}
+
+	if len(products) > 0 {
+		tx := s.db.Begin()
+		defer func() {
+			if r := recover(); r != nil {
+				tx.Rollback()
+			}
+		}()
+
+		productRepoWithTx := s.productRepo.WithTx(tx)
+		for _, product := range products {
+			if err := productRepoWithTx.Create(product); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+		if err := tx.Commit().Error; err != nil {
+			return nil, err
+		}
+	}
+
+	result.Created = len(products)
+	result.ProductIDs = make([]uint, 0, len(products))
+	for _, product := range products {
+		result.ProductIDs = append(result.ProductIDs, product.ID)
+	}
+	return result, nil
+}
+
+// parseImportHeader memetakan nama kolom ke indeksnya dan menolak kolom yang hilang, ganda, atau tidak dikenal
+func parseImportHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Excel menambahkan BOM UTF-8 di awal file
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, dup := columns[name]; dup {
+			return nil, ErrImportInvalidHeader
+		}
+		columns[name] = i
+	}
+
+	if len(columns) != len(productImportColumns) {
+		return nil, ErrImportInvalidHeader
+	}
+	for _, name := range productImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, ErrImportInvalidHeader
+		}
+	}
+	return columns, nil
+}
+
+// parseImportRow memvalidasi satu baris CSV dengan aturan yang sama seperti CreateProductRequest.
+// seenSKUs berisi SKU dari baris sebelumnya agar SKU ganda dalam satu file ikut ditolak.
+func (s *productService) parseImportRow(record []string, columns map[string]int, categoryExists map[uint]bool, seenSKUs map[string]bool) (*entity.Product, error) {
+	field := func(name string) string {
+		return strings.TrimSpace(record[columns[name]])
+	}
+
+	name := field("name")
+	if n := utf8.RuneCountInString(name); n < 2 || n > 200 {
+		return nil, errors.New("name must be between 2 and 200 characters")
+	}
+
+	sku := field("sku")
+	if err := skuValidator.Var(sku, "required,max=100,sku"); err != nil {
+		return nil, errors.New("sku is required and may only contain letters, numbers and single dashes (max 100 characters)")
+	}
+	if seenSKUs[sku] {
+		return nil, fmt.Errorf("sku %s is duplicated in the file", sku)
+	}
+	if err := s.ensureSKUAvailable(sku); err != nil {
+		if errors.Is(err, ErrSKUExists) {
+			return nil, fmt.Errorf("sku %s already exists", sku)
+		}
+		return nil, err
+	}
+
+	price, err := money.Parse(field("price"))
+	if err != nil || price.Cents() <= 0 {
+		return nil, errors.New("price must be a positive amount")
+	}
+
+	stock := 0
+	if raw := field("stock"); raw != "" {
+		stock, err = strconv.Atoi(raw)
+		if err != nil || stock < 0 {
+			return nil, errors.New("stock must be a non-negative integer")
+		}
+	}
+
+	var categoryID uint
+	if raw := field("category_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return nil, errors.New("category_id must be a positive integer")
+		}
+		categoryID = uint(id)
+	}
+	if categoryID > 0 {
+		exists, checked := categoryExists[categoryID]
+		if !checked {
+			_, err := s.categoryRepo.FindByID(categoryID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			exists = err == nil
+			categoryExists[categoryID] = exists
+		}
+		if !exists {
+			return nil, fmt.Errorf("category %d not found", categoryID)
+		}
+	}
+
+	seenSKUs[sku] = true
+	return &entity.Product{
+		Name:        name,
+		SKU:         sku,
+		Description: field("description"),
+		Price:       price,
+		Currency:    s.baseCurrency, // CSV tidak punya kolom currency
+		Stock:       stock,
+		CategoryID:  categoryID,
+		ImageURL:    field("image_url"),
+		IsActive:    true,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportProducts_CreatesValidRowsAndReportsErrors(t *testing.T) {
+	svc, db := setupProductService(t)
+	category := &entity.Category{Name: "Electronics"}
+	require.NoError(t, db.Create(category).Error)
+
+	csvData := "name,sku,description,price,stock,category_id,image_url\n" +
+		fmt.Sprintf("Laptop,LAPTOP-1,Fast laptop,1299.99,5,%d,https://example.com/laptop.png\n", category.ID) +
+		"X,X-1,Too short,10,1,,\n" +
+		"Mouse,MOUSE-1,,abc,1,,\n" +
+		"Keyboard,KB-1,,49.50,-1,,\n" +
+		"Monitor,MON-1,,199,2,999,\n" +
+		"Cable,CABLE-1,,5,,,\n"
+
+	result, err := svc.ImportProducts(7, strings.NewReader(csvData))
+	require.NoError(t, err)
+	assert.Equal(t, 6, result.TotalRows)
+	assert.Equal(t, 2, result.Created)
+	assert.Len(t, result.ProductIDs, 2)
+
+	require.Len(t, result.Errors, 4)
+	assert.Equal(t, 3, result.Errors[0].Line)
+	assert.Contains(t, result.Errors[0].Error, "name")
+	assert.Equal(t, 4, result.Errors[1].Line)
+	assert.Contains(t, result.Errors[1].Error, "price")
+	assert.Equal(t, 5, result.Errors[2].Line)
+	assert.Contains(t, result.Errors[2].Error, "stock")
+	assert.Equal(t, 6, result.Errors[3].Line)
+	assert.Contains(t, result.Errors[3].Error, "category 999")
+
+	var products []entity.Product
+	require.NoError(t, db.Order("id").Find(&products).Error)
+	require.Len(t, products, 2)
+	assert.Equal(t, "Laptop", products[0].Name)
+	assert.Equal(t, money.FromFloat(1299.99), products[0].Price)
+	assert.Equal(t, uint(7), products[0].SellerID)
+	assert.Equal(t, category.ID, products[0].CategoryID)
+	assert.Equal(t, "Cable", products[1].Name)
+	assert.Equal(t, 0, products[1].Stock)
+}
+
+func TestImportProducts_HeaderValidation(t *testing.T) {
+	svc, _ := setupProductService(t)
+
+	cases := map[string]string{
+		"missing column":   "name,sku,price,stock,category_id,image_url\nLaptop,LAPTOP-1,10,1,,\n",
+		"missing sku":      "name,description,price,stock,category_id,image_url\nLaptop,,10,1,,\n",
+		"unknown column":   "name,sku,description,price,stock,category_id,image_url,color\nLaptop,LAPTOP-1,,10,1,,,red\n",
+		"duplicate column": "name,name,sku,price,stock,category_id,image_url\nLaptop,Laptop,LAPTOP-1,10,1,,\n",
+	}
+	for name, csvData := range cases {
+		_, err := svc.ImportProducts(1, strings.NewReader(csvData))
+		assert.ErrorIs(t, err, ErrImportInvalidHeader, name)
+	}
+
+	// Urutan kolom bebas, header case-insensitive, BOM dari Excel diabaikan
+	result, err := svc.ImportProducts(1, strings.NewReader("\ufeffPrice,Name,Stock,SKU,Description,Category_ID,Image_URL\n10,Laptop,1,LAPTOP-1,,,\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+}
+
+func TestImportProducts_EmptyAndFieldCount(t *testing.T) {
+	svc, _ := setupProductService(t)
+
+	_, err := svc.ImportProducts(1, strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrImportEmpty)
+
+	_, err = svc.ImportProducts(1, strings.NewReader("name,sku,description,price,stock,category_id,image_url\n"))
+	assert.ErrorIs(t, err, ErrImportEmpty)
+
+	result, err := svc.ImportProducts(1, strings.NewReader("name,sku,description,price,stock,category_id,image_url\nLaptop,LAPTOP-1,,10\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Created)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 2, result.Errors[0].Line)
+}
+
+func TestImportProducts_RejectsInvalidAndDuplicateSKUs(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.Create(&entity.Product{Name: "Existing", SKU: "EXISTING-1", Price: money.FromFloat(10), SellerID: 7}).Error)
+
+	csvData := "name,sku,description,price,stock,category_id,image_url\n" +
+		"Laptop,LAPTOP-1,,10,1,,\n" +
+		"Laptop Copy,LAPTOP-1,,10,1,,\n" +
+		"Mouse,EXISTING-1,,10,1,,\n" +
+		"Cable,CABLE 1,,10,1,,\n" +
+		"Monitor,,,10,1,,\n"
+
+	result, err := svc.ImportProducts(7, strings.NewReader(csvData))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+
+	require.Len(t, result.Errors, 4)
+	assert.Contains(t, result.Errors[0].Error, "duplicated")
+	assert.Contains(t, result.Errors[1].Error, "already exists")
+	assert.Contains(t, result.Errors[2].Error, "sku")
+	assert.Contains(t, result.Errors[3].Error, "sku")
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	authEntity "github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllProducts_HidesDeactivatedSellers(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.AutoMigrate(&authEntity.User{}))
+
+	active := &authEntity.User{Name: "Active", Email: "active@example.com", Password: "x", Role: authEntity.RoleSeller, IsActive: true}
+	inactive := &authEntity.User{Name: "Inactive", Email: "inactive@example.com", Password: "x", Role: authEntity.RoleSeller, IsActive: true}
+	require.NoError(t, db.Create(active).Error)
+	require.NoError(t, db.Create(inactive).Error)
+	// default:true membuat nilai false diabaikan saat Create, jadi nonaktifkan lewat update
+	require.NoError(t, db.Model(inactive).Update("is_active", false).Error)
+
+	for _, sellerID := range []uint{active.ID, inactive.ID} {
+		require.NoError(t, db.Create(&entity.Product{
+			Name: fmt.Sprintf("Product of %d", sellerID), SKU: fmt.Sprintf("SELLER-%d", sellerID), Price: money.FromFloat(10), Stock: 1, SellerID: sellerID, IsActive: true,
+		}).Error)
+	}
+
+	result, err := svc.GetAllProducts(&dto.ProductQueryParams{})
+	require.NoError(t, err)
+	require.Len(t, result.Products, 1)
+	assert.Equal(t, active.ID, result.Products[0].SellerID)
+}
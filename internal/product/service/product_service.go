@@ -1,66 +1,211 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"io"
 	"math"
+	"time"
 
 	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
 	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
 	"github.com/akbarwjyy/go-commerce-api/internal/product/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/notifier"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+	"github.com/akbarwjyy/go-commerce-api/pkg/storage"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
 // Common errors
 var (
-	ErrProductNotFound     = errors.New("product not found")
-	ErrCategoryNotFound    = errors.New("category not found")
-	ErrUnauthorized        = errors.New("you are not authorized to perform this action")
-	ErrInsufficientStock   = errors.New("insufficient stock")
-	ErrCategoryExists      = errors.New("category already exists")
-	ErrInvalidStockAction  = errors.New("invalid stock action")
+	ErrProductNotFound    = errors.New("product not found")
+	ErrCategoryNotFound   = errors.New("category not found")
+	ErrUnauthorized       = errors.New("you are not authorized to perform this action")
+	ErrInsufficientStock  = errors.New("insufficient stock")
+	ErrCategoryExists     = errors.New("category already exists")
+	ErrInvalidStockAction = errors.New("invalid stock action")
+
+	ErrParentCategoryNotFound = errors.New("parent category not found")
+	ErrCategoryHasChildren    = errors.New("category still has subcategories")
+	ErrCategoryHasProducts    = errors.New("category still has products")
+	ErrCategoryCycle          = errors.New("category cannot be moved under itself or its descendants")
+
+	ErrInvalidCategoryReassign  = errors.New("use either reassign_to (another category) or unassign, not both")
+	ErrReassignCategoryNotFound = errors.New("target category not found")
+
+	ErrSKUExists = errors.New("product SKU already exists")
+
+	ErrProductInOrders = errors.New("product is still referenced by orders that are not cancelled")
+
+	ErrVariantNotFound    = errors.New("product variant not found")
+	ErrVariantSKUExists   = errors.New("variant SKU already exists")
+	ErrVariantRequired    = errors.New("product has variants, a variant must be selected")
+	ErrProductHasVariants = errors.New("stock of a product with variants is managed per variant")
+
+	ErrStockBelowReserved = errors.New("stock cannot be lower than the quantity reserved by pending orders")
+	ErrStockConflict      = errors.New("stock was changed concurrently, please retry")
+
+	ErrConcurrentModification = errors.New("product was modified by another request, please refetch and retry")
 )
 
 // ProductService interface untuk business logic produk
 type ProductService interface {
 	// Product operations
 	CreateProduct(sellerID uint, req *dto.CreateProductRequest) (*dto.ProductResponse, error)
+	ImportProducts(sellerID uint, r io.Reader) (*dto.ProductImportResult, error)
 	GetProduct(id uint) (*dto.ProductResponse, error)
+	GetProductBySKU(sku string) (*dto.ProductResponse, error)
+	CheckAvailability(req *dto.CheckAvailabilityRequest) (*dto.CheckAvailabilityResponse, error)
+	CompareProducts(ids []uint) (*dto.ProductComparisonResponse, error)
 	GetAllProducts(params *dto.ProductQueryParams) (*dto.ProductListResponse, error)
+	GetSellerProducts(sellerID uint, params *dto.ProductQueryParams) (*dto.ProductListResponse, error)
 	GetMyProducts(sellerID uint) ([]dto.ProductResponse, error)
+	GetLowStockProducts(sellerID uint) ([]dto.ProductResponse, error)
 	UpdateProduct(sellerID uint, productID uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error)
 	DeleteProduct(sellerID uint, productID uint) error
+	AttachTags(sellerID uint, productID uint, req *dto.ProductTagsRequest) (*dto.ProductResponse, error)
+	DetachTags(sellerID uint, productID uint, req *dto.ProductTagsRequest) (*dto.ProductResponse, error)
+	BulkDeleteProducts(sellerID uint, req *dto.BulkDeleteProductsRequest) (*dto.BulkDeleteProductsResult, error)
+	AdminDeleteProduct(productID uint, params *dto.AdminDeleteProductParams) (int64, error)
 	UpdateStock(sellerID uint, productID uint, req *dto.UpdateStockRequest) (*dto.ProductResponse, error)
+	AdminSetStock(adminID uint, productID uint, req *dto.AdminSetStockRequest) (*dto.ProductResponse, error)
+	GetInventoryLog(userID uint, productID uint, isAdmin bool, params *dto.InventoryLogQueryParams) (*dto.InventoryLogListResponse, error)
+	GetPriceHistory(userID uint, productID uint, isAdmin bool, params *dto.PriceHistoryQueryParams) (*dto.PriceHistoryListResponse, error)
+	SubscribeBackInStock(userID uint, productID uint) error
+	UnsubscribeBackInStock(userID uint, productID uint) error
+	UploadProductImage(sellerID uint, productID uint, r io.Reader, size int64) (*dto.ProductResponse, error)
+
+	// Variant operations
+	AddVariant(sellerID uint, productID uint, req *dto.CreateVariantRequest) (*dto.VariantResponse, error)
+	UpdateVariant(sellerID uint, productID uint, variantID uint, req *dto.UpdateVariantRequest) (*dto.VariantResponse, error)
+	DeleteVariant(sellerID uint, productID uint, variantID uint) error
+	ListVariants(productID uint) ([]dto.VariantResponse, error)
 
 	// Category operations
 	CreateCategory(req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
+	CreateCategories(req *dto.BatchCreateCategoryRequest) ([]dto.CategoryResponse, []string, error)
 	GetAllCategories() ([]dto.CategoryResponse, error)
+	GetCategoryTree() ([]dto.CategoryResponse, error)
 	GetCategory(id uint) (*dto.CategoryResponse, error)
 	UpdateCategory(id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error)
-	DeleteCategory(id uint) error
+	DeleteCategory(id uint, params *dto.DeleteCategoryParams) (int64, error)
 
 	// For inter-module communication
 	GetProductByID(id uint) (*entity.Product, error)
-	ReduceStock(productID uint, quantity int) error
-	RestoreStock(productID uint, quantity int) error
+	GetInventoryValue(sellerID uint) (money.Money, error)
+	CountProducts() (int64, error)
+	ReduceStock(productID uint, quantity int, change StockChange) error
+	ReduceStockTx(tx *gorm.DB, productID uint, quantity int, change StockChange) (func(), error)
+	RestoreStock(productID uint, quantity int, change StockChange) error
+	RestoreStockTx(tx *gorm.DB, productID uint, quantity int, change StockChange) error
+	HasVariants(productID uint) (bool, error)
+	GetVariant(productID uint, variantID uint) (*entity.ProductVariant, error)
+	ReduceVariantStockTx(tx *gorm.DB, productID uint, variantID uint, quantity int, change StockChange) (func(), error)
+	RestoreVariantStockTx(tx *gorm.DB, productID uint, variantID uint, quantity int, change StockChange) error
+	ReserveStockTx(tx *gorm.DB, orderID uint, productID uint, variantID *uint, quantity int) error
+	CommitReservationsTx(tx *gorm.DB, orderID uint, change StockChange) (func(), error)
+	ReleaseReservationsTx(tx *gorm.DB, orderID uint) (bool, error)
+	ReleaseExpiredReservations() (int, error)
+	NotifyBackInStock(productIDs ...uint)
+	RunReservationWorker(ctx context.Context, interval time.Duration)
+	UpdateRatingSummary(productID uint, average float64, count int) error
 }
 
 // productService implementasi ProductService
 type productService struct {
-	productRepo  repository.ProductRepository
-	categoryRepo repository.CategoryRepository
-	db           *gorm.DB
+	productRepo   repository.ProductRepository
+	categoryRepo  repository.CategoryRepository
+	variantRepo   repository.ProductVariantRepository
+	inventoryLog  repository.InventoryLogRepository
+	priceHistory  repository.PriceHistoryRepository
+	reservations  repository.StockReservationRepository
+	tags          repository.TagRepository
+	subscriptions repository.StockSubscriptionRepository
+	db            *gorm.DB
+	redisClient   *redis.Client // cache detail produk; nil = cache nonaktif
+
+	stockNotifier     StockNotifier
+	userNotifier      *notifier.UserNotifier // email stok kembali tersedia ke pelanggan; nil = nonaktif
+	lowStockThreshold int                    // default jika produk tidak punya threshold sendiri
+	reservationTTL    time.Duration          // masa berlaku reservasi stok checkout; 0 = tidak kedaluwarsa
+
+	imageStorage storage.Storage // nil = upload gambar nonaktif
+	baseCurrency string          // currency produk yang dibuat tanpa currency
+}
+
+// ProductServiceDeps berisi dependency ProductService. Repository yang nil dibuat dari DB,
+// dan dependency opsional lain (Redis, notifier, storage) boleh dikosongkan untuk menonaktifkan fiturnya.
+type ProductServiceDeps struct {
+	ProductRepo      repository.ProductRepository
+	CategoryRepo     repository.CategoryRepository
+	VariantRepo      repository.ProductVariantRepository
+	InventoryLogRepo repository.InventoryLogRepository
+	PriceHistoryRepo repository.PriceHistoryRepository
+	ReservationRepo  repository.StockReservationRepository
+	TagRepo          repository.TagRepository
+	SubscriptionRepo repository.StockSubscriptionRepository
+	DB               *gorm.DB
+
+	RedisClient       *redis.Client          // cache detail produk; nil = cache nonaktif
+	StockNotifier     StockNotifier          // nil = stok menipis hanya dicatat di log
+	UserNotifier      *notifier.UserNotifier // email stok kembali tersedia; nil = nonaktif
+	LowStockThreshold int                    // default jika produk tidak punya threshold sendiri
+	ReservationTTL    time.Duration          // masa berlaku reservasi stok checkout; 0 = tidak kedaluwarsa
+	ImageStorage      storage.Storage        // nil = upload gambar nonaktif
+	BaseCurrency      string                 // kosong = money.DefaultCurrency
 }
 
 // NewProductService membuat instance baru ProductService
-func NewProductService(
-	productRepo repository.ProductRepository,
-	categoryRepo repository.CategoryRepository,
-	db *gorm.DB,
-) ProductService {
+func NewProductService(deps ProductServiceDeps) ProductService {
+	db := deps.DB
+	if deps.ProductRepo == nil {
+		deps.ProductRepo = repository.NewProductRepository(db)
+	}
+	if deps.CategoryRepo == nil {
+		deps.CategoryRepo = repository.NewCategoryRepository(db)
+	}
+	if deps.VariantRepo == nil {
+		deps.VariantRepo = repository.NewProductVariantRepository(db)
+	}
+	if deps.InventoryLogRepo == nil {
+		deps.InventoryLogRepo = repository.NewInventoryLogRepository(db)
+	}
+	if deps.PriceHistoryRepo == nil {
+		deps.PriceHistoryRepo = repository.NewPriceHistoryRepository(db)
+	}
+	if deps.ReservationRepo == nil {
+		deps.ReservationRepo = repository.NewStockReservationRepository(db)
+	}
+	if deps.TagRepo == nil {
+		deps.TagRepo = repository.NewTagRepository(db)
+	}
+	if deps.SubscriptionRepo == nil {
+		deps.SubscriptionRepo = repository.NewStockSubscriptionRepository(db)
+	}
+	if deps.BaseCurrency == "" {
+		deps.BaseCurrency = money.DefaultCurrency
+	}
+
 	return &productService{
-		productRepo:  productRepo,
-		categoryRepo: categoryRepo,
-		db:           db,
+		productRepo:       deps.ProductRepo,
+		categoryRepo:      deps.CategoryRepo,
+		variantRepo:       deps.VariantRepo,
+		inventoryLog:      deps.InventoryLogRepo,
+		priceHistory:      deps.PriceHistoryRepo,
+		reservations:      deps.ReservationRepo,
+		tags:              deps.TagRepo,
+		subscriptions:     deps.SubscriptionRepo,
+		db:                db,
+		redisClient:       deps.RedisClient,
+		stockNotifier:     deps.StockNotifier,
+		userNotifier:      deps.UserNotifier,
+		lowStockThreshold: deps.LowStockThreshold,
+		reservationTTL:    deps.ReservationTTL,
+		imageStorage:      deps.ImageStorage,
+		baseCurrency:      deps.BaseCurrency,
 	}
 }
 
@@ -81,15 +226,28 @@ func (s *productService) CreateProduct(sellerID uint, req *dto.CreateProductRequ
 		}
 	}
 
+	if err := s.ensureSKUAvailable(req.SKU); err != nil {
+		return nil, err
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = s.baseCurrency
+	}
+
 	product := &entity.Product{
 		Name:        req.Name,
+		SKU:         req.SKU,
 		Description: req.Description,
 		Price:       req.Price,
+		Currency:    currency,
 		Stock:       req.Stock,
 		CategoryID:  req.CategoryID,
 		SellerID:    sellerID,
 		ImageURL:    req.ImageURL,
 		IsActive:    true,
+
+		LowStockThreshold: req.LowStockThreshold,
 	}
 
 	if err := s.productRepo.Create(product); err != nil {
@@ -102,8 +260,12 @@ func (s *productService) CreateProduct(sellerID uint, req *dto.CreateProductRequ
 	return s.toProductResponse(product), nil
 }
 
-// GetProduct mengambil produk berdasarkan ID
+// GetProduct mengambil produk berdasarkan ID (read-through cache Redis)
 func (s *productService) GetProduct(id uint) (*dto.ProductResponse, error) {
+	if cached, ok := s.getCachedProduct(id); ok {
+		return cached, nil
+	}
+
 	product, err := s.productRepo.FindByIDWithCategory(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -111,21 +273,43 @@ func (s *productService) GetProduct(id uint) (*dto.ProductResponse, error) {
 		}
 		return nil, err
 	}
-	return s.toProductResponse(product), nil
+
+	resp := s.toProductResponse(product)
+	s.cacheProduct(resp)
+	return resp, nil
 }
 
-// GetAllProducts mengambil semua produk dengan filter dan pagination
-func (s *productService) GetAllProducts(params *dto.ProductQueryParams) (*dto.ProductListResponse, error) {
-	// Set default pagination
-	if params.Page <= 0 {
-		params.Page = 1
+// GetProductBySKU mengambil produk berdasarkan SKU
+func (s *productService) GetProductBySKU(sku string) (*dto.ProductResponse, error) {
+	product, err := s.productRepo.FindBySKU(sku)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
 	}
-	if params.Limit <= 0 {
-		params.Limit = 10
+	return s.toProductResponse(product), nil
+}
+
+// ensureSKUAvailable mengembalikan ErrSKUExists jika SKU sudah dipakai produk lain
+func (s *productService) ensureSKUAvailable(sku string) error {
+	exists, err := s.productRepo.SKUExists(sku)
+	if err != nil {
+		return err
 	}
-	if params.Limit > 100 {
-		params.Limit = 100
+	if exists {
+		return ErrSKUExists
 	}
+	return nil
+}
+
+// GetAllProducts mengambil semua produk dengan filter dan pagination untuk listing publik.
+// Produk nonaktif dan yang sudah dihapus tidak pernah ditampilkan.
+func (s *productService) GetAllProducts(params *dto.ProductQueryParams) (*dto.ProductListResponse, error) {
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+	active := true
+	params.IsActive = &active
+	params.TagNames = parseTagFilter(params.Tags)
 
 	products, total, err := s.productRepo.FindAll(params)
 	if err != nil {
@@ -137,18 +321,20 @@ func (s *productService) GetAllProducts(params *dto.ProductQueryParams) (*dto.Pr
 		productResponses = append(productResponses, *s.toProductResponse(&p))
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(params.Limit)))
-
 	return &dto.ProductListResponse{
-		Products:   productResponses,
-		Total:      total,
-		Page:       params.Page,
-		Limit:      params.Limit,
-		TotalPages: totalPages,
+		Products: productResponses,
+		Meta:     pagination.BuildMeta(total, params.Page, params.Limit),
 	}, nil
 }
 
-// GetMyProducts mengambil produk milik seller
+// GetSellerProducts mengambil produk aktif milik seller untuk halaman toko publik,
+// dengan filter dan pagination yang sama seperti GetAllProducts
+func (s *productService) GetSellerProducts(sellerID uint, params *dto.ProductQueryParams) (*dto.ProductListResponse, error) {
+	params.SellerID = sellerID
+	return s.GetAllProducts(params)
+}
+
+// GetMyProducts mengambil produk milik seller (termasuk yang nonaktif)
 func (s *productService) GetMyProducts(sellerID uint) ([]dto.ProductResponse, error) {
 	products, err := s.productRepo.FindBySellerID(sellerID)
 	if err != nil {
@@ -162,6 +348,20 @@ func (s *productService) GetMyProducts(sellerID uint) ([]dto.ProductResponse, er
 	return responses, nil
 }
 
+// GetLowStockProducts mengambil produk milik seller yang stoknya menipis
+func (s *productService) GetLowStockProducts(sellerID uint) ([]dto.ProductResponse, error) {
+	products, err := s.productRepo.FindLowStockBySellerID(sellerID, s.lowStockThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []dto.ProductResponse{}
+	for _, p := range products {
+		responses = append(responses, *s.toProductResponse(&p))
+	}
+	return responses, nil
+}
+
 // UpdateProduct mengupdate produk
 func (s *productService) UpdateProduct(sellerID uint, productID uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error) {
 	product, err := s.productRepo.FindByID(productID)
@@ -177,40 +377,91 @@ func (s *productService) UpdateProduct(sellerID uint, productID uint, req *dto.U
 		return nil, ErrUnauthorized
 	}
 
-	// Update fields
-	if req.Name != "" {
-		product.Name = req.Name
+	previousStock := product.Stock
+	previousAvailable := product.AvailableStock()
+	previousPrice := product.Price
+
+	// Update fields (nil = tidak diubah)
+	if req.Name != nil {
+		product.Name = *req.Name
 	}
-	if req.Description != "" {
-		product.Description = req.Description
+	if req.SKU != nil && *req.SKU != product.SKU {
+		if err := s.ensureSKUAvailable(*req.SKU); err != nil {
+			return nil, err
+		}
+		product.SKU = *req.SKU
 	}
-	if req.Price > 0 {
-		product.Price = req.Price
+	if req.Description != nil {
+		product.Description = *req.Description
 	}
-	if req.Stock >= 0 {
-		product.Stock = req.Stock
+	if req.Price != nil {
+		product.Price = *req.Price
 	}
-	if req.CategoryID > 0 {
+	// Stok produk bervarian selalu total stok varian (disinkronkan di transaction), jangan ditimpa dari request
+	hasVariants, err := s.HasVariants(product.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Stock != nil && !hasVariants {
+		// Reservasi setelah produk dibaca menaikkan version, sehingga Update di bawah gagal sebagai konflik
+		if *req.Stock < product.ReservedStock {
+			return nil, ErrStockBelowReserved
+		}
+		product.Stock = *req.Stock
+	}
+	if req.CategoryID != nil {
 		// Validate category
-		_, err := s.categoryRepo.FindByID(req.CategoryID)
+		_, err := s.categoryRepo.FindByID(*req.CategoryID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return nil, ErrCategoryNotFound
 			}
 			return nil, err
 		}
-		product.CategoryID = req.CategoryID
+		product.CategoryID = *req.CategoryID
 	}
-	if req.ImageURL != "" {
-		product.ImageURL = req.ImageURL
+	if req.ImageURL != nil {
+		product.ImageURL = *req.ImageURL
 	}
 	if req.IsActive != nil {
 		product.IsActive = *req.IsActive
 	}
+	if req.LowStockThreshold != nil {
+		product.LowStockThreshold = req.LowStockThreshold
+	}
 
-	if err := s.productRepo.Update(product); err != nil {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		productRepo := s.productRepo.WithTx(tx)
+		if err := productRepo.Update(product); err != nil {
+			return err
+		}
+		if hasVariants {
+			if err := productRepo.SyncStockFromVariants(product.ID); err != nil {
+				return err
+			}
+		}
+		if product.Price != previousPrice {
+			if err := s.logPriceChange(tx, product.ID, previousPrice, product.Price, sellerID); err != nil {
+				return err
+			}
+		}
+		// Stok produk bervarian sudah dicatat per perubahan varian
+		if hasVariants || product.Stock == previousStock {
+			return nil
+		}
+		return s.logStockChange(tx, product.ID, nil, product.Stock-previousStock, StockChange{
+			Reason:  entity.InventoryReasonAdjustment,
+			ActorID: &sellerID,
+		})
+	})
+	if errors.Is(err, repository.ErrStaleProduct) {
+		return nil, ErrConcurrentModification
+	}
+	if err != nil {
 		return nil, err
 	}
+	s.invalidateProductCache(product.ID)
+	s.checkBackInStock(product, previousAvailable)
 
 	// Reload with category
 	product, _ = s.productRepo.FindByIDWithCategory(product.ID)
@@ -233,7 +484,115 @@ func (s *productService) DeleteProduct(sellerID uint, productID uint) error {
 		return ErrUnauthorized
 	}
 
-	return s.productRepo.Delete(productID)
+	if err := s.productRepo.Delete(productID); err != nil {
+		return err
+	}
+	s.invalidateProductCache(productID)
+	return nil
+}
+
+// BulkDeleteProducts menghapus (soft delete) banyak produk milik seller dalam satu transaction.
+// Seluruh batch ditolak jika ada ID yang tidak ditemukan atau bukan milik seller; produk yang
+// masih ada di order yang belum dibatalkan dilewati dan dilaporkan di Skipped.
+func (s *productService) BulkDeleteProducts(sellerID uint, req *dto.BulkDeleteProductsRequest) (*dto.BulkDeleteProductsResult, error) {
+	ids := make([]uint, 0, len(req.ProductIDs))
+	seen := make(map[uint]bool, len(req.ProductIDs))
+	for _, id := range req.ProductIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	products, err := s.productRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) != len(ids) {
+		return nil, ErrProductNotFound
+	}
+	for _, product := range products {
+		if !product.IsOwner(sellerID) {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	result := &dto.BulkDeleteProductsResult{
+		DeletedIDs: []uint{},
+		Skipped:    []dto.BulkDeleteSkippedProduct{},
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		productRepo := s.productRepo.WithTx(tx)
+		orderCounts, err := productRepo.CountOpenOrderReferencesByIDs(ids)
+		if err != nil {
+			return err
+		}
+
+		// Urutan hasil mengikuti urutan ID di request
+		for _, id := range ids {
+			if count := orderCounts[id]; count > 0 {
+				result.Skipped = append(result.Skipped, dto.BulkDeleteSkippedProduct{ProductID: id, OrderCount: count})
+				continue
+			}
+			result.DeletedIDs = append(result.DeletedIDs, id)
+		}
+		return productRepo.DeleteByIDs(result.DeletedIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range result.DeletedIDs {
+		s.invalidateProductCache(id)
+	}
+	return result, nil
+}
+
+// AdminDeleteProduct menghapus produk milik siapa pun (untuk admin). Default soft delete;
+// dengan params.Hard produk dihapus permanen, termasuk yang sudah di-soft delete, kecuali
+// masih ada order yang belum dibatalkan yang memuatnya (jumlah order itu dikembalikan).
+func (s *productService) AdminDeleteProduct(productID uint, params *dto.AdminDeleteProductParams) (int64, error) {
+	if !params.Hard {
+		if _, err := s.productRepo.FindByID(productID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, ErrProductNotFound
+			}
+			return 0, err
+		}
+		if err := s.productRepo.Delete(productID); err != nil {
+			return 0, err
+		}
+		s.invalidateProductCache(productID)
+		return 0, nil
+	}
+
+	if _, err := s.productRepo.FindByIDUnscoped(productID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrProductNotFound
+		}
+		return 0, err
+	}
+
+	var orderCount int64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		productRepo := s.productRepo.WithTx(tx)
+		count, err := productRepo.CountOpenOrderReferences(productID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			orderCount = count
+			return ErrProductInOrders
+		}
+		return productRepo.HardDelete(productID)
+	})
+	if err != nil {
+		return orderCount, err
+	}
+
+	s.invalidateProductCache(productID)
+	logger.Info().Uint("product_id", productID).Msg("Product permanently deleted by admin")
+	return 0, nil
 }
 
 // UpdateStock mengupdate stok produk
@@ -251,24 +610,285 @@ func (s *productService) UpdateStock(sellerID uint, productID uint, req *dto.Upd
 		return nil, ErrUnauthorized
 	}
 
+	hasVariants, err := s.HasVariants(productID)
+	if err != nil {
+		return nil, err
+	}
+	if hasVariants {
+		return nil, ErrProductHasVariants
+	}
+
+	previousStock := product.Stock
+	previousAvailable := product.AvailableStock()
+	change := StockChange{ActorID: &sellerID}
 	switch req.Action {
 	case "add":
 		product.AddStock(req.Quantity)
+		change.Reason = entity.InventoryReasonManualAdd
 	case "reduce":
 		if !product.ReduceStock(req.Quantity) {
 			return nil, ErrInsufficientStock
 		}
+		change.Reason = entity.InventoryReasonManualReduce
 	default:
 		return nil, ErrInvalidStockAction
 	}
 
-	if err := s.productRepo.Update(product); err != nil {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.productRepo.WithTx(tx).Update(product); err != nil {
+			return err
+		}
+		return s.logStockChange(tx, product.ID, nil, product.Stock-previousStock, change)
+	})
+	if errors.Is(err, repository.ErrStaleProduct) {
+		return nil, ErrConcurrentModification
+	}
+	if err != nil {
 		return nil, err
 	}
+	s.invalidateProductCache(product.ID)
+	s.checkLowStock(product, previousStock)
+	s.checkBackInStock(product, previousAvailable)
 
 	return s.toProductResponse(product), nil
 }
 
+// maxStockSetAttempts membatasi percobaan ulang AdminSetStock saat stok berubah bersamaan
+const maxStockSetAttempts = 3
+
+// AdminSetStock mengoreksi stok produk milik siapa pun ke nilai absolut (untuk admin).
+// Stok diganti dengan compare-and-set agar perubahan stok yang terjadi bersamaan (mis. checkout)
+// tidak tertimpa diam-diam; selisihnya dicatat di inventory log bersama alasan dari admin.
+func (s *productService) AdminSetStock(adminID uint, productID uint, req *dto.AdminSetStockRequest) (*dto.ProductResponse, error) {
+	hasVariants, err := s.HasVariants(productID)
+	if err != nil {
+		return nil, err
+	}
+	if hasVariants {
+		return nil, ErrProductHasVariants
+	}
+
+	newStock := *req.Stock
+	for attempt := 0; attempt < maxStockSetAttempts; attempt++ {
+		product, err := s.productRepo.FindByID(productID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrProductNotFound
+			}
+			return nil, err
+		}
+		if newStock < product.ReservedStock {
+			return nil, ErrStockBelowReserved
+		}
+
+		previousStock := product.Stock
+		previousAvailable := product.AvailableStock()
+		applied := false
+		err = s.db.Transaction(func(tx *gorm.DB) error {
+			ok, err := s.productRepo.WithTx(tx).SetStockAtomic(productID, previousStock, newStock)
+			if err != nil || !ok {
+				return err
+			}
+			applied = true
+			if newStock == previousStock {
+				return nil
+			}
+			return s.logStockChange(tx, productID, nil, newStock-previousStock, StockChange{
+				Reason:  entity.InventoryReasonAdminSet,
+				ActorID: &adminID,
+				Note:    req.Reason,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !applied {
+			// Stok atau reservasi berubah sejak dibaca, baca ulang lalu coba lagi
+			continue
+		}
+
+		product.Stock = newStock
+		s.invalidateProductCache(productID)
+		s.checkLowStock(product, previousStock)
+		s.checkBackInStock(product, previousAvailable)
+		logger.Info().
+			Uint("product_id", productID).
+			Uint("admin_id", adminID).
+			Int("previous_stock", previousStock).
+			Int("stock", newStock).
+			Msg("Product stock set by admin")
+		return s.toProductResponse(product), nil
+	}
+	return nil, ErrStockConflict
+}
+
+// ========================================
+// Variant Operations
+// ========================================
+
+// AddVariant menambah varian ke produk milik seller lalu menghitung ulang stok produk
+func (s *productService) AddVariant(sellerID uint, productID uint, req *dto.CreateVariantRequest) (*dto.VariantResponse, error) {
+	product, err := s.findOwnedProduct(sellerID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing, _ := s.variantRepo.FindBySKU(req.SKU); existing != nil {
+		return nil, ErrVariantSKUExists
+	}
+
+	variant := &entity.ProductVariant{
+		ProductID:  productID,
+		Attributes: req.Attributes,
+		SKU:        req.SKU,
+		Price:      req.Price,
+		Stock:      req.Stock,
+	}
+
+	err = s.withVariantTx(sellerID, variant, func(variantRepo repository.ProductVariantRepository) error {
+		return variantRepo.Create(variant)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toVariantResponse(variant, product.Currency), nil
+}
+
+// UpdateVariant mengupdate varian produk milik seller lalu menghitung ulang stok produk
+func (s *productService) UpdateVariant(sellerID uint, productID uint, variantID uint, req *dto.UpdateVariantRequest) (*dto.VariantResponse, error) {
+	product, err := s.findOwnedProduct(sellerID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := s.GetVariant(productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SKU != "" && req.SKU != variant.SKU {
+		if existing, _ := s.variantRepo.FindBySKU(req.SKU); existing != nil {
+			return nil, ErrVariantSKUExists
+		}
+		variant.SKU = req.SKU
+	}
+	if len(req.Attributes) > 0 {
+		variant.Attributes = req.Attributes
+	}
+	if req.Price > 0 {
+		variant.Price = req.Price
+	}
+	if req.Stock != nil {
+		variant.Stock = *req.Stock
+	}
+
+	err = s.withVariantTx(sellerID, variant, func(variantRepo repository.ProductVariantRepository) error {
+		return variantRepo.Update(variant)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toVariantResponse(variant, product.Currency), nil
+}
+
+// DeleteVariant menghapus varian produk milik seller lalu menghitung ulang stok produk
+func (s *productService) DeleteVariant(sellerID uint, productID uint, variantID uint) error {
+	if _, err := s.findOwnedProduct(sellerID, productID); err != nil {
+		return err
+	}
+
+	variant, err := s.GetVariant(productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	return s.withVariantTx(sellerID, variant, func(variantRepo repository.ProductVariantRepository) error {
+		return variantRepo.Delete(variantID)
+	})
+}
+
+// ListVariants mengambil semua varian produk
+func (s *productService) ListVariants(productID uint) ([]dto.VariantResponse, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	variants, err := s.variantRepo.FindByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []dto.VariantResponse{}
+	for _, v := range variants {
+		responses = append(responses, *toVariantResponse(&v, product.Currency))
+	}
+	return responses, nil
+}
+
+// withVariantTx menjalankan perubahan varian, menghitung ulang stok produk, dan mencatat
+// selisih stoknya di inventory log dalam satu transaction
+func (s *productService) withVariantTx(actorID uint, variant *entity.ProductVariant, fn func(variantRepo repository.ProductVariantRepository) error) error {
+	productID := variant.ProductID
+
+	tx := s.db.Begin()
+	productRepo := s.productRepo.WithTx(tx)
+	before, err := productRepo.FindByID(productID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := fn(s.variantRepo.WithTx(tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := productRepo.SyncStockFromVariants(productID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	after, err := productRepo.FindByID(productID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if delta := after.Stock - before.Stock; delta != 0 {
+		if err := s.logStockChange(tx, productID, &variant.ID, delta, StockChange{
+			Reason:  entity.InventoryReasonAdjustment,
+			ActorID: &actorID,
+		}); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	s.invalidateProductCache(productID)
+	s.checkBackInStock(after, before.AvailableStock())
+	return nil
+}
+
+// findOwnedProduct mengambil produk dan memastikan seller adalah pemiliknya
+func (s *productService) findOwnedProduct(sellerID uint, productID uint) (*entity.Product, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	if !product.IsOwner(sellerID) {
+		return nil, ErrUnauthorized
+	}
+	return product, nil
+}
+
 // ========================================
 // Category Operations
 // ========================================
@@ -286,6 +906,16 @@ func (s *productService) CreateCategory(req *dto.CreateCategoryRequest) (*dto.Ca
 		Description: req.Description,
 	}
 
+	if req.ParentID != nil && *req.ParentID > 0 {
+		if _, err := s.categoryRepo.FindByID(*req.ParentID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrParentCategoryNotFound
+			}
+			return nil, err
+		}
+		category.ParentID = req.ParentID
+	}
+
 	if err := s.categoryRepo.Create(category); err != nil {
 		return nil, err
 	}
@@ -293,21 +923,150 @@ func (s *productService) CreateCategory(req *dto.CreateCategoryRequest) (*dto.Ca
 	return s.toCategoryResponse(category), nil
 }
 
-// GetAllCategories mengambil semua kategori
+// CreateCategories membuat banyak kategori dalam satu transaction (semua atau tidak sama sekali).
+// Nama yang sudah ada di database atau muncul lebih dari sekali dalam batch dikembalikan bersama
+// ErrCategoryExists tanpa ada kategori yang dibuat. Parent harus kategori yang sudah ada.
+func (s *productService) CreateCategories(req *dto.BatchCreateCategoryRequest) ([]dto.CategoryResponse, []string, error) {
+	names := make([]string, 0, len(req.Categories))
+	seen := make(map[string]bool, len(req.Categories))
+	conflicts := []string{}
+	for _, c := range req.Categories {
+		if seen[c.Name] {
+			conflicts = appendUnique(conflicts, c.Name)
+			continue
+		}
+		seen[c.Name] = true
+		names = append(names, c.Name)
+	}
+
+	existing, err := s.categoryRepo.FindExistingNames(names)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range existing {
+		conflicts = appendUnique(conflicts, name)
+	}
+	if len(conflicts) > 0 {
+		return nil, conflicts, ErrCategoryExists
+	}
+
+	categories := make([]entity.Category, 0, len(req.Categories))
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		categoryRepo := s.categoryRepo.WithTx(tx)
+		for _, c := range req.Categories {
+			category := entity.Category{Name: c.Name, Description: c.Description}
+			if c.ParentID != nil && *c.ParentID > 0 {
+				if _, err := categoryRepo.FindByID(*c.ParentID); err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return ErrParentCategoryNotFound
+					}
+					return err
+				}
+				category.ParentID = c.ParentID
+			}
+			if err := categoryRepo.Create(&category); err != nil {
+				return err
+			}
+			categories = append(categories, category)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]dto.CategoryResponse, 0, len(categories))
+	for i := range categories {
+		responses = append(responses, *s.toCategoryResponse(&categories[i]))
+	}
+	return responses, nil, nil
+}
+
+// appendUnique menambahkan value ke list jika belum ada
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// GetAllCategories mengambil semua kategori beserta jumlah produk yang tampil di listing publik
 func (s *productService) GetAllCategories() ([]dto.CategoryResponse, error) {
 	categories, err := s.categoryRepo.FindAll()
 	if err != nil {
 		return nil, err
 	}
 
+	ids := make([]uint, len(categories))
+	for i, c := range categories {
+		ids[i] = c.ID
+	}
+	counts, err := s.categoryRepo.CountVisibleProducts(ids)
+	if err != nil {
+		return nil, err
+	}
+
 	var responses []dto.CategoryResponse
 	for _, c := range categories {
-		responses = append(responses, *s.toCategoryResponse(&c))
+		response := s.toCategoryResponse(&c)
+		count := counts[c.ID]
+		response.ProductCount = &count
+		responses = append(responses, *response)
 	}
 	return responses, nil
 }
 
-// GetCategory mengambil kategori berdasarkan ID
+// GetCategoryTree mengambil semua kategori dalam bentuk pohon
+func (s *productService) GetCategoryTree() ([]dto.CategoryResponse, error) {
+	categories, err := s.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories), nil
+}
+
+// buildCategoryTree menyusun list kategori flat menjadi pohon.
+// Kategori yang parent-nya sudah tidak ada diperlakukan sebagai root.
+func buildCategoryTree(categories []entity.Category) []dto.CategoryResponse {
+	exists := make(map[uint]bool, len(categories))
+	for _, c := range categories {
+		exists[c.ID] = true
+	}
+
+	childrenOf := make(map[uint][]entity.Category)
+	var roots []entity.Category
+	for _, c := range categories {
+		if c.ParentID == nil || !exists[*c.ParentID] {
+			roots = append(roots, c)
+			continue
+		}
+		childrenOf[*c.ParentID] = append(childrenOf[*c.ParentID], c)
+	}
+
+	var build func(c entity.Category) dto.CategoryResponse
+	build = func(c entity.Category) dto.CategoryResponse {
+		node := dto.CategoryResponse{
+			ID:          c.ID,
+			Name:        c.Name,
+			Description: c.Description,
+			ParentID:    c.ParentID,
+		}
+		for _, child := range childrenOf[c.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	tree := []dto.CategoryResponse{}
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree
+}
+
+// GetCategory mengambil kategori berdasarkan ID beserta jumlah produk yang tampil di listing publik
 func (s *productService) GetCategory(id uint) (*dto.CategoryResponse, error) {
 	category, err := s.categoryRepo.FindByID(id)
 	if err != nil {
@@ -316,7 +1075,15 @@ func (s *productService) GetCategory(id uint) (*dto.CategoryResponse, error) {
 		}
 		return nil, err
 	}
-	return s.toCategoryResponse(category), nil
+	counts, err := s.categoryRepo.CountVisibleProducts([]uint{category.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toCategoryResponse(category)
+	count := counts[category.ID]
+	response.ProductCount = &count
+	return response, nil
 }
 
 // UpdateCategory mengupdate kategori
@@ -335,6 +1102,16 @@ func (s *productService) UpdateCategory(id uint, req *dto.UpdateCategoryRequest)
 	if req.Description != "" {
 		category.Description = req.Description
 	}
+	if req.ParentID != nil {
+		if *req.ParentID == 0 {
+			category.ParentID = nil
+		} else {
+			if err := s.checkCategoryParent(id, *req.ParentID); err != nil {
+				return nil, err
+			}
+			category.ParentID = req.ParentID
+		}
+	}
 
 	if err := s.categoryRepo.Update(category); err != nil {
 		return nil, err
@@ -343,16 +1120,101 @@ func (s *productService) UpdateCategory(id uint, req *dto.UpdateCategoryRequest)
 	return s.toCategoryResponse(category), nil
 }
 
-// DeleteCategory menghapus kategori
-func (s *productService) DeleteCategory(id uint) error {
+// checkCategoryParent memastikan parent baru ada dan bukan kategori itu sendiri
+// atau salah satu turunannya (mencegah cycle)
+func (s *productService) checkCategoryParent(categoryID uint, parentID uint) error {
+	visited := make(map[uint]bool)
+	for current := parentID; ; {
+		if current == categoryID {
+			return ErrCategoryCycle
+		}
+		// Data lama yang sudah mengandung cycle tidak boleh membuat loop tanpa akhir
+		if visited[current] {
+			return ErrCategoryCycle
+		}
+		visited[current] = true
+
+		parent, err := s.categoryRepo.FindByID(current)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				if current == parentID {
+					return ErrParentCategoryNotFound
+				}
+				return nil
+			}
+			return err
+		}
+		if parent.ParentID == nil {
+			return nil
+		}
+		current = *parent.ParentID
+	}
+}
+
+// DeleteCategory menghapus kategori yang sudah tidak memiliki sub-kategori.
+// Produk di kategori dipindahkan ke params.ReassignTo atau dikosongkan (params.Unassign) dalam
+// transaction yang sama dengan penghapusan. Tanpa salah satu opsi, kategori yang masih memiliki produk
+// ditolak dengan ErrCategoryHasProducts. Mengembalikan jumlah produk (aktif) di kategori tersebut.
+func (s *productService) DeleteCategory(id uint, params *dto.DeleteCategoryParams) (int64, error) {
 	_, err := s.categoryRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrCategoryNotFound
+			return 0, ErrCategoryNotFound
 		}
-		return err
+		return 0, err
+	}
+
+	if params.ReassignTo != 0 && params.Unassign {
+		return 0, ErrInvalidCategoryReassign
+	}
+	if params.ReassignTo == id {
+		return 0, ErrInvalidCategoryReassign
+	}
+	if params.ReassignTo != 0 {
+		if _, err := s.categoryRepo.FindByID(params.ReassignTo); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, ErrReassignCategoryNotFound
+			}
+			return 0, err
+		}
+	}
+
+	children, err := s.categoryRepo.FindChildren(id)
+	if err != nil {
+		return 0, err
+	}
+	if len(children) > 0 {
+		return 0, ErrCategoryHasChildren
+	}
+
+	productCount, err := s.categoryRepo.CountProducts(id)
+	if err != nil {
+		return 0, err
+	}
+	if productCount > 0 && params.ReassignTo == 0 && !params.Unassign {
+		return productCount, ErrCategoryHasProducts
+	}
+
+	var movedIDs []uint
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		categoryRepo := s.categoryRepo.WithTx(tx)
+		if params.ReassignTo != 0 || params.Unassign {
+			ids, err := categoryRepo.ReassignProducts(id, params.ReassignTo)
+			if err != nil {
+				return err
+			}
+			movedIDs = ids
+		}
+		return categoryRepo.Delete(id)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, productID := range movedIDs {
+		s.invalidateProductCache(productID)
 	}
-	return s.categoryRepo.Delete(id)
+	return productCount, nil
 }
 
 // ========================================
@@ -364,23 +1226,185 @@ func (s *productService) GetProductByID(id uint) (*entity.Product, error) {
 	return s.productRepo.FindByID(id)
 }
 
+// GetInventoryValue menghitung total nilai stok produk seller (untuk dashboard seller)
+func (s *productService) GetInventoryValue(sellerID uint) (money.Money, error) {
+	return s.productRepo.SumInventoryValueBySellerID(sellerID)
+}
+
+// CountProducts menghitung jumlah seluruh produk (untuk dashboard admin)
+func (s *productService) CountProducts() (int64, error) {
+	return s.productRepo.Count()
+}
+
 // ReduceStock mengurangi stok (dipanggil dari Order Module)
-func (s *productService) ReduceStock(productID uint, quantity int) error {
-	product, err := s.productRepo.FindByID(productID)
+func (s *productService) ReduceStock(productID uint, quantity int, change StockChange) error {
+	var notifyLowStock func()
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		notifyLowStock, err = s.ReduceStockTx(tx, productID, quantity, change)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	notifyLowStock()
+	return nil
+}
+
+// ReduceStockTx mengurangi stok di dalam transaction milik pemanggil (mis. checkout).
+// Fungsi yang dikembalikan mengirim notifikasi stok menipis dan harus dipanggil setelah tx di-commit.
+func (s *productService) ReduceStockTx(tx *gorm.DB, productID uint, quantity int, change StockChange) (func(), error) {
+	productRepo := s.productRepo.WithTx(tx)
+	ok, err := productRepo.ReduceStockAtomic(productID, quantity)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Tidak ada baris yang terupdate: produk tidak ada atau stok kurang
+		if _, err := productRepo.FindByID(productID); err != nil {
+			return nil, ErrProductNotFound
+		}
+		return nil, ErrInsufficientStock
+	}
+
+	if err := s.logStockChange(tx, productID, nil, -quantity, change); err != nil {
+		return nil, err
+	}
+	s.invalidateProductCache(productID)
+	return s.lowStockCheck(productRepo, productID, quantity), nil
+}
+
+// lowStockCheck membaca ulang stok produk di dalam tx setelah dikurangi quantity dan mengembalikan
+// fungsi pengecekan stok menipis, agar notifikasi tidak terkirim untuk perubahan yang di-rollback
+func (s *productService) lowStockCheck(productRepo repository.ProductRepository, productID uint, quantity int) func() {
+	product, err := productRepo.FindByID(productID)
 	if err != nil {
-		return ErrProductNotFound
+		return func() {}
 	}
+	return func() { s.checkLowStock(product, product.Stock+quantity) }
+}
 
-	if !product.HasStock(quantity) {
-		return ErrInsufficientStock
+// checkLowStock mengirim notifikasi jika stok baru saja turun melewati threshold.
+// Hanya dipicu saat melewati batas (sebelumnya di atas threshold) agar tidak spam setiap order.
+func (s *productService) checkLowStock(product *entity.Product, previousStock int) {
+	threshold := product.EffectiveLowStockThreshold(s.lowStockThreshold)
+	if product.Stock > threshold || previousStock <= threshold {
+		return
 	}
 
-	return s.productRepo.UpdateStock(productID, -quantity)
+	logger.Warn().
+		Uint("product_id", product.ID).
+		Uint("seller_id", product.SellerID).
+		Int("stock", product.Stock).
+		Int("threshold", threshold).
+		Msg("Product stock is low")
+
+	if s.stockNotifier != nil {
+		s.stockNotifier.NotifyLowStock(product, threshold)
+	}
+}
+
+// HasVariants mengecek apakah produk memiliki varian
+func (s *productService) HasVariants(productID uint) (bool, error) {
+	count, err := s.variantRepo.CountByProductID(productID)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetVariant mengambil varian milik produk tertentu
+func (s *productService) GetVariant(productID uint, variantID uint) (*entity.ProductVariant, error) {
+	variant, err := s.variantRepo.FindByID(variantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVariantNotFound
+		}
+		return nil, err
+	}
+	if variant.ProductID != productID {
+		return nil, ErrVariantNotFound
+	}
+	return variant, nil
+}
+
+// ReduceVariantStockTx mengurangi stok varian secara atomik di dalam transaction milik pemanggil,
+// lalu menyamakan stok produk dengan total stok varian.
+// Fungsi yang dikembalikan mengirim notifikasi stok menipis dan harus dipanggil setelah tx di-commit.
+func (s *productService) ReduceVariantStockTx(tx *gorm.DB, productID uint, variantID uint, quantity int, change StockChange) (func(), error) {
+	variantRepo := s.variantRepo.WithTx(tx)
+	productRepo := s.productRepo.WithTx(tx)
+
+	ok, err := variantRepo.ReduceStockAtomic(variantID, productID, quantity)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		variant, err := variantRepo.FindByID(variantID)
+		if err != nil || variant.ProductID != productID {
+			return nil, ErrVariantNotFound
+		}
+		return nil, ErrInsufficientStock
+	}
+
+	if err := productRepo.SyncStockFromVariants(productID); err != nil {
+		return nil, err
+	}
+	if err := s.logStockChange(tx, productID, &variantID, -quantity, change); err != nil {
+		return nil, err
+	}
+	s.invalidateProductCache(productID)
+	return s.lowStockCheck(productRepo, productID, quantity), nil
+}
+
+// RestoreVariantStockTx mengembalikan stok varian (mis. cancel order) di dalam transaction milik pemanggil.
+// Pemanggil harus memanggil NotifyBackInStock setelah tx di-commit.
+func (s *productService) RestoreVariantStockTx(tx *gorm.DB, productID uint, variantID uint, quantity int, change StockChange) error {
+	if err := s.variantRepo.WithTx(tx).UpdateStock(variantID, quantity); err != nil {
+		return err
+	}
+	if err := s.productRepo.WithTx(tx).SyncStockFromVariants(productID); err != nil {
+		return err
+	}
+	if err := s.logStockChange(tx, productID, &variantID, quantity, change); err != nil {
+		return err
+	}
+	s.invalidateProductCache(productID)
+	return nil
 }
 
 // RestoreStock mengembalikan stok (jika order dibatalkan)
-func (s *productService) RestoreStock(productID uint, quantity int) error {
-	return s.productRepo.UpdateStock(productID, quantity)
+func (s *productService) RestoreStock(productID uint, quantity int, change StockChange) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		return s.RestoreStockTx(tx, productID, quantity, change)
+	})
+	if err != nil {
+		return err
+	}
+	s.NotifyBackInStock(productID)
+	return nil
+}
+
+// RestoreStockTx mengembalikan stok di dalam transaction milik pemanggil (mis. cancel order).
+// Pemanggil harus memanggil NotifyBackInStock setelah tx di-commit.
+func (s *productService) RestoreStockTx(tx *gorm.DB, productID uint, quantity int, change StockChange) error {
+	if err := s.productRepo.WithTx(tx).UpdateStock(productID, quantity); err != nil {
+		return err
+	}
+	if err := s.logStockChange(tx, productID, nil, quantity, change); err != nil {
+		return err
+	}
+	s.invalidateProductCache(productID)
+	return nil
+}
+
+// UpdateRatingSummary menyimpan ringkasan rating (dipanggil dari Review Module)
+func (s *productService) UpdateRatingSummary(productID uint, average float64, count int) error {
+	if err := s.productRepo.UpdateRatingSummary(productID, math.Round(average*100)/100, count); err != nil {
+		return err
+	}
+	s.invalidateProductCache(productID)
+	return nil
 }
 
 // ========================================
@@ -389,28 +1413,57 @@ func (s *productService) RestoreStock(productID uint, quantity int) error {
 
 func (s *productService) toProductResponse(p *entity.Product) *dto.ProductResponse {
 	resp := &dto.ProductResponse{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Stock:       p.Stock,
-		CategoryID:  p.CategoryID,
-		SellerID:    p.SellerID,
-		ImageURL:    p.ImageURL,
-		IsActive:    p.IsActive,
+		ID:            p.ID,
+		Name:          p.Name,
+		SKU:           p.SKU,
+		Description:   p.Description,
+		Price:         p.Price,
+		Currency:      p.Currency,
+		Stock:         p.Stock,
+		CategoryID:    p.CategoryID,
+		SellerID:      p.SellerID,
+		ImageURL:      p.ImageURL,
+		IsActive:      p.IsActive,
+		AverageRating: p.AverageRating,
+		ReviewCount:   p.ReviewCount,
+
+		AvailableStock:    p.AvailableStock(),
+		LowStockThreshold: p.EffectiveLowStockThreshold(s.lowStockThreshold),
 	}
 
 	if p.Category != nil {
 		resp.Category = s.toCategoryResponse(p.Category)
 	}
+	for _, v := range p.Variants {
+		resp.Variants = append(resp.Variants, *toVariantResponse(&v, p.Currency))
+	}
+	for _, t := range p.Tags {
+		resp.Tags = append(resp.Tags, t.Name)
+	}
 
 	return resp
 }
 
+// toVariantResponse memetakan varian; currency diambil dari produk induknya
+func toVariantResponse(v *entity.ProductVariant, currency string) *dto.VariantResponse {
+	return &dto.VariantResponse{
+		ID:         v.ID,
+		ProductID:  v.ProductID,
+		Attributes: v.Attributes,
+		SKU:        v.SKU,
+		Price:      v.Price,
+		Currency:   currency,
+		Stock:      v.Stock,
+
+		AvailableStock: v.AvailableStock(),
+	}
+}
+
 func (s *productService) toCategoryResponse(c *entity.Category) *dto.CategoryResponse {
 	return &dto.CategoryResponse{
 		ID:          c.ID,
 		Name:        c.Name,
 		Description: c.Description,
+		ParentID:    c.ParentID,
 	}
 }
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// setupProductDB membuat database sqlite in-memory berisi seluruh tabel Product Module
+func setupProductDB(t *testing.T) *gorm.DB {
+	return dbtest.NewSQLite(t,
+		&entity.Category{},
+		&entity.Product{},
+		&entity.ProductVariant{},
+		&entity.InventoryLog{},
+		&entity.PriceHistory{},
+		&entity.StockReservation{},
+		&entity.StockSubscription{},
+	)
+}
+
+// setupProductService membuat ProductService di atas setupProductDB
+func setupProductService(t *testing.T) (ProductService, *gorm.DB) {
+	db := setupProductDB(t)
+	return NewProductService(ProductServiceDeps{DB: db}), db
+}
+
+// fakeCategoryRepository menyimpan kategori di memory untuk pengujian
+type fakeCategoryRepository struct {
+	repository.CategoryRepository
+	categories map[uint]*entity.Category
+}
+
+func (r *fakeCategoryRepository) FindByID(id uint) (*entity.Category, error) {
+	if c, ok := r.categories[id]; ok {
+		return c, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// Test Category Tree
+func TestBuildCategoryTree(t *testing.T) {
+	categories := []entity.Category{
+		{ID: 1, Name: "Electronics"},
+		{ID: 2, Name: "Phones", ParentID: uintPtr(1)},
+		{ID: 3, Name: "Android", ParentID: uintPtr(2)},
+		{ID: 4, Name: "Books"},
+		{ID: 5, Name: "Orphan", ParentID: uintPtr(99)},
+	}
+
+	tree := buildCategoryTree(categories)
+
+	require.Len(t, tree, 3)
+	assert.Equal(t, "Electronics", tree[0].Name)
+	require.Len(t, tree[0].Children, 1)
+	assert.Equal(t, "Phones", tree[0].Children[0].Name)
+	require.Len(t, tree[0].Children[0].Children, 1)
+	assert.Equal(t, "Android", tree[0].Children[0].Children[0].Name)
+	assert.Empty(t, tree[1].Children)
+	assert.Equal(t, "Orphan", tree[2].Name)
+}
+
+func TestBuildCategoryTree_Empty(t *testing.T) {
+	tree := buildCategoryTree(nil)
+	assert.NotNil(t, tree)
+	assert.Empty(t, tree)
+}
+
+// Test Category Cycle Detection
+func TestCheckCategoryParent(t *testing.T) {
+	// Electronics(1) > Phones(2) > Android(3)
+	repo := &fakeCategoryRepository{categories: map[uint]*entity.Category{
+		1: {ID: 1, Name: "Electronics"},
+		2: {ID: 2, Name: "Phones", ParentID: uintPtr(1)},
+		3: {ID: 3, Name: "Android", ParentID: uintPtr(2)},
+		4: {ID: 4, Name: "Books"},
+	}}
+	svc := &productService{categoryRepo: repo}
+
+	assert.ErrorIs(t, svc.checkCategoryParent(1, 1), ErrCategoryCycle)
+	assert.ErrorIs(t, svc.checkCategoryParent(1, 3), ErrCategoryCycle)
+	assert.ErrorIs(t, svc.checkCategoryParent(2, 3), ErrCategoryCycle)
+	assert.ErrorIs(t, svc.checkCategoryParent(1, 99), ErrParentCategoryNotFound)
+	assert.NoError(t, svc.checkCategoryParent(3, 1))
+	assert.NoError(t, svc.checkCategoryParent(4, 3))
+}
+
+// recordingStockNotifier mencatat produk yang dilaporkan stoknya menipis
+type recordingStockNotifier struct {
+	notified []uint
+}
+
+func (n *recordingStockNotifier) NotifyLowStock(product *entity.Product, threshold int) {
+	n.notified = append(n.notified, product.ID)
+}
+
+func TestCheckLowStock_NotifiesOnlyWhenCrossingThreshold(t *testing.T) {
+	notifier := &recordingStockNotifier{}
+	svc := &productService{stockNotifier: notifier, lowStockThreshold: 5}
+
+	// Masih di atas threshold
+	svc.checkLowStock(&entity.Product{ID: 1, Stock: 6}, 10)
+	assert.Empty(t, notifier.notified)
+
+	// Turun melewati threshold default
+	svc.checkLowStock(&entity.Product{ID: 2, Stock: 5}, 7)
+	assert.Equal(t, []uint{2}, notifier.notified)
+
+	// Sudah di bawah threshold sebelumnya, tidak dilaporkan ulang
+	svc.checkLowStock(&entity.Product{ID: 2, Stock: 3}, 5)
+	assert.Equal(t, []uint{2}, notifier.notified)
+
+	// Threshold per produk menggantikan default
+	threshold := 20
+	svc.checkLowStock(&entity.Product{ID: 3, Stock: 15, LowStockThreshold: &threshold}, 25)
+	assert.Equal(t, []uint{2, 3}, notifier.notified)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateProduct_RejectsDuplicateSKU(t *testing.T) {
+	svc, db := setupProductService(t)
+
+	created, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100)})
+	require.NoError(t, err)
+	assert.Equal(t, "LAPTOP-1", created.SKU)
+
+	_, err = svc.CreateProduct(8, &dto.CreateProductRequest{Name: "Other Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100)})
+	assert.ErrorIs(t, err, ErrSKUExists)
+
+	// SKU produk yang sudah dihapus tetap terpakai karena unique index mencakup baris soft delete
+	require.NoError(t, db.Delete(&entity.Product{}, created.ID).Error)
+	_, err = svc.CreateProduct(8, &dto.CreateProductRequest{Name: "Other Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100)})
+	assert.ErrorIs(t, err, ErrSKUExists)
+}
+
+func TestUpdateProduct_ChangesSKUUnlessTaken(t *testing.T) {
+	svc, _ := setupProductService(t)
+
+	laptop, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100)})
+	require.NoError(t, err)
+	_, err = svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Mouse", SKU: "MOUSE-1", Price: money.FromFloat(10)})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateProduct(7, laptop.ID, &dto.UpdateProductRequest{SKU: ptr("MOUSE-1")})
+	assert.ErrorIs(t, err, ErrSKUExists)
+
+	// SKU yang sama dengan miliknya sendiri bukan konflik
+	_, err = svc.UpdateProduct(7, laptop.ID, &dto.UpdateProductRequest{SKU: ptr("LAPTOP-1")})
+	require.NoError(t, err)
+
+	updated, err := svc.UpdateProduct(7, laptop.ID, &dto.UpdateProductRequest{SKU: ptr("LAPTOP-2")})
+	require.NoError(t, err)
+	assert.Equal(t, "LAPTOP-2", updated.SKU)
+}
+
+func TestGetProductBySKU(t *testing.T) {
+	svc, _ := setupProductService(t)
+
+	created, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Laptop", SKU: "LAPTOP-1", Price: money.FromFloat(100)})
+	require.NoError(t, err)
+
+	found, err := svc.GetProductBySKU("LAPTOP-1")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+
+	_, err = svc.GetProductBySKU("MISSING-1")
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestBackfillProductSKUs_AssignsPlaceholders(t *testing.T) {
+	_, db := setupProductService(t)
+
+	// Simulasikan tabel lama: SKU kosong sebelum kolom menjadi unik
+	require.NoError(t, db.Exec("DROP INDEX IF EXISTS idx_products_sku").Error)
+	require.NoError(t, db.Exec("INSERT INTO products (name, sku, price, seller_id) VALUES ('Old A', '', 100, 7), ('Old B', '', 100, 7), ('Has SKU', 'KEEP-1', 100, 7)").Error)
+
+	require.NoError(t, repository.BackfillProductSKUs(db))
+
+	var products []entity.Product
+	require.NoError(t, db.Order("id").Find(&products).Error)
+	require.Len(t, products, 3)
+	assert.Equal(t, "PRD-1", products[0].SKU)
+	assert.Equal(t, "PRD-2", products[1].SKU)
+	assert.Equal(t, "KEEP-1", products[2].SKU)
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	authEntity "github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSellerProducts_OnlyActiveProductsOfSeller(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.AutoMigrate(&authEntity.User{}))
+
+	seller := &authEntity.User{Name: "Seller", Email: "seller@example.com", Password: "x", Role: authEntity.RoleSeller, IsActive: true}
+	other := &authEntity.User{Name: "Other", Email: "other@example.com", Password: "x", Role: authEntity.RoleSeller, IsActive: true}
+	require.NoError(t, db.Create(seller).Error)
+	require.NoError(t, db.Create(other).Error)
+
+	newProduct := func(sku string, sellerID uint) *entity.Product {
+		p := &entity.Product{Name: sku, SKU: sku, Price: money.FromFloat(10), Stock: 1, SellerID: sellerID, IsActive: true}
+		require.NoError(t, db.Create(p).Error)
+		return p
+	}
+	visible := newProduct("VISIBLE", seller.ID)
+	inactive := newProduct("INACTIVE", seller.ID)
+	deleted := newProduct("DELETED", seller.ID)
+	newProduct("OTHER", other.ID)
+
+	// default:true membuat nilai false diabaikan saat Create, jadi nonaktifkan lewat update
+	require.NoError(t, db.Model(inactive).Update("is_active", false).Error)
+	require.NoError(t, db.Delete(deleted).Error)
+
+	result, err := svc.GetSellerProducts(seller.ID, &dto.ProductQueryParams{})
+	require.NoError(t, err)
+	require.Len(t, result.Products, 1)
+	assert.Equal(t, visible.ID, result.Products[0].ID)
+	assert.Equal(t, int64(1), result.Total)
+
+	// seller_id di listing publik memberi hasil yang sama
+	public, err := svc.GetAllProducts(&dto.ProductQueryParams{SellerID: seller.ID})
+	require.NoError(t, err)
+	require.Len(t, public.Products, 1)
+	assert.Equal(t, visible.ID, public.Products[0].ID)
+
+	// Seller tetap melihat produk nonaktif miliknya sendiri
+	mine, err := svc.GetMyProducts(seller.ID)
+	require.NoError(t, err)
+	assert.Len(t, mine, 2)
+}
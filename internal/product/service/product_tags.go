@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidTags dikembalikan jika tidak ada nama tag yang valid setelah dinormalisasi
+var ErrInvalidTags = errors.New("at least one non-empty tag is required")
+
+// AttachTags memasang tag ke produk milik seller. Tag yang belum ada dibuat otomatis,
+// tag yang sudah terpasang dilewati.
+func (s *productService) AttachTags(sellerID uint, productID uint, req *dto.ProductTagsRequest) (*dto.ProductResponse, error) {
+	names := normalizeTagNames(req.Tags)
+	if len(names) == 0 {
+		return nil, ErrInvalidTags
+	}
+	if _, err := s.findOwnedProduct(sellerID, productID); err != nil {
+		return nil, err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		tagRepo := s.tags.WithTx(tx)
+		tags, err := tagRepo.FindOrCreateByNames(names)
+		if err != nil {
+			return err
+		}
+		tagIDs := make([]uint, 0, len(tags))
+		for _, tag := range tags {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+		return tagRepo.AttachToProduct(productID, tagIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadTaggedProduct(productID)
+}
+
+// DetachTags melepas tag dari produk milik seller. Tag yang tidak terpasang diabaikan.
+func (s *productService) DetachTags(sellerID uint, productID uint, req *dto.ProductTagsRequest) (*dto.ProductResponse, error) {
+	names := normalizeTagNames(req.Tags)
+	if len(names) == 0 {
+		return nil, ErrInvalidTags
+	}
+	if _, err := s.findOwnedProduct(sellerID, productID); err != nil {
+		return nil, err
+	}
+
+	if err := s.tags.DetachFromProduct(productID, names); err != nil {
+		return nil, err
+	}
+	return s.reloadTaggedProduct(productID)
+}
+
+// reloadTaggedProduct menghapus cache detail produk lalu memuat ulang produk beserta tag-nya
+func (s *productService) reloadTaggedProduct(productID uint) (*dto.ProductResponse, error) {
+	s.invalidateProductCache(productID)
+	product, err := s.productRepo.FindByIDWithCategory(productID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toProductResponse(product), nil
+}
+
+// parseTagFilter mengubah query tags (dipisah koma) menjadi daftar nama tag untuk filter list produk
+func parseTagFilter(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	return normalizeTagNames(strings.Split(raw, ","))
+}
+
+// normalizeTagNames merapikan nama tag (trim, lowercase), membuang yang kosong dan duplikat
+func normalizeTagNames(raw []string) []string {
+	names := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, name := range raw {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+
+	authEntity "github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// productNames mengambil nama produk dari hasil list
+func productNames(result *dto.ProductListResponse) []string {
+	names := []string{}
+	for _, p := range result.Products {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestAttachTags_NormalizesAndDetaches(t *testing.T) {
+	svc, db := setupProductService(t)
+
+	product, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: "Tas Kanvas", SKU: "BAG-1", Price: money.FromFloat(100), Stock: 5})
+	require.NoError(t, err)
+
+	resp, err := svc.AttachTags(7, product.ID, &dto.ProductTagsRequest{Tags: []string{" Sale", "eco", "SALE", ""}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eco", "sale"}, resp.Tags)
+
+	// Tag yang sudah terpasang dilewati, tag yang sudah ada dipakai ulang
+	resp, err = svc.AttachTags(7, product.ID, &dto.ProductTagsRequest{Tags: []string{"sale", "new"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eco", "new", "sale"}, resp.Tags)
+	var tagCount int64
+	require.NoError(t, db.Model(&entity.Tag{}).Count(&tagCount).Error)
+	assert.Equal(t, int64(3), tagCount)
+
+	resp, err = svc.DetachTags(7, product.ID, &dto.ProductTagsRequest{Tags: []string{"SALE", "missing"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eco", "new"}, resp.Tags)
+
+	_, err = svc.AttachTags(8, product.ID, &dto.ProductTagsRequest{Tags: []string{"sale"}})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = svc.AttachTags(7, product.ID, &dto.ProductTagsRequest{Tags: []string{"  "}})
+	assert.ErrorIs(t, err, ErrInvalidTags)
+}
+
+func TestGetAllProducts_FiltersByTags(t *testing.T) {
+	svc, db := setupProductService(t)
+	require.NoError(t, db.AutoMigrate(&authEntity.User{}))
+
+	bags := &entity.Category{Name: "Bags"}
+	require.NoError(t, db.Create(bags).Error)
+
+	create := func(name, sku string, price float64, categoryID uint, tags ...string) {
+		product, err := svc.CreateProduct(7, &dto.CreateProductRequest{Name: name, SKU: sku, Price: money.FromFloat(price), Stock: 5, CategoryID: categoryID})
+		require.NoError(t, err)
+		if len(tags) > 0 {
+			_, err = svc.AttachTags(7, product.ID, &dto.ProductTagsRequest{Tags: tags})
+			require.NoError(t, err)
+		}
+	}
+	create("Eco Sale Bag", "TAG-1", 100, bags.ID, "eco", "sale")
+	create("Eco Bag", "TAG-2", 300, bags.ID, "eco")
+	create("Sale Shirt", "TAG-3", 100, 0, "sale", "new")
+	create("Plain Shirt", "TAG-4", 100, 0)
+
+	// Mode any (default): produk dengan salah satu tag, tanpa duplikat baris
+	result, err := svc.GetAllProducts(&dto.ProductQueryParams{Tags: "eco, SALE"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Eco Sale Bag", "Eco Bag", "Sale Shirt"}, productNames(result))
+	assert.Equal(t, int64(3), result.Total)
+
+	// Mode all: produk harus punya semua tag
+	result, err = svc.GetAllProducts(&dto.ProductQueryParams{Tags: "eco,sale", TagMode: dto.TagModeAll})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Eco Sale Bag"}, productNames(result))
+	assert.Equal(t, []string{"eco", "sale"}, result.Products[0].Tags)
+
+	// Filter tag tetap digabung dengan filter kategori dan harga
+	result, err = svc.GetAllProducts(&dto.ProductQueryParams{Tags: "eco", CategoryID: bags.ID, MaxPrice: money.FromFloat(200)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Eco Sale Bag"}, productNames(result))
+
+	result, err = svc.GetAllProducts(&dto.ProductQueryParams{Tags: "sale", CategoryID: bags.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Eco Sale Bag"}, productNames(result))
+
+	result, err = svc.GetAllProducts(&dto.ProductQueryParams{Tags: "unknown"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Products)
+}
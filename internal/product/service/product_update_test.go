@@ -0,0 +1,85 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestUpdateProduct_NilFieldsAreUnchanged(t *testing.T) {
+	svc, _ := setupProductService(t)
+
+	product, err := svc.CreateProduct(7, &dto.CreateProductRequest{
+		Name: "Laptop", SKU: "LAPTOP-1", Description: "Fast laptop", Price: money.FromFloat(100), Stock: 5,
+	})
+	require.NoError(t, err)
+
+	updated, err := svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Name: ptr("Laptop Pro")})
+	require.NoError(t, err)
+	assert.Equal(t, "Laptop Pro", updated.Name)
+	assert.Equal(t, "Fast laptop", updated.Description)
+	assert.Equal(t, money.FromFloat(100), updated.Price)
+	assert.Equal(t, 5, updated.Stock)
+}
+
+func TestUpdateProduct_AppliesProvidedZeroValues(t *testing.T) {
+	svc, _ := setupProductService(t)
+
+	product, err := svc.CreateProduct(7, &dto.CreateProductRequest{
+		Name: "Laptop", SKU: "LAPTOP-1", Description: "Fast laptop", Price: money.FromFloat(100), Stock: 5, ImageURL: "https://example.com/a.png",
+	})
+	require.NoError(t, err)
+
+	updated, err := svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{
+		Description: ptr(""),
+		ImageURL:    ptr(""),
+		Stock:       ptr(0),
+		Price:       ptr(money.FromFloat(0.01)),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, updated.Description)
+	assert.Empty(t, updated.ImageURL)
+	assert.Equal(t, 0, updated.Stock)
+	assert.Equal(t, money.FromFloat(0.01), updated.Price)
+	assert.Equal(t, "Laptop", updated.Name)
+}
+
+func TestUpdateProduct_RejectsStockBelowReserved(t *testing.T) {
+	svc, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 5, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return svc.ReserveStockTx(tx, 1, product.ID, nil, 3)
+	}))
+
+	_, err := svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Stock: ptr(2)})
+	assert.ErrorIs(t, err, ErrStockBelowReserved)
+
+	updated, err := svc.UpdateProduct(7, product.ID, &dto.UpdateProductRequest{Stock: ptr(3)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, updated.Stock)
+	assert.Zero(t, updated.AvailableStock)
+}
+
+func TestUpdateProduct_ReservationAfterReadIsAConflict(t *testing.T) {
+	svc, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 5, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	// Produk dibaca sebelum checkout lain mereservasi stok: stok baru tidak boleh menimpa reservasi itu
+	repo := repository.NewProductRepository(db)
+	stale, err := repo.FindByID(product.ID)
+	require.NoError(t, err)
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return svc.ReserveStockTx(tx, 1, product.ID, nil, 3)
+	}))
+
+	stale.Stock = 1
+	assert.ErrorIs(t, repo.Update(stale), repository.ErrStaleProduct)
+}
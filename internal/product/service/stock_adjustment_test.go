@@ -0,0 +1,102 @@
+package service
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/product/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAdminSetStock_SetsAbsoluteValueAndLogsReason(t *testing.T) {
+	svc, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 10, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	result, err := svc.AdminSetStock(1, product.ID, &dto.AdminSetStockRequest{Stock: ptr(4), Reason: "Dispute #12: recount found 4 units"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Stock)
+
+	// Stok bisa di-set ke nol
+	result, err = svc.AdminSetStock(1, product.ID, &dto.AdminSetStockRequest{Stock: ptr(0), Reason: "Warehouse damage"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Stock)
+
+	logs, err := svc.GetInventoryLog(1, product.ID, true, &dto.InventoryLogQueryParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, logs.Logs, 2)
+	assert.Equal(t, entity.InventoryReasonAdminSet, logs.Logs[0].Reason)
+	assert.Equal(t, -4, logs.Logs[0].Delta)
+	assert.Equal(t, "Warehouse damage", logs.Logs[0].Note)
+	assert.Equal(t, -6, logs.Logs[1].Delta)
+	assert.Equal(t, "Dispute #12: recount found 4 units", logs.Logs[1].Note)
+	require.NotNil(t, logs.Logs[1].ActorID)
+	assert.Equal(t, uint(1), *logs.Logs[1].ActorID)
+}
+
+func TestAdminSetStock_RejectsStockBelowReservations(t *testing.T) {
+	svc, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 10, ReservedStock: 3, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	_, err := svc.AdminSetStock(1, product.ID, &dto.AdminSetStockRequest{Stock: ptr(2), Reason: "Recount"})
+	assert.ErrorIs(t, err, ErrStockBelowReserved)
+
+	_, err = svc.AdminSetStock(1, product.ID+100, &dto.AdminSetStockRequest{Stock: ptr(2), Reason: "Recount"})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+
+	var reloaded entity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 10, reloaded.Stock)
+
+	var logCount int64
+	require.NoError(t, db.Model(&entity.InventoryLog{}).Count(&logCount).Error)
+	assert.Zero(t, logCount)
+}
+
+func TestAdminSetStock_RetriesWhenStockChangesConcurrently(t *testing.T) {
+	_, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 10, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	// Checkout paralel mengurangi stok tepat sebelum compare-and-set pertama
+	racing := &racingProductRepository{
+		ProductRepository: repository.NewProductRepository(db),
+		beforeSet:         func() { db.Model(product).Update("stock", 8) },
+		once:              &sync.Once{},
+	}
+	svc := NewProductService(ProductServiceDeps{
+		ProductRepo: racing,
+		DB:          db,
+	})
+
+	result, err := svc.AdminSetStock(1, product.ID, &dto.AdminSetStockRequest{Stock: ptr(5), Reason: "Recount"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Stock)
+
+	// Delta dihitung dari stok yang benar-benar diganti, bukan dari pembacaan pertama
+	var log entity.InventoryLog
+	require.NoError(t, db.First(&log).Error)
+	assert.Equal(t, -3, log.Delta)
+}
+
+// racingProductRepository menjalankan beforeSet sekali sebelum SetStockAtomic pertama
+type racingProductRepository struct {
+	repository.ProductRepository
+	beforeSet func()
+	once      *sync.Once
+}
+
+func (r *racingProductRepository) SetStockAtomic(id uint, expected int, stock int) (bool, error) {
+	r.once.Do(r.beforeSet)
+	return r.ProductRepository.SetStockAtomic(id, expected, stock)
+}
+
+func (r *racingProductRepository) WithTx(tx *gorm.DB) repository.ProductRepository {
+	return &racingProductRepository{ProductRepository: r.ProductRepository.WithTx(tx), beforeSet: r.beforeSet, once: r.once}
+}
@@ -0,0 +1,9 @@
+package service
+
+import "github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+
+// StockNotifier dipanggil ketika stok produk turun sampai atau di bawah threshold.
+// Implementasi (email, webhook, dll) dipasang lewat NewProductService; nil berarti hanya log.
+type StockNotifier interface {
+	NotifyLowStock(product *entity.Product, threshold int)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// reservationReleaseBatch membatasi jumlah reservasi yang dilepas dalam satu putaran worker
+const reservationReleaseBatch = 100
+
+// ReserveStockTx menahan stok untuk order PENDING di dalam transaction milik pemanggil (checkout).
+// Stok fisik tidak berubah; hanya stok yang bisa dijual yang berkurang sampai order dibayar atau dilepas.
+// variantID nil berarti reservasi pada produk tanpa varian.
+func (s *productService) ReserveStockTx(tx *gorm.DB, orderID uint, productID uint, variantID *uint, quantity int) error {
+	productRepo := s.productRepo.WithTx(tx)
+
+	if variantID != nil {
+		variantRepo := s.variantRepo.WithTx(tx)
+		ok, err := variantRepo.ReserveStockAtomic(*variantID, productID, quantity)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			variant, err := variantRepo.FindByID(*variantID)
+			if err != nil || variant.ProductID != productID {
+				return ErrVariantNotFound
+			}
+			return ErrInsufficientStock
+		}
+		if err := productRepo.SyncStockFromVariants(productID); err != nil {
+			return err
+		}
+	} else {
+		ok, err := productRepo.ReserveStockAtomic(productID, quantity)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// Tidak ada baris yang terupdate: produk tidak ada atau stok kurang
+			if _, err := productRepo.FindByID(productID); err != nil {
+				return ErrProductNotFound
+			}
+			return ErrInsufficientStock
+		}
+	}
+
+	reservation := &entity.StockReservation{
+		ProductID: productID,
+		VariantID: variantID,
+		OrderID:   orderID,
+		Quantity:  quantity,
+		Status:    entity.ReservationStatusActive,
+	}
+	if s.reservationTTL > 0 {
+		expiresAt := time.Now().Add(s.reservationTTL)
+		reservation.ExpiresAt = &expiresAt
+	}
+	if err := s.reservations.WithTx(tx).Create(reservation); err != nil {
+		return err
+	}
+
+	s.invalidateProductCache(productID)
+	return nil
+}
+
+// CommitReservationsTx mengubah reservasi order menjadi pengurangan stok fisik saat order dibayar.
+// Reservasi yang sudah dilepas (kedaluwarsa sebelum dibayar) dikurangi ulang dari stok yang tersedia
+// dan bisa gagal dengan ErrInsufficientStock. Order tanpa reservasi (dibuat sebelum fitur ini) tidak diubah.
+// Fungsi yang dikembalikan mengirim notifikasi stok menipis dan harus dipanggil setelah tx di-commit.
+func (s *productService) CommitReservationsTx(tx *gorm.DB, orderID uint, change StockChange) (func(), error) {
+	reservationRepo := s.reservations.WithTx(tx)
+	reservations, err := reservationRepo.FindByOrderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lowStockChecks []func()
+
+	for i := range reservations {
+		r := &reservations[i]
+		if r.Status == entity.ReservationStatusCommitted {
+			continue
+		}
+
+		// Update bersyarat agar reservasi yang dilepas worker secara bersamaan tidak diproses dua kali
+		ok, err := reservationRepo.UpdateStatusIf(r.ID, entity.ReservationStatusActive, entity.ReservationStatusCommitted)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if err := s.releaseReservedCounter(tx, r); err != nil {
+				return nil, err
+			}
+		} else {
+			ok, err = reservationRepo.UpdateStatusIf(r.ID, entity.ReservationStatusReleased, entity.ReservationStatusCommitted)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		var notifyLowStock func()
+		if r.VariantID != nil {
+			notifyLowStock, err = s.ReduceVariantStockTx(tx, r.ProductID, *r.VariantID, r.Quantity, change)
+		} else {
+			notifyLowStock, err = s.ReduceStockTx(tx, r.ProductID, r.Quantity, change)
+		}
+		if err != nil {
+			return nil, err
+		}
+		lowStockChecks = append(lowStockChecks, notifyLowStock)
+	}
+	return func() {
+		for _, check := range lowStockChecks {
+			check()
+		}
+	}, nil
+}
+
+// ReleaseReservationsTx melepas reservasi ACTIVE milik order (cancel/expire) di dalam transaction
+// milik pemanggil. Mengembalikan false jika order tidak punya reservasi sama sekali, sehingga
+// pemanggil tahu stoknya dikurangi langsung saat checkout (order lama) dan harus dikembalikan.
+// Pemanggil harus memanggil NotifyBackInStock setelah tx di-commit.
+func (s *productService) ReleaseReservationsTx(tx *gorm.DB, orderID uint) (bool, error) {
+	reservations, err := s.reservations.WithTx(tx).FindByOrderID(orderID)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range reservations {
+		if !reservations[i].IsActive() {
+			continue
+		}
+		if _, err := s.releaseReservation(tx, &reservations[i]); err != nil {
+			return false, err
+		}
+	}
+	return len(reservations) > 0, nil
+}
+
+// ReleaseExpiredReservations melepas reservasi ACTIVE yang sudah melewati ExpiresAt.
+// Order-nya tetap PENDING; jika dibayar belakangan stok dikurangi ulang saat commit.
+// Mengembalikan jumlah reservasi yang dilepas.
+func (s *productService) ReleaseExpiredReservations() (int, error) {
+	reservations, err := s.reservations.FindExpiredActive(time.Now(), reservationReleaseBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for i := range reservations {
+		r := &reservations[i]
+		var ok bool
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			ok, err = s.releaseReservation(tx, r)
+			return err
+		})
+		if err != nil {
+			logger.Error().Err(err).Uint("reservation_id", r.ID).Uint("order_id", r.OrderID).Msg("Failed to release expired stock reservation")
+			continue
+		}
+		if ok {
+			released++
+			s.NotifyBackInStock(r.ProductID)
+		}
+	}
+	return released, nil
+}
+
+// RunReservationWorker menjalankan ReleaseExpiredReservations secara berkala sampai ctx dibatalkan.
+// Dijalankan sebagai goroutine dari main.go.
+func (s *productService) RunReservationWorker(ctx context.Context, interval time.Duration) {
+	if s.reservationTTL <= 0 || interval <= 0 {
+		logger.Info().Msg("Stock reservation worker disabled")
+		return
+	}
+
+	logger.Info().Dur("ttl", s.reservationTTL).Dur("interval", interval).Msg("Stock reservation worker started")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if released, err := s.ReleaseExpiredReservations(); err != nil {
+				logger.Error().Err(err).Msg("Stock reservation release run failed")
+			} else if released > 0 {
+				logger.Info().Int("released", released).Msg("Released expired stock reservations")
+			}
+		case <-ctx.Done():
+			logger.Info().Msg("Stock reservation worker stopped")
+			return
+		}
+	}
+}
+
+// releaseReservation menandai reservasi RELEASED dan mengembalikan stok yang ditahan.
+// Mengembalikan false tanpa error jika reservasi sudah diproses lebih dulu.
+func (s *productService) releaseReservation(tx *gorm.DB, r *entity.StockReservation) (bool, error) {
+	ok, err := s.reservations.WithTx(tx).UpdateStatusIf(r.ID, entity.ReservationStatusActive, entity.ReservationStatusReleased)
+	if err != nil || !ok {
+		return false, err
+	}
+	r.Status = entity.ReservationStatusReleased
+	if err := s.releaseReservedCounter(tx, r); err != nil {
+		return false, err
+	}
+	s.invalidateProductCache(r.ProductID)
+	return true, nil
+}
+
+// releaseReservedCounter mengurangi reserved_stock varian/produk sebesar quantity reservasi
+func (s *productService) releaseReservedCounter(tx *gorm.DB, r *entity.StockReservation) error {
+	productRepo := s.productRepo.WithTx(tx)
+	if r.VariantID != nil {
+		if err := s.variantRepo.WithTx(tx).ReleaseReservedStock(*r.VariantID, r.Quantity); err != nil {
+			return err
+		}
+		return productRepo.SyncStockFromVariants(r.ProductID)
+	}
+	return productRepo.ReleaseReservedStock(r.ProductID, r.Quantity)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/product/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestReserveStock_LimitsAvailableStock(t *testing.T) {
+	svc, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 5, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return svc.ReserveStockTx(tx, 1, product.ID, nil, 4)
+	}))
+
+	// Reservasi kedua melebihi stok yang tersedia meskipun stok fisik masih 5
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return svc.ReserveStockTx(tx, 2, product.ID, nil, 2)
+	})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	resp, err := svc.GetProduct(product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, resp.Stock)
+	assert.Equal(t, 1, resp.AvailableStock)
+
+	// Release mengembalikan stok yang bisa dijual tanpa mengubah stok fisik
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		reserved, err := svc.ReleaseReservationsTx(tx, 1)
+		assert.True(t, reserved)
+		return err
+	}))
+	var reloaded entity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 5, reloaded.Stock)
+	assert.Zero(t, reloaded.ReservedStock)
+
+	// Order tanpa reservasi dilaporkan agar pemanggil mengembalikan stok dengan cara lama
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		reserved, err := svc.ReleaseReservationsTx(tx, 99)
+		assert.False(t, reserved)
+		return err
+	}))
+}
+
+func TestReleaseExpiredReservations_ThenCommitReducesAgain(t *testing.T) {
+	svc, db := setupProductService(t)
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 5, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return svc.ReserveStockTx(tx, 1, product.ID, nil, 3)
+	}))
+	require.NoError(t, db.Model(&entity.StockReservation{}).Where("order_id = ?", 1).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	released, err := svc.ReleaseExpiredReservations()
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+
+	var reloaded entity.Product
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Zero(t, reloaded.ReservedStock)
+
+	// Reservasi yang sudah dilepas tidak dilepas dua kali
+	released, err = svc.ReleaseExpiredReservations()
+	require.NoError(t, err)
+	assert.Zero(t, released)
+
+	// Order yang dibayar setelah reservasinya kedaluwarsa tetap mengurangi stok fisik
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		_, err := svc.CommitReservationsTx(tx, 1, StockChange{Reason: entity.InventoryReasonCheckout})
+		return err
+	}))
+	require.NoError(t, db.First(&reloaded, product.ID).Error)
+	assert.Equal(t, 2, reloaded.Stock)
+	assert.Zero(t, reloaded.ReservedStock)
+
+	var reservation entity.StockReservation
+	require.NoError(t, db.Where("order_id = ?", 1).First(&reservation).Error)
+	assert.Equal(t, entity.ReservationStatusCommitted, reservation.Status)
+}
+
+func TestCommitReservationsTx_NotifiesLowStockOnlyAfterCommit(t *testing.T) {
+	db := setupProductDB(t)
+	notifier := &recordingStockNotifier{}
+	svc := NewProductService(ProductServiceDeps{DB: db, StockNotifier: notifier, LowStockThreshold: 5})
+	product := &entity.Product{Name: "Laptop", SKU: "LAPTOP", Price: money.FromFloat(100), Stock: 8, SellerID: 7, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return svc.ReserveStockTx(tx, 1, product.ID, nil, 4)
+	}))
+
+	// Transaction yang di-rollback tidak mengirim notifikasi
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if _, err := svc.CommitReservationsTx(tx, 1, StockChange{Reason: entity.InventoryReasonCheckout}); err != nil {
+			return err
+		}
+		return errors.New("payment update failed")
+	})
+	require.Error(t, err)
+	assert.Empty(t, notifier.notified)
+
+	var notifyLowStock func()
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		notifyLowStock, err = svc.CommitReservationsTx(tx, 1, StockChange{Reason: entity.InventoryReasonCheckout})
+		return err
+	}))
+	assert.Empty(t, notifier.notified)
+	notifyLowStock()
+	assert.Equal(t, []uint{product.ID}, notifier.notified)
+}
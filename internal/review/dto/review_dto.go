@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+
+// CreateReviewRequest untuk request membuat review
+type CreateReviewRequest struct {
+	Rating  int    `json:"rating" binding:"required,gte=1,lte=5"`
+	Comment string `json:"comment" binding:"max=1000"`
+}
+
+// ReviewResponse untuk response data review
+type ReviewResponse struct {
+	ID        uint   `json:"id"`
+	ProductID uint   `json:"product_id"`
+	UserID    uint   `json:"user_id"`
+	Rating    int    `json:"rating"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ReviewListResponse untuk response list review dengan pagination
+type ReviewListResponse struct {
+	Reviews []ReviewResponse `json:"reviews"`
+	pagination.Meta
+}
+
+// ReviewQueryParams untuk pagination
+type ReviewQueryParams struct {
+	Page  int `form:"page,default=1"`
+	Limit int `form:"limit"`
+}
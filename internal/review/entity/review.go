@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Rating bounds
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// Review entity untuk tabel reviews
+type Review struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	ProductID uint           `gorm:"index;not null" json:"product_id"`
+	UserID    uint           `gorm:"index;not null" json:"user_id"`
+	Rating    int            `gorm:"not null" json:"rating"`
+	Comment   string         `gorm:"type:text" json:"comment,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName menentukan nama tabel di database
+func (Review) TableName() string {
+	return "reviews"
+}
+
+// IsOwner mengecek apakah user adalah penulis review
+func (r *Review) IsOwner(userID uint) bool {
+	return r.UserID == userID
+}
+
+// IsValidRating memvalidasi rating dalam rentang 1-5
+func IsValidRating(rating int) bool {
+	return rating >= MinRating && rating <= MaxRating
+}
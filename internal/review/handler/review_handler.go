@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	authEntity "github.com/akbarwjyy/go-commerce-api/internal/auth/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
+	"github.com/akbarwjyy/go-commerce-api/internal/review/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/review/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewHandler menangani HTTP request untuk review produk
+type ReviewHandler struct {
+	reviewService service.ReviewService
+}
+
+// NewReviewHandler membuat instance baru ReviewHandler
+func NewReviewHandler(reviewService service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{reviewService: reviewService}
+}
+
+// CreateReview godoc
+// @Summary      Create product review
+// @Description  Review a product (only buyers with a COMPLETED order containing the product)
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Product ID"
+// @Param        request body dto.CreateReviewRequest true "Create review request"
+// @Success      201 {object} response.APIResponse{data=dto.ReviewResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      409 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /products/{id}/reviews [post]
+func (h *ReviewHandler) CreateReview(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+
+	productID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.reviewService.CreateReview(userID.(uint), uint(productID), &req)
+	if err != nil {
+		switch err {
+		case service.ErrProductNotFound:
+			response.NotFound(ctx, "Product not found")
+		case service.ErrInvalidRating:
+			response.BadRequest(ctx, "Rating must be between 1 and 5", nil)
+		case service.ErrNotEligible:
+			response.Forbidden(ctx, "Only buyers with a completed order can review this product")
+		case service.ErrAlreadyReviewed:
+			response.Error(ctx, http.StatusConflict, "You have already reviewed this product", nil)
+		default:
+			response.InternalServerError(ctx, "Failed to create review", err.Error())
+		}
+		return
+	}
+
+	response.Created(ctx, "Review created successfully", result)
+}
+
+// GetReviewsByProduct godoc
+// @Summary      Get product reviews
+// @Description  Get reviews of a product with pagination
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Product ID"
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Success      200 {object} response.APIResponse{data=dto.ReviewListResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /products/{id}/reviews [get]
+func (h *ReviewHandler) GetReviewsByProduct(ctx *gin.Context) {
+	productID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid product ID", nil)
+		return
+	}
+
+	var params dto.ReviewQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.reviewService.GetReviewsByProduct(uint(productID), &params)
+	if err != nil {
+		if err == service.ErrProductNotFound {
+			response.NotFound(ctx, "Product not found")
+			return
+		}
+		response.InternalServerError(ctx, "Failed to get reviews", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Reviews retrieved successfully", result)
+}
+
+// DeleteReview godoc
+// @Summary      Delete review
+// @Description  Delete a review (author or admin)
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Review ID"
+// @Success      200 {object} response.APIResponse
+// @Failure      400 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /reviews/{id} [delete]
+func (h *ReviewHandler) DeleteReview(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+	userRole, _ := ctx.Get("userRole")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid review ID", nil)
+		return
+	}
+
+	isAdmin := userRole.(string) == authEntity.RoleAdmin
+	if err := h.reviewService.DeleteReview(userID.(uint), uint(id), isAdmin); err != nil {
+		switch err {
+		case service.ErrReviewNotFound:
+			response.NotFound(ctx, "Review not found")
+		case service.ErrUnauthorized:
+			response.Forbidden(ctx, "You are not authorized to delete this review")
+		default:
+			response.InternalServerError(ctx, "Failed to delete review", err.Error())
+		}
+		return
+	}
+
+	response.OK(ctx, "Review deleted successfully", nil)
+}
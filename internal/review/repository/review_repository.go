@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/review/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/review/entity"
+	"gorm.io/gorm"
+)
+
+// RatingSummary berisi agregat rating sebuah produk
+type RatingSummary struct {
+	Average float64
+	Count   int
+}
+
+// ReviewRepository interface untuk akses data review
+type ReviewRepository interface {
+	Create(review *entity.Review) error
+	FindByID(id uint) (*entity.Review, error)
+	FindByProductID(productID uint, params *dto.ReviewQueryParams) ([]entity.Review, int64, error)
+	ExistsByUserAndProduct(userID uint, productID uint) (bool, error)
+	GetRatingSummary(productID uint) (*RatingSummary, error)
+	Delete(id uint) error
+}
+
+// reviewRepository implementasi ReviewRepository
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository membuat instance baru ReviewRepository
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+// Create menyimpan review baru ke database
+func (r *reviewRepository) Create(review *entity.Review) error {
+	return r.db.Create(review).Error
+}
+
+// FindByID mencari review berdasarkan ID
+func (r *reviewRepository) FindByID(id uint) (*entity.Review, error) {
+	var review entity.Review
+	if err := r.db.First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// FindByProductID mengambil review sebuah produk dengan pagination
+func (r *reviewRepository) FindByProductID(productID uint, params *dto.ReviewQueryParams) ([]entity.Review, int64, error) {
+	var reviews []entity.Review
+	var total int64
+
+	query := r.db.Model(&entity.Review{}).Where("product_id = ?", productID)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (params.Page - 1) * params.Limit
+	if err := query.Order("created_at DESC").Offset(offset).Limit(params.Limit).Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// ExistsByUserAndProduct mengecek apakah user sudah mereview produk
+func (r *reviewRepository) ExistsByUserAndProduct(userID uint, productID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.Review{}).
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetRatingSummary menghitung rata-rata rating dan jumlah review produk
+func (r *reviewRepository) GetRatingSummary(productID uint) (*RatingSummary, error) {
+	var summary RatingSummary
+	if err := r.db.Model(&entity.Review{}).
+		Select("COALESCE(AVG(rating), 0) AS average, COUNT(*) AS count").
+		Where("product_id = ?", productID).
+		Scan(&summary).Error; err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// Delete menghapus review (soft delete)
+func (r *reviewRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.Review{}, id).Error
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	orderService "github.com/akbarwjyy/go-commerce-api/internal/order/service"
+	productService "github.com/akbarwjyy/go-commerce-api/internal/product/service"
+	"github.com/akbarwjyy/go-commerce-api/internal/review/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/review/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/review/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// Common errors
+var (
+	ErrReviewNotFound  = errors.New("review not found")
+	ErrProductNotFound = errors.New("product not found")
+	ErrInvalidRating   = errors.New("rating must be between 1 and 5")
+	ErrNotEligible     = errors.New("only buyers with a completed order can review this product")
+	ErrAlreadyReviewed = errors.New("you have already reviewed this product")
+	ErrUnauthorized    = errors.New("you are not authorized to perform this action")
+)
+
+// ReviewService interface untuk business logic review
+type ReviewService interface {
+	CreateReview(userID uint, productID uint, req *dto.CreateReviewRequest) (*dto.ReviewResponse, error)
+	GetReviewsByProduct(productID uint, params *dto.ReviewQueryParams) (*dto.ReviewListResponse, error)
+	DeleteReview(userID uint, reviewID uint, isAdmin bool) error
+}
+
+// reviewService implementasi ReviewService
+type reviewService struct {
+	reviewRepo     repository.ReviewRepository
+	productService productService.ProductService
+	orderService   orderService.OrderService
+}
+
+// NewReviewService membuat instance baru ReviewService
+func NewReviewService(
+	reviewRepo repository.ReviewRepository,
+	productSvc productService.ProductService,
+	orderSvc orderService.OrderService,
+) ReviewService {
+	return &reviewService{
+		reviewRepo:     reviewRepo,
+		productService: productSvc,
+		orderService:   orderSvc,
+	}
+}
+
+// CreateReview membuat review untuk produk yang sudah dibeli user
+func (s *reviewService) CreateReview(userID uint, productID uint, req *dto.CreateReviewRequest) (*dto.ReviewResponse, error) {
+	if !entity.IsValidRating(req.Rating) {
+		return nil, ErrInvalidRating
+	}
+
+	if _, err := s.productService.GetProductByID(productID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	// Hanya pembeli dengan order COMPLETED yang berisi produk ini yang boleh mereview
+	eligible, err := s.orderService.HasCompletedOrderWithProduct(userID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !eligible {
+		return nil, ErrNotEligible
+	}
+
+	reviewed, err := s.reviewRepo.ExistsByUserAndProduct(userID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewed {
+		return nil, ErrAlreadyReviewed
+	}
+
+	review := &entity.Review{
+		ProductID: productID,
+		UserID:    userID,
+		Rating:    req.Rating,
+		Comment:   req.Comment,
+	}
+
+	if err := s.reviewRepo.Create(review); err != nil {
+		return nil, err
+	}
+
+	if err := s.syncRatingSummary(productID); err != nil {
+		return nil, err
+	}
+
+	return s.toReviewResponse(review), nil
+}
+
+// GetReviewsByProduct mengambil review sebuah produk dengan pagination
+func (s *reviewService) GetReviewsByProduct(productID uint, params *dto.ReviewQueryParams) (*dto.ReviewListResponse, error) {
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+
+	if _, err := s.productService.GetProductByID(productID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	reviews, total, err := s.reviewRepo.FindByProductID(productID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewResponses []dto.ReviewResponse
+	for _, r := range reviews {
+		reviewResponses = append(reviewResponses, *s.toReviewResponse(&r))
+	}
+
+	return &dto.ReviewListResponse{
+		Reviews: reviewResponses,
+		Meta:    pagination.BuildMeta(total, params.Page, params.Limit),
+	}, nil
+}
+
+// DeleteReview menghapus review (penulis review atau admin)
+func (s *reviewService) DeleteReview(userID uint, reviewID uint, isAdmin bool) error {
+	review, err := s.reviewRepo.FindByID(reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrReviewNotFound
+		}
+		return err
+	}
+
+	if !isAdmin && !review.IsOwner(userID) {
+		return ErrUnauthorized
+	}
+
+	if err := s.reviewRepo.Delete(review.ID); err != nil {
+		return err
+	}
+
+	return s.syncRatingSummary(review.ProductID)
+}
+
+// Helper Functions
+
+// syncRatingSummary menghitung ulang rating produk dan menyimpannya di Product Module
+func (s *reviewService) syncRatingSummary(productID uint) error {
+	summary, err := s.reviewRepo.GetRatingSummary(productID)
+	if err != nil {
+		return err
+	}
+	return s.productService.UpdateRatingSummary(productID, summary.Average, summary.Count)
+}
+
+func (s *reviewService) toReviewResponse(r *entity.Review) *dto.ReviewResponse {
+	return &dto.ReviewResponse{
+		ID:        r.ID,
+		ProductID: r.ProductID,
+		UserID:    r.UserID,
+		Rating:    r.Rating,
+		Comment:   r.Comment,
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+}
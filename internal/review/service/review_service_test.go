@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/review/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Review Entity Methods
+func TestReviewEntity_IsOwner(t *testing.T) {
+	review := &entity.Review{ID: 1, UserID: 10}
+
+	assert.True(t, review.IsOwner(10))
+	assert.False(t, review.IsOwner(20))
+}
+
+func TestIsValidRating(t *testing.T) {
+	tests := []struct {
+		rating int
+		valid  bool
+	}{
+		{0, false},
+		{1, true},
+		{3, true},
+		{5, true},
+		{6, false},
+		{-1, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.valid, entity.IsValidRating(tt.rating), "rating %d", tt.rating)
+	}
+}
+
+// Test Error Constants
+func TestReviewErrors(t *testing.T) {
+	assert.NotNil(t, ErrReviewNotFound)
+	assert.NotNil(t, ErrNotEligible)
+	assert.NotNil(t, ErrAlreadyReviewed)
+}
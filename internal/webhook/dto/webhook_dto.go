@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+)
+
+// CreateWebhookRequest untuk request pendaftaran webhook.
+// Secret kosong akan dibuatkan otomatis dan hanya ditampilkan sekali di response.
+type CreateWebhookRequest struct {
+	URL      string   `json:"url" binding:"required,url,max=500" example:"https://example.com/hooks/commerce"`
+	Events   []string `json:"events" binding:"required,min=1,dive,oneof=order.status_changed payment.succeeded payment.failed" example:"order.status_changed,payment.succeeded"`
+	Secret   string   `json:"secret" binding:"omitempty,min=16,max=255"`
+	IsActive *bool    `json:"is_active"`
+}
+
+// UpdateWebhookRequest untuk request update webhook; field kosong tidak diubah
+type UpdateWebhookRequest struct {
+	URL      string   `json:"url" binding:"omitempty,url,max=500"`
+	Events   []string `json:"events" binding:"omitempty,min=1,dive,oneof=order.status_changed payment.succeeded payment.failed"`
+	Secret   string   `json:"secret" binding:"omitempty,min=16,max=255"`
+	IsActive *bool    `json:"is_active"`
+}
+
+// WebhookResponse untuk response data webhook
+type WebhookResponse struct {
+	ID        uint     `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Secret    string   `json:"secret,omitempty"` // hanya diisi saat webhook dibuat
+	IsActive  bool     `json:"is_active"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// WebhookListResponse untuk response list webhook dengan pagination
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+	pagination.Meta
+}
+
+// WebhookQueryParams untuk pagination list webhook dan dead letter
+type WebhookQueryParams struct {
+	Page  int `form:"page,default=1"`
+	Limit int `form:"limit"`
+}
+
+// DeadLetterQueryParams untuk filter list dead letter
+type DeadLetterQueryParams struct {
+	WebhookID uint `form:"webhook_id"`
+	Page      int  `form:"page,default=1"`
+	Limit     int  `form:"limit"`
+}
+
+// DeadLetterResponse untuk response delivery webhook yang gagal permanen
+type DeadLetterResponse struct {
+	ID             uint   `json:"id"`
+	WebhookID      uint   `json:"webhook_id"`
+	DeliveryID     string `json:"delivery_id"`
+	Event          string `json:"event"`
+	URL            string `json:"url"`
+	Payload        string `json:"payload"`
+	Attempts       int    `json:"attempts"`
+	LastStatusCode int    `json:"last_status_code,omitempty"`
+	LastError      string `json:"last_error"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// DeadLetterListResponse untuk response list dead letter dengan pagination
+type DeadLetterListResponse struct {
+	DeadLetters []DeadLetterResponse `json:"dead_letters"`
+	pagination.Meta
+}
+
+// EventPayload adalah body JSON yang dikirim ke URL webhook
+type EventPayload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt string      `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// OrderStatusChangedData adalah data event order.status_changed
+type OrderStatusChangedData struct {
+	OrderID     uint        `json:"order_id"`
+	UserID      uint        `json:"user_id"` // 0 untuk order guest
+	FromStatus  string      `json:"from_status"`
+	ToStatus    string      `json:"to_status"`
+	TotalAmount money.Money `json:"total_amount" swaggertype:"string" example:"250000.00"`
+	Currency    string      `json:"currency" example:"IDR"`
+}
+
+// PaymentEventData adalah data event payment.succeeded dan payment.failed
+type PaymentEventData struct {
+	PaymentID     uint        `json:"payment_id"`
+	OrderID       uint        `json:"order_id"`
+	UserID        uint        `json:"user_id"`
+	TransactionID string      `json:"transaction_id"`
+	Amount        money.Money `json:"amount" swaggertype:"string" example:"250000.00"`
+	Currency      string      `json:"currency" example:"IDR"`
+	Method        string      `json:"method"`
+	Status        string      `json:"status"`
+	FailedReason  string      `json:"failed_reason,omitempty"`
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Event yang bisa di-subscribe oleh webhook
+const (
+	EventOrderStatusChanged = "order.status_changed"
+	EventPaymentSucceeded   = "payment.succeeded"
+	EventPaymentFailed      = "payment.failed"
+)
+
+// IsValidEvent mengecek apakah event didukung
+func IsValidEvent(event string) bool {
+	switch event {
+	case EventOrderStatusChanged, EventPaymentSucceeded, EventPaymentFailed:
+		return true
+	}
+	return false
+}
+
+// WebhookEvents menyimpan daftar event yang di-subscribe sebagai JSON array
+type WebhookEvents []string
+
+// Value mengimplementasikan driver.Valuer
+func (e WebhookEvents) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan mengimplementasikan sql.Scanner
+func (e *WebhookEvents) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*e = WebhookEvents{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for WebhookEvents")
+	}
+	return json.Unmarshal(data, e)
+}
+
+// Webhook entity untuk tabel webhooks (endpoint eksternal yang menerima notifikasi event)
+type Webhook struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	URL       string         `gorm:"size:500;not null" json:"url"`
+	Events    WebhookEvents  `gorm:"type:jsonb;not null" json:"events"`
+	Secret    string         `gorm:"size:255;not null" json:"-"` // kunci HMAC untuk signature setiap delivery
+	IsActive  bool           `gorm:"not null" json:"is_active"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName menentukan nama tabel di database
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// Subscribes mengecek apakah webhook aktif dan berlangganan event
+func (w *Webhook) Subscribes(event string) bool {
+	if !w.IsActive {
+		return false
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// WebhookDeadLetter entity untuk tabel webhook_dead_letters.
+// Menyimpan delivery yang tetap gagal setelah seluruh percobaan ulang agar bisa ditelusuri admin.
+type WebhookDeadLetter struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	WebhookID      uint      `gorm:"index;not null" json:"webhook_id"`
+	DeliveryID     string    `gorm:"size:36;not null" json:"delivery_id"`
+	Event          string    `gorm:"size:50;not null" json:"event"`
+	URL            string    `gorm:"size:500;not null" json:"url"`
+	Payload        string    `gorm:"type:text;not null" json:"payload"`
+	Attempts       int       `gorm:"not null" json:"attempts"`
+	LastStatusCode int       `json:"last_status_code,omitempty"` // 0 jika request tidak mendapat response
+	LastError      string    `gorm:"type:text" json:"last_error"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TableName menentukan nama tabel di database
+func (WebhookDeadLetter) TableName() string {
+	return "webhook_dead_letters"
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/service"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler menangani HTTP request untuk pengelolaan webhook
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+// NewWebhookHandler membuat instance baru WebhookHandler
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// CreateWebhook godoc
+// @Summary      Create webhook
+// @Description  Register a URL that receives signed JSON notifications for the subscribed events (order.status_changed, payment.succeeded, payment.failed). Each delivery carries an X-Webhook-Signature header with the hex-encoded HMAC-SHA256 of the raw body using the webhook secret. If no secret is given one is generated; the secret is only returned in this response (Admin only)
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.CreateWebhookRequest true "Create webhook request"
+// @Success      201 {object} response.APIResponse{data=dto.WebhookResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /admin/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(ctx *gin.Context) {
+	var req dto.CreateWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.webhookService.CreateWebhook(&req)
+	if err != nil {
+		handleError(ctx, err, "Failed to create webhook")
+		return
+	}
+
+	response.Created(ctx, "Webhook created successfully", result)
+}
+
+// GetAllWebhooks godoc
+// @Summary      Get all webhooks
+// @Description  Get all registered webhooks with pagination (Admin only)
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Success      200 {object} response.APIResponse{data=dto.WebhookListResponse}
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Router       /admin/webhooks [get]
+func (h *WebhookHandler) GetAllWebhooks(ctx *gin.Context) {
+	var params dto.WebhookQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.webhookService.GetAllWebhooks(&params)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get webhooks", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Webhooks retrieved successfully", result)
+}
+
+// GetWebhook godoc
+// @Summary      Get webhook
+// @Description  Get a webhook by ID (Admin only)
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Webhook ID"
+// @Success      200 {object} response.APIResponse{data=dto.WebhookResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /admin/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid webhook ID", nil)
+		return
+	}
+
+	result, err := h.webhookService.GetWebhook(uint(id))
+	if err != nil {
+		handleError(ctx, err, "Failed to get webhook")
+		return
+	}
+
+	response.OK(ctx, "Webhook retrieved successfully", result)
+}
+
+// UpdateWebhook godoc
+// @Summary      Update webhook
+// @Description  Update the URL, subscribed events, secret or active flag of a webhook (Admin only)
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Webhook ID"
+// @Param        request body dto.UpdateWebhookRequest true "Update webhook request"
+// @Success      200 {object} response.APIResponse{data=dto.WebhookResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Failure      422 {object} response.APIResponse
+// @Router       /admin/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid webhook ID", nil)
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BindError(ctx, err)
+		return
+	}
+
+	result, err := h.webhookService.UpdateWebhook(uint(id), &req)
+	if err != nil {
+		handleError(ctx, err, "Failed to update webhook")
+		return
+	}
+
+	response.OK(ctx, "Webhook updated successfully", result)
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete webhook
+// @Description  Delete a webhook; no further events are delivered to it (Admin only)
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Webhook ID"
+// @Success      200 {object} response.APIResponse
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Failure      404 {object} response.APIResponse
+// @Router       /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid webhook ID", nil)
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(uint(id)); err != nil {
+		handleError(ctx, err, "Failed to delete webhook")
+		return
+	}
+
+	response.OK(ctx, "Webhook deleted successfully", nil)
+}
+
+// GetDeadLetters godoc
+// @Summary      Get webhook dead letters
+// @Description  Get deliveries that still failed after every retry, newest first (Admin only)
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        webhook_id query int false "Filter by webhook ID"
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Success      200 {object} response.APIResponse{data=dto.DeadLetterListResponse}
+// @Failure      400 {object} response.APIResponse
+// @Failure      401 {object} response.APIResponse
+// @Failure      403 {object} response.APIResponse
+// @Router       /admin/webhooks/dead-letters [get]
+func (h *WebhookHandler) GetDeadLetters(ctx *gin.Context) {
+	var params dto.DeadLetterQueryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.BadRequest(ctx, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.webhookService.GetDeadLetters(&params)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get webhook dead letters", err.Error())
+		return
+	}
+
+	response.OK(ctx, "Webhook dead letters retrieved successfully", result)
+}
+
+// handleError memetakan error webhook ke HTTP response
+func handleError(ctx *gin.Context, err error, fallback string) {
+	switch err {
+	case service.ErrWebhookNotFound:
+		response.NotFound(ctx, "Webhook not found")
+	case service.ErrInvalidEvent:
+		response.BadRequest(ctx, "Unsupported webhook event", nil)
+	default:
+		response.InternalServerError(ctx, fallback, err.Error())
+	}
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/entity"
+	"gorm.io/gorm"
+)
+
+// WebhookRepository interface untuk akses data webhook dan dead letter
+type WebhookRepository interface {
+	Create(webhook *entity.Webhook) error
+	FindByID(id uint) (*entity.Webhook, error)
+	FindAll(page, limit int) ([]entity.Webhook, int64, error)
+	FindActive() ([]entity.Webhook, error)
+	Update(webhook *entity.Webhook) error
+	Delete(id uint) error
+
+	CreateDeadLetter(deadLetter *entity.WebhookDeadLetter) error
+	FindDeadLetters(webhookID uint, page, limit int) ([]entity.WebhookDeadLetter, int64, error)
+	WithTx(tx *gorm.DB) WebhookRepository
+}
+
+// webhookRepository implementasi WebhookRepository
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository membuat instance baru WebhookRepository
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// WithTx mengembalikan repository dengan transaction
+func (r *webhookRepository) WithTx(tx *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: tx}
+}
+
+// Create menyimpan webhook baru
+func (r *webhookRepository) Create(webhook *entity.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+// FindByID mencari webhook berdasarkan ID
+func (r *webhookRepository) FindByID(id uint) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+	if err := r.db.First(&webhook, id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// FindAll mengambil semua webhook dengan pagination
+func (r *webhookRepository) FindAll(page, limit int) ([]entity.Webhook, int64, error) {
+	var webhooks []entity.Webhook
+	var total int64
+
+	if err := r.db.Model(&entity.Webhook{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := r.db.Order("created_at DESC").Offset(offset).Limit(limit).Find(&webhooks).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return webhooks, total, nil
+}
+
+// FindActive mengambil semua webhook aktif; filter event dilakukan di service
+// karena jumlah webhook kecil dan kolom events berupa JSON
+func (r *webhookRepository) FindActive() ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	if err := r.db.Where("is_active = ?", true).Order("id").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Update menyimpan perubahan webhook
+func (r *webhookRepository) Update(webhook *entity.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+// Delete menghapus webhook (soft delete)
+func (r *webhookRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.Webhook{}, id).Error
+}
+
+// CreateDeadLetter mencatat delivery yang gagal permanen
+func (r *webhookRepository) CreateDeadLetter(deadLetter *entity.WebhookDeadLetter) error {
+	return r.db.Create(deadLetter).Error
+}
+
+// FindDeadLetters mengambil dead letter terbaru, opsional difilter per webhook
+func (r *webhookRepository) FindDeadLetters(webhookID uint, page, limit int) ([]entity.WebhookDeadLetter, int64, error) {
+	var deadLetters []entity.WebhookDeadLetter
+	var total int64
+
+	query := r.db.Model(&entity.WebhookDeadLetter{})
+	if webhookID != 0 {
+		query = query.Where("webhook_id = ?", webhookID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&deadLetters).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return deadLetters, total, nil
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Header yang dikirim bersama setiap delivery webhook
+const (
+	// SignatureHeader berisi HMAC-SHA256 (hex) dari raw body dengan secret webhook
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// defaultDeliveryTimeout adalah batas waktu satu kali request ke URL webhook
+const defaultDeliveryTimeout = 10 * time.Second
+
+// errAbortedByShutdown dicatat di dead letter untuk retry yang dihentikan saat shutdown
+const errAbortedByShutdown = "retry aborted by shutdown"
+
+// Dispatcher mengirim event ke webhook yang berlangganan secara asynchronous.
+// Delivery yang gagal diulang dengan exponential backoff; setelah maxAttempts
+// delivery dicatat ke dead letter. Method pada Dispatcher nil aman dipanggil dan tidak melakukan apa pun.
+type Dispatcher struct {
+	webhookRepo    repository.WebhookRepository
+	client         *http.Client
+	maxAttempts    int
+	retryBaseDelay time.Duration
+
+	// Melacak goroutine delivery agar bisa di-drain saat shutdown
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewDispatcher membuat instance baru Dispatcher
+func NewDispatcher(webhookRepo repository.WebhookRepository, maxAttempts int, retryBaseDelay time.Duration, timeout time.Duration) *Dispatcher {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	if timeout <= 0 {
+		timeout = defaultDeliveryTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		webhookRepo:    webhookRepo,
+		client:         &http.Client{Timeout: timeout},
+		maxAttempts:    maxAttempts,
+		retryBaseDelay: retryBaseDelay,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+// Dispatch mengirim event beserta data ke semua webhook aktif yang berlangganan event tersebut.
+// Tidak pernah memblokir pemanggil; dipanggil setelah perubahan data di-commit.
+func (d *Dispatcher) Dispatch(event string, data interface{}) {
+	if d == nil {
+		return
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error().Interface("panic", r).Str("event", event).Msg("Webhook dispatch panicked")
+			}
+		}()
+
+		webhooks, err := d.webhookRepo.FindActive()
+		if err != nil {
+			logger.Error().Err(err).Str("event", event).Msg("Failed to load webhooks")
+			return
+		}
+
+		for _, webhook := range webhooks {
+			if !webhook.Subscribes(event) {
+				continue
+			}
+
+			deliveryID := uuid.NewString()
+			body, err := json.Marshal(dto.EventPayload{
+				ID:        deliveryID,
+				Event:     event,
+				CreatedAt: time.Now().UTC().Format(time.RFC3339),
+				Data:      data,
+			})
+			if err != nil {
+				logger.Error().Err(err).Str("event", event).Msg("Failed to encode webhook payload")
+				return
+			}
+
+			d.wg.Add(1)
+			go func(webhook entity.Webhook) {
+				defer d.wg.Done()
+				d.deliver(&webhook, event, deliveryID, body)
+			}(webhook)
+		}
+	}()
+}
+
+// Shutdown menghentikan retry yang sedang menunggu (langsung dicatat ke dead letter)
+// lalu menunggu delivery yang masih berjalan sampai ctx habis
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	if d == nil {
+		return nil
+	}
+	d.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// deliver mengirim body ke URL webhook dengan retry; gagal permanen dicatat ke dead letter
+func (d *Dispatcher) deliver(webhook *entity.Webhook, event string, deliveryID string, body []byte) {
+	var statusCode int
+	var lastErr error
+
+	attempts := 0
+	for attempts < d.maxAttempts {
+		attempts++
+		statusCode, lastErr = d.send(webhook, event, deliveryID, body)
+		if lastErr == nil {
+			logger.Info().
+				Uint("webhook_id", webhook.ID).
+				Str("event", event).
+				Str("delivery_id", deliveryID).
+				Int("attempts", attempts).
+				Msg("Webhook delivered")
+			return
+		}
+
+		logger.Warn().Err(lastErr).
+			Uint("webhook_id", webhook.ID).
+			Str("event", event).
+			Str("delivery_id", deliveryID).
+			Int("attempt", attempts).
+			Msg("Webhook delivery failed")
+
+		if attempts >= d.maxAttempts {
+			break
+		}
+
+		// Exponential backoff: base, 2x base, 4x base, ...
+		select {
+		case <-time.After(d.retryBaseDelay << (attempts - 1)):
+		case <-d.ctx.Done():
+			lastErr = fmt.Errorf("%s: %w", errAbortedByShutdown, lastErr)
+			d.deadLetter(webhook, event, deliveryID, body, attempts, statusCode, lastErr)
+			return
+		}
+	}
+
+	d.deadLetter(webhook, event, deliveryID, body, attempts, statusCode, lastErr)
+}
+
+// send melakukan satu kali POST bertanda tangan HMAC; response selain 2xx dianggap gagal
+func (d *Dispatcher) send(webhook *entity.Webhook, event string, deliveryID string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-commerce-webhooks/1.0")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(SignatureHeader, utils.SignHMACSHA256(webhook.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Body dibuang agar koneksi bisa dipakai ulang
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// deadLetter mencatat delivery yang gagal permanen
+func (d *Dispatcher) deadLetter(webhook *entity.Webhook, event string, deliveryID string, body []byte, attempts int, statusCode int, lastErr error) {
+	deadLetter := &entity.WebhookDeadLetter{
+		WebhookID:      webhook.ID,
+		DeliveryID:     deliveryID,
+		Event:          event,
+		URL:            webhook.URL,
+		Payload:        string(body),
+		Attempts:       attempts,
+		LastStatusCode: statusCode,
+		LastError:      lastErr.Error(),
+	}
+	if err := d.webhookRepo.CreateDeadLetter(deadLetter); err != nil {
+		logger.Error().Err(err).
+			Uint("webhook_id", webhook.ID).
+			Str("event", event).
+			Str("delivery_id", deliveryID).
+			Msg("Failed to record webhook dead letter")
+		return
+	}
+
+	logger.Error().
+		Uint("webhook_id", webhook.ID).
+		Str("event", event).
+		Str("delivery_id", deliveryID).
+		Int("attempts", attempts).
+		Msg("Webhook delivery moved to dead letter")
+}
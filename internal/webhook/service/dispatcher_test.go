@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/database/dbtest"
+	"github.com/akbarwjyy/go-commerce-api/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupWebhookDB(t *testing.T) *gorm.DB {
+	return dbtest.NewSQLite(t, &entity.Webhook{}, &entity.WebhookDeadLetter{})
+}
+
+func TestDispatch_SendsSignedPayloadToSubscribedWebhooks(t *testing.T) {
+	db := setupWebhookDB(t)
+	repo := repository.NewWebhookRepository(db)
+
+	received := make(chan *http.Request, 2)
+	bodies := make(chan []byte, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	require.NoError(t, repo.Create(&entity.Webhook{URL: server.URL, Events: entity.WebhookEvents{entity.EventPaymentSucceeded}, Secret: "subscriber-secret", IsActive: true}))
+	// Tidak berlangganan event ini dan tidak aktif: tidak boleh menerima apa pun
+	require.NoError(t, repo.Create(&entity.Webhook{URL: server.URL + "/other", Events: entity.WebhookEvents{entity.EventPaymentFailed}, Secret: "other-secret", IsActive: true}))
+	require.NoError(t, repo.Create(&entity.Webhook{URL: server.URL + "/inactive", Events: entity.WebhookEvents{entity.EventPaymentSucceeded}, Secret: "inactive-secret", IsActive: false}))
+
+	d := NewDispatcher(repo, 3, time.Millisecond, time.Second)
+	d.Dispatch(entity.EventPaymentSucceeded, dto.PaymentEventData{PaymentID: 9, OrderID: 4, Status: "SUCCESS"})
+	d.wg.Wait()
+
+	require.Len(t, received, 1)
+	req := <-received
+	body := <-bodies
+	assert.Equal(t, "/", req.URL.Path)
+	assert.Equal(t, entity.EventPaymentSucceeded, req.Header.Get(EventHeader))
+	assert.True(t, utils.VerifyHMACSHA256("subscriber-secret", body, req.Header.Get(SignatureHeader)))
+
+	var payload struct {
+		ID    string               `json:"id"`
+		Event string               `json:"event"`
+		Data  dto.PaymentEventData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, req.Header.Get(DeliveryHeader), payload.ID)
+	assert.Equal(t, entity.EventPaymentSucceeded, payload.Event)
+	assert.Equal(t, uint(9), payload.Data.PaymentID)
+}
+
+func TestDispatch_RetriesUntilDelivered(t *testing.T) {
+	db := setupWebhookDB(t)
+	repo := repository.NewWebhookRepository(db)
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	require.NoError(t, repo.Create(&entity.Webhook{URL: server.URL, Events: entity.WebhookEvents{entity.EventOrderStatusChanged}, Secret: "secret", IsActive: true}))
+
+	d := NewDispatcher(repo, 3, time.Millisecond, time.Second)
+	d.Dispatch(entity.EventOrderStatusChanged, dto.OrderStatusChangedData{OrderID: 1, FromStatus: "PENDING", ToStatus: "PAID"})
+	d.wg.Wait()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	var deadLetters int64
+	require.NoError(t, db.Model(&entity.WebhookDeadLetter{}).Count(&deadLetters).Error)
+	assert.Zero(t, deadLetters)
+}
+
+func TestDispatch_DeadLettersAfterMaxAttempts(t *testing.T) {
+	db := setupWebhookDB(t)
+	repo := repository.NewWebhookRepository(db)
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhook := &entity.Webhook{URL: server.URL, Events: entity.WebhookEvents{entity.EventPaymentFailed}, Secret: "secret", IsActive: true}
+	require.NoError(t, repo.Create(webhook))
+
+	d := NewDispatcher(repo, 2, time.Millisecond, time.Second)
+	d.Dispatch(entity.EventPaymentFailed, dto.PaymentEventData{PaymentID: 3, Status: "FAILED"})
+	d.wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	deadLetters, total, err := repo.FindDeadLetters(webhook.ID, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, entity.EventPaymentFailed, deadLetters[0].Event)
+	assert.Equal(t, 2, deadLetters[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, deadLetters[0].LastStatusCode)
+	assert.Contains(t, deadLetters[0].Payload, `"payment_id":3`)
+}
+
+func TestDispatcher_ShutdownDeadLettersPendingRetries(t *testing.T) {
+	db := setupWebhookDB(t)
+	repo := repository.NewWebhookRepository(db)
+
+	attempted := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		attempted <- struct{}{}
+	}))
+	defer server.Close()
+
+	require.NoError(t, repo.Create(&entity.Webhook{URL: server.URL, Events: entity.WebhookEvents{entity.EventPaymentFailed}, Secret: "secret", IsActive: true}))
+
+	// Backoff panjang: delivery pasti sedang menunggu retry saat shutdown
+	d := NewDispatcher(repo, 5, time.Hour, time.Second)
+	d.Dispatch(entity.EventPaymentFailed, dto.PaymentEventData{PaymentID: 1})
+
+	<-attempted
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, d.Shutdown(ctx))
+
+	var deadLetter entity.WebhookDeadLetter
+	require.NoError(t, db.First(&deadLetter).Error)
+	assert.Equal(t, 1, deadLetter.Attempts)
+	assert.Contains(t, deadLetter.LastError, errAbortedByShutdown)
+}
+
+func TestDispatcher_NilIsNoOp(t *testing.T) {
+	var d *Dispatcher
+	d.Dispatch(entity.EventPaymentSucceeded, nil)
+	assert.NoError(t, d.Shutdown(context.Background()))
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	paymentEntity "github.com/akbarwjyy/go-commerce-api/internal/payment/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/entity"
+	"github.com/akbarwjyy/go-commerce-api/pkg/events"
+)
+
+// SubscribeToEvents mendaftarkan dispatcher ke event bus agar event payment.succeeded
+// dan payment.failed dikirim ke webhook tanpa Payment Module mengenal webhook
+func SubscribeToEvents(bus *events.Bus, dispatcher *Dispatcher) {
+	bus.Subscribe(events.NamePaymentSucceeded, func(ctx context.Context, event events.Event) error {
+		if e, ok := event.(events.PaymentSucceeded); ok {
+			dispatcher.Dispatch(entity.EventPaymentSucceeded, dto.PaymentEventData{
+				PaymentID:     e.PaymentID,
+				OrderID:       e.OrderID,
+				UserID:        e.UserID,
+				TransactionID: e.TransactionID,
+				Amount:        e.Amount,
+				Currency:      e.Currency,
+				Method:        e.Method,
+				Status:        paymentEntity.PaymentStatusSuccess,
+			})
+		}
+		return nil
+	})
+
+	bus.Subscribe(events.NamePaymentFailed, func(ctx context.Context, event events.Event) error {
+		if e, ok := event.(events.PaymentFailed); ok {
+			dispatcher.Dispatch(entity.EventPaymentFailed, dto.PaymentEventData{
+				PaymentID:     e.PaymentID,
+				OrderID:       e.OrderID,
+				UserID:        e.UserID,
+				TransactionID: e.TransactionID,
+				Amount:        e.Amount,
+				Currency:      e.Currency,
+				Method:        e.Method,
+				Status:        paymentEntity.PaymentStatusFailed,
+				FailedReason:  e.FailedReason,
+			})
+		}
+		return nil
+	})
+}
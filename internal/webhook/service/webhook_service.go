@@ -0,0 +1,218 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/repository"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// Common errors
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrInvalidEvent    = errors.New("unsupported webhook event")
+)
+
+// WebhookService interface untuk business logic pengelolaan webhook (admin)
+type WebhookService interface {
+	CreateWebhook(req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+	GetAllWebhooks(params *dto.WebhookQueryParams) (*dto.WebhookListResponse, error)
+	GetWebhook(id uint) (*dto.WebhookResponse, error)
+	UpdateWebhook(id uint, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error)
+	DeleteWebhook(id uint) error
+	GetDeadLetters(params *dto.DeadLetterQueryParams) (*dto.DeadLetterListResponse, error)
+}
+
+// webhookService implementasi WebhookService
+type webhookService struct {
+	webhookRepo repository.WebhookRepository
+}
+
+// NewWebhookService membuat instance baru WebhookService
+func NewWebhookService(webhookRepo repository.WebhookRepository) WebhookService {
+	return &webhookService{webhookRepo: webhookRepo}
+}
+
+// CreateWebhook mendaftarkan webhook baru. Secret dibuat acak jika tidak diisi
+// dan dikembalikan sekali di response agar penerima bisa memverifikasi signature.
+func (s *webhookService) CreateWebhook(req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	events, err := normalizeEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	webhook := &entity.Webhook{
+		URL:      req.URL,
+		Events:   events,
+		Secret:   secret,
+		IsActive: req.IsActive == nil || *req.IsActive,
+	}
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, err
+	}
+
+	resp := toWebhookResponse(webhook)
+	resp.Secret = webhook.Secret
+	return resp, nil
+}
+
+// GetAllWebhooks mengambil semua webhook dengan pagination
+func (s *webhookService) GetAllWebhooks(params *dto.WebhookQueryParams) (*dto.WebhookListResponse, error) {
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+
+	webhooks, total, err := s.webhookRepo.FindAll(params.Page, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookResponses := make([]dto.WebhookResponse, 0, len(webhooks))
+	for _, w := range webhooks {
+		webhookResponses = append(webhookResponses, *toWebhookResponse(&w))
+	}
+
+	return &dto.WebhookListResponse{
+		Webhooks: webhookResponses,
+		Meta:     pagination.BuildMeta(total, params.Page, params.Limit),
+	}, nil
+}
+
+// GetWebhook mengambil webhook berdasarkan ID
+func (s *webhookService) GetWebhook(id uint) (*dto.WebhookResponse, error) {
+	webhook, err := s.findWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	return toWebhookResponse(webhook), nil
+}
+
+// UpdateWebhook mengubah URL, event, secret, atau status aktif webhook
+func (s *webhookService) UpdateWebhook(id uint, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error) {
+	webhook, err := s.findWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != "" {
+		webhook.URL = req.URL
+	}
+	if len(req.Events) > 0 {
+		events, err := normalizeEvents(req.Events)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Events = events
+	}
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	if req.IsActive != nil {
+		webhook.IsActive = *req.IsActive
+	}
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, err
+	}
+	return toWebhookResponse(webhook), nil
+}
+
+// DeleteWebhook menghapus webhook; delivery yang sedang berjalan tetap diselesaikan
+func (s *webhookService) DeleteWebhook(id uint) error {
+	if _, err := s.findWebhook(id); err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(id)
+}
+
+// GetDeadLetters mengambil delivery yang gagal permanen dengan pagination
+func (s *webhookService) GetDeadLetters(params *dto.DeadLetterQueryParams) (*dto.DeadLetterListResponse, error) {
+	params.Page, params.Limit = pagination.Normalize(params.Page, params.Limit)
+
+	deadLetters, total, err := s.webhookRepo.FindDeadLetters(params.WebhookID, params.Page, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.DeadLetterResponse, 0, len(deadLetters))
+	for _, d := range deadLetters {
+		responses = append(responses, dto.DeadLetterResponse{
+			ID:             d.ID,
+			WebhookID:      d.WebhookID,
+			DeliveryID:     d.DeliveryID,
+			Event:          d.Event,
+			URL:            d.URL,
+			Payload:        d.Payload,
+			Attempts:       d.Attempts,
+			LastStatusCode: d.LastStatusCode,
+			LastError:      d.LastError,
+			CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &dto.DeadLetterListResponse{
+		DeadLetters: responses,
+		Meta:        pagination.BuildMeta(total, params.Page, params.Limit),
+	}, nil
+}
+
+// findWebhook mencari webhook dan memetakan record not found ke ErrWebhookNotFound
+func (s *webhookService) findWebhook(id uint) (*entity.Webhook, error) {
+	webhook, err := s.webhookRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// normalizeEvents memvalidasi event dan membuang duplikat dengan urutan tetap
+func normalizeEvents(events []string) (entity.WebhookEvents, error) {
+	seen := make(map[string]bool, len(events))
+	normalized := make(entity.WebhookEvents, 0, len(events))
+	for _, event := range events {
+		if !entity.IsValidEvent(event) {
+			return nil, ErrInvalidEvent
+		}
+		if seen[event] {
+			continue
+		}
+		seen[event] = true
+		normalized = append(normalized, event)
+	}
+	return normalized, nil
+}
+
+// generateSecret membuat secret acak 32 byte (hex) untuk signature HMAC
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Helper function untuk convert entity ke response (tanpa secret)
+func toWebhookResponse(w *entity.Webhook) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    w.Events,
+		IsActive:  w.IsActive,
+		CreatedAt: w.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: w.UpdatedAt.Format(time.RFC3339),
+	}
+}
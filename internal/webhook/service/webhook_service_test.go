@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/dto"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/entity"
+	"github.com/akbarwjyy/go-commerce-api/internal/webhook/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhook_GeneratesSecretShownOnce(t *testing.T) {
+	db := setupWebhookDB(t)
+	svc := NewWebhookService(repository.NewWebhookRepository(db))
+
+	created, err := svc.CreateWebhook(&dto.CreateWebhookRequest{
+		URL:    "https://example.com/hooks",
+		Events: []string{entity.EventPaymentSucceeded, entity.EventPaymentSucceeded, entity.EventOrderStatusChanged},
+	})
+	require.NoError(t, err)
+	assert.Len(t, created.Secret, 64)
+	assert.True(t, created.IsActive)
+	assert.Equal(t, []string{entity.EventPaymentSucceeded, entity.EventOrderStatusChanged}, created.Events)
+
+	fetched, err := svc.GetWebhook(created.ID)
+	require.NoError(t, err)
+	assert.Empty(t, fetched.Secret)
+}
+
+func TestUpdateWebhook_PartialUpdate(t *testing.T) {
+	db := setupWebhookDB(t)
+	svc := NewWebhookService(repository.NewWebhookRepository(db))
+
+	created, err := svc.CreateWebhook(&dto.CreateWebhookRequest{
+		URL:    "https://example.com/hooks",
+		Events: []string{entity.EventPaymentFailed},
+		Secret: "a-long-enough-secret",
+	})
+	require.NoError(t, err)
+
+	inactive := false
+	updated, err := svc.UpdateWebhook(created.ID, &dto.UpdateWebhookRequest{IsActive: &inactive})
+	require.NoError(t, err)
+	assert.False(t, updated.IsActive)
+	assert.Equal(t, "https://example.com/hooks", updated.URL)
+	assert.Equal(t, []string{entity.EventPaymentFailed}, updated.Events)
+
+	_, err = svc.UpdateWebhook(created.ID, &dto.UpdateWebhookRequest{Events: []string{"order.created"}})
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+
+	require.NoError(t, svc.DeleteWebhook(created.ID))
+	_, err = svc.GetWebhook(created.ID)
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+}
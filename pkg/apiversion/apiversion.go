@@ -0,0 +1,68 @@
+package apiversion
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Header adalah header request untuk memilih versi bentuk response API
+const Header = "Accept-Version"
+
+// Versi response yang didukung. Semua versi memakai route /api/v1 yang sama;
+// yang berbeda hanya bentuk response endpoint tertentu.
+const (
+	V1 = "v1"
+	V2 = "v2"
+
+	// Default dipakai jika client tidak mengirim Accept-Version
+	Default = V1
+)
+
+var supported = map[string]bool{V1: true, V2: true}
+
+type contextKey struct{}
+
+// Parse menormalkan nilai Accept-Version ("v2", "V2", atau "2" menjadi "v2").
+// Nilai kosong menjadi Default; ok bernilai false jika versinya tidak didukung.
+func Parse(raw string) (version string, ok bool) {
+	version = strings.ToLower(strings.TrimSpace(raw))
+	if version == "" {
+		return Default, true
+	}
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return version, supported[version]
+}
+
+// WithVersion menyimpan versi API ke dalam context
+func WithVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, contextKey{}, version)
+}
+
+// FromContext mengambil versi API dari context, Default jika tidak ada
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return Default
+	}
+	if version, ok := ctx.Value(contextKey{}).(string); ok && version != "" {
+		return version
+	}
+	return Default
+}
+
+// AtLeast mengecek apakah versi API di context sama dengan atau lebih baru dari version,
+// untuk membentuk response berbeda per versi (mis. AtLeast(ctx, V2))
+func AtLeast(ctx context.Context, version string) bool {
+	return number(FromContext(ctx)) >= number(version)
+}
+
+// number mengambil nomor versi dari "vN" (0 jika tidak valid)
+func number(version string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return 0
+	}
+	return n
+}
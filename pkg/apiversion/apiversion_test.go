@@ -0,0 +1,24 @@
+package apiversion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext_DefaultsToV1(t *testing.T) {
+	assert.Equal(t, V1, FromContext(context.Background()))
+	assert.Equal(t, V2, FromContext(WithVersion(context.Background(), V2)))
+}
+
+func TestAtLeast(t *testing.T) {
+	v1 := WithVersion(context.Background(), V1)
+	v2 := WithVersion(context.Background(), V2)
+
+	assert.True(t, AtLeast(v1, V1))
+	assert.False(t, AtLeast(v1, V2))
+	assert.True(t, AtLeast(v2, V1))
+	assert.True(t, AtLeast(v2, V2))
+	assert.False(t, AtLeast(context.Background(), V2))
+}
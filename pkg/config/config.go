@@ -1,22 +1,56 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/akbarwjyy/go-commerce-api/pkg/pagination"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Nilai default yang hanya layak untuk development; ditolak oleh Validate
+const (
+	DefaultJWTSecret  = "your-secret-key-change-in-production"
+	DefaultDBPassword = "postgres"
+
+	// MinJWTSecretLength adalah panjang minimum JWT_SECRET (256 bit untuk HMAC-SHA256)
+	MinJWTSecretLength = 32
 )
 
+// placeholderMarker menandai secret contoh dari .env.example / docker-compose.yml
+const placeholderMarker = "change-in-production"
+
 // Config menyimpan konfigurasi aplikasi
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
+	App       AppConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	JWT       JWTConfig
+	Auth      AuthConfig
+	Payment   PaymentConfig
+	RateLimit RateLimitConfig
+	Inventory InventoryConfig
+	Order     OrderConfig
+	SMTP      SMTPConfig
+	Storage   StorageConfig
+	Webhook   WebhookConfig
 }
 
 // AppConfig untuk konfigurasi aplikasi
 type AppConfig struct {
-	Name string
-	Env  string
-	Port string
+	Name         string
+	Env          string
+	Port         string
+	BaseCurrency string // kode ISO 4217 untuk produk tanpa currency dan data lama
+
+	DefaultPageSize int // limit endpoint list jika client tidak mengirim limit
+	MaxPageSize     int // batas atas limit endpoint list
+
+	GzipMinSize int // ukuran body minimum (byte) yang dikompres gzip, negatif = kompresi mati
 }
 
 // DatabaseConfig untuk konfigurasi PostgreSQL
@@ -27,6 +61,11 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// Connection pool
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
 }
 
 // RedisConfig untuk konfigurasi Redis
@@ -35,43 +74,308 @@ type RedisConfig struct {
 	Port     string
 	Password string
 	DB       int
+	Prefix   string // namespace semua key Redis, mis. "staging" menjadi "staging:blacklist:<jti>"
 }
 
 // JWTConfig untuk konfigurasi JWT
 type JWTConfig struct {
-	Secret     string
-	ExpireHour int
+	Algorithm         string // "HS256" (default, pakai Secret) atau "RS256" (pakai pasangan key RSA)
+	Secret            string
+	ExpireMinutes     int // umur access token; dibuat singkat karena sesi diperpanjang lewat refresh token
+	RefreshExpireHour int
+
+	// RS256: private key untuk signing, public key tambahan untuk verifikasi token lama saat rotasi.
+	// Public key dari private key selalu ikut dipakai untuk verifikasi.
+	PrivateKeyFile string
+	PublicKeyFiles []string
+}
+
+// AuthConfig untuk konfigurasi hashing password dan lockout login
+type AuthConfig struct {
+	BcryptCost int // cost bcrypt untuk hash password, harus di antara bcrypt.MinCost dan bcrypt.MaxCost
+
+	LoginMaxAttempts          int // gagal login beruntun sebelum email dikunci (0 = nonaktif)
+	LoginLockoutWindowMinutes int // window penghitungan gagal login sekaligus lama cooldown
+}
+
+// JWT algorithm constants
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+)
+
+// PaymentConfig untuk konfigurasi payment gateway
+type PaymentConfig struct {
+	WebhookSecret              string // shared secret untuk verifikasi signature HMAC webhook
+	OrderExpiryMinutes         int    // order PENDING lebih tua dari ini dibatalkan otomatis (0 = nonaktif)
+	ExpiryCheckIntervalSeconds int    // interval expiry worker
+	StatusMaxWaitSeconds       int    // batas long-polling GET /payments/:id/status?wait=true (0 = tanpa menunggu)
+	StreamMaxSeconds           int    // umur maksimum koneksi SSE GET /payments/:id/stream
+
+	// Simulasi gateway (processPaymentAsync)
+	SimSuccessRate float64       // peluang payment berhasil, 0..1
+	SimMinDelay    time.Duration // delay minimum sebelum payment difinalisasi
+	SimMaxDelay    time.Duration // delay maksimum sebelum payment difinalisasi
+}
+
+// RateLimitConfig untuk konfigurasi rate limiter (request per menit per client)
+type RateLimitConfig struct {
+	AuthPerMinute int // untuk /auth/login dan /auth/register
+	APIPerMinute  int // untuk endpoint lainnya
+}
+
+// InventoryConfig untuk konfigurasi stok
+type InventoryConfig struct {
+	LowStockThreshold int // default threshold stok menipis jika produk tidak mengatur sendiri
+	ImportMaxSizeKB   int // ukuran maksimum file CSV import produk
+	CompareMaxItems   int // jumlah produk maksimum per GET /products/compare
+
+	ReservationTTLMinutes           int // reservasi stok checkout dilepas setelah ini (0 = tidak kedaluwarsa)
+	ReservationCheckIntervalSeconds int // interval worker pelepas reservasi kedaluwarsa
+}
+
+// OrderConfig untuk konfigurasi biaya order
+type OrderConfig struct {
+	ShippingFlatFee money.Money // ongkos kirim flat per order
+	TaxPercent      float64     // persentase pajak dari subtotal item (mis. 11 = 11%)
+}
+
+// SMTPConfig untuk konfigurasi pengiriman email. Host kosong = email tidak dikirim.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// StorageConfig untuk konfigurasi penyimpanan file upload (gambar produk)
+type StorageConfig struct {
+	Driver         string // "local" (default) atau "s3"
+	LocalDir       string // direktori file untuk driver local
+	PublicBaseURL  string // prefix URL publik file (local: path yang disajikan router, s3: CDN/bucket URL)
+	MaxImageSizeKB int    // ukuran maksimum gambar produk
+	S3Endpoint     string
+	S3Region       string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
 }
 
-// Load membaca konfigurasi dari environment variables
+// WebhookConfig untuk konfigurasi pengiriman webhook keluar
+type WebhookConfig struct {
+	MaxAttempts    int           // jumlah percobaan per delivery sebelum masuk dead letter
+	RetryBaseDelay time.Duration // jeda retry pertama, berlipat dua setiap percobaan berikutnya
+	Timeout        time.Duration // batas waktu satu request ke URL webhook
+}
+
+// Load membaca konfigurasi dari environment variables.
+// File .env (atau path di ENV_FILE) dibaca lebih dulu; environment asli tetap diprioritaskan.
 func Load() *Config {
+	if err := LoadEnvFile(getEnv("ENV_FILE", DefaultEnvFile)); err != nil {
+		// Logger belum diinisialisasi di tahap ini
+		fmt.Fprintf(os.Stderr, "config: failed to load env file: %v\n", err)
+	}
+
 	return &Config{
 		App: AppConfig{
 			Name: getEnv("APP_NAME", "go-commerce-api"),
 			Env:  getEnv("APP_ENV", "development"),
 			Port: getEnv("APP_PORT", "8080"),
+
+			BaseCurrency: getEnv("BASE_CURRENCY", money.DefaultCurrency),
+
+			DefaultPageSize: getEnvAsInt("DEFAULT_PAGE_SIZE", pagination.DefaultLimit),
+			MaxPageSize:     getEnvAsInt("MAX_PAGE_SIZE", pagination.MaxLimit),
+
+			GzipMinSize: getEnvAsInt("GZIP_MIN_SIZE", 1024),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5432"),
 			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
+			Password: getEnv("DB_PASSWORD", DefaultDBPassword),
 			DBName:   getEnv("DB_NAME", "go_commerce"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime: getEnvAsDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
 		},
 		Redis: RedisConfig{
 			Host:     getEnv("REDIS_HOST", "localhost"),
 			Port:     getEnv("REDIS_PORT", "6379"),
 			Password: getEnv("REDIS_PASSWORD", ""),
 			DB:       0,
+			Prefix:   getEnv("REDIS_PREFIX", ""),
 		},
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
-			ExpireHour: 24,
+			Algorithm:         strings.ToUpper(getEnv("JWT_ALGORITHM", JWTAlgorithmHS256)),
+			PrivateKeyFile:    getEnv("JWT_PRIVATE_KEY_FILE", ""),
+			PublicKeyFiles:    getEnvAsList("JWT_PUBLIC_KEY_FILES"),
+			Secret:            getEnv("JWT_SECRET", DefaultJWTSecret),
+			ExpireMinutes:     getEnvAsInt("JWT_EXPIRE_MINUTES", 15),
+			RefreshExpireHour: getEnvAsInt("JWT_REFRESH_EXPIRE_HOUR", 168),
+		},
+		Auth: AuthConfig{
+			BcryptCost: getEnvAsInt("BCRYPT_COST", bcrypt.DefaultCost),
+
+			LoginMaxAttempts:          getEnvAsInt("LOGIN_MAX_ATTEMPTS", 5),
+			LoginLockoutWindowMinutes: getEnvAsInt("LOGIN_LOCKOUT_WINDOW_MINUTES", 15),
+		},
+		Payment: PaymentConfig{
+			WebhookSecret:              getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+			OrderExpiryMinutes:         getEnvAsInt("ORDER_EXPIRY_MINUTES", 60),
+			ExpiryCheckIntervalSeconds: getEnvAsInt("ORDER_EXPIRY_CHECK_INTERVAL_SECONDS", 60),
+			StatusMaxWaitSeconds:       getEnvAsInt("PAYMENT_STATUS_MAX_WAIT_SECONDS", 30),
+			StreamMaxSeconds:           getEnvAsInt("PAYMENT_STREAM_MAX_SECONDS", 300),
+			SimSuccessRate:             getEnvAsFloat("PAYMENT_SIM_SUCCESS_RATE", 0.9),
+			SimMinDelay:                time.Duration(getEnvAsInt("PAYMENT_SIM_MIN_DELAY_MS", 2000)) * time.Millisecond,
+			SimMaxDelay:                time.Duration(getEnvAsInt("PAYMENT_SIM_MAX_DELAY_MS", 5000)) * time.Millisecond,
+		},
+		RateLimit: RateLimitConfig{
+			AuthPerMinute: getEnvAsInt("RATE_LIMIT_AUTH_PER_MINUTE", 10),
+			APIPerMinute:  getEnvAsInt("RATE_LIMIT_API_PER_MINUTE", 120),
+		},
+		Inventory: InventoryConfig{
+			LowStockThreshold: getEnvAsInt("LOW_STOCK_THRESHOLD", 5),
+			ImportMaxSizeKB:   getEnvAsInt("PRODUCT_IMPORT_MAX_SIZE_KB", 1024),
+			CompareMaxItems:   getEnvAsInt("PRODUCT_COMPARE_MAX_ITEMS", 4),
+
+			ReservationTTLMinutes:           getEnvAsInt("STOCK_RESERVATION_TTL_MINUTES", 60),
+			ReservationCheckIntervalSeconds: getEnvAsInt("STOCK_RESERVATION_CHECK_INTERVAL_SECONDS", 60),
+		},
+		Order: OrderConfig{
+			ShippingFlatFee: getEnvAsMoney("SHIPPING_FLAT_FEE", money.Zero),
+			TaxPercent:      getEnvAsFloat("TAX_PERCENT", 0),
+		},
+		Storage: StorageConfig{
+			Driver:         getEnv("STORAGE_DRIVER", "local"),
+			LocalDir:       getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+			PublicBaseURL:  getEnv("STORAGE_PUBLIC_BASE_URL", "/uploads"),
+			MaxImageSizeKB: getEnvAsInt("PRODUCT_IMAGE_MAX_SIZE_KB", 2048),
+			S3Endpoint:     getEnv("S3_ENDPOINT", ""),
+			S3Region:       getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:       getEnv("S3_BUCKET", ""),
+			S3AccessKey:    getEnv("S3_ACCESS_KEY", ""),
+			S3SecretKey:    getEnv("S3_SECRET_KEY", ""),
+		},
+		Webhook: WebhookConfig{
+			MaxAttempts:    getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 5),
+			RetryBaseDelay: time.Duration(getEnvAsInt("WEBHOOK_RETRY_BASE_DELAY_MS", 1000)) * time.Millisecond,
+			Timeout:        time.Duration(getEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", "Go-Commerce <no-reply@go-commerce.local>"),
 		},
 	}
 }
 
+// ValidationError berisi semua field konfigurasi yang tidak valid
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(e.Problems, "; "))
+}
+
+// IsProduction mengecek apakah aplikasi berjalan di environment production
+func (c *Config) IsProduction() bool {
+	return c.App.Env == "production"
+}
+
+// Validate mengecek secret dan kredensial yang masih memakai nilai default / tidak aman.
+// Mengembalikan *ValidationError yang berisi setiap field bermasalah, atau nil jika valid.
+// Pemanggil memutuskan apakah error ini fatal (production) atau cukup peringatan (development).
+func (c *Config) Validate() error {
+	var problems []string
+
+	switch c.JWT.Algorithm {
+	case "", JWTAlgorithmHS256:
+		switch {
+		case c.JWT.Secret == "":
+			problems = append(problems, "JWT_SECRET must be set")
+		case c.JWT.Secret == DefaultJWTSecret || strings.Contains(c.JWT.Secret, placeholderMarker):
+			problems = append(problems, "JWT_SECRET must not use the default value")
+		case len(c.JWT.Secret) < MinJWTSecretLength:
+			problems = append(problems, fmt.Sprintf("JWT_SECRET must be at least %d characters", MinJWTSecretLength))
+		}
+	case JWTAlgorithmRS256:
+		// Secret tidak dipakai untuk RS256
+		if c.JWT.PrivateKeyFile == "" {
+			problems = append(problems, "JWT_PRIVATE_KEY_FILE must be set when JWT_ALGORITHM is RS256")
+		}
+	default:
+		problems = append(problems, "JWT_ALGORITHM must be HS256 or RS256")
+	}
+
+	if c.Auth.BcryptCost < bcrypt.MinCost || c.Auth.BcryptCost > bcrypt.MaxCost {
+		problems = append(problems, fmt.Sprintf("BCRYPT_COST must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost))
+	}
+	if c.Auth.LoginMaxAttempts < 0 {
+		problems = append(problems, "LOGIN_MAX_ATTEMPTS must be >= 0")
+	}
+	if c.Auth.LoginMaxAttempts > 0 && c.Auth.LoginLockoutWindowMinutes <= 0 {
+		problems = append(problems, "LOGIN_LOCKOUT_WINDOW_MINUTES must be > 0 when LOGIN_MAX_ATTEMPTS is set")
+	}
+
+	if c.Database.User == "" {
+		problems = append(problems, "DB_USER must be set")
+	}
+	switch c.Database.Password {
+	case "":
+		problems = append(problems, "DB_PASSWORD must be set")
+	case DefaultDBPassword:
+		problems = append(problems, "DB_PASSWORD must not use the default value")
+	}
+
+	if !money.IsValidCurrency(c.App.BaseCurrency) {
+		problems = append(problems, "BASE_CURRENCY must be a 3-letter uppercase ISO 4217 code, e.g. IDR")
+	}
+
+	if c.App.MaxPageSize <= 0 {
+		problems = append(problems, "MAX_PAGE_SIZE must be > 0")
+	}
+	if c.App.DefaultPageSize <= 0 || c.App.DefaultPageSize > c.App.MaxPageSize {
+		problems = append(problems, "DEFAULT_PAGE_SIZE must be > 0 and not greater than MAX_PAGE_SIZE")
+	}
+
+	if c.Payment.SimSuccessRate < 0 || c.Payment.SimSuccessRate > 1 {
+		problems = append(problems, "PAYMENT_SIM_SUCCESS_RATE must be between 0 and 1")
+	}
+	if c.Payment.SimMinDelay < 0 || c.Payment.SimMaxDelay < c.Payment.SimMinDelay {
+		problems = append(problems, "PAYMENT_SIM_MIN_DELAY_MS must be >= 0 and not greater than PAYMENT_SIM_MAX_DELAY_MS")
+	}
+
+	if c.Payment.StatusMaxWaitSeconds < 0 {
+		problems = append(problems, "PAYMENT_STATUS_MAX_WAIT_SECONDS must be >= 0")
+	}
+	if c.Payment.StreamMaxSeconds <= 0 {
+		problems = append(problems, "PAYMENT_STREAM_MAX_SECONDS must be > 0")
+	}
+
+	if c.Inventory.CompareMaxItems <= 0 {
+		problems = append(problems, "PRODUCT_COMPARE_MAX_ITEMS must be > 0")
+	}
+
+	if c.Webhook.MaxAttempts < 0 {
+		problems = append(problems, "WEBHOOK_MAX_ATTEMPTS must be >= 0")
+	}
+	if c.Webhook.RetryBaseDelay < 0 {
+		problems = append(problems, "WEBHOOK_RETRY_BASE_DELAY_MS must be >= 0")
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
 // getEnv membaca env variable dengan default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -79,3 +383,54 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvAsList membaca env variable berisi daftar yang dipisah koma; item kosong diabaikan
+func getEnvAsList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvAsInt membaca env variable sebagai integer dengan default value
+func getEnvAsInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsDuration membaca env variable sebagai time.Duration (mis. "30m", "1h") dengan default value
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsFloat membaca env variable sebagai float64 dengan default value
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsMoney membaca env variable sebagai nominal uang (mis. "10.00") dengan default value
+func getEnvAsMoney(key string, defaultValue money.Money) money.Money {
+	if value := os.Getenv(key); value != "" {
+		if amount, err := money.Parse(value); err == nil {
+			return amount
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,217 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func validConfig() *Config {
+	return &Config{
+		App:       AppConfig{Env: "production", BaseCurrency: "IDR", DefaultPageSize: 10, MaxPageSize: 100},
+		Database:  DatabaseConfig{User: "commerce", Password: "s3cr3t-db-password"},
+		JWT:       JWTConfig{Secret: strings.Repeat("k", MinJWTSecretLength)},
+		Auth:      AuthConfig{BcryptCost: bcrypt.DefaultCost},
+		Payment:   PaymentConfig{StreamMaxSeconds: 300},
+		Inventory: InventoryConfig{CompareMaxItems: 4},
+	}
+}
+
+func TestValidate_AcceptsSecureConfig(t *testing.T) {
+	assert.NoError(t, validConfig().Validate())
+}
+
+func TestValidate_ListsEveryInvalidField(t *testing.T) {
+	cfg := validConfig()
+	cfg.JWT.Secret = DefaultJWTSecret
+	cfg.Database.Password = DefaultDBPassword
+	cfg.Database.User = ""
+
+	err := cfg.Validate()
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Problems, 3)
+	assert.Contains(t, err.Error(), "JWT_SECRET")
+	assert.Contains(t, err.Error(), "DB_USER")
+	assert.Contains(t, err.Error(), "DB_PASSWORD")
+}
+
+func TestValidate_RejectsShortJWTSecret(t *testing.T) {
+	cfg := validConfig()
+	cfg.JWT.Secret = "short"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least 32 characters")
+}
+
+func TestValidate_RejectsPlaceholderJWTSecret(t *testing.T) {
+	cfg := validConfig()
+	cfg.JWT.Secret = "your-super-secret-jwt-key-change-in-production"
+
+	assert.Error(t, cfg.Validate())
+}
+
+func TestValidate_RejectsInvalidBaseCurrency(t *testing.T) {
+	for _, code := range []string{"", "idr", "RUPIAH"} {
+		cfg := validConfig()
+		cfg.App.BaseCurrency = code
+
+		err := cfg.Validate()
+		require.Error(t, err, code)
+		assert.Contains(t, err.Error(), "BASE_CURRENCY")
+	}
+}
+
+func TestLoad_ReadsConnectionPoolSettings(t *testing.T) {
+	t.Setenv("ENV_FILE", filepath.Join(t.TempDir(), "missing.env"))
+	t.Setenv("DB_MAX_OPEN_CONNS", "50")
+	t.Setenv("DB_MAX_IDLE_CONNS", "5")
+	t.Setenv("DB_CONN_MAX_LIFETIME", "15m")
+
+	cfg := Load()
+	assert.Equal(t, 50, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 5, cfg.Database.MaxIdleConns)
+	assert.Equal(t, 15*time.Minute, cfg.Database.ConnMaxLifetime)
+
+	t.Setenv("DB_CONN_MAX_LIFETIME", "not-a-duration")
+	assert.Equal(t, 30*time.Minute, Load().Database.ConnMaxLifetime)
+}
+
+func TestLoad_PaymentSimulatorDefaultsAndOverrides(t *testing.T) {
+	t.Setenv("ENV_FILE", filepath.Join(t.TempDir(), "missing.env"))
+
+	cfg := Load()
+	assert.Equal(t, 0.9, cfg.Payment.SimSuccessRate)
+	assert.Equal(t, 2*time.Second, cfg.Payment.SimMinDelay)
+	assert.Equal(t, 5*time.Second, cfg.Payment.SimMaxDelay)
+
+	t.Setenv("PAYMENT_SIM_SUCCESS_RATE", "0")
+	t.Setenv("PAYMENT_SIM_MIN_DELAY_MS", "0")
+	t.Setenv("PAYMENT_SIM_MAX_DELAY_MS", "0")
+
+	cfg = Load()
+	assert.Equal(t, 0.0, cfg.Payment.SimSuccessRate)
+	assert.Equal(t, time.Duration(0), cfg.Payment.SimMinDelay)
+	assert.Equal(t, time.Duration(0), cfg.Payment.SimMaxDelay)
+}
+
+func TestValidate_RejectsInvalidPaymentSimulator(t *testing.T) {
+	cfg := validConfig()
+	cfg.Payment.SimSuccessRate = 1.5
+	cfg.Payment.SimMinDelay = 2 * time.Second
+	cfg.Payment.SimMaxDelay = time.Second
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PAYMENT_SIM_SUCCESS_RATE")
+	assert.Contains(t, err.Error(), "PAYMENT_SIM_MIN_DELAY_MS")
+}
+
+func TestLoad_ReadsJWTKeySettings(t *testing.T) {
+	t.Setenv("ENV_FILE", filepath.Join(t.TempDir(), "missing.env"))
+
+	cfg := Load()
+	assert.Equal(t, JWTAlgorithmHS256, cfg.JWT.Algorithm)
+	assert.Empty(t, cfg.JWT.PublicKeyFiles)
+	assert.Equal(t, 15, cfg.JWT.ExpireMinutes)
+
+	t.Setenv("JWT_EXPIRE_MINUTES", "5")
+	t.Setenv("JWT_ALGORITHM", "rs256")
+	t.Setenv("JWT_PRIVATE_KEY_FILE", "/keys/current.pem")
+	t.Setenv("JWT_PUBLIC_KEY_FILES", "/keys/old-1.pem, ,/keys/old-2.pem")
+
+	cfg = Load()
+	assert.Equal(t, JWTAlgorithmRS256, cfg.JWT.Algorithm)
+	assert.Equal(t, "/keys/current.pem", cfg.JWT.PrivateKeyFile)
+	assert.Equal(t, []string{"/keys/old-1.pem", "/keys/old-2.pem"}, cfg.JWT.PublicKeyFiles)
+	assert.Equal(t, 5, cfg.JWT.ExpireMinutes)
+}
+
+func TestValidate_RS256RequiresPrivateKeyInsteadOfSecret(t *testing.T) {
+	cfg := validConfig()
+	cfg.JWT.Algorithm = JWTAlgorithmRS256
+	cfg.JWT.Secret = ""
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "JWT_PRIVATE_KEY_FILE")
+	assert.NotContains(t, err.Error(), "JWT_SECRET")
+
+	cfg.JWT.PrivateKeyFile = "/keys/current.pem"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.JWT.Algorithm = "ES256"
+	assert.ErrorContains(t, cfg.Validate(), "JWT_ALGORITHM")
+}
+
+func TestLoad_BcryptCostDefaultsAndOverride(t *testing.T) {
+	t.Setenv("ENV_FILE", filepath.Join(t.TempDir(), "missing.env"))
+
+	assert.Equal(t, bcrypt.DefaultCost, Load().Auth.BcryptCost)
+
+	t.Setenv("BCRYPT_COST", "12")
+	assert.Equal(t, 12, Load().Auth.BcryptCost)
+}
+
+func TestValidate_RejectsBcryptCostOutOfRange(t *testing.T) {
+	cfg := validConfig()
+
+	cfg.Auth.BcryptCost = bcrypt.MinCost - 1
+	assert.ErrorContains(t, cfg.Validate(), "BCRYPT_COST")
+
+	cfg.Auth.BcryptCost = bcrypt.MaxCost + 1
+	assert.ErrorContains(t, cfg.Validate(), "BCRYPT_COST")
+
+	cfg.Auth.BcryptCost = bcrypt.MinCost
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_RejectsInvalidLoginLockout(t *testing.T) {
+	cfg := validConfig()
+
+	cfg.Auth.LoginMaxAttempts = -1
+	assert.ErrorContains(t, cfg.Validate(), "LOGIN_MAX_ATTEMPTS")
+
+	cfg.Auth.LoginMaxAttempts = 5
+	assert.ErrorContains(t, cfg.Validate(), "LOGIN_LOCKOUT_WINDOW_MINUTES")
+
+	cfg.Auth.LoginLockoutWindowMinutes = 15
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoad_PageSizeDefaultsAndOverrides(t *testing.T) {
+	t.Setenv("ENV_FILE", filepath.Join(t.TempDir(), "missing.env"))
+
+	cfg := Load()
+	assert.Equal(t, 10, cfg.App.DefaultPageSize)
+	assert.Equal(t, 100, cfg.App.MaxPageSize)
+
+	t.Setenv("DEFAULT_PAGE_SIZE", "50")
+	t.Setenv("MAX_PAGE_SIZE", "500")
+	cfg = Load()
+	assert.Equal(t, 50, cfg.App.DefaultPageSize)
+	assert.Equal(t, 500, cfg.App.MaxPageSize)
+}
+
+func TestValidate_RejectsDefaultPageSizeAboveMax(t *testing.T) {
+	cfg := validConfig()
+
+	cfg.App.DefaultPageSize = 200
+	assert.ErrorContains(t, cfg.Validate(), "DEFAULT_PAGE_SIZE")
+
+	cfg.App.DefaultPageSize = 0
+	assert.ErrorContains(t, cfg.Validate(), "DEFAULT_PAGE_SIZE")
+
+	cfg.App.DefaultPageSize = 10
+	cfg.App.MaxPageSize = 0
+	assert.ErrorContains(t, cfg.Validate(), "MAX_PAGE_SIZE")
+
+	cfg.App.MaxPageSize = 10
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// DefaultEnvFile adalah file .env yang dibaca jika ENV_FILE tidak diset
+const DefaultEnvFile = ".env"
+
+// LoadEnvFile membaca file KEY=VALUE dan men-set environment variable yang belum ada.
+// Variable yang sudah ada di environment asli tidak ditimpa. File yang tidak ada bukan error.
+func LoadEnvFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		key, value, ok, err := parseEnvLine(scanner.Text())
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+		}
+		if !ok {
+			continue
+		}
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// parseEnvLine mem-parsing satu baris .env. ok=false untuk baris kosong atau komentar.
+// Mendukung prefix "export", nilai dalam kutip tunggal/ganda, dan komentar "#" setelah nilai tanpa kutip.
+func parseEnvLine(line string) (key string, value string, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false, nil
+	}
+	line = strings.TrimPrefix(line, "export ")
+
+	key, value, found := strings.Cut(line, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" || strings.ContainsAny(key, " \t") {
+		return "", "", false, errors.New("expected KEY=VALUE")
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		quote := value[0]
+		end := strings.IndexByte(value[1:], quote)
+		if end < 0 {
+			return "", "", false, errors.New("unterminated quoted value")
+		}
+		value = value[1 : end+1]
+		if quote == '"' {
+			value = strings.NewReplacer(`\n`, "\n", `\"`, `"`).Replace(value)
+		}
+		return key, value, true, nil
+	}
+
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = strings.TrimSpace(value[:idx])
+	}
+	return key, value, true, nil
+}
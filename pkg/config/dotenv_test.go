@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEnvFile_RealEnvironmentTakesPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	content := `# comment
+APP_NAME=from-file
+export DOTENV_TEST_EXPORTED=exported
+DOTENV_TEST_QUOTED="hello # not a comment"
+DOTENV_TEST_SINGLE='single'
+DOTENV_TEST_INLINE=value # trailing comment
+DOTENV_TEST_EMPTY=
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	t.Setenv("APP_NAME", "from-env")
+	for _, key := range []string{"DOTENV_TEST_EXPORTED", "DOTENV_TEST_QUOTED", "DOTENV_TEST_SINGLE", "DOTENV_TEST_INLINE", "DOTENV_TEST_EMPTY"} {
+		t.Setenv(key, "")
+		require.NoError(t, os.Unsetenv(key))
+	}
+
+	require.NoError(t, LoadEnvFile(path))
+
+	assert.Equal(t, "from-env", os.Getenv("APP_NAME"))
+	assert.Equal(t, "exported", os.Getenv("DOTENV_TEST_EXPORTED"))
+	assert.Equal(t, "hello # not a comment", os.Getenv("DOTENV_TEST_QUOTED"))
+	assert.Equal(t, "single", os.Getenv("DOTENV_TEST_SINGLE"))
+	assert.Equal(t, "value", os.Getenv("DOTENV_TEST_INLINE"))
+	value, exists := os.LookupEnv("DOTENV_TEST_EMPTY")
+	assert.True(t, exists)
+	assert.Empty(t, value)
+}
+
+func TestLoadEnvFile_MissingFileIsNoop(t *testing.T) {
+	assert.NoError(t, LoadEnvFile(filepath.Join(t.TempDir(), "missing.env")))
+}
+
+func TestLoadEnvFile_RejectsMalformedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte("NOT_A_PAIR\n"), 0o600))
+
+	err := LoadEnvFile(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ":1:")
+}
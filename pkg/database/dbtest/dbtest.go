@@ -0,0 +1,34 @@
+// Package dbtest menyediakan database SQLite in-memory untuk test service dan repository.
+package dbtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NewSQLite membuka database SQLite in-memory milik satu test lalu menjalankan AutoMigrate untuk models.
+// Shared cache (dinamai sesuai t.Name()) dipakai agar koneksi root dan transaction melihat database
+// yang sama; koneksi ditutup saat test selesai.
+func NewSQLite(t testing.TB, models ...interface{}) *gorm.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if len(models) > 0 {
+		require.NoError(t, db.AutoMigrate(models...))
+	}
+	return db
+}
@@ -0,0 +1,21 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// pgUniqueViolation adalah SQLSTATE PostgreSQL untuk pelanggaran unique constraint/index
+const pgUniqueViolation = "23505"
+
+// IsUniqueViolation mengecek apakah err disebabkan pelanggaran unique constraint/index,
+// mis. dua request paralel yang sama-sama lolos pengecekan duplikat sebelum INSERT
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUniqueViolation
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/akbarwjyy/go-commerce-api/pkg/config"
 	"gorm.io/driver/postgres"
@@ -10,24 +11,41 @@ import (
 	"gorm.io/gorm/logger"
 )
 
-// NewPostgresDB membuat koneksi baru ke PostgreSQL
-func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+// NewPostgresDB membuat koneksi baru ke PostgreSQL dan mengatur connection pool.
+// Log query GORM dimatikan di production.
+func NewPostgresDB(cfg *config.DatabaseConfig, env string) (*gorm.DB, error) {
 	dsn := fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
 	)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: logger.Default.LogMode(gormLogLevel(env)),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database handle: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
 	log.Println("Successfully connected to PostgreSQL database")
 	return db, nil
 }
 
+// gormLogLevel menentukan level log query GORM berdasarkan environment
+func gormLogLevel(env string) logger.LogLevel {
+	if env == "production" {
+		return logger.Silent
+	}
+	return logger.Info
+}
+
 // AutoMigrate menjalankan auto migration untuk semua entity
 // Hanya jalankan di environment development/staging
 func AutoMigrate(db *gorm.DB, models ...interface{}) error {
@@ -37,3 +55,70 @@ func AutoMigrate(db *gorm.DB, models ...interface{}) error {
 	log.Println("Database migration completed")
 	return nil
 }
+
+// BackfillCurrency mengisi kolom currency yang masih kosong (baris lama sebelum multi-currency)
+// dengan mata uang dasar. Dijalankan setelah AutoMigrate menambahkan kolomnya.
+func BackfillCurrency(db *gorm.DB, currency string, models ...interface{}) error {
+	for _, model := range models {
+		if err := db.Model(model).Where("currency IS NULL OR currency = ''").Update("currency", currency).Error; err != nil {
+			return fmt.Errorf("failed to backfill currency: %w", err)
+		}
+	}
+	return nil
+}
+
+// ConvertMoneyToCents mengubah kolom uang lama decimal(12,2) menjadi bigint berisi sen (150000.00 menjadi 15000000).
+// Harus dijalankan sebelum AutoMigrate, karena AutoMigrate hanya meng-cast nilainya menjadi 150000 yang lalu terbaca
+// sebagai sen. Kolom yang sudah bukan numeric/decimal dilewati, sehingga aman dijalankan berulang kali.
+func ConvertMoneyToCents(db *gorm.DB, model interface{}, columns ...string) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(model) {
+		return nil
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	columnTypes, err := migrator.ColumnTypes(model)
+	if err != nil {
+		return err
+	}
+
+	for _, column := range columns {
+		if !isDecimalColumn(columnTypes, column) {
+			continue
+		}
+
+		if db.Dialector.Name() == "postgres" {
+			err = db.Exec(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE bigint USING round(%s * 100)::bigint",
+				stmt.Table, column, column)).Error
+		} else {
+			// Dialect tanpa ALTER COLUMN ... USING: konversi nilai lalu ubah tipe kolom sesuai model
+			err = db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(fmt.Sprintf("UPDATE %s SET %s = CAST(ROUND(%s * 100) AS INTEGER)",
+					stmt.Table, column, column)).Error; err != nil {
+					return err
+				}
+				return tx.Migrator().AlterColumn(model, column)
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to convert %s.%s to cents: %w", stmt.Table, column, err)
+		}
+		log.Printf("Converted %s.%s to integer cents", stmt.Table, column)
+	}
+	return nil
+}
+
+// isDecimalColumn mengecek apakah kolom masih bertipe numeric/decimal (belum dikonversi ke sen)
+func isDecimalColumn(columnTypes []gorm.ColumnType, name string) bool {
+	for _, columnType := range columnTypes {
+		if columnType.Name() != name {
+			continue
+		}
+		typeName := strings.ToLower(columnType.DatabaseTypeName())
+		return strings.Contains(typeName, "numeric") || strings.Contains(typeName, "decimal")
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/database/dbtest"
+	"github.com/akbarwjyy/go-commerce-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyPricedItem adalah bentuk tabel sebelum uang disimpan dalam sen
+type legacyPricedItem struct {
+	ID    uint
+	Name  string
+	Price float64 `gorm:"type:decimal(12,2);not null"`
+}
+
+func (legacyPricedItem) TableName() string { return "priced_items" }
+
+type pricedItem struct {
+	ID    uint
+	Name  string
+	Price money.Money `gorm:"type:bigint;not null"`
+}
+
+func (pricedItem) TableName() string { return "priced_items" }
+
+func TestConvertMoneyToCents_KeepsLegacyDecimalValues(t *testing.T) {
+	db := dbtest.NewSQLite(t, &legacyPricedItem{})
+	require.NoError(t, db.Create(&legacyPricedItem{Name: "Laptop", Price: 150000.00}).Error)
+	require.NoError(t, db.Create(&legacyPricedItem{Name: "Cable", Price: 19.99}).Error)
+
+	require.NoError(t, ConvertMoneyToCents(db, &pricedItem{}, "price"))
+	require.NoError(t, db.AutoMigrate(&pricedItem{}))
+	// Dijalankan lagi saat startup berikutnya: kolom sudah bigint sehingga nilai tidak dikali ulang
+	require.NoError(t, ConvertMoneyToCents(db, &pricedItem{}, "price"))
+
+	var items []pricedItem
+	require.NoError(t, db.Order("id").Find(&items).Error)
+	require.Len(t, items, 2)
+	assert.Equal(t, money.FromFloat(150000), items[0].Price)
+	assert.Equal(t, money.FromFloat(19.99), items[1].Price)
+}
+
+func TestConvertMoneyToCents_SkipsMissingTable(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	assert.NoError(t, ConvertMoneyToCents(db, &pricedItem{}, "price"))
+}
@@ -4,11 +4,31 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/akbarwjyy/go-commerce-api/pkg/config"
 	"github.com/redis/go-redis/v9"
 )
 
+// redisKeyPrefix ditambahkan di depan semua key dan channel Redis, diatur sekali saat startup
+var redisKeyPrefix string
+
+// SetRedisKeyPrefix mengatur namespace key Redis (REDIS_PREFIX) agar beberapa environment
+// bisa berbagi satu instance Redis. Pemisah ":" ditambahkan jika belum ada; string kosong berarti tanpa prefix.
+func SetRedisKeyPrefix(prefix string) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix != "" && !strings.HasSuffix(prefix, ":") {
+		prefix += ":"
+	}
+	redisKeyPrefix = prefix
+}
+
+// RedisKey menambahkan prefix namespace ke key atau channel Redis.
+// Semua key Redis harus dibuat lewat fungsi ini agar tidak bertabrakan antar environment.
+func RedisKey(key string) string {
+	return redisKeyPrefix + key
+}
+
 // NewRedisClient membuat koneksi baru ke Redis
 func NewRedisClient(cfg *config.RedisConfig) (*redis.Client, error) {
 	client := redis.NewClient(&redis.Options{
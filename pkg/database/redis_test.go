@@ -0,0 +1,23 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisKey_Prefix(t *testing.T) {
+	t.Cleanup(func() { SetRedisKeyPrefix("") })
+
+	assert.Equal(t, "blacklist:abc", RedisKey("blacklist:abc"))
+
+	SetRedisKeyPrefix("staging")
+	assert.Equal(t, "staging:blacklist:abc", RedisKey("blacklist:abc"))
+
+	// Pemisah tidak digandakan jika prefix sudah diakhiri ":"
+	SetRedisKeyPrefix(" shop:prod: ")
+	assert.Equal(t, "shop:prod:product:1", RedisKey("product:1"))
+
+	SetRedisKeyPrefix("")
+	assert.Equal(t, "product:1", RedisKey("product:1"))
+}
@@ -0,0 +1,31 @@
+package database
+
+import "gorm.io/gorm"
+
+// WithinTransaction menjalankan fn di dalam satu transaction: commit jika fn mengembalikan nil,
+// rollback jika fn mengembalikan error atau panic (panic diteruskan kembali setelah rollback).
+// Jika commit gagal, transaction tetap di-rollback agar koneksinya dilepas, lalu error commit dikembalikan.
+func WithinTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/database/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type txRecord struct {
+	ID   uint
+	Name string
+}
+
+func setupTxDB(t *testing.T) *gorm.DB {
+	return dbtest.NewSQLite(t, &txRecord{})
+}
+
+func countRecords(t *testing.T, db *gorm.DB) int64 {
+	var count int64
+	require.NoError(t, db.Model(&txRecord{}).Count(&count).Error)
+	return count
+}
+
+func TestWithinTransaction_CommitsOnSuccess(t *testing.T) {
+	db := setupTxDB(t)
+
+	err := WithinTransaction(db, func(tx *gorm.DB) error {
+		return tx.Create(&txRecord{Name: "kept"}).Error
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), countRecords(t, db))
+}
+
+func TestWithinTransaction_RollsBackOnError(t *testing.T) {
+	db := setupTxDB(t)
+	errFailed := errors.New("failed")
+
+	err := WithinTransaction(db, func(tx *gorm.DB) error {
+		require.NoError(t, tx.Create(&txRecord{Name: "discarded"}).Error)
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+	assert.Zero(t, countRecords(t, db))
+}
+
+func TestWithinTransaction_RollsBackAndRepanics(t *testing.T) {
+	db := setupTxDB(t)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = WithinTransaction(db, func(tx *gorm.DB) error {
+			require.NoError(t, tx.Create(&txRecord{Name: "discarded"}).Error)
+			panic("boom")
+		})
+	})
+	assert.Zero(t, countRecords(t, db))
+}
@@ -0,0 +1,82 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Handler memproses satu event. Error dikembalikan ke pemanggil Publish.
+type Handler func(ctx context.Context, event Event) error
+
+// Bus adalah event bus in-process yang sederhana. Publish berjalan synchronous: setiap handler
+// dipanggil berurutan sesuai urutan Subscribe, sehingga publisher bisa menangani kegagalan subscriber.
+// Method pada Bus nil aman dipanggil dan tidak melakukan apa pun.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	all      []Handler
+}
+
+// NewBus membuat instance baru Bus
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe mendaftarkan handler untuk event dengan nama tertentu
+func (b *Bus) Subscribe(name string, handler Handler) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// SubscribeAll mendaftarkan handler untuk semua event (mis. logging)
+func (b *Bus) SubscribeAll(handler Handler) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.all = append(b.all, handler)
+}
+
+// Publish mengirim event ke handler SubscribeAll lalu ke handler event tersebut.
+// Semua handler tetap dipanggil walau ada yang gagal; error satu-satunya dikembalikan apa adanya,
+// beberapa error digabung dengan errors.Join. Panic di handler diubah menjadi error.
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.all)+len(b.handlers[event.EventName()]))
+	handlers = append(handlers, b.all...)
+	handlers = append(handlers, b.handlers[event.EventName()]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := callHandler(ctx, handler, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// callHandler memanggil handler dan mengubah panic menjadi error agar subscriber lain tetap jalan
+func callHandler(ctx context.Context, handler Handler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("event handler for %s panicked: %v", event.EventName(), r)
+		}
+	}()
+	return handler(ctx, event)
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_PublishCallsSubscribersInOrder(t *testing.T) {
+	bus := NewBus()
+	var calls []string
+	bus.Subscribe(NameOrderPaid, func(ctx context.Context, event Event) error {
+		calls = append(calls, "paid:"+event.EventName())
+		return nil
+	})
+	bus.SubscribeAll(func(ctx context.Context, event Event) error {
+		calls = append(calls, "all:"+event.EventName())
+		return nil
+	})
+
+	assert.NoError(t, bus.Publish(context.Background(), OrderPaid{OrderID: 1}))
+	assert.NoError(t, bus.Publish(context.Background(), OrderCancelled{OrderID: 1}))
+
+	// Handler SubscribeAll selalu dipanggil lebih dulu
+	assert.Equal(t, []string{"all:order.paid", "paid:order.paid", "all:order.cancelled"}, calls)
+}
+
+func TestBus_PublishReturnsSubscriberErrors(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	bus := NewBus()
+	bus.Subscribe(NamePaymentSucceeded, func(ctx context.Context, event Event) error { return errFirst })
+	assert.Equal(t, errFirst, bus.Publish(context.Background(), PaymentSucceeded{}))
+
+	// Subscriber berikutnya tetap dipanggil dan error-nya digabung
+	called := false
+	bus.Subscribe(NamePaymentSucceeded, func(ctx context.Context, event Event) error { called = true; return errSecond })
+	err := bus.Publish(context.Background(), PaymentSucceeded{})
+	assert.True(t, called)
+	assert.ErrorIs(t, err, errFirst)
+	assert.ErrorIs(t, err, errSecond)
+}
+
+func TestBus_RecoversPanickingSubscriber(t *testing.T) {
+	bus := NewBus()
+	called := false
+	bus.Subscribe(NamePaymentFailed, func(ctx context.Context, event Event) error { panic("boom") })
+	bus.Subscribe(NamePaymentFailed, func(ctx context.Context, event Event) error { called = true; return nil })
+
+	err := bus.Publish(context.Background(), PaymentFailed{})
+	assert.ErrorContains(t, err, "panicked")
+	assert.True(t, called)
+}
+
+func TestBus_NilIsNoOp(t *testing.T) {
+	var bus *Bus
+	bus.Subscribe(NameOrderCreated, func(ctx context.Context, event Event) error { return nil })
+	bus.SubscribeAll(LogEvent)
+	assert.NoError(t, bus.Publish(context.Background(), OrderCreated{}))
+}
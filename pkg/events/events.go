@@ -0,0 +1,80 @@
+package events
+
+import "github.com/akbarwjyy/go-commerce-api/pkg/money"
+
+// Nama event yang dipublish oleh Order dan Payment Module
+const (
+	NameOrderCreated     = "order.created"
+	NameOrderPaid        = "order.paid"
+	NameOrderCancelled   = "order.cancelled"
+	NamePaymentSucceeded = "payment.succeeded"
+	NamePaymentFailed    = "payment.failed"
+)
+
+// Event adalah perubahan state yang dipublish ke Bus
+type Event interface {
+	EventName() string
+}
+
+// OrderCreated dipublish setelah checkout (termasuk guest checkout) di-commit
+type OrderCreated struct {
+	OrderID     uint        `json:"order_id"`
+	UserID      uint        `json:"user_id"` // 0 untuk guest checkout
+	TotalAmount money.Money `json:"total_amount"`
+	Currency    string      `json:"currency"`
+	ItemCount   int         `json:"item_count"`
+}
+
+// EventName mengembalikan nama event
+func (OrderCreated) EventName() string { return NameOrderCreated }
+
+// OrderPaid dipublish setelah order berpindah ke status PAID
+type OrderPaid struct {
+	OrderID     uint        `json:"order_id"`
+	UserID      uint        `json:"user_id"`
+	TotalAmount money.Money `json:"total_amount"`
+	Currency    string      `json:"currency"`
+}
+
+// EventName mengembalikan nama event
+func (OrderPaid) EventName() string { return NameOrderPaid }
+
+// OrderCancelled dipublish setelah order dibatalkan (oleh user, admin, atau karena expired)
+type OrderCancelled struct {
+	OrderID    uint   `json:"order_id"`
+	UserID     uint   `json:"user_id"`
+	FromStatus string `json:"from_status"`
+}
+
+// EventName mengembalikan nama event
+func (OrderCancelled) EventName() string { return NameOrderCancelled }
+
+// PaymentSucceeded dipublish setelah payment berstatus SUCCESS di-commit.
+// Order Module men-subscribe event ini untuk menandai order sebagai PAID.
+type PaymentSucceeded struct {
+	PaymentID     uint        `json:"payment_id"`
+	OrderID       uint        `json:"order_id"`
+	UserID        uint        `json:"user_id"`
+	TransactionID string      `json:"transaction_id"`
+	Amount        money.Money `json:"amount"`
+	Currency      string      `json:"currency"`
+	Method        string      `json:"method"`
+}
+
+// EventName mengembalikan nama event
+func (PaymentSucceeded) EventName() string { return NamePaymentSucceeded }
+
+// PaymentFailed dipublish setelah payment berstatus FAILED di-commit (ditolak gateway atau expired)
+type PaymentFailed struct {
+	PaymentID     uint        `json:"payment_id"`
+	OrderID       uint        `json:"order_id"`
+	UserID        uint        `json:"user_id"`
+	TransactionID string      `json:"transaction_id"`
+	Amount        money.Money `json:"amount"`
+	Currency      string      `json:"currency"`
+	Method        string      `json:"method"`
+	FailedReason  string      `json:"failed_reason"`
+}
+
+// EventName mengembalikan nama event
+func (PaymentFailed) EventName() string { return NamePaymentFailed }
@@ -0,0 +1,18 @@
+package events
+
+import (
+	"context"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+)
+
+// LogEvent adalah subscriber default yang mencatat setiap event sebagai log terstruktur
+// (JSON di production) beserta request ID jika ada di ctx
+func LogEvent(ctx context.Context, event Event) error {
+	logger.Info().
+		Str("request_id", logger.RequestIDFromContext(ctx)).
+		Str("event", event.EventName()).
+		Interface("data", event).
+		Msg("Event published")
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package health berisi endpoint liveness dan readiness untuk orchestrator / load balancer
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// DefaultCheckTimeout membatasi durasi setiap ping agar endpoint readiness tidak pernah hang
+const DefaultCheckTimeout = 2 * time.Second
+
+var errRedisNotConnected = errors.New("redis client is not connected")
+
+// DependencyStatus adalah hasil pengecekan satu dependency
+type DependencyStatus struct {
+	Status    string  `json:"status" example:"up"`
+	LatencyMs float64 `json:"latency_ms" example:"1.25"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// LivenessResponse untuk response /health/live
+type LivenessResponse struct {
+	App    string `json:"app" example:"go-commerce-api"`
+	Env    string `json:"env" example:"development"`
+	Status string `json:"status" example:"up"`
+}
+
+// ReadinessResponse untuk response /health/ready
+type ReadinessResponse struct {
+	Status string                      `json:"status" example:"up"`
+	Checks map[string]DependencyStatus `json:"checks"`
+}
+
+// Handler menangani endpoint health check
+type Handler struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+	appName     string
+	env         string
+	timeout     time.Duration
+}
+
+// NewHandler membuat instance baru Handler
+func NewHandler(db *gorm.DB, redisClient *redis.Client, appName string, env string) *Handler {
+	return &Handler{
+		db:          db,
+		redisClient: redisClient,
+		appName:     appName,
+		env:         env,
+		timeout:     DefaultCheckTimeout,
+	}
+}
+
+// Live godoc
+// @Summary      Liveness probe
+// @Description  Check that the process is up (does not touch dependencies)
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} response.APIResponse{data=health.LivenessResponse}
+// @Router       /health/live [get]
+func (h *Handler) Live(ctx *gin.Context) {
+	response.OK(ctx, "Service is alive", LivenessResponse{
+		App:    h.appName,
+		Env:    h.env,
+		Status: StatusUp,
+	})
+}
+
+// Ready godoc
+// @Summary      Readiness probe
+// @Description  Ping PostgreSQL and Redis; returns 503 with per-dependency status if any check fails
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} response.APIResponse{data=health.ReadinessResponse}
+// @Failure      503 {object} response.APIResponse{error=health.ReadinessResponse}
+// @Router       /health/ready [get]
+func (h *Handler) Ready(ctx *gin.Context) {
+	checks := map[string]func(context.Context) error{
+		"database": h.pingDatabase,
+		"redis":    h.pingRedis,
+	}
+
+	result := ReadinessResponse{Status: StatusUp, Checks: make(map[string]DependencyStatus, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(context.Context) error) {
+			defer wg.Done()
+			status := h.runCheck(ctx.Request.Context(), check)
+			mu.Lock()
+			result.Checks[name] = status
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	for _, status := range result.Checks {
+		if status.Status != StatusUp {
+			result.Status = StatusDown
+		}
+	}
+
+	if result.Status != StatusUp {
+		response.Error(ctx, http.StatusServiceUnavailable, "Service is not ready", result)
+		return
+	}
+	response.OK(ctx, "Service is ready", result)
+}
+
+// runCheck menjalankan satu pengecekan dengan timeout dan mencatat latency-nya
+func (h *Handler) runCheck(parent context.Context, check func(context.Context) error) DependencyStatus {
+	ctx, cancel := context.WithTimeout(parent, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	status := DependencyStatus{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		status.Status = StatusDown
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// pingDatabase melakukan ping ke koneksi database
+func (h *Handler) pingDatabase(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// pingRedis melakukan ping ke Redis; client nil berarti koneksi gagal saat startup
+func (h *Handler) pingRedis(ctx context.Context) error {
+	if h.redisClient == nil {
+		return errRedisNotConnected
+	}
+	return h.redisClient.Ping(ctx).Err()
+}
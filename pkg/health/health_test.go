@@ -0,0 +1,60 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/database/dbtest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupHealthRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
+	gin.SetMode(gin.TestMode)
+	db := dbtest.NewSQLite(t)
+
+	h := NewHandler(db, nil, "go-commerce-api", "test")
+	router := gin.New()
+	router.GET("/health/live", h.Live)
+	router.GET("/health/ready", h.Ready)
+	return router, db
+}
+
+func TestLive_AlwaysUp(t *testing.T) {
+	router, _ := setupHealthRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReady_ReportsEachDependency(t *testing.T) {
+	router, db := setupHealthRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var body struct {
+		Error ReadinessResponse `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, StatusDown, body.Error.Status)
+	assert.Equal(t, StatusUp, body.Error.Checks["database"].Status)
+	assert.Equal(t, StatusDown, body.Error.Checks["redis"].Status)
+	assert.NotEmpty(t, body.Error.Checks["redis"].Error)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, StatusDown, body.Error.Checks["database"].Status)
+}
@@ -0,0 +1,22 @@
+package logger
+
+import "context"
+
+// RequestIDKey adalah key request ID di gin.Context
+const RequestIDKey = "request_id"
+
+type requestIDContextKey struct{}
+
+// WithRequestID menyimpan request ID ke dalam context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext mengambil request ID dari context, string kosong jika tidak ada
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
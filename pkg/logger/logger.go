@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"net/url"
 	"os"
 	"time"
 
@@ -34,7 +35,7 @@ func GinLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
-		query := c.Request.URL.RawQuery
+		query := redactQuery(c.Request.URL.RawQuery)
 
 		// Process request
 		c.Next()
@@ -51,6 +52,7 @@ func GinLogger() gin.HandlerFunc {
 		}
 
 		event.
+			Str("request_id", c.GetString(RequestIDKey)).
 			Str("method", c.Request.Method).
 			Str("path", path).
 			Str("query", query).
@@ -62,6 +64,29 @@ func GinLogger() gin.HandlerFunc {
 	}
 }
 
+// redactedQueryParams adalah query parameter yang nilainya tidak boleh tercatat di log (mis. token akses)
+var redactedQueryParams = []string{"access_token"}
+
+// redactQuery mengganti nilai query parameter sensitif dengan REDACTED
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+	// ParseQuery tetap mengembalikan pasangan yang valid meski ada bagian query yang rusak
+	values, _ := url.ParseQuery(rawQuery)
+	redacted := false
+	for _, key := range redactedQueryParams {
+		if values.Has(key) {
+			values.Set(key, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return rawQuery
+	}
+	return values.Encode()
+}
+
 // Info logs info level message
 func Info() *zerolog.Event {
 	return log.Info()
@@ -0,0 +1,13 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactQuery(t *testing.T) {
+	assert.Equal(t, "", redactQuery(""))
+	assert.Equal(t, "status=PAID&sort=amount_desc", redactQuery("status=PAID&sort=amount_desc"))
+	assert.Equal(t, "access_token=REDACTED&status=PAID", redactQuery("status=PAID&access_token=eyJhbGciOi.abc.def"))
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
+	"github.com/akbarwjyy/go-commerce-api/pkg/apiversion"
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersion membaca header Accept-Version (default v1) lalu menyimpannya di context request
+// agar handler bisa membentuk response sesuai versi lewat apiversion.FromContext.
+// Versi yang tidak didukung ditolak dengan 400.
+func APIVersion() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		version, ok := apiversion.Parse(ctx.GetHeader(apiversion.Header))
+		if !ok {
+			response.BadRequest(ctx, "Unsupported Accept-Version, use v1 or v2", nil)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(apiversion.WithVersion(ctx.Request.Context(), version))
+		// Response bergantung pada header ini, cache tidak boleh mencampur versi
+		ctx.Writer.Header().Add("Vary", apiversion.Header)
+
+		ctx.Next()
+	}
+}
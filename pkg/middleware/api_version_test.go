@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/apiversion"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAPIVersionRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(APIVersion())
+	router.GET("/ping", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, apiversion.FromContext(ctx.Request.Context()))
+	})
+	return router
+}
+
+func TestAPIVersion_StoresRequestedVersion(t *testing.T) {
+	router := newAPIVersionRouter()
+
+	for incoming, expected := range map[string]string{"": "v1", "v1": "v1", "v2": "v2", " V2 ": "v2", "2": "v2"} {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		if incoming != "" {
+			req.Header.Set(apiversion.Header, incoming)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, expected, w.Body.String(), "Accept-Version %q", incoming)
+		assert.Equal(t, apiversion.Header, w.Header().Get("Vary"))
+	}
+}
+
+func TestAPIVersion_RejectsUnsupportedVersion(t *testing.T) {
+	router := newAPIVersionRouter()
+
+	for _, incoming := range []string{"v3", "latest", "v"} {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set(apiversion.Header, incoming)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Accept-Version %q", incoming)
+	}
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// uncompressibleContentTypes sudah terkompresi (gambar, PDF, arsip) atau harus di-stream apa adanya (SSE)
+var uncompressibleContentTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"application/pdf",
+	"application/zip",
+	"application/gzip",
+	"text/event-stream",
+}
+
+// Gzip mengompres response dengan gzip jika client mengirim "Accept-Encoding: gzip"
+// dan body mencapai minSize byte. Body di bawah minSize, content type yang sudah terkompresi,
+// dan response yang di-flush sebelum mencapai minSize (SSE, download streaming) dikirim apa adanya.
+// minSize < 0 mematikan kompresi.
+func Gzip(minSize int) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if minSize < 0 || ctx.Request.Method == http.MethodHead || !acceptsGzip(ctx.Request.Header.Get("Accept-Encoding")) {
+			ctx.Next()
+			return
+		}
+
+		writer := &gzipResponseWriter{ResponseWriter: ctx.Writer, minSize: minSize}
+		ctx.Writer = writer
+		defer func() {
+			writer.finish()
+			ctx.Writer = writer.ResponseWriter
+		}()
+
+		ctx.Next()
+	}
+}
+
+// acceptsGzip mengecek apakah header Accept-Encoding mengizinkan gzip (q=0 berarti ditolak)
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		name, value, found := strings.Cut(strings.TrimSpace(params), "=")
+		if !found || strings.TrimSpace(name) != "q" {
+			return true
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return err != nil || q > 0
+	}
+	return false
+}
+
+// gzipResponseWriter menampung body sampai minSize byte sebelum memutuskan untuk mengompres,
+// sehingga header Content-Encoding hanya dikirim untuk response yang memang dikompres
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	minSize int
+	buf     bytes.Buffer
+	gz      *gzip.Writer
+	bypass  bool // diputuskan tidak dikompres, tulis langsung ke writer asli
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+	if w.bypass {
+		return w.ResponseWriter.Write(data)
+	}
+
+	if !w.compressible() {
+		if err := w.startBypass(); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf.Write(data)
+	if w.buf.Len() >= w.minSize {
+		if err := w.startGzip(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sebelum kompresi dimulai menandakan response streaming, yang dikirim tanpa kompresi
+func (w *gzipResponseWriter) Flush() {
+	if w.gz != nil {
+		w.gz.Flush()
+	} else if !w.bypass {
+		w.startBypass()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// compressible mengecek header response yang sudah di-set handler sebelum byte pertama ditulis
+func (w *gzipResponseWriter) compressible() bool {
+	status := w.ResponseWriter.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified || status == http.StatusPartialContent {
+		return false
+	}
+
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, prefix := range uncompressibleContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// startGzip mengirim header kompresi lalu menulis body yang sudah ditampung lewat gzip
+func (w *gzipResponseWriter) startGzip() error {
+	header := w.Header()
+	header.Set("Content-Encoding", "gzip")
+	header.Add("Vary", "Accept-Encoding")
+	header.Del("Content-Length")
+
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+	_, err := w.gz.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// startBypass menulis body yang sudah ditampung tanpa kompresi
+func (w *gzipResponseWriter) startBypass() error {
+	w.bypass = true
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// finish dipanggil setelah handler selesai: menutup stream gzip atau mengirim body kecil apa adanya
+func (w *gzipResponseWriter) finish() {
+	if w.gz != nil {
+		w.gz.Close()
+		return
+	}
+	if !w.bypass {
+		w.startBypass()
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGzipRouter(minSize int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Gzip(minSize))
+	router.GET("/large", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, strings.Repeat("product ", 500))
+	})
+	router.GET("/small", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	router.GET("/image", func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "image/png", make([]byte, 4096))
+	})
+	router.GET("/stream", func(ctx *gin.Context) {
+		for i := 0; i < 3; i++ {
+			ctx.SSEvent("status", strings.Repeat("x", 2048))
+			ctx.Writer.Flush()
+		}
+	})
+	router.GET("/download", func(ctx *gin.Context) {
+		ctx.Header("Content-Type", "text/csv")
+		ctx.Writer.WriteString("id,status\n")
+		ctx.Writer.Flush()
+		ctx.Writer.WriteString(strings.Repeat("1,PAID\n", 500))
+	})
+	return router
+}
+
+func serveGzip(router *gin.Engine, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGzip_CompressesLargeResponses(t *testing.T) {
+	w := serveGzip(newGzipRouter(1024), "/large", "gzip, deflate")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("product ", 500), string(body))
+}
+
+func TestGzip_SkipsSmallUnacceptedAndCompressedResponses(t *testing.T) {
+	router := newGzipRouter(1024)
+
+	w := serveGzip(router, "/small", "gzip")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.JSONEq(t, `{"ok":true}`, w.Body.String())
+
+	w = serveGzip(router, "/large", "")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, strings.Repeat("product ", 500), w.Body.String())
+
+	w = serveGzip(router, "/large", "gzip;q=0")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+
+	w = serveGzip(router, "/image", "gzip")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Len(t, w.Body.Bytes(), 4096)
+
+	// Ukuran minimum negatif mematikan kompresi
+	w = serveGzip(newGzipRouter(-1), "/large", "gzip")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+}
+
+func TestGzip_LeavesEventStreamsUncompressed(t *testing.T) {
+	w := serveGzip(newGzipRouter(1024), "/stream", "gzip")
+
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/event-stream")
+	assert.Equal(t, 3, strings.Count(w.Body.String(), "event:status"))
+	assert.True(t, w.Flushed)
+
+	// Response yang di-flush sebelum mencapai ukuran minimum tetap di-stream tanpa kompresi
+	w = serveGzip(newGzipRouter(1024), "/download", "gzip")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "id,status\n"+strings.Repeat("1,PAID\n", 500), w.Body.String())
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	assert.True(t, acceptsGzip("gzip"))
+	assert.True(t, acceptsGzip("deflate, GZIP;q=0.5"))
+	assert.False(t, acceptsGzip(""))
+	assert.False(t, acceptsGzip("deflate, br"))
+	assert.False(t, acceptsGzip("gzip;q=0"))
+	assert.False(t, acceptsGzip("gzip; q=0.000"))
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_AllowsWhenRedisIsNil(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ping", RateLimit(nil, RateLimitRule{Name: "test", Limit: 1, Window: time.Minute}), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+func TestRateLimitKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx.Request.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "ratelimit:auth:ip:10.0.0.1", rateLimitKey(ctx, "auth"))
+
+	ctx.Set("userID", uint(42))
+	assert.Equal(t, "ratelimit:auth:user:42", rateLimitKey(ctx, "auth"))
+}
+
+func TestRateLimitKey_UsesRedisPrefix(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	database.SetRedisKeyPrefix("staging")
+	t.Cleanup(func() { database.SetRedisKeyPrefix("") })
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx.Request.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "staging:ratelimit:auth:ip:10.0.0.1", rateLimitKey(ctx, "auth"))
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	assert.Equal(t, 1, retryAfterSeconds(0))
+	assert.Equal(t, 1, retryAfterSeconds(200))
+	assert.Equal(t, 2, retryAfterSeconds(1001))
+	assert.Equal(t, 60, retryAfterSeconds(60000))
+}
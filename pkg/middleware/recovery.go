@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"io"
+	"runtime/debug"
+
+	"github.com/akbarwjyy/go-commerce-api/internal/common/response"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery menangkap panic di handler, mencatatnya lewat structured logger,
+// dan mengembalikan response 500 standar.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(ctx *gin.Context, recovered any) {
+		logger.Error().
+			Str("request_id", ctx.GetString(logger.RequestIDKey)).
+			Str("method", ctx.Request.Method).
+			Str("path", ctx.Request.URL.Path).
+			Interface("panic", recovered).
+			Bytes("stack", debug.Stack()).
+			Msg("Recovered from panic")
+		response.InternalServerError(ctx, "Internal server error", nil)
+		ctx.Abort()
+	})
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader adalah header untuk meneruskan correlation ID antar service
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength membatasi panjang request ID dari client agar log tetap rapi
+const maxRequestIDLength = 128
+
+// RequestID membaca X-Request-ID dari request (atau membuat UUID baru), lalu menyimpannya
+// di gin.Context, context request, dan header response.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		ctx.Set(logger.RequestIDKey, requestID)
+		ctx.Request = ctx.Request.WithContext(logger.WithRequestID(ctx.Request.Context(), requestID))
+		ctx.Header(RequestIDHeader, requestID)
+
+		ctx.Next()
+	}
+}
+
+// isValidRequestID hanya menerima karakter ASCII yang aman ditulis ke log dan header
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
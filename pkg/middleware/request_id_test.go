@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRequestIDRouter(seen *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	router.GET("/ping", func(ctx *gin.Context) {
+		*seen = logger.RequestIDFromContext(ctx.Request.Context())
+		ctx.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestRequestID_PropagatesIncomingHeader(t *testing.T) {
+	var seen string
+	router := newRequestIDRouter(&seen)
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "abc-123", w.Header().Get(RequestIDHeader))
+	assert.Equal(t, "abc-123", seen)
+}
+
+func TestRequestID_GeneratesWhenMissingOrInvalid(t *testing.T) {
+	var seen string
+	router := newRequestIDRouter(&seen)
+
+	for _, incoming := range []string{"", "bad id\nwith newline", strings.Repeat("x", maxRequestIDLength+1)} {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		if incoming != "" {
+			req.Header.Set(RequestIDHeader, incoming)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		generated := w.Header().Get(RequestIDHeader)
+		assert.Len(t, generated, 36)
+		assert.NotEqual(t, incoming, generated)
+		assert.Equal(t, generated, seen)
+	}
+}
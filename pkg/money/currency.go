@@ -0,0 +1,13 @@
+package money
+
+import "regexp"
+
+// DefaultCurrency adalah mata uang dasar jika BASE_CURRENCY tidak diatur
+const DefaultCurrency = "IDR"
+
+var currencyCodeRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// IsValidCurrency mengecek format kode mata uang ISO 4217 (tiga huruf kapital, mis. "IDR")
+func IsValidCurrency(code string) bool {
+	return currencyCodeRegex.MatchString(code)
+}
@@ -0,0 +1,175 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money menyimpan nominal uang dalam satuan terkecil (sen) sebagai int64
+// agar perhitungan total tidak terkena error pembulatan float64.
+// Di JSON ditampilkan sebagai string desimal, misalnya "199.99".
+type Money int64
+
+// Zero adalah nominal nol
+const Zero Money = 0
+
+// FromCents membuat Money dari nilai sen
+func FromCents(cents int64) Money {
+	return Money(cents)
+}
+
+// FromFloat membuat Money dari nilai desimal, dibulatkan ke sen terdekat
+func FromFloat(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+// Parse membaca string desimal seperti "199.99" atau "-5" menjadi Money
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, fmt.Errorf("money: empty amount")
+	}
+
+	negative := false
+	if s[0] == '-' || s[0] == '+' {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	if strings.ContainsAny(s, "+-") {
+		return Zero, fmt.Errorf("money: invalid amount %q", s)
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" {
+		whole = "0"
+	}
+	if len(frac) > 2 {
+		return Zero, fmt.Errorf("money: %q has more than 2 decimal places", s)
+	}
+	frac += strings.Repeat("0", 2-len(frac))
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Zero, fmt.Errorf("money: invalid amount %q", s)
+	}
+	cents, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return Zero, fmt.Errorf("money: invalid amount %q", s)
+	}
+
+	total := units*100 + cents
+	if negative {
+		total = -total
+	}
+	return Money(total), nil
+}
+
+// Cents mengembalikan nilai dalam sen
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// Float64 mengembalikan nilai desimal (hanya untuk tampilan/perhitungan non-kritis)
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// Add menjumlahkan dua nominal
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+// Sub mengurangi nominal
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+// Mul mengalikan nominal dengan jumlah (mis. harga x quantity)
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Percent menghitung persentase dari nominal, dibulatkan ke sen terdekat
+func (m Money) Percent(percent float64) Money {
+	return Money(math.Round(float64(m) * percent / 100))
+}
+
+// String memformat nominal sebagai desimal dengan 2 angka di belakang koma
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON menulis nominal sebagai string desimal
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON menerima string desimal ("199.99") maupun angka (199.99)
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var s string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	} else {
+		s = string(data)
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// UnmarshalParam dipakai Gin untuk binding query/form parameter
+func (m *Money) UnmarshalParam(param string) error {
+	parsed, err := Parse(param)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value menyimpan nominal ke database sebagai bigint (sen)
+func (m Money) Value() (driver.Value, error) {
+	return int64(m), nil
+}
+
+// Scan membaca nominal dari kolom bigint (sen)
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = Zero
+	case int64:
+		*m = Money(v)
+	case []byte:
+		cents, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return fmt.Errorf("money: cannot scan %q", v)
+		}
+		*m = Money(cents)
+	case string:
+		cents, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("money: cannot scan %q", v)
+		}
+		*m = Money(cents)
+	default:
+		return fmt.Errorf("money: cannot scan type %T", value)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Money
+		wantErr  bool
+	}{
+		{"199.99", 19999, false},
+		{"10", 1000, false},
+		{"10.5", 1050, false},
+		{"0.07", 7, false},
+		{"-3.25", -325, false},
+		{".5", 50, false},
+		{"1.999", 0, true},
+		{"abc", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "199.99", Money(19999).String())
+	assert.Equal(t, "0.05", Money(5).String())
+	assert.Equal(t, "-1.50", Money(-150).String())
+	assert.Equal(t, "0.00", Zero.String())
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	price := FromCents(1999)
+
+	assert.Equal(t, Money(5997), price.Mul(3))
+	assert.Equal(t, Money(2999), price.Add(FromCents(1000)))
+	assert.Equal(t, Money(999), price.Sub(FromCents(1000)))
+	assert.Equal(t, Money(200), price.Percent(10))
+}
+
+func TestMoney_NoFloatDrift(t *testing.T) {
+	// 0.1 + 0.2 != 0.3 pada float64
+	total := Zero
+	total = total.Add(FromFloat(0.1))
+	total = total.Add(FromFloat(0.2))
+
+	assert.Equal(t, FromFloat(0.3), total)
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Price Money `json:"price"`
+	}{Price: 19999})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"price":"199.99"}`, string(data))
+
+	var fromString, fromNumber Money
+	require.NoError(t, json.Unmarshal([]byte(`"12.30"`), &fromString))
+	require.NoError(t, json.Unmarshal([]byte(`12.3`), &fromNumber))
+	assert.Equal(t, Money(1230), fromString)
+	assert.Equal(t, Money(1230), fromNumber)
+}
+
+func TestMoney_Scan(t *testing.T) {
+	var m Money
+
+	require.NoError(t, m.Scan(int64(4500)))
+	assert.Equal(t, Money(4500), m)
+
+	require.NoError(t, m.Scan([]byte("120")))
+	assert.Equal(t, Money(120), m)
+
+	assert.Error(t, m.Scan(1.5))
+}
@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/config"
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+)
+
+// Message adalah email plain-text yang akan dikirim
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// EmailSender interface untuk mengirim email
+type EmailSender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewEmailSender mengembalikan SMTPSender jika SMTP_HOST diisi, selain itu NoopSender
+func NewEmailSender(cfg *config.SMTPConfig) EmailSender {
+	if cfg.Host == "" {
+		return NoopSender{}
+	}
+	return NewSMTPSender(cfg)
+}
+
+// NoopSender tidak mengirim apa pun, hanya mencatat email ke log (dipakai saat SMTP belum dikonfigurasi).
+// Isi email dicatat di level debug agar flow seperti reset password tetap bisa diuji di development.
+type NoopSender struct{}
+
+// Send mencatat email yang seharusnya dikirim
+func (NoopSender) Send(ctx context.Context, msg Message) error {
+	logger.Debug().
+		Str("to", msg.To).
+		Str("subject", msg.Subject).
+		Str("body", msg.Body).
+		Msg("Email not sent: SMTP is not configured")
+	return nil
+}
@@ -0,0 +1,122 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSender mencatat email yang dikirim dan bisa dibuat lambat atau gagal
+type recordingSender struct {
+	mu      sync.Mutex
+	sent    []Message
+	block   chan struct{}
+	sendErr error
+}
+
+func (s *recordingSender) Send(ctx context.Context, msg Message) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, msg)
+	return s.sendErr
+}
+
+func (s *recordingSender) messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.sent...)
+}
+
+func TestNewEmailSender_NoopWithoutHost(t *testing.T) {
+	assert.IsType(t, NoopSender{}, NewEmailSender(&config.SMTPConfig{}))
+	assert.IsType(t, &SMTPSender{}, NewEmailSender(&config.SMTPConfig{Host: "smtp.example.com", Port: "587"}))
+}
+
+func TestNewSMTPSender_EnvelopeFromStripsDisplayName(t *testing.T) {
+	sender := NewSMTPSender(&config.SMTPConfig{Host: "smtp.example.com", Port: "587", From: "Shop <no-reply@example.com>"})
+	assert.Equal(t, "no-reply@example.com", sender.envelopeFrom)
+	assert.Equal(t, "smtp.example.com:587", sender.addr)
+}
+
+func TestBuildMessage_StripsHeaderInjection(t *testing.T) {
+	raw := string(buildMessage("no-reply@example.com", Message{
+		To:      "user@example.com",
+		Subject: "Hello\r\nBcc: attacker@example.com",
+		Body:    "line1\nline2",
+	}))
+
+	assert.Contains(t, raw, "Subject: Hello Bcc: attacker@example.com\r\n")
+	assert.NotContains(t, raw, "\r\nBcc:")
+	assert.True(t, strings.HasSuffix(raw, "\r\n\r\nline1\r\nline2"))
+}
+
+func TestUserNotifier_SendsAsynchronously(t *testing.T) {
+	sender := &recordingSender{block: make(chan struct{})}
+	n := NewUserNotifier(sender, func(userID uint) (string, error) {
+		return "user@example.com", nil
+	})
+
+	// NotifyUser harus langsung kembali walaupun sender masih tertahan
+	returned := make(chan struct{})
+	go func() {
+		n.NotifyUser(1, "Order received", "Thanks")
+		close(returned)
+	}()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatal("NotifyUser blocked on the sender")
+	}
+
+	close(sender.block)
+	require.NoError(t, n.Shutdown(context.Background()))
+
+	sent := sender.messages()
+	require.Len(t, sent, 1)
+	assert.Equal(t, Message{To: "user@example.com", Subject: "Order received", Body: "Thanks"}, sent[0])
+}
+
+func TestUserNotifier_FailuresAreSwallowed(t *testing.T) {
+	sender := &recordingSender{sendErr: errors.New("smtp down")}
+	n := NewUserNotifier(sender, func(userID uint) (string, error) {
+		return "", errors.New("user not found")
+	})
+
+	n.NotifyUser(1, "Order received", "Thanks")
+	n.NotifyEmail("user@example.com", "Reset your password", "token")
+	require.NoError(t, n.Shutdown(context.Background()))
+
+	// Lookup gagal: email tidak dikirim; Send gagal: hanya dicatat ke log
+	assert.Len(t, sender.messages(), 1)
+}
+
+func TestUserNotifier_NilIsNoop(t *testing.T) {
+	var n *UserNotifier
+	assert.NotPanics(t, func() {
+		n.NotifyUser(1, "subject", "body")
+		n.NotifyEmail("user@example.com", "subject", "body")
+	})
+	assert.NoError(t, n.Shutdown(context.Background()))
+}
+
+func TestUserNotifier_ShutdownTimesOut(t *testing.T) {
+	sender := &recordingSender{block: make(chan struct{})}
+	defer close(sender.block)
+	n := NewUserNotifier(sender, nil)
+
+	n.NotifyEmail("user@example.com", "subject", "body")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, n.Shutdown(ctx), context.DeadlineExceeded)
+}
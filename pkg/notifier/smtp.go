@@ -0,0 +1,74 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/config"
+)
+
+// SMTPSender mengirim email melalui server SMTP
+type SMTPSender struct {
+	addr         string
+	auth         smtp.Auth
+	from         string // header From, boleh berisi nama tampilan
+	envelopeFrom string // alamat saja, untuk perintah MAIL FROM
+}
+
+// NewSMTPSender membuat SMTPSender dari konfigurasi. Auth PLAIN hanya dipakai jika username diisi.
+func NewSMTPSender(cfg *config.SMTPConfig) *SMTPSender {
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	envelopeFrom := cfg.From
+	if addr, err := mail.ParseAddress(cfg.From); err == nil {
+		envelopeFrom = addr.Address
+	}
+	return &SMTPSender{
+		addr:         net.JoinHostPort(cfg.Host, cfg.Port),
+		auth:         auth,
+		from:         cfg.From,
+		envelopeFrom: envelopeFrom,
+	}
+}
+
+// Send mengirim email. net/smtp tidak mendukung context, jadi pembatalan ctx
+// hanya menghentikan penantian; koneksi yang sedang berjalan dibiarkan selesai sendiri.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(s.addr, s.auth, s.envelopeFrom, []string{msg.To}, buildMessage(s.from, msg))
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// buildMessage menyusun email RFC 5322 sederhana (plain text, UTF-8)
+func buildMessage(from string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", sanitizeHeader(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", sanitizeHeader(msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// sanitizeHeader membuang CR/LF agar nilai header tidak bisa menyisipkan header lain
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", "", "\n", " ").Replace(value)
+}
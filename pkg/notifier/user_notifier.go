@@ -0,0 +1,90 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/logger"
+)
+
+// sendTimeout adalah batas waktu satu kali pengiriman email
+const sendTimeout = 30 * time.Second
+
+// EmailLookup mencari alamat email user berdasarkan ID
+type EmailLookup func(userID uint) (string, error)
+
+// UserNotifier mengirim email ke user secara asynchronous.
+// Kegagalan hanya dicatat ke log dan tidak pernah memblokir atau menggagalkan request utama.
+// Method pada UserNotifier nil aman dipanggil dan tidak melakukan apa pun.
+type UserNotifier struct {
+	sender EmailSender
+	lookup EmailLookup
+
+	// Melacak goroutine pengiriman agar bisa di-drain saat shutdown
+	wg sync.WaitGroup
+}
+
+// NewUserNotifier membuat instance baru UserNotifier
+func NewUserNotifier(sender EmailSender, lookup EmailLookup) *UserNotifier {
+	return &UserNotifier{sender: sender, lookup: lookup}
+}
+
+// NotifyUser mengirim email ke user dengan ID tertentu di background
+func (n *UserNotifier) NotifyUser(userID uint, subject string, body string) {
+	if n == nil {
+		return
+	}
+	n.dispatch(func() (string, error) { return n.lookup(userID) }, subject, body)
+}
+
+// NotifyEmail mengirim email ke alamat tertentu di background
+func (n *UserNotifier) NotifyEmail(to string, subject string, body string) {
+	if n == nil {
+		return
+	}
+	n.dispatch(func() (string, error) { return to, nil }, subject, body)
+}
+
+// Shutdown menunggu email yang masih dikirim sampai ctx habis
+func (n *UserNotifier) Shutdown(ctx context.Context) error {
+	if n == nil {
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *UserNotifier) dispatch(recipient func() (string, error), subject string, body string) {
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error().Interface("panic", r).Str("subject", subject).Msg("Email notification panicked")
+			}
+		}()
+
+		to, err := recipient()
+		if err != nil {
+			logger.Warn().Err(err).Str("subject", subject).Msg("Failed to resolve email recipient")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+		if err := n.sender.Send(ctx, Message{To: to, Subject: subject, Body: body}); err != nil {
+			logger.Warn().Err(err).Str("to", to).Str("subject", subject).Msg("Failed to send email notification")
+		}
+	}()
+}
@@ -0,0 +1,88 @@
+package pagination
+
+import "math"
+
+// Batas pagination bawaan untuk semua endpoint list; bisa diubah lewat Configure
+const (
+	DefaultPage  = 1
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+// Batas yang sedang berlaku, diatur sekali saat startup sebelum server menerima request
+var (
+	defaultLimit = DefaultLimit
+	maxLimit     = MaxLimit
+)
+
+// Configure mengganti limit default dan limit maksimum (DEFAULT_PAGE_SIZE / MAX_PAGE_SIZE).
+// Nilai <= 0 memakai bawaan, dan default tidak pernah melebihi maksimum.
+func Configure(defaultSize, maxSize int) {
+	if maxSize <= 0 {
+		maxSize = MaxLimit
+	}
+	if defaultSize <= 0 {
+		defaultSize = DefaultLimit
+	}
+	maxLimit = maxSize
+	defaultLimit = min(defaultSize, maxSize)
+}
+
+// Limits mengembalikan limit default dan limit maksimum yang sedang berlaku
+func Limits() (defaultSize, maxSize int) {
+	return defaultLimit, maxLimit
+}
+
+// Meta berisi informasi pagination pada response list.
+// Di-embed ke response list agar field-nya tampil sejajar dengan data (total, page, limit, total_pages).
+// HasNext/HasPrev dan NextPage/PrevPage membantu frontend membuat tombol navigasi halaman.
+type Meta struct {
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	TotalPages int   `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+	HasPrev    bool  `json:"has_prev"`
+	NextPage   *int  `json:"next_page,omitempty"`
+	PrevPage   *int  `json:"prev_page,omitempty"`
+}
+
+// Normalize mengisi default page/limit yang kosong atau tidak valid dan membatasi limit ke limit maksimum
+func Normalize(page, limit int) (int, int) {
+	if page < 1 {
+		page = DefaultPage
+	}
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	return page, limit
+}
+
+// BuildMeta membuat Meta dari total data dan page/limit yang sudah dinormalisasi
+func BuildMeta(total int64, page, limit int) Meta {
+	totalPages := 0
+	if limit > 0 {
+		totalPages = int(math.Ceil(float64(total) / float64(limit)))
+	}
+	meta := Meta{
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+	if meta.HasNext {
+		next := page + 1
+		meta.NextPage = &next
+	}
+	if meta.HasPrev {
+		// Page di luar jangkauan diarahkan kembali ke halaman terakhir yang berisi data
+		prev := min(page-1, max(totalPages, 1))
+		meta.PrevPage = &prev
+	}
+	return meta
+}
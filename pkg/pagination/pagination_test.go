@@ -0,0 +1,99 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name        string
+		page, limit int
+		wantPage    int
+		wantLimit   int
+	}{
+		{"valid values unchanged", 3, 25, 3, 25},
+		{"limit zero uses default", 1, 0, 1, DefaultLimit},
+		{"negative limit uses default", 1, -5, 1, DefaultLimit},
+		{"limit above max is capped", 1, 500, 1, MaxLimit},
+		{"limit at max is kept", 1, MaxLimit, 1, MaxLimit},
+		{"page zero becomes first page", 0, 10, 1, 10},
+		{"negative page becomes first page", -2, 10, 1, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, limit := Normalize(tt.page, tt.limit)
+			assert.Equal(t, tt.wantPage, page)
+			assert.Equal(t, tt.wantLimit, limit)
+		})
+	}
+}
+
+func TestConfigure_ChangesNormalizeLimits(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultLimit, MaxLimit) })
+
+	Configure(25, 250)
+	defaultSize, maxSize := Limits()
+	assert.Equal(t, 25, defaultSize)
+	assert.Equal(t, 250, maxSize)
+
+	_, limit := Normalize(1, 0)
+	assert.Equal(t, 25, limit)
+	_, limit = Normalize(1, 200)
+	assert.Equal(t, 200, limit)
+	_, limit = Normalize(1, 1000)
+	assert.Equal(t, 250, limit)
+
+	// Default tidak boleh melebihi maksimum, nilai tidak valid memakai bawaan
+	Configure(50, 20)
+	_, limit = Normalize(1, 0)
+	assert.Equal(t, 20, limit)
+	Configure(0, 0)
+	defaultSize, maxSize = Limits()
+	assert.Equal(t, DefaultLimit, defaultSize)
+	assert.Equal(t, MaxLimit, maxSize)
+}
+
+func TestBuildMeta(t *testing.T) {
+	assert.Equal(t, Meta{Total: 0, Page: 1, Limit: 10, TotalPages: 0}, BuildMeta(0, 1, 10))
+	assert.Equal(t, Meta{Total: 10, Page: 1, Limit: 10, TotalPages: 1}, BuildMeta(10, 1, 10))
+	assert.Equal(t, Meta{Total: 11, Page: 2, Limit: 10, TotalPages: 2, HasPrev: true, PrevPage: intPtr(1)}, BuildMeta(11, 2, 10))
+	// Limit nol tidak menyebabkan pembagian dengan nol
+	assert.Equal(t, 0, BuildMeta(5, 1, 0).TotalPages)
+}
+
+func TestBuildMeta_Navigation(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int64
+		page        int
+		wantHasNext bool
+		wantHasPrev bool
+		wantNext    *int
+		wantPrev    *int
+	}{
+		{"first of many", 35, 1, true, false, intPtr(2), nil},
+		{"middle page", 35, 2, true, true, intPtr(3), intPtr(1)},
+		{"last page", 35, 4, false, true, nil, intPtr(3)},
+		{"single page", 5, 1, false, false, nil, nil},
+		{"empty result", 0, 1, false, false, nil, nil},
+		{"page beyond last points back to last", 35, 9, false, true, nil, intPtr(4)},
+		{"page beyond empty result points to first", 0, 3, false, true, nil, intPtr(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := BuildMeta(tt.total, tt.page, 10)
+			assert.Equal(t, tt.wantHasNext, meta.HasNext)
+			assert.Equal(t, tt.wantHasPrev, meta.HasPrev)
+			assert.Equal(t, tt.wantNext, meta.NextPage)
+			assert.Equal(t, tt.wantPrev, meta.PrevPage)
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/config"
+)
+
+// unsignedPayload dipakai agar body upload tidak perlu di-hash dulu (didukung S3 dan MinIO)
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Storage menyimpan file di bucket S3-compatible (AWS S3, MinIO, R2, dll) dengan path-style URL.
+// Request ditandatangani dengan AWS Signature Version 4.
+type S3Storage struct {
+	endpoint      *url.URL
+	region        string
+	bucket        string
+	accessKey     string
+	secretKey     string
+	publicBaseURL string
+	client        *http.Client
+	now           func() time.Time
+}
+
+// NewS3Storage membuat S3Storage dari konfigurasi
+func NewS3Storage(cfg *config.StorageConfig) (*S3Storage, error) {
+	if cfg.S3Endpoint == "" || cfg.S3Bucket == "" || cfg.S3AccessKey == "" || cfg.S3SecretKey == "" {
+		return nil, errors.New("S3 storage requires S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY and S3_SECRET_KEY")
+	}
+	endpoint, err := url.Parse(strings.TrimRight(cfg.S3Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3_ENDPOINT %q", cfg.S3Endpoint)
+	}
+
+	region := cfg.S3Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	// Tanpa base URL publik (mis. CDN), file diakses langsung dari endpoint bucket
+	publicBaseURL := strings.TrimRight(cfg.PublicBaseURL, "/")
+	if publicBaseURL == "" {
+		publicBaseURL = endpoint.String() + "/" + cfg.S3Bucket
+	}
+
+	return &S3Storage{
+		endpoint:      endpoint,
+		region:        region,
+		bucket:        cfg.S3Bucket,
+		accessKey:     cfg.S3AccessKey,
+		secretKey:     cfg.S3SecretKey,
+		publicBaseURL: publicBaseURL,
+		client:        &http.Client{Timeout: 60 * time.Second},
+		now:           time.Now,
+	}, nil
+}
+
+// Put meng-upload objek dengan PUT Object
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), r)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req)
+
+	if err := s.do(req, http.StatusOK); err != nil {
+		return "", err
+	}
+	return s.publicBaseURL + "/" + key, nil
+}
+
+// Delete menghapus objek; S3 mengembalikan 204 juga untuk objek yang tidak ada
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	s.sign(req)
+	return s.do(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+// KeyFromURL mengembalikan key untuk URL di bawah base URL publik
+func (s *S3Storage) KeyFromURL(url string) (string, bool) {
+	return keyFromPrefixedURL(s.publicBaseURL, url)
+}
+
+func (s *S3Storage) objectURL(key string) string {
+	return s.endpoint.String() + "/" + s.bucket + "/" + escapePath(key)
+}
+
+func (s *S3Storage) do(req *http.Request, okStatuses ...int) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	for _, status := range okStatuses {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 %s %s: unexpected status %d: %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// sign menambahkan header Authorization AWS Signature Version 4
+func (s *S3Storage) sign(req *http.Request) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + unsignedPayload + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+// escapePath meng-encode tiap segmen key sesuai aturan URI encoding S3
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/config"
+)
+
+// Storage interface untuk menyimpan file upload (gambar produk, dll)
+type Storage interface {
+	// Put menyimpan isi r dengan key tertentu dan mengembalikan URL publiknya
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+	// Delete menghapus file dengan key tertentu. File yang tidak ada bukan error.
+	Delete(ctx context.Context, key string) error
+	// KeyFromURL mengembalikan key jika URL disimpan oleh storage ini.
+	// URL eksternal (mis. diisi manual lewat image_url) menghasilkan false.
+	KeyFromURL(url string) (string, bool)
+}
+
+// New membuat Storage sesuai STORAGE_DRIVER ("local" atau "s3")
+func New(cfg *config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStorage(cfg.LocalDir, cfg.PublicBaseURL)
+	case "s3":
+		return NewS3Storage(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// keyFromPrefixedURL memotong baseURL dari url; key harus relatif dan tanpa path traversal
+func keyFromPrefixedURL(baseURL string, url string) (string, bool) {
+	prefix := strings.TrimRight(baseURL, "/") + "/"
+	if !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	key := strings.TrimPrefix(url, prefix)
+	if key == "" || strings.Contains(key, "..") {
+		return "", false
+	}
+	return key, true
+}
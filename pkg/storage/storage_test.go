@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_PutAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocalStorage(dir, "/uploads/")
+	require.NoError(t, err)
+
+	url, err := store.Put(context.Background(), "products/1/a.png", strings.NewReader("data"), 4, "image/png")
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/products/1/a.png", url)
+
+	content, err := os.ReadFile(filepath.Join(dir, "products", "1", "a.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(content))
+
+	key, ok := store.KeyFromURL(url)
+	require.True(t, ok)
+	require.NoError(t, store.Delete(context.Background(), key))
+	_, err = os.Stat(filepath.Join(dir, "products", "1", "a.png"))
+	assert.True(t, os.IsNotExist(err))
+
+	// File yang sudah tidak ada bukan error
+	assert.NoError(t, store.Delete(context.Background(), key))
+}
+
+func TestLocalStorage_RejectsKeyOutsideRoot(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	_, err = store.Put(context.Background(), "../escape.png", strings.NewReader("x"), 1, "image/png")
+	assert.Error(t, err)
+	assert.Error(t, store.Delete(context.Background(), "/etc/passwd"))
+}
+
+func TestKeyFromURL_IgnoresForeignURLs(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	_, ok := store.KeyFromURL("https://cdn.example.com/a.png")
+	assert.False(t, ok)
+	_, ok = store.KeyFromURL("/uploads/../secret")
+	assert.False(t, ok)
+	_, ok = store.KeyFromURL("")
+	assert.False(t, ok)
+}
+
+func TestS3Storage_SignsPutAndDelete(t *testing.T) {
+	type received struct {
+		method, path, auth, contentType, body string
+	}
+	var requests []received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, received{r.Method, r.URL.Path, r.Header.Get("Authorization"), r.Header.Get("Content-Type"), string(body)})
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewS3Storage(&config.StorageConfig{
+		S3Endpoint:    server.URL,
+		S3Region:      "ap-southeast-1",
+		S3Bucket:      "media",
+		S3AccessKey:   "AKID",
+		S3SecretKey:   "secret",
+		PublicBaseURL: "https://cdn.example.com",
+	})
+	require.NoError(t, err)
+	store.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	url, err := store.Put(context.Background(), "products/1/a b.png", strings.NewReader("data"), 4, "image/png")
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/products/1/a b.png", url)
+
+	key, ok := store.KeyFromURL(url)
+	require.True(t, ok)
+	require.NoError(t, store.Delete(context.Background(), key))
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, http.MethodPut, requests[0].method)
+	assert.Equal(t, "/media/products/1/a b.png", requests[0].path)
+	assert.Equal(t, "image/png", requests[0].contentType)
+	assert.Equal(t, "data", requests[0].body)
+	assert.True(t, strings.HasPrefix(requests[0].auth,
+		"AWS4-HMAC-SHA256 Credential=AKID/20240102/ap-southeast-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+	assert.Equal(t, http.MethodDelete, requests[1].method)
+}
+
+func TestS3Storage_ReturnsErrorOnFailedUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+	}))
+	defer server.Close()
+
+	store, err := NewS3Storage(&config.StorageConfig{
+		S3Endpoint: server.URL, S3Bucket: "media", S3AccessKey: "AKID", S3SecretKey: "secret",
+	})
+	require.NoError(t, err)
+
+	_, err = store.Put(context.Background(), "a.png", strings.NewReader("x"), 1, "image/png")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AccessDenied")
+}
+
+func TestNew_RejectsUnknownDriverAndIncompleteS3(t *testing.T) {
+	_, err := New(&config.StorageConfig{Driver: "ftp"})
+	assert.Error(t, err)
+
+	_, err = New(&config.StorageConfig{Driver: "s3", S3Bucket: "media"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyHMACSHA256(t *testing.T) {
+	secret := "webhook-secret"
+	payload := []byte(`{"transaction_id":"TRX-1","status":"SUCCESS"}`)
+	signature := SignHMACSHA256(secret, payload)
+
+	assert.True(t, VerifyHMACSHA256(secret, payload, signature))
+	assert.False(t, VerifyHMACSHA256("other-secret", payload, signature))
+	assert.False(t, VerifyHMACSHA256(secret, []byte(`{"status":"FAILED"}`), signature))
+	assert.False(t, VerifyHMACSHA256(secret, payload, "not-hex"))
+	assert.False(t, VerifyHMACSHA256(secret, payload, ""))
+	assert.False(t, VerifyHMACSHA256("", payload, SignHMACSHA256("", payload)))
+}
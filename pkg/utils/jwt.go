@@ -1,58 +1,188 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
 	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Token type constants
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// JWT errors
+var (
+	ErrInvalidTokenType = errors.New("invalid token type")
+	ErrUnknownKeyID     = errors.New("unknown token key id")
+)
+
 // JWTClaims custom claims untuk JWT
 type JWTClaims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID    uint   `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	TokenType string `json:"token_type,omitempty"`
+	// SessionID adalah jti refresh token dari sesi login asal access token ini
+	// (kosong untuk refresh token dan token tanpa sesi)
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// JWTService untuk operasi JWT
+// TokenPair berisi access token dan refresh token
+type TokenPair struct {
+	AccessToken          string
+	AccessTokenExpiresAt time.Time
+	RefreshToken         string
+	RefreshJTI           string
+}
+
+// JWTService untuk operasi JWT.
+// Mendukung HS256 (secret simetris) dan RS256 (private key untuk signing,
+// satu atau lebih public key untuk verifikasi, dipilih lewat header kid).
 type JWTService struct {
-	secretKey  string
-	expireHour int
+	method            jwt.SigningMethod
+	secretKey         string
+	signingKey        *rsa.PrivateKey
+	signingKID        string
+	verifyKeys        map[string]*rsa.PublicKey
+	expireMinutes     int
+	refreshExpireHour int
 }
 
-// NewJWTService membuat instance JWTService
-func NewJWTService(secretKey string, expireHour int) *JWTService {
+// NewJWTService membuat instance JWTService dengan HS256
+func NewJWTService(secretKey string, expireMinutes, refreshExpireHour int) *JWTService {
 	return &JWTService{
-		secretKey:  secretKey,
-		expireHour: expireHour,
+		method:            jwt.SigningMethodHS256,
+		secretKey:         secretKey,
+		expireMinutes:     expireMinutes,
+		refreshExpireHour: refreshExpireHour,
 	}
 }
 
-// GenerateToken membuat JWT token baru
+// NewRSAJWTService membuat instance JWTService dengan RS256.
+// verifyKeys berisi public key tambahan (mis. key lama saat rotasi); public key dari
+// signingKey selalu ikut dipakai untuk verifikasi.
+func NewRSAJWTService(signingKey *rsa.PrivateKey, verifyKeys []*rsa.PublicKey, expireMinutes, refreshExpireHour int) (*JWTService, error) {
+	if signingKey == nil {
+		return nil, errors.New("jwt: RSA signing key is required")
+	}
+
+	signingKID, err := KeyID(&signingKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{signingKID: &signingKey.PublicKey}
+	for _, key := range verifyKeys {
+		kid, err := KeyID(key)
+		if err != nil {
+			return nil, err
+		}
+		keys[kid] = key
+	}
+
+	return &JWTService{
+		method:            jwt.SigningMethodRS256,
+		signingKey:        signingKey,
+		signingKID:        signingKID,
+		verifyKeys:        keys,
+		expireMinutes:     expireMinutes,
+		refreshExpireHour: refreshExpireHour,
+	}, nil
+}
+
+// Algorithm mengembalikan nama algoritma signing yang dipakai (HS256 atau RS256)
+func (j *JWTService) Algorithm() string {
+	return j.method.Alg()
+}
+
+// GenerateToken membuat JWT access token baru
 func (j *JWTService) GenerateToken(userID uint, email, role string) (string, error) {
+	token, _, err := j.GenerateTokenWithExpiry(userID, email, role)
+	return token, err
+}
+
+// GenerateTokenWithExpiry membuat JWT access token baru beserta waktu expiry-nya (sama dengan claim exp)
+func (j *JWTService) GenerateTokenWithExpiry(userID uint, email, role string) (string, time.Time, error) {
+	return j.GenerateSessionToken(userID, email, role, "")
+}
+
+// GenerateSessionToken sama dengan GenerateTokenWithExpiry, tetapi access token ditandai
+// milik sesi sessionID (claim sid) sehingga ikut tercabut saat sesi itu dicabut
+func (j *JWTService) GenerateSessionToken(userID uint, email, role, sessionID string) (string, time.Time, error) {
+	token, _, expiresAt, err := j.generate(userID, email, role, TokenTypeAccess, sessionID, j.GetTokenExpiry())
+	return token, expiresAt, err
+}
+
+// GenerateTokenPair membuat access token dan refresh token sekaligus.
+// Jti refresh token menjadi ID sesi yang dicatat di access token.
+func (j *JWTService) GenerateTokenPair(userID uint, email, role string) (*TokenPair, error) {
+	refreshToken, jti, _, err := j.generate(userID, email, role, TokenTypeRefresh, "", j.GetRefreshTokenExpiry())
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, expiresAt, err := j.GenerateSessionToken(userID, email, role, jti)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: expiresAt,
+		RefreshToken:         refreshToken,
+		RefreshJTI:           jti,
+	}, nil
+}
+
+// generate membuat token dengan jenis dan masa berlaku tertentu.
+// Mengembalikan token, jti, dan waktu expiry (dibulatkan ke presisi claim exp).
+func (j *JWTService) generate(userID uint, email, role, tokenType, sessionID string, ttl time.Duration) (string, string, time.Time, error) {
+	jti, err := generateJTI()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl).Truncate(jwt.TimePrecision)
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		TokenType: tokenType,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(j.expireHour) * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(j.secretKey))
+	token := jwt.NewWithClaims(j.method, claims)
+	var key interface{} = []byte(j.secretKey)
+	if j.signingKey != nil {
+		token.Header["kid"] = j.signingKID
+		key = j.signingKey
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return signed, jti, expiresAt, nil
 }
 
 // ValidateToken memvalidasi dan parse JWT token
 func (j *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(j.secretKey), nil
-	})
+	// Hanya algoritma yang dikonfigurasi yang diterima, mencegah serangan algorithm confusion
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, j.verificationKey,
+		jwt.WithValidMethods([]string{j.method.Alg()}))
 
 	if err != nil {
 		return nil, err
@@ -65,7 +195,58 @@ func (j *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	return nil, errors.New("invalid token")
 }
 
-// GetTokenExpiry mengembalikan durasi expiry token
+// verificationKey memilih key untuk verifikasi token; untuk RS256 berdasarkan header kid
+func (j *JWTService) verificationKey(token *jwt.Token) (interface{}, error) {
+	if j.verifyKeys == nil {
+		return []byte(j.secretKey), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := j.verifyKeys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+	return key, nil
+}
+
+// ValidateRefreshToken memvalidasi token dan memastikan jenisnya refresh token
+func (j *JWTService) ValidateRefreshToken(tokenString string) (*JWTClaims, error) {
+	claims, err := j.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.IsRefreshToken() {
+		return nil, ErrInvalidTokenType
+	}
+	return claims, nil
+}
+
+// IsAccessToken mengecek apakah claims milik access token.
+// Token lama tanpa token_type dianggap sebagai access token.
+func (c *JWTClaims) IsAccessToken() bool {
+	return c.TokenType == TokenTypeAccess || c.TokenType == ""
+}
+
+// IsRefreshToken mengecek apakah claims milik refresh token
+func (c *JWTClaims) IsRefreshToken() bool {
+	return c.TokenType == TokenTypeRefresh
+}
+
+// GetTokenExpiry mengembalikan durasi expiry access token
 func (j *JWTService) GetTokenExpiry() time.Duration {
-	return time.Duration(j.expireHour) * time.Hour
+	return time.Duration(j.expireMinutes) * time.Minute
+}
+
+// GetRefreshTokenExpiry mengembalikan durasi expiry refresh token
+func (j *JWTService) GetRefreshTokenExpiry() time.Duration {
+	return time.Duration(j.refreshExpireHour) * time.Hour
+}
+
+// generateJTI membuat ID unik untuk token (claim jti)
+func generateJTI() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/config"
+)
+
+// NewJWTServiceFromConfig membuat JWTService sesuai JWT_ALGORITHM.
+// HS256 dipakai jika algoritma kosong, sehingga konfigurasi lama tetap berjalan.
+func NewJWTServiceFromConfig(cfg *config.JWTConfig) (*JWTService, error) {
+	switch cfg.Algorithm {
+	case "", config.JWTAlgorithmHS256:
+		return NewJWTService(cfg.Secret, cfg.ExpireMinutes, cfg.RefreshExpireHour), nil
+	case config.JWTAlgorithmRS256:
+		signingKey, err := LoadRSAPrivateKeyFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		verifyKeys := make([]*rsa.PublicKey, 0, len(cfg.PublicKeyFiles))
+		for _, path := range cfg.PublicKeyFiles {
+			key, err := LoadRSAPublicKeyFile(path)
+			if err != nil {
+				return nil, err
+			}
+			verifyKeys = append(verifyKeys, key)
+		}
+
+		return NewRSAJWTService(signingKey, verifyKeys, cfg.ExpireMinutes, cfg.RefreshExpireHour)
+	default:
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q", cfg.Algorithm)
+	}
+}
+
+// LoadRSAPrivateKeyFile membaca private key RSA dari file PEM (PKCS#1 atau PKCS#8)
+func LoadRSAPrivateKeyFile(path string) (*rsa.PrivateKey, error) {
+	block, err := readPEMFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: parse private key %s: %w", path, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("jwt: private key %s is not an RSA key", path)
+	}
+	return key, nil
+}
+
+// LoadRSAPublicKeyFile membaca public key RSA dari file PEM (PKIX atau PKCS#1)
+func LoadRSAPublicKeyFile(path string) (*rsa.PublicKey, error) {
+	block, err := readPEMFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: parse public key %s: %w", path, err)
+	}
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("jwt: public key %s is not an RSA key", path)
+	}
+	return key, nil
+}
+
+// KeyID menghitung kid dari public key: 16 karakter hex pertama dari SHA-256 DER PKIX.
+// kid yang sama selalu dihasilkan untuk key yang sama, sehingga tidak perlu dikonfigurasi manual.
+func KeyID(key *rsa.PublicKey) (string, error) {
+	if key == nil {
+		return "", errors.New("jwt: RSA public key is required")
+	}
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// readPEMFile membaca block PEM pertama dari file
+func readPEMFile(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwt: %s does not contain a PEM block", path)
+	}
+	return block, nil
+}
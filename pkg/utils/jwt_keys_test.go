@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/akbarwjyy/go-commerce-api/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func TestLoadRSAKeyFiles_SupportsCommonEncodings(t *testing.T) {
+	key := newTestRSAKey(t)
+
+	pkcs1 := writePEM(t, "pkcs1.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))
+	loaded, err := LoadRSAPrivateKeyFile(pkcs1)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(loaded))
+
+	pkcs8DER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	loaded, err = LoadRSAPrivateKeyFile(writePEM(t, "pkcs8.pem", "PRIVATE KEY", pkcs8DER))
+	require.NoError(t, err)
+	assert.True(t, key.Equal(loaded))
+
+	pkixDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	pub, err := LoadRSAPublicKeyFile(writePEM(t, "pub.pem", "PUBLIC KEY", pkixDER))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(pub))
+
+	_, err = LoadRSAPrivateKeyFile(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+
+	notPEM := filepath.Join(t.TempDir(), "garbage.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a key"), 0o600))
+	_, err = LoadRSAPublicKeyFile(notPEM)
+	assert.Error(t, err)
+}
+
+func TestNewJWTServiceFromConfig(t *testing.T) {
+	hs, err := NewJWTServiceFromConfig(&config.JWTConfig{Secret: "test-secret", ExpireMinutes: 15, RefreshExpireHour: 24})
+	require.NoError(t, err)
+	assert.Equal(t, "HS256", hs.Algorithm())
+
+	oldKey := newTestRSAKey(t)
+	newKey := newTestRSAKey(t)
+	oldService, err := NewRSAJWTService(oldKey, nil, 1, 24)
+	require.NoError(t, err)
+	oldToken, err := oldService.GenerateToken(1, "test@example.com", "user")
+	require.NoError(t, err)
+
+	oldPub, err := x509.MarshalPKIXPublicKey(&oldKey.PublicKey)
+	require.NoError(t, err)
+	rs, err := NewJWTServiceFromConfig(&config.JWTConfig{
+		Algorithm:         config.JWTAlgorithmRS256,
+		PrivateKeyFile:    writePEM(t, "current.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(newKey)),
+		PublicKeyFiles:    []string{writePEM(t, "old.pem", "PUBLIC KEY", oldPub)},
+		ExpireMinutes:     15,
+		RefreshExpireHour: 24,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", rs.Algorithm())
+	_, err = rs.ValidateToken(oldToken)
+	assert.NoError(t, err)
+
+	_, err = NewJWTServiceFromConfig(&config.JWTConfig{Algorithm: config.JWTAlgorithmRS256})
+	assert.Error(t, err)
+
+	_, err = NewJWTServiceFromConfig(&config.JWTConfig{Algorithm: "none"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTokenPair(t *testing.T) {
+	j := NewJWTService("test-secret", 1, 24)
+
+	pair, err := j.GenerateTokenPair(1, "test@example.com", "user")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.NotEmpty(t, pair.RefreshToken)
+	assert.NotEmpty(t, pair.RefreshJTI)
+
+	accessClaims, err := j.ValidateToken(pair.AccessToken)
+	assert.NoError(t, err)
+	assert.True(t, accessClaims.IsAccessToken())
+
+	refreshClaims, err := j.ValidateRefreshToken(pair.RefreshToken)
+	assert.NoError(t, err)
+	assert.Equal(t, pair.RefreshJTI, refreshClaims.ID)
+	assert.False(t, refreshClaims.IsAccessToken())
+
+	// Access token mencatat sesi (jti refresh token) asalnya
+	assert.Equal(t, pair.RefreshJTI, accessClaims.SessionID)
+	assert.NotEqual(t, pair.RefreshJTI, accessClaims.ID)
+	assert.Empty(t, refreshClaims.SessionID)
+}
+
+func TestValidateRefreshToken_RejectsAccessToken(t *testing.T) {
+	j := NewJWTService("test-secret", 1, 24)
+
+	token, err := j.GenerateToken(1, "test@example.com", "user")
+	assert.NoError(t, err)
+
+	_, err = j.ValidateRefreshToken(token)
+	assert.ErrorIs(t, err, ErrInvalidTokenType)
+}
+
+func TestGenerateTokenWithExpiry_MatchesExpClaim(t *testing.T) {
+	j := NewJWTService("test-secret", 30, 24)
+
+	before := time.Now()
+	token, expiresAt, err := j.GenerateTokenWithExpiry(1, "test@example.com", "user")
+	assert.NoError(t, err)
+
+	claims, err := j.ValidateToken(token)
+	assert.NoError(t, err)
+	assert.True(t, claims.ExpiresAt.Time.Equal(expiresAt))
+	assert.WithinDuration(t, before.Add(30*time.Minute), expiresAt, 2*time.Second)
+
+	pair, err := j.GenerateTokenPair(1, "test@example.com", "user")
+	assert.NoError(t, err)
+	accessClaims, err := j.ValidateToken(pair.AccessToken)
+	assert.NoError(t, err)
+	assert.True(t, accessClaims.ExpiresAt.Time.Equal(pair.AccessTokenExpiresAt))
+}
+
+func newTestRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func TestRS256_SignsWithKeyID(t *testing.T) {
+	key := newTestRSAKey(t)
+	j, err := NewRSAJWTService(key, nil, 1, 24)
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", j.Algorithm())
+
+	pair, err := j.GenerateTokenPair(1, "test@example.com", "user")
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(pair.AccessToken, &JWTClaims{})
+	require.NoError(t, err)
+	kid, err := KeyID(&key.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, kid, parsed.Header["kid"])
+	assert.Equal(t, "RS256", parsed.Header["alg"])
+
+	claims, err := j.ValidateToken(pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), claims.UserID)
+
+	_, err = j.ValidateRefreshToken(pair.RefreshToken)
+	assert.NoError(t, err)
+}
+
+func TestRS256_KeyRotationKeepsOldTokensValid(t *testing.T) {
+	oldKey := newTestRSAKey(t)
+	newKey := newTestRSAKey(t)
+
+	before, err := NewRSAJWTService(oldKey, nil, 1, 24)
+	require.NoError(t, err)
+	oldToken, err := before.GenerateToken(1, "test@example.com", "user")
+	require.NoError(t, err)
+
+	// Setelah rotasi: sign dengan key baru, public key lama masih diterima
+	after, err := NewRSAJWTService(newKey, []*rsa.PublicKey{&oldKey.PublicKey}, 1, 24)
+	require.NoError(t, err)
+	_, err = after.ValidateToken(oldToken)
+	assert.NoError(t, err)
+
+	newToken, err := after.GenerateToken(1, "test@example.com", "user")
+	require.NoError(t, err)
+	_, err = after.ValidateToken(newToken)
+	assert.NoError(t, err)
+
+	// Setelah public key lama dihapus, token lama ditolak
+	retired, err := NewRSAJWTService(newKey, nil, 1, 24)
+	require.NoError(t, err)
+	_, err = retired.ValidateToken(oldToken)
+	assert.ErrorIs(t, err, ErrUnknownKeyID)
+}
+
+func TestValidateToken_RejectsOtherAlgorithm(t *testing.T) {
+	hs := NewJWTService("test-secret", 1, 24)
+	rs, err := NewRSAJWTService(newTestRSAKey(t), nil, 1, 24)
+	require.NoError(t, err)
+
+	hsToken, err := hs.GenerateToken(1, "test@example.com", "user")
+	require.NoError(t, err)
+	_, err = rs.ValidateToken(hsToken)
+	assert.Error(t, err)
+
+	rsToken, err := rs.GenerateToken(1, "test@example.com", "user")
+	require.NoError(t, err)
+	_, err = hs.ValidateToken(rsToken)
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -15,14 +16,36 @@ type CustomValidator struct {
 // New creates a new CustomValidator with custom validations registered
 func New() *CustomValidator {
 	v := validator.New()
+	registerCustomValidations(v)
 
-	// Register custom validators
+	return &CustomValidator{validate: v}
+}
+
+// NewGinValidator creates a CustomValidator that reads the `binding` struct tag,
+// so it can be assigned to gin's binding.Validator. Field names in validation
+// errors follow the `json` tag so they match the request body.
+func NewGinValidator() *CustomValidator {
+	v := validator.New()
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return fld.Name
+		}
+		return name
+	})
+	registerCustomValidations(v)
+
+	return &CustomValidator{validate: v}
+}
+
+// registerCustomValidations registers all custom validation tags
+func registerCustomValidations(v *validator.Validate) {
 	v.RegisterValidation("password", validatePassword)
 	v.RegisterValidation("phone", validatePhone)
 	v.RegisterValidation("no_spaces", validateNoSpaces)
 	v.RegisterValidation("alpha_space", validateAlphaSpace)
-
-	return &CustomValidator{validate: v}
+	v.RegisterValidation("sku", validateSKU)
 }
 
 // Validate validates a struct
@@ -30,6 +53,38 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 	return cv.validate.Struct(i)
 }
 
+// ValidateStruct implements gin's binding.StructValidator
+func (cv *CustomValidator) ValidateStruct(obj interface{}) error {
+	if obj == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(obj)
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		return cv.ValidateStruct(value.Elem().Interface())
+	case reflect.Struct:
+		return cv.validate.Struct(obj)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := cv.ValidateStruct(value.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Engine implements gin's binding.StructValidator
+func (cv *CustomValidator) Engine() interface{} {
+	return cv.validate
+}
+
 // GetValidator returns the underlying validator
 func (cv *CustomValidator) GetValidator() *validator.Validate {
 	return cv.validate
@@ -73,6 +128,14 @@ func validateAlphaSpace(fl validator.FieldLevel) bool {
 	return alphaSpaceRegex.MatchString(value)
 }
 
+// skuRegex: huruf dan angka, boleh dipisah satu tanda hubung (mis. LAPTOP-15-BLK)
+var skuRegex = regexp.MustCompile(`^[A-Za-z0-9]+(-[A-Za-z0-9]+)*$`)
+
+// validateSKU checks SKU format: alphanumeric segments separated by single dashes
+func validateSKU(fl validator.FieldLevel) bool {
+	return skuRegex.MatchString(fl.Field().String())
+}
+
 // ValidationErrorMessages provides custom error messages
 var ValidationErrorMessages = map[string]string{
 	"required":    "This field is required",
@@ -86,8 +149,10 @@ var ValidationErrorMessages = map[string]string{
 	"phone":       "Invalid phone number format",
 	"no_spaces":   "Spaces are not allowed",
 	"alpha_space": "Only alphabets and spaces are allowed",
+	"sku":         "SKU may only contain letters, numbers and single dashes",
 	"oneof":       "Invalid value",
 	"url":         "Invalid URL format",
+	"iso4217":     "Invalid ISO 4217 currency code",
 }
 
 // GetErrorMessage returns a human-readable error message
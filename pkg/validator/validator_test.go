@@ -133,6 +133,39 @@ func TestValidateAlphaSpace(t *testing.T) {
 	}
 }
 
+func TestValidateSKU(t *testing.T) {
+	cv := New()
+	v := cv.GetValidator()
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"Alphanumeric", "LAPTOP15", true},
+		{"With dashes", "LAPTOP-15-blk", true},
+		{"Leading dash", "-LAPTOP", false},
+		{"Double dash", "LAPTOP--15", false},
+		{"With space", "LAPTOP 15", false},
+		{"With underscore", "LAPTOP_15", false},
+	}
+
+	type TestStruct struct {
+		SKU string `validate:"sku"`
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(&TestStruct{SKU: tt.value})
+			if tt.valid {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
 func TestGetErrorMessage(t *testing.T) {
 	msg := ValidationErrorMessages["required"]
 	assert.Equal(t, "This field is required", msg)
@@ -143,3 +176,22 @@ func TestGetErrorMessage(t *testing.T) {
 	msg = ValidationErrorMessages["password"]
 	assert.Equal(t, "Password must be at least 8 characters with uppercase, lowercase, and number", msg)
 }
+
+func TestGinValidator_ValidateStruct(t *testing.T) {
+	cv := NewGinValidator()
+
+	type TestStruct struct {
+		Password string `json:"password" binding:"required,password"`
+		Phone    string `json:"phone,omitempty" binding:"omitempty,phone"`
+	}
+
+	assert.Nil(t, cv.ValidateStruct(&TestStruct{Password: "Password123"}))
+	assert.Nil(t, cv.ValidateStruct(&TestStruct{Password: "Password123", Phone: "081234567890"}))
+
+	err := cv.ValidateStruct(&TestStruct{Password: "weak", Phone: "123"})
+	assert.NotNil(t, err)
+
+	errs := FormatValidationErrors(err)
+	assert.Equal(t, ValidationErrorMessages["password"], errs["password"])
+	assert.Equal(t, ValidationErrorMessages["phone"], errs["phone"])
+}